@@ -42,8 +42,8 @@ type BuildClusterSpec struct {
 type ToolchainSpec struct {
 	// Optional, defaults to "kind-version"
 	Name *string `json:"name"`
-	// Kind is one of ["gcc", "custom"] (default is "gcc")
-	// +kubebuilder:validation:Enum=gcc;custom
+	// Kind is one of ["gcc", "clang", "custom"] (default is "gcc")
+	// +kubebuilder:validation:Enum=gcc;clang;custom
 	// +kubebuilder:default=gcc
 	Kind string `json:"kind,omitempty"`
 	// Version is the version of the toolchain image (default is "latest")
@@ -55,6 +55,8 @@ type ToolchainSpec struct {
 	Arch string `json:"arch,omitempty"`
 	// CustomImage is the base image for the toolchain (without the tag)
 	// If Kind is gcc, this defaults to "docker.io/gcc", but can be overridden.
+	// If Kind is clang, this defaults to "docker.io/silkeh/clang", but can be
+	// overridden.
 	// If Kind is custom, this field is required.
 	CustomImage *string `json:"image,omitempty"`
 }
@@ -97,6 +99,12 @@ type AgentSpec struct {
 type SchedulerSpec struct {
 	NodeAffinity *v1.NodeAffinity        `json:"nodeAffinity,omitempty"`
 	Resources    v1.ResourceRequirements `json:"resources,omitempty"`
+	// Replicas is the number of scheduler replicas. If more than one replica
+	// is deployed, leader election is enabled so that only one of them is
+	// active, and agents connect to the leader through the monitor.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	Replicas *int32 `json:"replicas,omitempty"`
 }
 
 type MonitorSpec struct {
@@ -111,6 +119,12 @@ type CacheSpec struct {
 	VolatileStorage *config.VolatileStorageSpec `json:"volatileStorage,omitempty"`
 	LocalStorage    *config.LocalStorageSpec    `json:"localStorage,omitempty"`
 	RemoteStorage   *config.RemoteStorageSpec   `json:"remoteStorage,omitempty"`
+	// Replicas is the number of cache servers. Cache servers are deployed as
+	// a StatefulSet so that each of them keeps its hostname when restarted.
+	// If more than one replica is deployed, the scheduler shards objects
+	// across all of them. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	Replicas *int32 `json:"replicas,omitempty"`
 }
 
 // BuildClusterStatus defines the observed state of BuildCluster.
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/kubecc-io/kubecc/pkg/clients"
@@ -52,6 +53,7 @@ type AgentServer struct {
 	schedulerClient  types.SchedulerClient
 	monitorClient    types.MonitorClient
 	usageLimits      *metrics.UsageLimits
+	usageLimitsMu    sync.Mutex
 	runningTasks     *atomic.Int32
 	cfsQuota         int64
 	cfsPeriod        int64
@@ -156,12 +158,49 @@ func NewAgentServer(
 }
 
 func (s *AgentServer) postUsageLimits() {
+	s.usageLimitsMu.Lock()
 	qp := &metrics.UsageLimits{
-		ConcurrentProcessLimit: s.usageLimits.ConcurrentProcessLimit,
+		ConcurrentProcessLimit: s.usageLimits.GetConcurrentProcessLimit(),
 	}
+	s.usageLimitsMu.Unlock()
 	s.metricsProvider.Post(qp)
 }
 
+// watchUsageLimits updates the agent's usage limits when the scheduler's
+// optimizer resizes the agent's token pool.
+func (s *AgentServer) watchUsageLimits() {
+	id := meta.UUID(s.srvContext)
+	l := clients.NewMetricsListener(s.srvContext, s.monitorClient)
+	l.OnProviderAdded(func(ctx context.Context, uuid string) {
+		whois, err := s.monitorClient.Whois(s.srvContext, &types.WhoisRequest{
+			UUID: uuid,
+		})
+		if err != nil || whois.Component != types.Scheduler {
+			return
+		}
+		l.OnValueChanged(uuid, func(u *metrics.AgentUsageLimits) {
+			limits, ok := u.GetAgents()[id]
+			if !ok {
+				return
+			}
+			s.usageLimitsMu.Lock()
+			changed := limits.GetConcurrentProcessLimit() !=
+				s.usageLimits.GetConcurrentProcessLimit()
+			if changed {
+				s.usageLimits = limits
+			}
+			s.usageLimitsMu.Unlock()
+			if changed {
+				s.lg.With(
+					"limit", limits.GetConcurrentProcessLimit(),
+				).Info("Usage limits updated by scheduler")
+				s.postUsageLimits()
+			}
+		})
+		<-ctx.Done()
+	})
+}
+
 func (s *AgentServer) postTaskStatus() {
 	ts := &metrics.TaskStatus{
 		NumRunning: s.runningTasks.Load(),
@@ -184,6 +223,7 @@ func (s *AgentServer) postCpuStats() {
 		return
 	}
 	s.metricsProvider.Post(&metrics.CpuStats{
+		WallTime: uint64(time.Now().UnixNano()),
 		CpuUsage: &metrics.CpuUsage{
 			TotalUsage: stats.CpuStats.CpuUsage.TotalUsage,
 			CfsQuota:   s.cfsQuota,
@@ -206,6 +246,9 @@ func (s *AgentServer) StartMetricsProvider() {
 		s.postCpuStats)
 	util.RunPeriodic(s.srvContext, 5*time.Second, 0.5, true,
 		s.postUsageLimits, s.postToolchains)
+	if s.monitorClient != nil {
+		s.watchUsageLimits()
+	}
 }
 
 func (s *AgentServer) HandleStream(stream grpc.ClientStream) error {
@@ -224,7 +267,17 @@ func (s *AgentServer) HandleStream(stream grpc.ClientStream) error {
 		}
 		streamCtx := stream.Context()
 		go func() {
-			err := stream.SendMsg(s.compile(streamCtx, compileRequest))
+			resp := s.compile(streamCtx, compileRequest)
+			if resp.CompileResult == types.CompileResponse_InternalError &&
+				streamCtx.Err() != nil {
+				// The task was interrupted because the agent is disconnecting. The
+				// scheduler will send it to another agent.
+				s.lg.With(
+					zap.String("id", compileRequest.RequestID),
+				).Debug("Task interrupted by disconnect")
+				return
+			}
+			err := stream.SendMsg(resp)
 			if err != nil {
 				s.lg.With(
 					zap.Error(err),
@@ -248,6 +301,32 @@ func (s *AgentServer) Target() string {
 	return "scheduler"
 }
 
+// Drain asks the scheduler to stop sending new tasks to this agent, then
+// blocks until the tasks the agent is running have completed or the context
+// is done.
+func (s *AgentServer) Drain(ctx context.Context) error {
+	stream, err := s.schedulerClient.DrainAgent(ctx, &types.DrainAgentRequest{
+		UUID:   meta.UUID(s.srvContext),
+		Action: types.Drain,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		progress, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if progress.GetDrained() {
+			s.lg.Info("Agent drained")
+			return nil
+		}
+		s.lg.With(
+			"running", progress.GetRunningTasks(),
+		).Info("Draining agent, waiting for running tasks to complete")
+	}
+}
+
 func (s *AgentServer) compile(
 	ctx context.Context,
 	req *types.CompileRequest,
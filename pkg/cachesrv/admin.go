@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cachesrv
+
+import (
+	"context"
+	"sort"
+
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tiers returns the storage providers used by the cache server, from fastest
+// to slowest.
+func (s *CacheServer) tiers() []storage.StorageProvider {
+	if chain, ok := s.storageProvider.(*storage.ChainStorageProvider); ok {
+		return chain.Providers()
+	}
+	return []storage.StorageProvider{s.storageProvider}
+}
+
+// Stats returns usage and hit statistics for each storage provider, and the
+// number and size of cached objects produced by each toolchain.
+func (s *CacheServer) Stats(
+	ctx context.Context,
+	_ *types.Empty,
+) (*types.CacheStats, error) {
+	s.lg.Debug("Handling stats request")
+	stats := &types.CacheStats{}
+	for _, tier := range s.tiers() {
+		usage := tier.UsageInfo()
+		hits := tier.CacheHits()
+		stats.Tiers = append(stats.Tiers, &types.CacheTierStats{
+			Location:            tier.Location(),
+			ObjectCount:         usage.GetObjectCount(),
+			TotalSize:           usage.GetTotalSize(),
+			UsagePercent:        usage.GetUsagePercent(),
+			CacheHitsTotal:      hits.GetCacheHitsTotal(),
+			CacheMissesTotal:    hits.GetCacheMissesTotal(),
+			CorruptObjectsTotal: hits.GetCorruptObjectsTotal(),
+		})
+	}
+
+	toolchains := map[string]*types.CacheToolchainStats{}
+	err := storage.ForEachObject(ctx, s.storageProvider, nil,
+		func(_ *types.CacheKey, md *types.CacheObjectMeta) error {
+			name := md.GetTags()[storage.ToolchainTag]
+			if name == "" {
+				name = "unknown"
+			}
+			tc, ok := toolchains[name]
+			if !ok {
+				tc = &types.CacheToolchainStats{
+					Toolchain: name,
+				}
+				toolchains[name] = tc
+			}
+			tc.ObjectCount++
+			tc.TotalSize += md.GetManagedFields().GetSize()
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	for _, tc := range toolchains {
+		stats.Toolchains = append(stats.Toolchains, tc)
+	}
+	sort.Slice(stats.Toolchains, func(i, j int) bool {
+		return stats.Toolchains[i].Toolchain < stats.Toolchains[j].Toolchain
+	})
+	return stats, nil
+}
+
+// Inspect returns the metadata of an object in each storage provider which
+// contains it.
+func (s *CacheServer) Inspect(
+	ctx context.Context,
+	key *types.CacheKey,
+) (*types.InspectResponse, error) {
+	s.lg.Debug("Handling inspect request")
+	resp := &types.InspectResponse{}
+	for _, tier := range s.tiers() {
+		results, err := tier.Query(ctx, []*types.CacheKey{key})
+		if err != nil {
+			return nil, err
+		}
+		if len(results) > 0 && results[0] != nil {
+			resp.Tiers = append(resp.Tiers, results[0])
+		}
+	}
+	if len(resp.Tiers) == 0 {
+		return nil, status.Error(codes.NotFound, "Object not found")
+	}
+	return resp, nil
+}
+
+// Purge deletes all objects matching the request's filter.
+func (s *CacheServer) Purge(
+	ctx context.Context,
+	req *types.PurgeRequest,
+) (*types.PurgeResponse, error) {
+	s.lg.Debug("Handling purge request")
+	resp, err := storage.PurgeObjects(ctx, s.storageProvider,
+		req.GetFilter(), req.GetDryRun())
+	if err != nil {
+		return nil, err
+	}
+	if !req.GetDryRun() {
+		s.lg.With(
+			"objects", resp.ObjectCount,
+			"size", resp.TotalSize,
+		).Info("Purged objects")
+	}
+	return resp, nil
+}
+
+// List streams the keys of all objects in storage, without their data.
+func (s *CacheServer) List(
+	_ *types.Empty,
+	srv types.Cache_ListServer,
+) error {
+	s.lg.Debug("Handling list request")
+	keys, err := s.storageProvider.List(srv.Context())
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := srv.Send(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cachesrv_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/test"
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+var _ = Describe("Cache administration", func() {
+	testEnv := test.NewBufconnEnvironmentWithLogLevel(zapcore.ErrorLevel)
+	var (
+		ctx    context.Context
+		client types.CacheClient
+	)
+	Specify("setup", func() {
+		ctx, _ = test.SpawnCache(testEnv, test.WithName("admin"))
+		client = types.NewCacheClient(testEnv.Dial(ctx, types.Cache, "admin"))
+		for i := 0; i < 6; i++ {
+			toolchain := "gnu"
+			if i%3 == 0 {
+				toolchain = "clang"
+			}
+			_, err := client.Push(ctx, &types.PushRequest{
+				Key: &types.CacheKey{
+					Hash: fmt.Sprintf("object%d", i),
+				},
+				Object: &types.CacheObject{
+					Data: []byte("data"),
+					Metadata: &types.CacheObjectMeta{
+						Tags: map[string]string{
+							storage.ToolchainTag: toolchain,
+							"index":              fmt.Sprint(i),
+						},
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+	It("should report per-tier and per-toolchain statistics", func() {
+		stats, err := client.Stats(ctx, &types.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats.Tiers).To(HaveLen(1))
+		Expect(stats.Tiers[0].Location).To(Equal(types.Memory))
+		Expect(stats.Tiers[0].ObjectCount).To(BeEquivalentTo(6))
+		Expect(stats.Toolchains).To(HaveLen(2))
+		Expect(stats.Toolchains[0].Toolchain).To(Equal("clang"))
+		Expect(stats.Toolchains[0].ObjectCount).To(BeEquivalentTo(2))
+		Expect(stats.Toolchains[1].Toolchain).To(Equal("gnu"))
+		Expect(stats.Toolchains[1].ObjectCount).To(BeEquivalentTo(4))
+		Expect(stats.Toolchains[1].TotalSize).To(BeEquivalentTo(4 * len("data")))
+	})
+	It("should inspect individual objects", func() {
+		resp, err := client.Inspect(ctx, &types.CacheKey{
+			Hash: "object1",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Tiers).To(HaveLen(1))
+		Expect(resp.Tiers[0].GetTags()).To(HaveKeyWithValue("index", "1"))
+		Expect(resp.Tiers[0].GetManagedFields().GetLocation()).To(Equal(types.Memory))
+
+		_, err = client.Inspect(ctx, &types.CacheKey{
+			Hash: "missing",
+		})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+	It("should list the keys of all objects", func() {
+		stream, err := client.List(ctx, &types.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		hashes := []string{}
+		for {
+			key, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			Expect(err).NotTo(HaveOccurred())
+			hashes = append(hashes, key.Hash)
+		}
+		Expect(hashes).To(ConsistOf(
+			"object0", "object1", "object2", "object3", "object4", "object5",
+		))
+	})
+	It("should purge objects matching a filter", func() {
+		filter := &types.CacheFilter{
+			Toolchains: []string{"clang"},
+		}
+		resp, err := client.Purge(ctx, &types.PurgeRequest{
+			Filter: filter,
+			DryRun: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.ObjectCount).To(BeEquivalentTo(2))
+
+		resp, err = client.Purge(ctx, &types.PurgeRequest{
+			Filter: filter,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.ObjectCount).To(BeEquivalentTo(2))
+
+		stats, err := client.Stats(ctx, &types.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats.Tiers[0].ObjectCount).To(BeEquivalentTo(4))
+		Expect(stats.Toolchains).To(HaveLen(1))
+		Expect(stats.Toolchains[0].Toolchain).To(Equal("gnu"))
+	})
+})
@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cachesrv
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// archiveChunkSize is the maximum size of archive chunks sent by Export.
+const archiveChunkSize = 1024 * 1024
+
+type exportWriter struct {
+	srv types.Cache_ExportServer
+}
+
+func (w exportWriter) Write(p []byte) (int, error) {
+	if err := w.srv.Send(&types.ArchiveChunk{
+		Data: p,
+	}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+type importReader struct {
+	srv types.Cache_ImportServer
+	buf []byte
+}
+
+func (r *importReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.srv.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = req.GetData()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// archiveError converts errors from reading or writing archives to status
+// errors.
+func archiveError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, storage.ErrInvalidArchive) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// Export streams a cache archive containing the objects matching the
+// request's filter.
+func (s *CacheServer) Export(
+	req *types.ExportRequest,
+	srv types.Cache_ExportServer,
+) error {
+	s.lg.Debug("Handling export request")
+	w := bufio.NewWriterSize(exportWriter{srv: srv}, archiveChunkSize)
+	count, err := storage.ExportObjects(srv.Context(), s.storageProvider,
+		req.GetFilter(), w)
+	if err != nil {
+		return archiveError(err)
+	}
+	if err := w.Flush(); err != nil {
+		return archiveError(err)
+	}
+	s.lg.With("objects", count).Info("Exported cache archive")
+	return nil
+}
+
+// Import stores the objects in a cache archive. If the first request sets a
+// storage location, objects are stored only in the storage provider with that
+// location.
+func (s *CacheServer) Import(srv types.Cache_ImportServer) error {
+	s.lg.Debug("Handling import request")
+	first, err := srv.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.InvalidArgument, "No archive data received")
+		}
+		return err
+	}
+	target := s.storageProvider
+	if loc := first.GetLocation(); loc != types.Unknown {
+		var ok bool
+		if chain, isChain := target.(*storage.ChainStorageProvider); isChain {
+			target, ok = chain.Provider(loc)
+		} else {
+			ok = target.Location() == loc
+		}
+		if !ok {
+			return status.Errorf(codes.InvalidArgument,
+				"No storage provider with location %s", loc.String())
+		}
+	}
+	resp, err := storage.ImportObjects(srv.Context(), target, &importReader{
+		srv: srv,
+		buf: first.GetData(),
+	})
+	if err != nil {
+		return archiveError(err)
+	}
+	s.lg.With(
+		"imported", resp.Imported,
+		"skipped", resp.Skipped,
+		"invalid", resp.Invalid,
+	).Info("Imported cache archive")
+	return srv.SendAndClose(resp)
+}
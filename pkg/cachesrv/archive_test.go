@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cachesrv_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kubecc-io/kubecc/pkg/clients"
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/test"
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+var _ = Describe("Cache archives", func() {
+	testEnv := test.NewBufconnEnvironmentWithLogLevel(zapcore.ErrorLevel)
+	var (
+		ctx     context.Context
+		src     types.CacheClient
+		dst     types.CacheClient
+		archive bytes.Buffer
+		dir     string
+	)
+	Specify("setup", func() {
+		var err error
+		dir, err = os.MkdirTemp("", "kubecc-archive-test")
+		Expect(err).NotTo(HaveOccurred())
+		ctx, _ = test.SpawnCache(testEnv, test.WithName("archive-src"))
+		test.SpawnCache(testEnv,
+			test.WithName("archive-dst"),
+			test.WithConfig(config.CacheSpec{
+				LocalStorage: &config.LocalStorageSpec{
+					Path: dir,
+					Limits: config.StorageLimitsSpec{
+						Disk: "1Gi",
+					},
+				},
+			}),
+		)
+		src = types.NewCacheClient(testEnv.Dial(ctx, types.Cache, "archive-src"))
+		dst = types.NewCacheClient(testEnv.Dial(ctx, types.Cache, "archive-dst"))
+		for i := 0; i < 10; i++ {
+			toolchain := "gnu"
+			if i%2 == 1 {
+				toolchain = "clang"
+			}
+			_, err := src.Push(ctx, &types.PushRequest{
+				Key: &types.CacheKey{
+					Hash: fmt.Sprintf("object%d", i),
+				},
+				Object: &types.CacheObject{
+					Data: []byte(fmt.Sprintf("data %d", i)),
+					Metadata: &types.CacheObjectMeta{
+						Tags: map[string]string{
+							storage.ToolchainTag: toolchain,
+						},
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+	It("should export objects matching a filter", func() {
+		Expect(clients.ExportArchive(ctx, src, &types.CacheFilter{
+			Toolchains: []string{"gnu"},
+		}, &archive)).To(Succeed())
+
+		ar, err := storage.NewArchiveReader(bytes.NewReader(archive.Bytes()))
+		Expect(err).NotTo(HaveOccurred())
+		count := 0
+		for {
+			key, obj, err := ar.Next()
+			if err != nil {
+				break
+			}
+			Expect(obj.GetMetadata().GetTags()).
+				To(HaveKeyWithValue(storage.ToolchainTag, "gnu"))
+			Expect(obj.Data).To(BeEquivalentTo(
+				"data " + key.GetHash()[len("object"):]))
+			count++
+		}
+		Expect(count).To(Equal(5))
+	})
+	It("should import objects into a specific storage provider", func() {
+		resp, err := clients.ImportArchive(ctx, dst, types.Disk,
+			bytes.NewReader(archive.Bytes()))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Imported).To(BeEquivalentTo(5))
+
+		keys := []*types.CacheKey{}
+		for i := 0; i < 10; i++ {
+			keys = append(keys, &types.CacheKey{
+				Hash: fmt.Sprintf("object%d", i),
+			})
+		}
+		results, err := dst.Query(ctx, &types.QueryRequest{
+			Keys: keys,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		for i, md := range results.Results {
+			if i%2 == 0 {
+				Expect(md.GetManagedFields().GetLocation()).To(Equal(types.Disk))
+				Expect(md.GetTags()).To(HaveKeyWithValue(storage.ToolchainTag, "gnu"))
+			} else {
+				Expect(md.GetManagedFields()).To(BeNil())
+			}
+		}
+	})
+	It("should skip objects that already exist", func() {
+		resp, err := clients.ImportArchive(ctx, dst, types.Disk,
+			bytes.NewReader(archive.Bytes()))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Imported).To(BeEquivalentTo(0))
+		Expect(resp.Skipped).To(BeEquivalentTo(5))
+	})
+	It("should reject invalid archives and locations", func() {
+		_, err := clients.ImportArchive(ctx, dst, types.Unknown,
+			bytes.NewReader([]byte("not an archive")))
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		_, err = clients.ImportArchive(ctx, dst, types.S3,
+			bytes.NewReader(archive.Bytes()))
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	})
+	Specify("cleanup", func() {
+		os.RemoveAll(dir)
+	})
+})
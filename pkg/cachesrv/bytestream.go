@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cachesrv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	repb "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"google.golang.org/genproto/googleapis/bytestream"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// readChunkSize is the maximum size of each message sent by Read.
+const readChunkSize = 64 * 1024
+
+// parseResourceName extracts the digest from a ByteStream resource name.
+// Read resource names have the form "[{instance}/]blobs/{hash}/{size}[/...]"
+// and write resource names have the form
+// "[{instance}/]uploads/{uuid}/blobs/{hash}/{size}[/...]".
+func parseResourceName(name string, write bool) (*repb.Digest, error) {
+	parts := strings.Split(name, "/")
+	for i, part := range parts {
+		switch {
+		case write && part == "uploads" && i+2 < len(parts):
+			// Skip the upload uuid
+			return parseBlobPath(name, parts[i+2:])
+		case !write && (part == "blobs" || part == "compressed-blobs"):
+			return parseBlobPath(name, parts[i:])
+		}
+	}
+	return nil, status.Errorf(codes.InvalidArgument,
+		"Invalid resource name: %q", name)
+}
+
+// parseBlobPath parses the "blobs/{hash}/{size}" part of a resource name.
+func parseBlobPath(name string, parts []string) (*repb.Digest, error) {
+	if parts[0] == "compressed-blobs" {
+		return nil, status.Error(codes.InvalidArgument,
+			"Compression is not supported")
+	}
+	if parts[0] != "blobs" || len(parts) < 3 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Invalid resource name: %q", name)
+	}
+	size, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Invalid resource name: %q", name)
+	}
+	d := &repb.Digest{
+		Hash:      parts[1],
+		SizeBytes: size,
+	}
+	if err := validateDigest(d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (s *REAPIServer) Read(
+	req *bytestream.ReadRequest,
+	srv bytestream.ByteStream_ReadServer,
+) error {
+	d, err := parseResourceName(req.ResourceName, false)
+	if err != nil {
+		return err
+	}
+	if req.ReadOffset < 0 || req.ReadOffset > d.SizeBytes {
+		return status.Errorf(codes.OutOfRange,
+			"Invalid read offset: %d", req.ReadOffset)
+	}
+	if req.ReadLimit < 0 {
+		return status.Errorf(codes.InvalidArgument,
+			"Invalid read limit: %d", req.ReadLimit)
+	}
+	data, err := s.getBlob(srv.Context(), d)
+	if err != nil {
+		return err
+	}
+	// The blob is known to have the size given in the resource name, so the
+	// offset checked above is within bounds
+	data = data[req.ReadOffset:]
+	if req.ReadLimit > 0 && req.ReadLimit < int64(len(data)) {
+		data = data[:req.ReadLimit]
+	}
+	for len(data) > 0 {
+		n := readChunkSize
+		if n > len(data) {
+			n = len(data)
+		}
+		if err := srv.Send(&bytestream.ReadResponse{
+			Data: data[:n],
+		}); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// Write receives a blob and stores it once the write is finished. Partial
+// writes are not persisted, so interrupted uploads must be restarted.
+func (s *REAPIServer) Write(srv bytestream.ByteStream_WriteServer) error {
+	req, err := srv.Recv()
+	if err != nil {
+		return err
+	}
+	d, err := parseResourceName(req.ResourceName, true)
+	if err != nil {
+		return err
+	}
+	if d.SizeBytes > s.maxObjectSize {
+		return status.Errorf(codes.InvalidArgument,
+			"Blob size %d exceeds the limit of %d bytes",
+			d.SizeBytes, s.maxObjectSize)
+	}
+	if s.blobExists(srv.Context(), d) {
+		// The client can stop uploading as soon as it receives a response
+		return srv.SendAndClose(&bytestream.WriteResponse{
+			CommittedSize: d.SizeBytes,
+		})
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, d.SizeBytes))
+	for {
+		if req.WriteOffset != int64(buf.Len()) {
+			return status.Errorf(codes.InvalidArgument,
+				"Invalid write offset %d, expected %d", req.WriteOffset, buf.Len())
+		}
+		buf.Write(req.Data)
+		if int64(buf.Len()) > d.SizeBytes {
+			return status.Errorf(codes.InvalidArgument,
+				"Received more than %d bytes", d.SizeBytes)
+		}
+		if req.FinishWrite {
+			break
+		}
+		req, err = srv.Recv()
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.InvalidArgument,
+				"Stream closed before the write was finished")
+		} else if err != nil {
+			return err
+		}
+	}
+	if err := s.putBlob(srv.Context(), d, buf.Bytes()); err != nil {
+		return err
+	}
+	return srv.SendAndClose(&bytestream.WriteResponse{
+		CommittedSize: int64(buf.Len()),
+	})
+}
+
+// QueryWriteStatus reports uploads as complete if the blob exists. Since
+// partial writes are not persisted, uploads in progress can not be resumed.
+func (s *REAPIServer) QueryWriteStatus(
+	ctx context.Context,
+	req *bytestream.QueryWriteStatusRequest,
+) (*bytestream.QueryWriteStatusResponse, error) {
+	d, err := parseResourceName(req.ResourceName, true)
+	if err != nil {
+		return nil, err
+	}
+	if !s.blobExists(ctx, d) {
+		return nil, status.Errorf(codes.NotFound,
+			"No upload found for %q", req.ResourceName)
+	}
+	return &bytestream.QueryWriteStatusResponse{
+		CommittedSize: d.SizeBytes,
+		Complete:      true,
+	}, nil
+}
+
+func (s *REAPIServer) blobExists(ctx context.Context, d *repb.Digest) bool {
+	missing, err := s.FindMissingBlobs(ctx, &repb.FindMissingBlobsRequest{
+		BlobDigests: []*repb.Digest{d},
+	})
+	return err == nil && len(missing.MissingBlobDigests) == 0
+}
@@ -18,17 +18,164 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package cachesrv_test
 
 import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/atomic"
 	"go.uber.org/zap/zapcore"
 
-	// . "github.com/onsi/gomega"
-
+	"github.com/kubecc-io/kubecc/pkg/cachesrv"
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/storage"
 	"github.com/kubecc-io/kubecc/pkg/test"
+	"github.com/kubecc-io/kubecc/pkg/types"
 )
 
+func syncKeys(
+	ctx context.Context,
+	client types.CacheClient,
+	req *types.SyncRequest,
+) []string {
+	stream, err := client.Sync(ctx, req)
+	Expect(err).NotTo(HaveOccurred())
+	keys := []string{}
+	for {
+		obj, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obj.GetObject().GetData()).NotTo(BeEmpty())
+		keys = append(keys, obj.GetKey().GetHash())
+	}
+	return keys
+}
+
 var _ = Describe("Cache Server", func() {
 	testEnv := test.NewBufconnEnvironmentWithLogLevel(zapcore.ErrorLevel)
+	var client types.CacheClient
 	Specify("setup", func() {
-		test.SpawnCache(testEnv)
+		ctx, _ := test.SpawnCache(testEnv)
+		client = test.NewCacheClient(testEnv, ctx)
+	})
+	Context("Sync", func() {
+		sizes := map[string]int{
+			"small":  1024,
+			"large1": 4096,
+			"large2": 4096,
+		}
+		It("should store objects", func() {
+			for hash, size := range sizes {
+				_, err := client.Push(testEnv.Context(), &types.PushRequest{
+					Key: &types.CacheKey{Hash: hash},
+					Object: &types.CacheObject{
+						Data: make([]byte, size),
+						Metadata: &types.CacheObjectMeta{
+							ExpirationDate: time.Now().Add(time.Hour).UnixNano(),
+						},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+		It("should send all objects when there is enough space", func() {
+			Expect(syncKeys(testEnv.Context(), client, &types.SyncRequest{
+				DesiredCacheSizeKb: 16,
+			})).To(ConsistOf("small", "large1", "large2"))
+		})
+		It("should not send objects the client already has", func() {
+			Expect(syncKeys(testEnv.Context(), client, &types.SyncRequest{
+				LocalCache:         []*types.CacheKey{{Hash: "large1"}},
+				DesiredCacheSizeKb: 16,
+			})).To(ConsistOf("small", "large2"))
+		})
+		It("should not exceed the desired cache size", func() {
+			Expect(syncKeys(testEnv.Context(), client, &types.SyncRequest{
+				DesiredCacheSizeKb: 2,
+			})).To(ConsistOf("small"))
+		})
+		It("should reject invalid requests", func() {
+			stream, err := client.Sync(testEnv.Context(), &types.SyncRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = stream.Recv()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+// remoteProvider reports itself as remote storage, and counts how many times
+// objects are listed or queried.
+type remoteProvider struct {
+	storage.StorageProvider
+	calls *atomic.Int64
+}
+
+func (remoteProvider) Location() types.StorageLocation {
+	return types.S3
+}
+
+func (p remoteProvider) List(ctx context.Context) ([]*types.CacheKey, error) {
+	p.calls.Inc()
+	return p.StorageProvider.List(ctx)
+}
+
+func (p remoteProvider) Query(
+	ctx context.Context,
+	keys []*types.CacheKey,
+) ([]*types.CacheObjectMeta, error) {
+	p.calls.Inc()
+	return p.StorageProvider.Query(ctx, keys)
+}
+
+var _ = Describe("Cache Server Sync Tiers", func() {
+	testEnv := test.NewBufconnEnvironmentWithLogLevel(zapcore.ErrorLevel)
+	var client types.CacheClient
+	var remote remoteProvider
+	newVolatile := func() storage.StorageProvider {
+		return storage.NewVolatileStorageProvider(testEnv.Context(),
+			config.VolatileStorageSpec{
+				Limits: config.StorageLimitsSpec{
+					Memory: "1Mi",
+				},
+			})
+	}
+	Specify("setup", func() {
+		remote = remoteProvider{
+			StorageProvider: newVolatile(),
+			calls:           atomic.NewInt64(0),
+		}
+		chain := storage.NewChainStorageProvider(testEnv.Context(),
+			[]storage.StorageProvider{newVolatile(), remote})
+		ctx, _ := test.SpawnCache(testEnv,
+			test.WithCacheOptions(cachesrv.WithStorageProvider(chain)))
+		client = test.NewCacheClient(testEnv, ctx)
+		for _, hash := range []string{"a", "b", "c"} {
+			_, err := client.Push(testEnv.Context(), &types.PushRequest{
+				Key: &types.CacheKey{Hash: hash},
+				Object: &types.CacheObject{
+					Data: make([]byte, 1024),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+	It("should not check objects in remote storage", func() {
+		Expect(syncKeys(testEnv.Context(), client, &types.SyncRequest{
+			DesiredCacheSizeKb: 16,
+		})).To(ConsistOf("a", "b", "c"))
+		Expect(remote.calls.Load()).To(BeZero())
+	})
+	It("should limit the number of objects checked", func() {
+		defer func(max int) {
+			cachesrv.MaxSyncCandidates = max
+		}(cachesrv.MaxSyncCandidates)
+		cachesrv.MaxSyncCandidates = 2
+		Expect(syncKeys(testEnv.Context(), client, &types.SyncRequest{
+			DesiredCacheSizeKb: 16,
+		})).To(HaveLen(2))
 	})
 })
@@ -0,0 +1,244 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cachesrv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/meta"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"github.com/kubecc-io/kubecc/pkg/util"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// HTTPTag is the tag key set on objects stored through the HTTP
+	// endpoint.
+	HTTPTag = "http"
+
+	httpNamespace = "http"
+
+	defaultMaxObjectSize = 64 * 1024 * 1024
+	maxPathLength        = 1024
+)
+
+// HTTPServer serves cached objects over plain HTTP using the protocol of the
+// ccache HTTP remote storage backend: objects are retrieved with GET, checked
+// with HEAD, and stored with PUT. Objects are identified by their full
+// request path, which allows any of ccache's layouts to be used, as well as
+// the nested paths used by the sccache WebDAV backend.
+type HTTPServer struct {
+	srvContext      context.Context
+	lg              *zap.SugaredLogger
+	cfg             config.HTTPCacheSpec
+	storageProvider storage.StorageProvider
+	maxObjectSize   int64
+	hitsTotal       *atomic.Int64
+	missesTotal     *atomic.Int64
+}
+
+// NewHTTPServer creates a new HTTPServer backed by the given storage
+// provider. The storage provider should already be configured.
+func NewHTTPServer(
+	ctx context.Context,
+	cfg config.HTTPCacheSpec,
+	sp storage.StorageProvider,
+) (*HTTPServer, error) {
+	srv := &HTTPServer{
+		srvContext:      ctx,
+		lg:              meta.Log(ctx),
+		cfg:             cfg,
+		storageProvider: sp,
+		maxObjectSize:   defaultMaxObjectSize,
+		hitsTotal:       atomic.NewInt64(0),
+		missesTotal:     atomic.NewInt64(0),
+	}
+	if cfg.MaxObjectSize != "" {
+		q, err := resource.ParseQuantity(cfg.MaxObjectSize)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid max object size: %s",
+				storage.ConfigurationError, err.Error())
+		}
+		srv.maxObjectSize = q.Value()
+	}
+	return srv, nil
+}
+
+// ListenAndServe serves the HTTP endpoint on the configured listen address
+// until the server's context is done.
+func (s *HTTPServer) ListenAndServe() error {
+	listener, err := net.Listen("tcp", s.cfg.ListenAddress)
+	if err != nil {
+		return err
+	}
+	s.lg.With(
+		zap.String("addr", listener.Addr().String()),
+	).Info("Serving HTTP cache endpoint")
+	srv := &http.Server{
+		Handler: s,
+		BaseContext: func(net.Listener) context.Context {
+			return s.srvContext
+		},
+	}
+	go func() {
+		<-s.srvContext.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}()
+	if err := srv.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// HitsTotal returns the number of objects found by GET or HEAD requests.
+func (s *HTTPServer) HitsTotal() int64 {
+	return s.hitsTotal.Load()
+}
+
+// MissesTotal returns the number of objects not found by GET or HEAD
+// requests.
+func (s *HTTPServer) MissesTotal() int64 {
+	return s.missesTotal.Load()
+}
+
+func (s *HTTPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "MKCOL" {
+		// WebDAV clients may try to create the directories containing an
+		// object before storing it. Directories are not needed here.
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+	key := path.Clean("/" + r.URL.Path)
+	if key == "/" || len(key) > maxPathLength {
+		http.Error(w, "invalid path", http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		s.handleGet(w, r, key)
+	case http.MethodPut:
+		s.handlePut(w, r, key)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *HTTPServer) handleGet(w http.ResponseWriter, r *http.Request, key string) {
+	var size int64
+	var data []byte
+	if r.Method == http.MethodHead {
+		results, err := s.storageProvider.Query(r.Context(), []*types.CacheKey{
+			httpKey(key),
+		})
+		if err != nil {
+			s.httpError(w, err)
+			return
+		}
+		if results[0] == nil {
+			s.missesTotal.Inc()
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		size = results[0].GetManagedFields().GetSize()
+	} else {
+		obj, err := s.storageProvider.Get(r.Context(), httpKey(key))
+		switch status.Code(err) {
+		case codes.OK:
+		case codes.NotFound, codes.DataLoss:
+			s.missesTotal.Inc()
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		default:
+			s.httpError(w, err)
+			return
+		}
+		data = obj.GetData()
+		size = int64(len(data))
+	}
+	s.hitsTotal.Inc()
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	w.WriteHeader(http.StatusOK)
+	if data != nil {
+		if _, err := w.Write(data); err != nil {
+			s.lg.With(zap.Error(err)).Debug("Error writing response")
+		}
+	}
+}
+
+func (s *HTTPServer) handlePut(w http.ResponseWriter, r *http.Request, key string) {
+	if r.ContentLength > s.maxObjectSize {
+		http.Error(w, "object too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	buf := &bytes.Buffer{}
+	_, err := buf.ReadFrom(http.MaxBytesReader(w, r.Body, s.maxObjectSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	err = s.storageProvider.Put(r.Context(), httpKey(key), &types.CacheObject{
+		Data: buf.Bytes(),
+		Metadata: &types.CacheObjectMeta{
+			Tags: map[string]string{
+				HTTPTag: httpNamespace,
+			},
+		},
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		w.WriteHeader(http.StatusCreated)
+	case codes.AlreadyExists:
+		// Objects are immutable, the existing object is kept
+		w.WriteHeader(http.StatusOK)
+	default:
+		s.httpError(w, err)
+	}
+}
+
+func (s *HTTPServer) httpError(w http.ResponseWriter, err error) {
+	s.lg.With(zap.Error(err)).Error("Error handling HTTP cache request")
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// httpKey returns the cache key of an object stored through the HTTP
+// endpoint. Paths are hashed so that they can be used as keys by any storage
+// provider, and the namespace is appended to keep keys evenly distributed
+// (see reapiKey).
+func httpKey(urlPath string) *types.CacheKey {
+	return &types.CacheKey{
+		Hash: fmt.Sprintf("%s.%s", util.Digest([]byte(urlPath)), httpNamespace),
+	}
+}
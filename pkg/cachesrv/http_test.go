@@ -0,0 +1,174 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cachesrv_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/kubecc-io/kubecc/pkg/cachesrv"
+	"github.com/kubecc-io/kubecc/pkg/clients"
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/meta"
+	"github.com/kubecc-io/kubecc/pkg/metrics"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/test"
+)
+
+var _ = Describe("HTTP Endpoint", func() {
+	testEnv := test.NewBufconnEnvironmentWithLogLevel(zapcore.ErrorLevel)
+	var (
+		httpSrv *cachesrv.HTTPServer
+		server  *httptest.Server
+	)
+	do := func(method, path string, body []byte) (int, []byte) {
+		req, err := http.NewRequest(method, server.URL+path, bytes.NewReader(body))
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		resp, err := server.Client().Do(req)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return resp.StatusCode, data
+	}
+	Specify("setup", func() {
+		sp := storage.NewVolatileStorageProvider(testEnv.Context(),
+			config.VolatileStorageSpec{
+				Limits: config.StorageLimitsSpec{
+					Memory: "1Mi",
+				},
+			})
+		Expect(sp.Configure()).To(Succeed())
+		var err error
+		httpSrv, err = cachesrv.NewHTTPServer(testEnv.Context(),
+			config.HTTPCacheSpec{
+				MaxObjectSize: "1Ki",
+			}, sp)
+		Expect(err).NotTo(HaveOccurred())
+		server = httptest.NewServer(httpSrv)
+	})
+	It("should report missing objects", func() {
+		code, _ := do(http.MethodGet, "/cache/0a1b2c3d", nil)
+		Expect(code).To(Equal(http.StatusNotFound))
+		code, _ = do(http.MethodHead, "/cache/0a1b2c3d", nil)
+		Expect(code).To(Equal(http.StatusNotFound))
+		Expect(httpSrv.MissesTotal()).To(BeEquivalentTo(2))
+	})
+	It("should store and retrieve objects", func() {
+		code, _ := do(http.MethodPut, "/cache/0a1b2c3d", []byte("object"))
+		Expect(code).To(Equal(http.StatusCreated))
+		code, data := do(http.MethodGet, "/cache/0a1b2c3d", nil)
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(data).To(Equal([]byte("object")))
+		code, data = do(http.MethodHead, "/cache/0a1b2c3d", nil)
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(data).To(BeEmpty())
+		Expect(httpSrv.HitsTotal()).To(BeEquivalentTo(2))
+	})
+	It("should distinguish objects by their full path", func() {
+		code, _ := do(http.MethodPut, "/cache/0a/1b2c3d", []byte("subdirs"))
+		Expect(code).To(Equal(http.StatusCreated))
+		code, _ = do(http.MethodPut, "/cache/0b/1b2c3d", []byte("other"))
+		Expect(code).To(Equal(http.StatusCreated))
+		_, data := do(http.MethodGet, "/cache/0a/1b2c3d", nil)
+		Expect(data).To(Equal([]byte("subdirs")))
+		_, data = do(http.MethodGet, "/cache/0b/1b2c3d", nil)
+		Expect(data).To(Equal([]byte("other")))
+	})
+	It("should keep existing objects", func() {
+		code, _ := do(http.MethodPut, "/cache/0a1b2c3d", []byte("changed"))
+		Expect(code).To(Equal(http.StatusOK))
+		_, data := do(http.MethodGet, "/cache/0a1b2c3d", nil)
+		Expect(data).To(Equal([]byte("object")))
+	})
+	It("should accept WebDAV directory creation", func() {
+		code, _ := do("MKCOL", "/a/b/c/", nil)
+		Expect(code).To(Equal(http.StatusCreated))
+	})
+	It("should reject invalid requests", func() {
+		code, _ := do(http.MethodPut, "/cache/large", make([]byte, 2048))
+		Expect(code).To(Equal(http.StatusRequestEntityTooLarge))
+		code, _ = do(http.MethodGet, "/", nil)
+		Expect(code).To(Equal(http.StatusBadRequest))
+		code, _ = do(http.MethodDelete, "/cache/0a1b2c3d", nil)
+		Expect(code).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
+
+// noStatsProvider reports no cache hit statistics, like a storage chain whose
+// first provider has not collected any yet.
+type noStatsProvider struct {
+	storage.StorageProvider
+}
+
+func (noStatsProvider) CacheHits() *metrics.CacheHits {
+	return nil
+}
+
+var _ = Describe("HTTP Endpoint Metrics", func() {
+	testEnv := test.NewBufconnEnvironmentWithLogLevel(zapcore.ErrorLevel)
+	hits := make(chan *metrics.CacheHits, 10)
+	Specify("setup", func() {
+		test.SpawnMonitor(testEnv)
+		sp := storage.NewVolatileStorageProvider(testEnv.Context(),
+			config.VolatileStorageSpec{
+				Limits: config.StorageLimitsSpec{
+					Memory: "1Mi",
+				},
+			})
+		ctx, _ := test.SpawnCache(testEnv,
+			test.WithConfig(config.CacheSpec{
+				VolatileStorage: &config.VolatileStorageSpec{
+					Limits: config.StorageLimitsSpec{
+						Memory: "1Mi",
+					},
+				},
+				HTTP: &config.HTTPCacheSpec{},
+			}),
+			test.WithCacheOptions(
+				cachesrv.WithStorageProvider(noStatsProvider{sp}),
+			),
+		)
+		listener := clients.NewMetricsListener(testEnv.Context(),
+			test.NewMonitorClient(testEnv, testEnv.Context()))
+		listener.OnProviderAdded(func(pctx context.Context, uuid string) {
+			if uuid != meta.UUID(ctx) {
+				return
+			}
+			listener.OnValueChanged(uuid, func(h *metrics.CacheHits) {
+				hits <- h
+			})
+			<-pctx.Done()
+		})
+	})
+	It("should post cache hits without any traffic", func() {
+		var h *metrics.CacheHits
+		Eventually(hits, 10*time.Second).Should(Receive(&h))
+		Expect(h.HTTPHitsTotal).To(BeZero())
+		Expect(h.HTTPMissesTotal).To(BeZero())
+		Expect(h.CacheHitsTotal).To(BeZero())
+	})
+})
@@ -0,0 +1,483 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cachesrv
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	repb "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"github.com/bazelbuild/remote-apis/build/bazel/semver"
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/meta"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"github.com/kubecc-io/kubecc/pkg/util"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/bytestream"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// REAPITag is the tag key set on objects stored through the REAPI
+	// frontend. Its value is the namespace the object belongs to.
+	REAPITag = "reapi"
+
+	casNamespace = "cas"
+	acNamespace  = "ac"
+
+	defaultMaxBatchSize = 4 * 1024 * 1024
+)
+
+// REAPIServices contains the full names of the services served by
+// REAPIServer. These are called by Bazel, which does not send any kubecc
+// metadata (see servers.WithExternalServices).
+var REAPIServices = []string{
+	"build.bazel.remote.execution.v2.ContentAddressableStorage",
+	"build.bazel.remote.execution.v2.ActionCache",
+	"build.bazel.remote.execution.v2.Capabilities",
+	"google.bytestream.ByteStream",
+}
+
+// emptyDigest is the SHA-256 digest of an empty blob. Clients may reference
+// it without uploading it first, so it is always considered to be present.
+var emptyDigest = util.Digest(nil)
+
+// REAPIServer serves the cache services of the Bazel Remote Execution API
+// using the same storage provider as the cache server. Blobs are stored in
+// the CAS namespace keyed by their digest, and action results are stored in
+// the AC namespace keyed by the digest of the action.
+//
+// Action cache entries can not be replaced until they expire, since storage
+// providers do not allow overwriting objects. Updating an existing entry
+// returns the result that is already stored.
+type REAPIServer struct {
+	srvContext      context.Context
+	lg              *zap.SugaredLogger
+	storageProvider storage.StorageProvider
+	maxBatchSize    int64
+	maxObjectSize   int64
+}
+
+var (
+	_ repb.ContentAddressableStorageServer = (*REAPIServer)(nil)
+	_ repb.ActionCacheServer               = (*REAPIServer)(nil)
+	_ repb.CapabilitiesServer              = (*REAPIServer)(nil)
+	_ bytestream.ByteStreamServer          = (*REAPIServer)(nil)
+)
+
+// NewREAPIServer creates a new REAPIServer backed by the given storage
+// provider. The storage provider should already be configured.
+func NewREAPIServer(
+	ctx context.Context,
+	cfg config.REAPISpec,
+	sp storage.StorageProvider,
+) (*REAPIServer, error) {
+	srv := &REAPIServer{
+		srvContext:      ctx,
+		lg:              meta.Log(ctx),
+		storageProvider: sp,
+		maxBatchSize:    defaultMaxBatchSize,
+		maxObjectSize:   defaultMaxObjectSize,
+	}
+	if cfg.MaxBatchSize != "" {
+		q, err := resource.ParseQuantity(cfg.MaxBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid max batch size: %s",
+				storage.ConfigurationError, err.Error())
+		}
+		srv.maxBatchSize = q.Value()
+	}
+	if cfg.MaxObjectSize != "" {
+		q, err := resource.ParseQuantity(cfg.MaxObjectSize)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid max object size: %s",
+				storage.ConfigurationError, err.Error())
+		}
+		srv.maxObjectSize = q.Value()
+	}
+	return srv, nil
+}
+
+// RegisterREAPIServer registers all REAPI cache services on the given server.
+func RegisterREAPIServer(s *grpc.Server, srv *REAPIServer) {
+	repb.RegisterContentAddressableStorageServer(s, srv)
+	repb.RegisterActionCacheServer(s, srv)
+	repb.RegisterCapabilitiesServer(s, srv)
+	bytestream.RegisterByteStreamServer(s, srv)
+}
+
+func (s *REAPIServer) GetCapabilities(
+	ctx context.Context,
+	req *repb.GetCapabilitiesRequest,
+) (*repb.ServerCapabilities, error) {
+	return &repb.ServerCapabilities{
+		CacheCapabilities: &repb.CacheCapabilities{
+			DigestFunctions: []repb.DigestFunction_Value{
+				repb.DigestFunction_SHA256,
+			},
+			ActionCacheUpdateCapabilities: &repb.ActionCacheUpdateCapabilities{
+				UpdateEnabled: true,
+			},
+			MaxBatchTotalSizeBytes:      s.maxBatchSize,
+			SymlinkAbsolutePathStrategy: repb.SymlinkAbsolutePathStrategy_ALLOWED,
+		},
+		LowApiVersion:  &semver.SemVer{Major: 2},
+		HighApiVersion: &semver.SemVer{Major: 2},
+	}, nil
+}
+
+func (s *REAPIServer) FindMissingBlobs(
+	ctx context.Context,
+	req *repb.FindMissingBlobsRequest,
+) (*repb.FindMissingBlobsResponse, error) {
+	digests := make([]*repb.Digest, 0, len(req.BlobDigests))
+	keys := make([]*types.CacheKey, 0, len(req.BlobDigests))
+	for _, d := range req.BlobDigests {
+		if err := validateDigest(d); err != nil {
+			return nil, err
+		}
+		if d.Hash == emptyDigest {
+			continue
+		}
+		digests = append(digests, d)
+		keys = append(keys, reapiKey(casNamespace, d))
+	}
+	results, err := s.storageProvider.Query(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	resp := &repb.FindMissingBlobsResponse{}
+	for i, md := range results {
+		if md == nil {
+			resp.MissingBlobDigests = append(resp.MissingBlobDigests, digests[i])
+		}
+	}
+	return resp, nil
+}
+
+func (s *REAPIServer) BatchUpdateBlobs(
+	ctx context.Context,
+	req *repb.BatchUpdateBlobsRequest,
+) (*repb.BatchUpdateBlobsResponse, error) {
+	var total int64
+	for _, r := range req.Requests {
+		total += int64(len(r.Data))
+	}
+	if total > s.maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Batch size %d exceeds the maximum of %d bytes", total, s.maxBatchSize)
+	}
+	resp := &repb.BatchUpdateBlobsResponse{
+		Responses: make([]*repb.BatchUpdateBlobsResponse_Response, len(req.Requests)),
+	}
+	for i, r := range req.Requests {
+		var err error
+		if r.Compressor != repb.Compressor_IDENTITY {
+			err = status.Error(codes.InvalidArgument, "Compression is not supported")
+		} else {
+			err = s.putBlob(ctx, r.Digest, r.Data)
+		}
+		resp.Responses[i] = &repb.BatchUpdateBlobsResponse_Response{
+			Digest: r.Digest,
+			Status: statusProto(err),
+		}
+	}
+	return resp, nil
+}
+
+func (s *REAPIServer) BatchReadBlobs(
+	ctx context.Context,
+	req *repb.BatchReadBlobsRequest,
+) (*repb.BatchReadBlobsResponse, error) {
+	var total int64
+	for _, d := range req.Digests {
+		total += d.GetSizeBytes()
+	}
+	if total > s.maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Batch size %d exceeds the maximum of %d bytes", total, s.maxBatchSize)
+	}
+	resp := &repb.BatchReadBlobsResponse{
+		Responses: make([]*repb.BatchReadBlobsResponse_Response, len(req.Digests)),
+	}
+	for i, d := range req.Digests {
+		data, err := s.getBlob(ctx, d)
+		resp.Responses[i] = &repb.BatchReadBlobsResponse_Response{
+			Digest: d,
+			Data:   data,
+			Status: statusProto(err),
+		}
+	}
+	return resp, nil
+}
+
+// GetTree returns the entire tree in a single page. Page sizes and tokens
+// requested by the client are ignored.
+func (s *REAPIServer) GetTree(
+	req *repb.GetTreeRequest,
+	srv repb.ContentAddressableStorage_GetTreeServer,
+) error {
+	ctx := srv.Context()
+	resp := &repb.GetTreeResponse{}
+	visited := map[string]struct{}{}
+	queue := []*repb.Digest{req.RootDigest}
+	for len(queue) > 0 {
+		d := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[d.GetHash()]; ok {
+			continue
+		}
+		visited[d.GetHash()] = struct{}{}
+		data, err := s.getBlob(ctx, d)
+		if err != nil {
+			if status.Code(err) == codes.NotFound && len(resp.Directories) > 0 {
+				// Missing subdirectories are omitted from the response
+				continue
+			}
+			return err
+		}
+		dir := &repb.Directory{}
+		if err := proto.Unmarshal(data, dir); err != nil {
+			return status.Errorf(codes.InvalidArgument,
+				"Blob %s is not a directory: %s", d.GetHash(), err.Error())
+		}
+		resp.Directories = append(resp.Directories, dir)
+		for _, child := range dir.Directories {
+			queue = append(queue, child.Digest)
+		}
+	}
+	return srv.Send(resp)
+}
+
+func (s *REAPIServer) GetActionResult(
+	ctx context.Context,
+	req *repb.GetActionResultRequest,
+) (*repb.ActionResult, error) {
+	if err := validateDigest(req.ActionDigest); err != nil {
+		return nil, err
+	}
+	result, err := s.getActionResult(ctx, req.ActionDigest)
+	if err != nil {
+		return nil, err
+	}
+	// Only return results for which all outputs are still available, since
+	// the referenced blobs may have been evicted independently.
+	if err := s.checkOutputsExist(ctx, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *REAPIServer) UpdateActionResult(
+	ctx context.Context,
+	req *repb.UpdateActionResultRequest,
+) (*repb.ActionResult, error) {
+	if err := validateDigest(req.ActionDigest); err != nil {
+		return nil, err
+	}
+	if req.ActionResult == nil {
+		return nil, status.Error(codes.InvalidArgument, "No action result given")
+	}
+	data, err := proto.Marshal(req.ActionResult)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	err = s.storageProvider.Put(ctx, reapiKey(acNamespace, req.ActionDigest),
+		&types.CacheObject{
+			Data: data,
+			Metadata: &types.CacheObjectMeta{
+				Tags: map[string]string{
+					REAPITag: acNamespace,
+				},
+			},
+		})
+	switch status.Code(err) {
+	case codes.OK:
+		return req.ActionResult, nil
+	case codes.AlreadyExists:
+		return s.getActionResult(ctx, req.ActionDigest)
+	default:
+		return nil, err
+	}
+}
+
+func (s *REAPIServer) getActionResult(
+	ctx context.Context,
+	d *repb.Digest,
+) (*repb.ActionResult, error) {
+	obj, err := s.storageProvider.Get(ctx, reapiKey(acNamespace, d))
+	if err != nil {
+		return nil, storageError(err)
+	}
+	result := &repb.ActionResult{}
+	if err := proto.Unmarshal(obj.GetData(), result); err != nil {
+		s.lg.With(
+			zap.Error(err),
+			zap.String("action", d.GetHash()),
+		).Warn("Cache entry is not a valid action result")
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return result, nil
+}
+
+func (s *REAPIServer) checkOutputsExist(
+	ctx context.Context,
+	result *repb.ActionResult,
+) error {
+	digests := []*repb.Digest{}
+	for _, f := range result.OutputFiles {
+		digests = append(digests, f.Digest)
+	}
+	for _, d := range result.OutputDirectories {
+		digests = append(digests, d.TreeDigest)
+	}
+	for _, d := range []*repb.Digest{result.StdoutDigest, result.StderrDigest} {
+		if d != nil {
+			digests = append(digests, d)
+		}
+	}
+	missing, err := s.FindMissingBlobs(ctx, &repb.FindMissingBlobsRequest{
+		BlobDigests: digests,
+	})
+	if err != nil {
+		return err
+	}
+	if len(missing.MissingBlobDigests) > 0 {
+		return status.Errorf(codes.NotFound,
+			"%d outputs of the action result are missing",
+			len(missing.MissingBlobDigests))
+	}
+	return nil
+}
+
+// putBlob verifies that the data matches the digest, then stores it in the
+// CAS namespace. Storing a blob that already exists succeeds.
+func (s *REAPIServer) putBlob(
+	ctx context.Context,
+	d *repb.Digest,
+	data []byte,
+) error {
+	if err := validateDigest(d); err != nil {
+		return err
+	}
+	if int64(len(data)) != d.SizeBytes {
+		return status.Errorf(codes.InvalidArgument,
+			"Blob size %d does not match digest size %d", len(data), d.SizeBytes)
+	}
+	if actual := util.Digest(data); actual != d.Hash {
+		return status.Errorf(codes.InvalidArgument,
+			"Blob hash %s does not match digest hash %s", actual, d.Hash)
+	}
+	if d.Hash == emptyDigest {
+		return nil
+	}
+	err := s.storageProvider.Put(ctx, reapiKey(casNamespace, d),
+		&types.CacheObject{
+			Data: data,
+			Metadata: &types.CacheObjectMeta{
+				Tags: map[string]string{
+					REAPITag: casNamespace,
+				},
+			},
+		})
+	if status.Code(err) == codes.AlreadyExists {
+		return nil
+	}
+	return err
+}
+
+// getBlob returns the data of a blob in the CAS namespace.
+func (s *REAPIServer) getBlob(
+	ctx context.Context,
+	d *repb.Digest,
+) ([]byte, error) {
+	if err := validateDigest(d); err != nil {
+		return nil, err
+	}
+	if d.Hash == emptyDigest {
+		return []byte{}, nil
+	}
+	obj, err := s.storageProvider.Get(ctx, reapiKey(casNamespace, d))
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if int64(len(obj.GetData())) != d.SizeBytes {
+		// The hash matches a stored blob, but the digest does not
+		return nil, status.Errorf(codes.NotFound,
+			"Blob %s/%d not found", d.Hash, d.SizeBytes)
+	}
+	return obj.GetData(), nil
+}
+
+// storageError converts an error returned by the storage provider when
+// reading an object. Missing and corrupt objects (which have already been
+// removed by the storage provider) are reported as not found, so that clients
+// upload them again. Other errors are returned as-is, so that an unavailable
+// storage backend is not mistaken for a cache miss.
+func storageError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound, codes.DataLoss:
+		return status.Error(codes.NotFound, status.Convert(err).Message())
+	default:
+		return err
+	}
+}
+
+// statusProto converts an error into a status message for batch responses.
+// Unlike status.Convert, a nil error results in an explicit OK status.
+func statusProto(err error) *spb.Status {
+	if err == nil {
+		return &spb.Status{Code: int32(codes.OK)}
+	}
+	return status.Convert(err).Proto()
+}
+
+// reapiKey returns the cache key of an object in the given namespace. The
+// namespace is appended to the hash so that keys are still distributed evenly
+// by storage providers which shard objects by hash prefix.
+func reapiKey(namespace string, d *repb.Digest) *types.CacheKey {
+	return &types.CacheKey{
+		Hash: fmt.Sprintf("%s.%s", d.GetHash(), namespace),
+	}
+}
+
+func validateDigest(d *repb.Digest) error {
+	if d == nil {
+		return status.Error(codes.InvalidArgument, "No digest given")
+	}
+	if len(d.Hash) != hex.EncodedLen(32) {
+		return status.Errorf(codes.InvalidArgument,
+			"Invalid SHA-256 digest: %q", d.Hash)
+	}
+	if _, err := hex.DecodeString(d.Hash); err != nil {
+		return status.Errorf(codes.InvalidArgument,
+			"Invalid SHA-256 digest: %q", d.Hash)
+	}
+	if d.SizeBytes < 0 {
+		return status.Errorf(codes.InvalidArgument,
+			"Invalid digest size: %d", d.SizeBytes)
+	}
+	return nil
+}
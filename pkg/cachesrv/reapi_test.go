@@ -0,0 +1,347 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cachesrv_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	repb "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/genproto/googleapis/bytestream"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kubecc-io/kubecc/pkg/cachesrv"
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/test"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"github.com/kubecc-io/kubecc/pkg/util"
+)
+
+func digestOf(data []byte) *repb.Digest {
+	return &repb.Digest{
+		Hash:      util.Digest(data),
+		SizeBytes: int64(len(data)),
+	}
+}
+
+var _ = Describe("REAPI", func() {
+	testEnv := test.NewBufconnEnvironmentWithLogLevel(zapcore.ErrorLevel)
+	var (
+		ctx      context.Context
+		cas      repb.ContentAddressableStorageClient
+		ac       repb.ActionCacheClient
+		bs       bytestream.ByteStreamClient
+		caps     repb.CapabilitiesClient
+		blob1    = []byte("blob 1")
+		blob2    = []byte("blob 2")
+		largeObj = make([]byte, 256*1024)
+	)
+	for i := range largeObj {
+		largeObj[i] = byte(i)
+	}
+	Specify("setup", func() {
+		ctx, _ = test.SpawnCache(testEnv,
+			test.WithName("reapi"),
+			test.WithConfig(config.CacheSpec{
+				REAPI: &config.REAPISpec{
+					MaxBatchSize:  "64Ki",
+					MaxObjectSize: "1Mi",
+				},
+			}),
+		)
+		cc := testEnv.Dial(ctx, types.Cache, "reapi")
+		cas = repb.NewContentAddressableStorageClient(cc)
+		ac = repb.NewActionCacheClient(cc)
+		bs = bytestream.NewByteStreamClient(cc)
+		caps = repb.NewCapabilitiesClient(cc)
+	})
+	It("should report cache capabilities", func() {
+		resp, err := caps.GetCapabilities(ctx, &repb.GetCapabilitiesRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.CacheCapabilities.DigestFunctions).
+			To(ConsistOf(repb.DigestFunction_SHA256))
+		Expect(resp.CacheCapabilities.MaxBatchTotalSizeBytes).
+			To(BeEquivalentTo(64 * 1024))
+		Expect(resp.CacheCapabilities.ActionCacheUpdateCapabilities.UpdateEnabled).
+			To(BeTrue())
+	})
+	Context("ContentAddressableStorage", func() {
+		It("should report missing blobs", func() {
+			resp, err := cas.FindMissingBlobs(ctx, &repb.FindMissingBlobsRequest{
+				BlobDigests: []*repb.Digest{digestOf(blob1), digestOf(nil)},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.MissingBlobDigests).To(HaveLen(1))
+			Expect(proto.Equal(resp.MissingBlobDigests[0], digestOf(blob1))).To(BeTrue())
+		})
+		It("should store blobs in batches", func() {
+			resp, err := cas.BatchUpdateBlobs(ctx, &repb.BatchUpdateBlobsRequest{
+				Requests: []*repb.BatchUpdateBlobsRequest_Request{
+					{Digest: digestOf(blob1), Data: blob1},
+					{Digest: digestOf(blob2), Data: blob2},
+					{Digest: digestOf(blob1), Data: blob2},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Responses).To(HaveLen(3))
+			Expect(resp.Responses[0].GetStatus().GetCode()).To(BeEquivalentTo(codes.OK))
+			Expect(resp.Responses[1].GetStatus().GetCode()).To(BeEquivalentTo(codes.OK))
+			Expect(resp.Responses[2].GetStatus().GetCode()).To(BeEquivalentTo(codes.InvalidArgument))
+
+			missing, err := cas.FindMissingBlobs(ctx, &repb.FindMissingBlobsRequest{
+				BlobDigests: []*repb.Digest{digestOf(blob1), digestOf(blob2)},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing.MissingBlobDigests).To(BeEmpty())
+		})
+		It("should read blobs in batches", func() {
+			resp, err := cas.BatchReadBlobs(ctx, &repb.BatchReadBlobsRequest{
+				Digests: []*repb.Digest{
+					digestOf(blob1),
+					digestOf(blob2),
+					digestOf([]byte("missing")),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Responses).To(HaveLen(3))
+			Expect(resp.Responses[0].Data).To(Equal(blob1))
+			Expect(resp.Responses[1].Data).To(Equal(blob2))
+			Expect(resp.Responses[2].GetStatus().GetCode()).To(BeEquivalentTo(codes.NotFound))
+		})
+		It("should reject batches larger than the limit", func() {
+			_, err := cas.BatchUpdateBlobs(ctx, &repb.BatchUpdateBlobsRequest{
+				Requests: []*repb.BatchUpdateBlobsRequest_Request{
+					{Digest: digestOf(largeObj), Data: largeObj},
+				},
+			})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("should return directory trees", func() {
+			child := &repb.Directory{
+				Files: []*repb.FileNode{
+					{Name: "blob2", Digest: digestOf(blob2)},
+				},
+			}
+			childData, _ := proto.Marshal(child)
+			root := &repb.Directory{
+				Files: []*repb.FileNode{
+					{Name: "blob1", Digest: digestOf(blob1)},
+				},
+				Directories: []*repb.DirectoryNode{
+					{Name: "child", Digest: digestOf(childData)},
+				},
+			}
+			rootData, _ := proto.Marshal(root)
+			_, err := cas.BatchUpdateBlobs(ctx, &repb.BatchUpdateBlobsRequest{
+				Requests: []*repb.BatchUpdateBlobsRequest_Request{
+					{Digest: digestOf(childData), Data: childData},
+					{Digest: digestOf(rootData), Data: rootData},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			stream, err := cas.GetTree(ctx, &repb.GetTreeRequest{
+				RootDigest: digestOf(rootData),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := stream.Recv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Directories).To(HaveLen(2))
+			Expect(proto.Equal(resp.Directories[0], root)).To(BeTrue())
+			Expect(proto.Equal(resp.Directories[1], child)).To(BeTrue())
+		})
+	})
+	Context("ByteStream", func() {
+		writeName := fmt.Sprintf("uploads/%s/blobs/%s/%d",
+			"5a5c4b1e-0000-4000-8000-000000000000",
+			util.Digest(largeObj), len(largeObj))
+		readName := fmt.Sprintf("instance/blobs/%s/%d",
+			util.Digest(largeObj), len(largeObj))
+		It("should write large blobs in chunks", func() {
+			stream, err := bs.Write(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			chunk := 64 * 1024
+			for offset := 0; offset < len(largeObj); offset += chunk {
+				Expect(stream.Send(&bytestream.WriteRequest{
+					ResourceName: writeName,
+					WriteOffset:  int64(offset),
+					Data:         largeObj[offset : offset+chunk],
+					FinishWrite:  offset+chunk == len(largeObj),
+				})).To(Succeed())
+			}
+			resp, err := stream.CloseAndRecv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.CommittedSize).To(BeEquivalentTo(len(largeObj)))
+
+			status, err := bs.QueryWriteStatus(ctx, &bytestream.QueryWriteStatusRequest{
+				ResourceName: writeName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Complete).To(BeTrue())
+		})
+		It("should read large blobs", func() {
+			stream, err := bs.Read(ctx, &bytestream.ReadRequest{
+				ResourceName: readName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			data := []byte{}
+			for {
+				resp, err := stream.Recv()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				Expect(err).NotTo(HaveOccurred())
+				data = append(data, resp.Data...)
+			}
+			Expect(data).To(Equal(largeObj))
+		})
+		It("should read blobs with an offset and limit", func() {
+			stream, err := bs.Read(ctx, &bytestream.ReadRequest{
+				ResourceName: readName,
+				ReadOffset:   100,
+				ReadLimit:    10,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := stream.Recv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Data).To(Equal(largeObj[100:110]))
+		})
+		It("should reject blobs which do not match their digest", func() {
+			stream, err := bs.Write(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stream.Send(&bytestream.WriteRequest{
+				ResourceName: fmt.Sprintf("uploads/x/blobs/%s/%d",
+					util.Digest([]byte("blob 3")), len(blob2)),
+				Data:        blob2,
+				FinishWrite: true,
+			})).To(Succeed())
+			_, err = stream.CloseAndRecv()
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("should reject blobs larger than the limit", func() {
+			stream, err := bs.Write(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stream.Send(&bytestream.WriteRequest{
+				ResourceName: fmt.Sprintf("uploads/x/blobs/%s/%d",
+					util.Digest(blob2), int64(1)<<40),
+				Data: blob2,
+			})).To(Succeed())
+			_, err = stream.CloseAndRecv()
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("should not read blobs with a mismatched size", func() {
+			stream, err := bs.Read(ctx, &bytestream.ReadRequest{
+				ResourceName: fmt.Sprintf("blobs/%s/%d",
+					util.Digest(largeObj), len(largeObj)*2),
+				ReadOffset: int64(len(largeObj)) + 1,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = stream.Recv()
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+	})
+	Context("ActionCache", func() {
+		action := digestOf([]byte("action"))
+		It("should return NotFound for unknown actions", func() {
+			_, err := ac.GetActionResult(ctx, &repb.GetActionResultRequest{
+				ActionDigest: action,
+			})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+		It("should store and return action results", func() {
+			result := &repb.ActionResult{
+				OutputFiles: []*repb.OutputFile{
+					{Path: "out/blob1", Digest: digestOf(blob1)},
+				},
+				StdoutDigest: digestOf(blob2),
+			}
+			_, err := ac.UpdateActionResult(ctx, &repb.UpdateActionResultRequest{
+				ActionDigest: action,
+				ActionResult: result,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			stored, err := ac.GetActionResult(ctx, &repb.GetActionResultRequest{
+				ActionDigest: action,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(stored, result)).To(BeTrue())
+		})
+		It("should not return results with missing outputs", func() {
+			incomplete := digestOf([]byte("incomplete action"))
+			_, err := ac.UpdateActionResult(ctx, &repb.UpdateActionResultRequest{
+				ActionDigest: incomplete,
+				ActionResult: &repb.ActionResult{
+					OutputFiles: []*repb.OutputFile{
+						{Path: "out/missing", Digest: digestOf([]byte("missing"))},
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = ac.GetActionResult(ctx, &repb.GetActionResultRequest{
+				ActionDigest: incomplete,
+			})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+	})
+})
+
+// unavailableProvider fails all reads, like a storage backend which cannot be
+// reached.
+type unavailableProvider struct {
+	storage.StorageProvider
+}
+
+func (unavailableProvider) Get(
+	context.Context,
+	*types.CacheKey,
+) (*types.CacheObject, error) {
+	return nil, status.Error(codes.Unavailable, "storage is unavailable")
+}
+
+var _ = Describe("REAPI Storage Errors", func() {
+	testEnv := test.NewBufconnEnvironmentWithLogLevel(zapcore.ErrorLevel)
+	var srv *cachesrv.REAPIServer
+	Specify("setup", func() {
+		var err error
+		srv, err = cachesrv.NewREAPIServer(testEnv.Context(),
+			config.REAPISpec{}, unavailableProvider{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("should not report unavailable storage as a cache miss", func() {
+		_, err := srv.GetActionResult(context.Background(),
+			&repb.GetActionResultRequest{
+				ActionDigest: digestOf([]byte("action")),
+			})
+		Expect(status.Code(err)).To(Equal(codes.Unavailable))
+
+		resp, err := srv.BatchReadBlobs(context.Background(),
+			&repb.BatchReadBlobsRequest{
+				Digests: []*repb.Digest{digestOf([]byte("blob"))},
+			})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Responses).To(HaveLen(1))
+		Expect(codes.Code(resp.Responses[0].Status.Code)).To(Equal(codes.Unavailable))
+	})
+})
@@ -19,6 +19,7 @@ package cachesrv
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/kubecc-io/kubecc/pkg/clients"
@@ -31,6 +32,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type CacheServer struct {
@@ -41,6 +43,8 @@ type CacheServer struct {
 	cfg             config.CacheSpec
 	metricsProvider clients.MetricsProvider
 	storageProvider storage.StorageProvider
+	reapi           *REAPIServer
+	http            *HTTPServer
 }
 
 type CacheServerOptions struct {
@@ -97,15 +101,50 @@ func NewCacheServer(
 		} else {
 			srv.metricsProvider = clients.NewNoopMetricsProvider()
 		}
+		if r, ok := srv.storageProvider.(storage.StatusReportingProvider); ok {
+			r.SetStatusReporter(&srv.StatusController)
+		}
 		if err := srv.storageProvider.Configure(); err != nil {
 			srv.ApplyCondition(ctx, metrics.StatusConditions_InvalidConfiguration,
 				err.Error())
 			srv.lg.Error(err)
 		}
+		if cfg.REAPI != nil {
+			reapi, err := NewREAPIServer(ctx, *cfg.REAPI, srv.storageProvider)
+			if err != nil {
+				srv.ApplyCondition(ctx, metrics.StatusConditions_InvalidConfiguration,
+					err.Error())
+				srv.lg.Error(err)
+			} else {
+				srv.reapi = reapi
+			}
+		}
+		if cfg.HTTP != nil {
+			httpSrv, err := NewHTTPServer(ctx, *cfg.HTTP, srv.storageProvider)
+			if err != nil {
+				srv.ApplyCondition(ctx, metrics.StatusConditions_InvalidConfiguration,
+					err.Error())
+				srv.lg.Error(err)
+			} else {
+				srv.http = httpSrv
+			}
+		}
 	}
 	return srv
 }
 
+// REAPI returns the Bazel Remote Execution API frontend for this cache
+// server, or nil if it is not enabled.
+func (s *CacheServer) REAPI() *REAPIServer {
+	return s.reapi
+}
+
+// HTTP returns the ccache/sccache HTTP endpoint for this cache server, or nil
+// if it is not enabled.
+func (s *CacheServer) HTTP() *HTTPServer {
+	return s.http
+}
+
 func (s *CacheServer) Push(
 	ctx context.Context,
 	req *types.PushRequest,
@@ -132,8 +171,145 @@ func (s *CacheServer) Query(
 	}, err
 }
 
-func (s *CacheServer) Sync(*types.SyncRequest, types.Cache_SyncServer) error {
-	return status.Errorf(codes.Unimplemented, "method Sync not implemented")
+// MaxSyncCandidates is the maximum number of objects whose metadata is
+// checked when ranking objects for a sync request.
+var MaxSyncCandidates = 10000
+
+// Sync streams the most valuable objects in the cache to a client which
+// maintains its own local cache. Objects are ranked by score (and then by
+// most recent use), and as many objects as will fit in the client's desired
+// cache size are selected. Only selected objects which the client does not
+// already have are sent.
+//
+// Candidates are taken from the fast storage tiers only (see syncTiers), and
+// at most MaxSyncCandidates objects are checked, so that the cost of a sync
+// does not grow with the size of the remote storage.
+func (s *CacheServer) Sync(
+	req *types.SyncRequest,
+	srv types.Cache_SyncServer,
+) error {
+	ctx := srv.Context()
+	s.lg.Debug("Handling sync request")
+	if req.DesiredCacheSizeKb <= 0 {
+		return status.Error(codes.InvalidArgument,
+			"Desired cache size must be greater than 0")
+	}
+
+	candidates, err := s.syncCandidates(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i].managed, candidates[j].managed
+		if a.GetScore() != b.GetScore() {
+			return a.GetScore() > b.GetScore()
+		}
+		return a.GetTimestamp() > b.GetTimestamp()
+	})
+
+	local := make(map[string]struct{}, len(req.LocalCache))
+	for _, key := range req.LocalCache {
+		local[key.GetHash()] = struct{}{}
+	}
+
+	remaining := req.DesiredCacheSizeKb * 1024
+	sent := 0
+	for _, c := range candidates {
+		size := c.managed.GetSize()
+		if size > remaining {
+			continue
+		}
+		remaining -= size
+		if _, ok := local[c.key.GetHash()]; ok {
+			continue
+		}
+		// Objects are read without recording a hit, so that syncing does not
+		// affect their score.
+		object, err := s.storageProvider.Peek(ctx, c.key)
+		if err != nil {
+			// The object may have expired since it was queried
+			s.lg.With(zap.Error(err)).Debug("Skipping object during sync")
+			continue
+		}
+		if err := srv.Send(&types.SyncObject{
+			Key:    c.key,
+			Object: object,
+		}); err != nil {
+			return err
+		}
+		sent++
+	}
+	s.lg.With(
+		"sent", sent,
+		"candidates", len(candidates),
+	).Debug("Sync complete")
+	return nil
+}
+
+// syncTiers returns the storage providers that sync candidates are taken
+// from. Remote (S3) storage is skipped, since checking its objects requires a
+// request for each one, unless it is the only storage provider.
+func (s *CacheServer) syncTiers() []storage.StorageProvider {
+	tiers := []storage.StorageProvider{}
+	for _, tier := range s.tiers() {
+		if tier.Location() != types.S3 {
+			tiers = append(tiers, tier)
+		}
+	}
+	if len(tiers) == 0 {
+		return s.tiers()
+	}
+	return tiers
+}
+
+// syncCandidates returns up to MaxSyncCandidates objects from the sync tiers,
+// along with their metadata.
+func (s *CacheServer) syncCandidates(ctx context.Context) ([]syncCandidate, error) {
+	seen := map[string]struct{}{}
+	candidates := []syncCandidate{}
+	for _, tier := range s.syncTiers() {
+		if len(seen) >= MaxSyncCandidates {
+			break
+		}
+		list, err := tier.List(ctx)
+		if err != nil {
+			s.lg.With(
+				zap.Error(err),
+				zap.String("location", tier.Location().String()),
+			).Warn("Failed to list objects for sync")
+			continue
+		}
+		keys := []*types.CacheKey{}
+		for _, key := range list {
+			if len(seen) >= MaxSyncCandidates {
+				break
+			}
+			if _, ok := seen[key.GetHash()]; ok {
+				continue
+			}
+			seen[key.GetHash()] = struct{}{}
+			keys = append(keys, key)
+		}
+		metadata, err := tier.Query(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+		for i, md := range metadata {
+			if md == nil {
+				continue
+			}
+			candidates = append(candidates, syncCandidate{
+				key:     keys[i],
+				managed: md.GetManagedFields(),
+			})
+		}
+	}
+	return candidates, nil
+}
+
+type syncCandidate struct {
+	key     *types.CacheKey
+	managed *types.CacheObjectManaged
 }
 
 func (s *CacheServer) postStorageInfo() {
@@ -141,7 +317,15 @@ func (s *CacheServer) postStorageInfo() {
 }
 
 func (s *CacheServer) postCacheHits() {
-	s.metricsProvider.Post(s.storageProvider.CacheHits())
+	hits := &metrics.CacheHits{}
+	if stats := s.storageProvider.CacheHits(); stats != nil {
+		hits = proto.Clone(stats).(*metrics.CacheHits)
+	}
+	if s.http != nil {
+		hits.HTTPHitsTotal = s.http.HitsTotal()
+		hits.HTTPMissesTotal = s.http.MissesTotal()
+	}
+	s.metricsProvider.Post(hits)
 }
 
 func (s *CacheServer) StartMetricsProvider() {
@@ -66,7 +66,26 @@ var (
 		"-fprofile-generate",
 		"-fprofile-use",
 		"-fauto-profile",
+		"-fprofile-instr-generate",
+		"-fprofile-instr-use",
+		"-fprofile-sample-use",
 	}
+	ClangArgsWithValues = mapset.NewSet( // --arg value
+		"-Xclang",
+		"-mllvm",
+		"-target",
+	)
+	ClangPluginArgs = mapset.NewSet( // -Xclang <arg>
+		"-load",
+		"-plugin",
+		"-add-plugin",
+	)
+	ColorDiagnosticsArgs = mapset.NewSet(
+		"-fcolor-diagnostics",
+		"-fno-color-diagnostics",
+		"-fdiagnostics-color",
+		"-fno-diagnostics-color",
+	)
 	LocalArgsWithValues = mapset.NewSet(
 		"-D",
 		"-I",
@@ -87,6 +106,7 @@ var (
 		"-isysroot",
 		"-iwithprefixbefore",
 		"-idirafter",
+		"-gcc-toolchain",
 	)
 	LocalArgsNoValues = mapset.NewSet(
 		"-undef",
@@ -120,6 +140,7 @@ var (
 		"-MQ",
 		"-isystem",
 		"-stdlib",
+		"--gcc-toolchain=",
 	}
 	LinkTimeOptimizationFlags = []string{
 		"-flto",
@@ -145,7 +166,7 @@ func (opt ActionOpt) String() string {
 	return string(opt)
 }
 
-// ArgParser represents GCC arguments.
+// ArgParser represents GCC or Clang arguments.
 type ArgParser struct {
 	lg             *zap.SugaredLogger
 	Args           []string
@@ -257,7 +278,7 @@ func (ap *ArgParser) Parse() {
 				seenOptE = true
 			case a == "-MD" || a == "-MMD":
 				ap.FlagIndexMap[a] = i
-				// OK
+				// OK, the dependency file is written by the local preprocessor
 			case a == "-MG" || a == "-MP":
 				ap.FlagIndexMap[a] = i
 				// OK
@@ -274,6 +295,29 @@ func (ap *ArgParser) Parse() {
 				ap.FlagIndexMap[a] = i
 				lg.Debug("-E possibly implied, compiling locally")
 				ap.Mode = RunLocal
+			case ClangArgsWithValues.Contains(a):
+				ap.FlagIndexMap[a] = i
+				skip = true
+				if i == len(ap.Args)-1 {
+					lg.Error("Argument is missing a value")
+					ap.Mode = RunLocal
+					break
+				}
+				if a == "-Xclang" && ClangPluginArgs.Contains(ap.Args[i+1]) {
+					lg.Debug("Compiling locally, clang plugins are not available remotely")
+					ap.Mode = RunLocal
+				}
+			case strings.HasPrefix(a, "--target="):
+				ap.FlagIndexMap["--target"] = i
+			case ColorDiagnosticsArgs.Contains(a) ||
+				strings.HasPrefix(a, "-fdiagnostics-color="):
+				ap.FlagIndexMap[a] = i
+			case strings.HasPrefix(a, "-fplugin="):
+				lg.Debug("Compiling locally, compiler plugins are not available remotely")
+				ap.Mode = RunLocal
+			case a == "-fmodules" || a == "-fcxx-modules":
+				lg.Debug("Compiling locally, clang modules are not supported")
+				ap.Mode = RunLocal
 			case a == "-march=native":
 				ap.Mode = RunLocal
 			case a == "-mtune=native":
@@ -522,6 +566,35 @@ func (ap *ArgParser) ConfigurePreprocessorOptions() {
 	ap.Parse()
 }
 
+// ConfigureDependencyOutput makes the dependency file path and target names
+// explicit when -MD or -MMD is given, so that the preprocessor can write the
+// dependency file on its own while its output is redirected to stdout.
+// Without these, the preprocessor would derive both the file name and target
+// from the '-' output path.
+// 1. If -MF is not set, add "-MF <output>.d" using the object file name
+// 2. If neither -MT nor -MQ is set, add "-MQ <output>"
+func (ap *ArgParser) ConfigureDependencyOutput() {
+	_, md := ap.FlagIndexMap["-MD"]
+	_, mmd := ap.FlagIndexMap["-MMD"]
+	if !md && !mmd {
+		return
+	}
+	if ap.OutputArgIndex == -1 {
+		ap.lg.Warn("Dependency file requested, but no output file was given")
+		return
+	}
+	output := ap.Args[ap.OutputArgIndex]
+	if _, ok := ap.FlagIndexMap["-MF"]; !ok {
+		ap.Args = append(ap.Args, "-MF", ReplaceExtension(output, ".d"))
+	}
+	_, mt := ap.FlagIndexMap["-MT"]
+	_, mq := ap.FlagIndexMap["-MQ"]
+	if !mt && !mq {
+		ap.Args = append(ap.Args, "-MQ", output)
+	}
+	ap.Parse()
+}
+
 // RemoveLocalArgs removes arguments that do not need to be
 // sent to the remote agent for compiling. These args are
 // related to preprocessing and linking.
@@ -530,6 +603,11 @@ func (ap *ArgParser) RemoveLocalArgs() {
 	for i := 0; i < len(ap.Args); i++ {
 		arg := ap.Args[i]
 		switch {
+		case ClangArgsWithValues.Contains(arg) && i < len(ap.Args)-1:
+			// Keep the value even if it looks like a local arg
+			newArgs = append(newArgs, arg, ap.Args[i+1])
+			i++
+			continue
 		case LocalArgsWithValues.Contains(arg):
 			i++ // Skip value (--arg value)
 			continue
@@ -236,6 +236,62 @@ var _ = Describe("CC Arg Parser", func() {
 		}
 		AddReportEntry(experiment.Name, experiment)
 	})
+	It("should make dependency output explicit", func() {
+		info := NewArgParser(ctx, strings.Split(`-MD -o src/test.o -c src/test.c`, " "))
+		info.Parse()
+		Expect(info.CanRunRemote()).To(BeTrue())
+		info.ConfigureDependencyOutput()
+		Expect(info.Args).To(Equal(strings.Split(`-MD -o src/test.o -c src/test.c -MF src/test.d -MQ src/test.o`, " ")))
+
+		info = NewArgParser(ctx, strings.Split(`-MMD -MT target -MF deps/test.o.d -o src/test.o -c src/test.c`, " "))
+		info.Parse()
+		Expect(info.CanRunRemote()).To(BeTrue())
+		info.ConfigureDependencyOutput()
+		Expect(info.Args).To(Equal(strings.Split(`-MMD -MT target -MF deps/test.o.d -o src/test.o -c src/test.c`, " ")))
+
+		info = NewArgParser(ctx, strings.Split(`-Wp,-MD,deps/test.o.d -c src/test.c`, " "))
+		info.Parse()
+		info.ConfigurePreprocessorOptions()
+		info.ConfigureDependencyOutput()
+		Expect(info.Args).To(Equal(strings.Split(`-MD -MF deps/test.o.d -c src/test.c -o src/test.o -MQ src/test.o`, " ")))
+
+		info.RemoveLocalArgs()
+		Expect(info.Args).To(Equal(strings.Split(`-c src/test.c -o src/test.o`, " ")))
+
+		info = NewArgParser(ctx, strings.Split(`-o src/test.o -c src/test.c`, " "))
+		info.Parse()
+		info.ConfigureDependencyOutput()
+		Expect(info.Args).To(Equal(strings.Split(`-o src/test.o -c src/test.c`, " ")))
+	})
+	It("should parse clang-specific arguments", func() {
+		info := NewArgParser(ctx, strings.Split(`--target=aarch64-linux-gnu -fcolor-diagnostics -Xclang -fno-pch-timestamp -mllvm -inline-threshold=100 -I include -o src/test.o -c src/test.c`, " "))
+		info.Parse()
+		Expect(info.CanRunRemote()).To(BeTrue())
+		Expect(info.FlagIndexMap).To(HaveKeyWithValue("--target", 0))
+		Expect(info.FlagIndexMap).To(HaveKeyWithValue("-Xclang", 2))
+		Expect(info.FlagIndexMap).To(HaveKeyWithValue("-mllvm", 4))
+		Expect(info.InputArgIndex).To(Equal(11))
+		info.RemoveLocalArgs()
+		Expect(info.Args).To(Equal(strings.Split(`--target=aarch64-linux-gnu -fcolor-diagnostics -Xclang -fno-pch-timestamp -mllvm -inline-threshold=100 -o src/test.o -c src/test.c`, " ")))
+
+		info = NewArgParser(ctx, strings.Split(`-target x86_64-linux-gnu -gcc-toolchain /opt/gcc --gcc-toolchain=/opt/gcc -o src/test.o -c src/test.c`, " "))
+		info.Parse()
+		Expect(info.CanRunRemote()).To(BeTrue())
+		info.RemoveLocalArgs()
+		Expect(info.Args).To(Equal(strings.Split(`-target x86_64-linux-gnu -o src/test.o -c src/test.c`, " ")))
+
+		for _, args := range []string{
+			`-Xclang -load -Xclang plugin.so -o src/test.o -c src/test.c`,
+			`-fplugin=plugin.so -o src/test.o -c src/test.c`,
+			`-fmodules -o src/test.o -c src/test.c`,
+			`-fprofile-instr-generate -o src/test.o -c src/test.c`,
+			`-o src/test.o -c src/test.c -Xclang`,
+		} {
+			info = NewArgParser(ctx, strings.Split(args, " "))
+			info.Parse()
+			Expect(info.CanRunRemote()).To(BeFalse(), args)
+		}
+	})
 	Specify("Prepending the language flag", func() {
 		experiment := gmeasure.NewExperiment("Prepending the language flag")
 		for i := 0; i < 1000; i++ {
@@ -102,7 +102,7 @@ func (t *compileTask) Run() {
 	err := cmd.Wait()
 	if err != nil {
 		t.Log.With(zap.Error(err)).Error("Compiler error")
-		t.SetErr(run.NewCompilerError(stderrBuf.String()))
+		t.SetErr(run.NewCompilerError(stderrBuf.String(), run.ExitCode(err)))
 		return
 	}
 	if t.OutputWriter != nil && !t.NoTempFile {
@@ -65,7 +65,7 @@ func (m localRunnerManager) Process(
 		errString := stderrBuf.String()
 		lg.Error(errString)
 		return &types.RunResponse{
-			ReturnCode: 1,
+			ReturnCode: run.CompilerExitCode(err),
 			Stdout:     stdoutBuf.Bytes(),
 			Stderr:     stderrBuf.Bytes(),
 		}, nil
@@ -44,6 +44,7 @@ func (m *recvRemoteRunnerManager) Process(
 	ap := cc.NewArgParser(ctx, req.Args)
 	ap.Parse()
 	lg.With(zap.Object("args", ap)).Info("Compile starting")
+	stdoutBuf := new(bytes.Buffer)
 	stderrBuf := new(bytes.Buffer)
 	outputFilename := new(bytes.Buffer)
 
@@ -74,7 +75,7 @@ func (m *recvRemoteRunnerManager) Process(
 		run.WithContext(ctx),
 		run.WithLog(lg),
 		run.WithOutputWriter(outputFilename),
-		run.WithOutputStreams(io.Discard, stderrBuf),
+		run.WithOutputStreams(stdoutBuf, stderrBuf),
 	)
 	task.Run()
 
@@ -87,6 +88,8 @@ func (m *recvRemoteRunnerManager) Process(
 			Data: &types.CompileResponse_Error{
 				Error: stderrBuf.String(),
 			},
+			Stdout:     stdoutBuf.Bytes(),
+			ReturnCode: run.CompilerExitCode(err),
 		}, nil
 	} else if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -110,5 +113,8 @@ func (m *recvRemoteRunnerManager) Process(
 		Data: &types.CompileResponse_CompiledSource{
 			CompiledSource: data,
 		},
+		Stdout: stdoutBuf.Bytes(),
+		Stderr: stderrBuf.Bytes(),
+		Digest: util.Digest(data),
 	}, nil
 }
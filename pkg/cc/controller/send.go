@@ -42,18 +42,18 @@ type remoteCompileTask struct {
 	run.TaskOptions
 
 	source []byte
-	tc     *types.Toolchain
+	req    *types.RunRequest
 	client run.SchedulerClientStream
 }
 
 func makeRemoteCompileTask(
 	client run.SchedulerClientStream,
-	tc *types.Toolchain,
+	req *types.RunRequest,
 	source []byte,
 	opts ...run.TaskOption,
 ) run.Task {
 	m := &remoteCompileTask{
-		tc:     tc,
+		req:    req,
 		source: source,
 		client: client,
 	}
@@ -64,9 +64,11 @@ func makeRemoteCompileTask(
 func (m *remoteCompileTask) Run() {
 	resp, err := m.client.Compile(&types.CompileRequest{
 		RequestID:          uuid.NewString(),
-		Toolchain:          m.tc,
+		Toolchain:          m.req.GetToolchain(),
 		Args:               m.Args,
 		PreprocessedSource: m.source,
+		Priority:           m.req.GetPriority(),
+		Tenant:             m.req.GetTenant(),
 	})
 	if err != nil {
 		m.SetErr(err)
@@ -78,6 +80,10 @@ func (m *remoteCompileTask) Run() {
 		out.CpuSecondsUsed = resp.CpuSecondsUsed
 		out.Data = resp.Data
 		out.RequestID = resp.RequestID
+		out.Stdout = resp.Stdout
+		out.Stderr = resp.Stderr
+		out.ReturnCode = resp.ReturnCode
+		out.Digest = resp.Digest
 	}
 	m.SetErr(nil)
 }
@@ -87,11 +93,14 @@ type sendRemoteRunnerManager struct {
 	ap        *cc.ArgParser
 }
 
+// runPreprocessor returns the preprocessed source and any output written to
+// stderr (such as #warning directives). If preprocessing fails, the response
+// to send back to the consumer is returned instead.
 func runPreprocessor(
 	ctx context.Context,
 	ap *cc.ArgParser,
 	req *types.RunRequest,
-) ([]byte, *types.RunResponse) {
+) ([]byte, []byte, *types.RunResponse) {
 	tracer := meta.Tracer(ctx)
 	span, sctx := opentracing.StartSpanFromContextWithTracer(
 		ctx, tracer, "preprocess")
@@ -119,13 +128,13 @@ func runPreprocessor(
 			zap.Object("args", ap),
 			zap.ByteString("stderr", stderr),
 		).Error("Compiler error")
-		return nil, &types.RunResponse{
-			ReturnCode: 1,
+		return nil, nil, &types.RunResponse{
+			ReturnCode: run.CompilerExitCode(err),
 			Stdout:     stdoutBuf.Bytes(),
 			Stderr:     stderr,
 		}
 	}
-	return outBuf.Bytes(), nil
+	return outBuf.Bytes(), stderrBuf.Bytes(), nil
 }
 
 func (m sendRemoteRunnerManager) Process(
@@ -141,12 +150,13 @@ func (m sendRemoteRunnerManager) Process(
 	ap := m.ap
 
 	ap.ConfigurePreprocessorOptions()
+	ap.ConfigureDependencyOutput()
 
 	opt := ap.ActionOpt()
 
 	lg.Debug("Preprocessing")
 	ap.SetActionOpt(cc.Preprocess)
-	preprocessedSource, errResp := runPreprocessor(sctx, ap, req)
+	preprocessedSource, ppStderr, errResp := runPreprocessor(sctx, ap, req)
 	if errResp != nil {
 		return errResp, nil
 	}
@@ -168,7 +178,7 @@ func (m sendRemoteRunnerManager) Process(
 	lg.Debug("Starting remote compile")
 	resp := types.CompileResponse{}
 	task := makeRemoteCompileTask(
-		m.reqClient, req.GetToolchain(), preprocessedSource,
+		m.reqClient, req, preprocessedSource,
 		run.WithContext(sctx),
 		run.WithArgs(ap.Args),
 		run.WithOutputVar(&resp),
@@ -182,6 +192,13 @@ func (m sendRemoteRunnerManager) Process(
 	lg.Debug("Remote compile completed")
 	switch resp.CompileResult {
 	case types.CompileResponse_Success:
+		if digest := resp.GetDigest(); digest != "" &&
+			util.Digest(resp.GetCompiledSource()) != digest {
+			lg.With(
+				zap.String("expected", digest),
+			).Error("Compiled source does not match its digest")
+			return nil, run.ErrCorruptResult
+		}
 		f, err := os.OpenFile(outputPath,
 			os.O_TRUNC|os.O_WRONLY|os.O_CREATE, 0777)
 		defer f.Close()
@@ -196,18 +213,23 @@ func (m sendRemoteRunnerManager) Process(
 		}
 		return &types.RunResponse{
 			ReturnCode: 0,
-			Stdout:     []byte{},
-			Stderr:     []byte(resp.GetError()),
+			Stdout:     resp.GetStdout(),
+			Stderr:     append(ppStderr, resp.GetStderr()...),
 		}, nil
 	case types.CompileResponse_Fail:
 		err := util.AnalyzeErrors(resp.GetError())
 		if err != nil {
 			return nil, status.Error(codes.Internal, "Internal error")
 		}
+		returnCode := resp.GetReturnCode()
+		if returnCode == 0 {
+			// Agents which do not report a return code
+			returnCode = 1
+		}
 		return &types.RunResponse{
-			ReturnCode: 1,
-			Stdout:     []byte{},
-			Stderr:     []byte(resp.GetError()),
+			ReturnCode: returnCode,
+			Stdout:     resp.GetStdout(),
+			Stderr:     append(ppStderr, resp.GetError()...),
 		}, nil
 	case types.CompileResponse_Retry:
 		switch resp.GetRetryAction() {
@@ -89,7 +89,7 @@ func (t *preprocessTask) Run() {
 	err = cmd.Wait()
 	if err != nil {
 		t.Log.With(zap.Error(err)).Error("Compiler error")
-		t.SetErr(run.NewCompilerError(stderrBuf.String()))
+		t.SetErr(run.NewCompilerError(stderrBuf.String(), run.ExitCode(err)))
 		return
 	}
 	t.SetErr(nil)
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+// archiveChunkSize is the size of archive chunks sent by ImportArchive.
+const archiveChunkSize = 1024 * 1024
+
+// ExportArchive writes a cache archive containing the objects matching the
+// filter to w.
+func ExportArchive(
+	ctx context.Context,
+	client types.CacheClient,
+	filter *types.CacheFilter,
+	w io.Writer,
+) error {
+	stream, err := client.Export(ctx, &types.ExportRequest{
+		Filter: filter,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(chunk.GetData()); err != nil {
+			return err
+		}
+	}
+}
+
+// ImportArchive streams a cache archive from r to the cache server. If loc
+// is not types.Unknown, objects are imported only into the storage provider
+// with that location.
+func ImportArchive(
+	ctx context.Context,
+	client types.CacheClient,
+	loc types.StorageLocation,
+	r io.Reader,
+) (*types.ImportResponse, error) {
+	stream, err := client.Import(ctx)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, archiveChunkSize)
+	first := true
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 || first {
+			req := &types.ImportRequest{
+				Data: buf[:n],
+			}
+			if first {
+				req.Location = loc
+				first = false
+			}
+			if sendErr := stream.Send(req); sendErr != nil {
+				// The server closed the stream, and the error is returned
+				// by CloseAndRecv.
+				break
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			stream.CloseSend()
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
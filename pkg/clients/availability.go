@@ -50,6 +50,9 @@ type AvailabilityChecker struct {
 	status RemoteStatus
 	filter AvailabilityFilter
 	cond   *sync.Cond
+	// The number of matching components which are currently available. The
+	// status is only Unavailable once all of them are gone.
+	count int
 }
 
 type AvailabilityFilter = func(*types.WhoisResponse) bool
@@ -109,6 +112,7 @@ func (rsm *AvailabilityChecker) OnComponentAvailable(
 	}
 
 	rsm.cond.L.Lock()
+	rsm.count++
 	rsm.status = Available
 	rsm.cond.L.Unlock()
 	rsm.cond.Broadcast()
@@ -116,7 +120,10 @@ func (rsm *AvailabilityChecker) OnComponentAvailable(
 	<-ctx.Done()
 
 	rsm.cond.L.Lock()
-	rsm.status = Unavailable
+	rsm.count--
+	if rsm.count == 0 {
+		rsm.status = Unavailable
+	}
 	rsm.cond.L.Unlock()
 	rsm.cond.Broadcast()
 }
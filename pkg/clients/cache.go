@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clients
+
+import (
+	"context"
+
+	"github.com/kubecc-io/kubecc/pkg/meta"
+	"github.com/kubecc-io/kubecc/pkg/run"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"github.com/kubecc-io/kubecc/pkg/util"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CachingRequestClient wraps a SchedulerClientStream and checks a local
+// storage provider for an existing result before sending compile requests to
+// the scheduler. Requests are hashed the same way as in the scheduler, so
+// objects synced from the central cache can be used here as well.
+type CachingRequestClient struct {
+	run.SchedulerClientStream
+	ctx     context.Context
+	lg      *zap.SugaredLogger
+	storage storage.StorageProvider
+	hashSrv *util.HashServer
+}
+
+func NewCachingRequestClient(
+	ctx context.Context,
+	client run.SchedulerClientStream,
+	sp storage.StorageProvider,
+) run.SchedulerClientStream {
+	return &CachingRequestClient{
+		SchedulerClientStream: client,
+		ctx:                   ctx,
+		lg:                    meta.Log(ctx),
+		storage:               sp,
+		hashSrv:               util.NewHashServer(),
+	}
+}
+
+func (c *CachingRequestClient) Compile(
+	req *types.CompileRequest,
+) (*types.CompileResponse, error) {
+	key := &types.CacheKey{
+		Hash: c.hashSrv.Hash(req),
+	}
+	if obj, err := c.storage.Get(c.ctx, key); err == nil {
+		if output, err := storage.DecodeCompileOutput(obj); err != nil {
+			c.lg.With(
+				zap.Error(err),
+			).Warn("Local cache entry is not a valid compile output")
+		} else {
+			c.lg.With(
+				"hash", types.FormatShortID(key.Hash, 6, types.ElideCenter),
+			).Info("Local Cache Hit")
+			return &types.CompileResponse{
+				RequestID:     req.GetRequestID(),
+				CompileResult: types.CompileResponse_Success,
+				Data: &types.CompileResponse_CompiledSource{
+					CompiledSource: output.GetCompiledSource(),
+				},
+				Stdout: output.GetStdout(),
+				Stderr: output.GetStderr(),
+				Digest: output.GetDigest(),
+			}, nil
+		}
+	}
+
+	resp, err := c.SchedulerClientStream.Compile(req)
+	if err == nil && resp.GetCompileResult() == types.CompileResponse_Success {
+		go c.store(key, req.GetToolchain().GetKind(), resp)
+	}
+	return resp, err
+}
+
+func (c *CachingRequestClient) store(
+	key *types.CacheKey,
+	kind types.ToolchainKind,
+	resp *types.CompileResponse,
+) {
+	data, err := storage.EncodeCompileOutput(&types.CompileOutput{
+		CompiledSource: resp.GetCompiledSource(),
+		Stdout:         resp.GetStdout(),
+		Stderr:         resp.GetStderr(),
+		Digest:         resp.GetDigest(),
+	})
+	if err != nil {
+		c.lg.With(zap.Error(err)).Error("Error encoding compile output")
+		return
+	}
+	err = c.storage.Put(c.ctx, key, &types.CacheObject{
+		Data: data,
+		// The expiration date is left unset so that it is determined by the
+		// retention policy of the storage provider.
+		Metadata: &types.CacheObjectMeta{
+			Tags: map[string]string{
+				storage.ToolchainTag: storage.ToolchainName(kind),
+				storage.FormatTag:    storage.CompileOutputFormat,
+			},
+		},
+	})
+	if err != nil && status.Code(err) != codes.AlreadyExists {
+		c.lg.With(zap.Error(err)).Error("Error storing result in local cache")
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clients_test
+
+import (
+	"github.com/kubecc-io/kubecc/internal/logkc"
+	"github.com/kubecc-io/kubecc/pkg/clients"
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/identity"
+	"github.com/kubecc-io/kubecc/pkg/meta"
+	"github.com/kubecc-io/kubecc/pkg/run"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/atomic"
+	"go.uber.org/zap/zapcore"
+)
+
+type fakeRequestClient struct {
+	count *atomic.Int32
+}
+
+func (c *fakeRequestClient) LoadNewStream(types.Scheduler_StreamOutgoingTasksClient) {}
+
+func (c *fakeRequestClient) Compile(
+	req *types.CompileRequest,
+) (*types.CompileResponse, error) {
+	c.count.Inc()
+	return &types.CompileResponse{
+		RequestID:     req.GetRequestID(),
+		CompileResult: types.CompileResponse_Success,
+		Data: &types.CompileResponse_CompiledSource{
+			CompiledSource: []byte("object"),
+		},
+		Stderr: []byte("warning"),
+	}, nil
+}
+
+var _ = Describe("Caching Request Client", func() {
+	ctx := meta.NewContext(
+		meta.WithProvider(identity.Component, meta.WithValue(types.TestComponent)),
+		meta.WithProvider(identity.UUID),
+		meta.WithProvider(logkc.Logger, meta.WithValue(logkc.New(types.TestComponent,
+			logkc.WithLogLevel(zapcore.ErrorLevel),
+		))),
+	)
+	sp := storage.NewVolatileStorageProvider(ctx, config.VolatileStorageSpec{
+		Limits: config.StorageLimitsSpec{
+			Memory: "1Mi",
+		},
+	})
+	fake := &fakeRequestClient{
+		count: atomic.NewInt32(0),
+	}
+	var client run.SchedulerClientStream
+	newRequest := func(source string) *types.CompileRequest {
+		return &types.CompileRequest{
+			Toolchain: &types.Toolchain{
+				Kind:       types.Gnu,
+				Lang:       types.CXX,
+				TargetArch: "amd64",
+				Version:    "10",
+			},
+			Args:               []string{"-c", "test.cpp", "-o", "test.o"},
+			PreprocessedSource: []byte(source),
+		}
+	}
+	It("should configure", func() {
+		Expect(sp.Configure()).To(Succeed())
+		client = clients.NewCachingRequestClient(ctx, fake, sp)
+	})
+	It("should send requests to the scheduler on a cache miss", func() {
+		resp, err := client.Compile(newRequest("source"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.GetCompiledSource()).To(BeEquivalentTo("object"))
+		Expect(fake.count.Load()).To(BeEquivalentTo(1))
+	})
+	It("should store successful results", func() {
+		Eventually(func() int {
+			keys, _ := sp.List(ctx)
+			return len(keys)
+		}).Should(Equal(1))
+	})
+	It("should return cached results without contacting the scheduler", func() {
+		resp, err := client.Compile(newRequest("source"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.GetCompileResult()).To(Equal(types.CompileResponse_Success))
+		Expect(resp.GetCompiledSource()).To(BeEquivalentTo("object"))
+		Expect(resp.GetStderr()).To(BeEquivalentTo("warning"))
+		Expect(fake.count.Load()).To(BeEquivalentTo(1))
+	})
+	It("should not return results for different sources", func() {
+		_, err := client.Compile(newRequest("other source"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fake.count.Load()).To(BeEquivalentTo(2))
+	})
+})
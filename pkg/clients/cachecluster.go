@@ -0,0 +1,753 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kubecc-io/kubecc/pkg/meta"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrNoCacheServers is returned by CacheCluster when no cache servers are
+// available.
+var ErrNoCacheServers = status.Error(codes.Unavailable,
+	"No cache servers available")
+
+// A CacheDialer connects to the cache server described by info.
+type CacheDialer func(
+	ctx context.Context,
+	info *types.WhoisResponse,
+) (*grpc.ClientConn, error)
+
+// CacheNodeName returns the name which identifies a cache server on the hash
+// ring. This is the hostname reported by the cache server, which does not
+// change when a pod in a StatefulSet is restarted, so that the restarted
+// cache server owns the same objects as before. Cache servers which do not
+// report a hostname are identified by their uuid.
+func CacheNodeName(info *types.WhoisResponse) string {
+	if info.Hostname != "" {
+		return info.Hostname
+	}
+	return info.UUID
+}
+
+// Default limits on the number of objects copied while rebalancing.
+const (
+	defaultRebalanceRate       = 100
+	defaultMaxRebalanceObjects = 10000
+)
+
+type CacheClusterOptions struct {
+	replicationFactor   int
+	rebalanceDelay      time.Duration
+	rebalanceRate       rate.Limit
+	maxRebalanceObjects int
+	virtualNodes        int
+}
+
+type CacheClusterOption func(*CacheClusterOptions)
+
+func (o *CacheClusterOptions) Apply(opts ...CacheClusterOption) {
+	for _, op := range opts {
+		op(o)
+	}
+}
+
+// WithReplicationFactor sets the number of cache servers each object is
+// stored on. Defaults to 1.
+func WithReplicationFactor(n int) CacheClusterOption {
+	return func(o *CacheClusterOptions) {
+		if n > 0 {
+			o.replicationFactor = n
+		}
+	}
+}
+
+// WithRebalancing enables copying objects to the cache servers which should
+// own them after cache servers join or leave the cluster. Rebalancing starts
+// once membership has not changed for the given delay.
+func WithRebalancing(delay time.Duration) CacheClusterOption {
+	return func(o *CacheClusterOptions) {
+		o.rebalanceDelay = delay
+	}
+}
+
+// WithRebalanceLimits sets the maximum number of objects copied per second
+// while rebalancing, and the maximum number of objects copied each time the
+// cluster is rebalanced. Defaults to 100 objects per second and 10000
+// objects.
+func WithRebalanceLimits(perSecond float64, maxObjects int) CacheClusterOption {
+	return func(o *CacheClusterOptions) {
+		if perSecond > 0 {
+			o.rebalanceRate = rate.Limit(perSecond)
+		}
+		if maxObjects > 0 {
+			o.maxRebalanceObjects = maxObjects
+		}
+	}
+}
+
+// CacheCluster is a types.CacheClient which shards objects across all cache
+// servers connected to the monitor using consistent hashing of the object's
+// key. Each object is stored on up to ReplicationFactor cache servers.
+// Objects found on a replica but not on a preferred owner are copied to the
+// owners when they are pulled.
+type CacheCluster struct {
+	CacheClusterOptions
+	ctx         context.Context
+	lg          *zap.SugaredLogger
+	dialer      CacheDialer
+	nodesMutex  *sync.RWMutex
+	nodes       map[string]*cacheNode // keyed by uuid
+	ringNodes   map[string]*cacheNode // keyed by name
+	ring        *hashRing
+	rebalanceCh chan struct{}
+	rebalancing *atomic.Bool
+	limiter     *rate.Limiter
+	// balancedRing is the hash ring as of the previous rebalance. It is only
+	// accessed by the rebalancer.
+	balancedRing *hashRing
+}
+
+var (
+	_ types.CacheClient    = (*CacheCluster)(nil)
+	_ AvailabilityListener = (*CacheCluster)(nil)
+)
+
+// NewCacheCluster creates a new CacheCluster which watches the monitor for
+// cache servers and connects to them using the given dialer.
+func NewCacheCluster(
+	ctx context.Context,
+	monClient types.MonitorClient,
+	dialer CacheDialer,
+	opts ...CacheClusterOption,
+) *CacheCluster {
+	options := CacheClusterOptions{
+		replicationFactor:   1,
+		rebalanceRate:       defaultRebalanceRate,
+		maxRebalanceObjects: defaultMaxRebalanceObjects,
+		virtualNodes:        defaultVirtualNodes,
+	}
+	options.Apply(opts...)
+
+	c := &CacheCluster{
+		CacheClusterOptions: options,
+		ctx:                 ctx,
+		lg:                  meta.Log(ctx),
+		dialer:              dialer,
+		nodesMutex:          &sync.RWMutex{},
+		nodes:               make(map[string]*cacheNode),
+		ringNodes:           make(map[string]*cacheNode),
+		ring:                newHashRing(nil, options.virtualNodes),
+		rebalanceCh:         make(chan struct{}, 1),
+		rebalancing:         atomic.NewBool(false),
+		limiter:             rate.NewLimiter(options.rebalanceRate, 1),
+	}
+	if options.rebalanceDelay > 0 {
+		go c.runRebalancer()
+	}
+	WatchAvailability(ctx, monClient, c)
+	return c
+}
+
+// OnComponentAvailable implements AvailabilityListener. Cache servers are
+// added to the cluster while they are connected to the monitor.
+func (c *CacheCluster) OnComponentAvailable(
+	ctx context.Context,
+	info *types.WhoisResponse,
+) {
+	if info.Component != types.Cache {
+		return
+	}
+	lg := c.lg.With(
+		types.ShortID(info.UUID),
+		zap.String("address", info.Address),
+	)
+	cc, err := c.dialer(c.ctx, info)
+	if err != nil {
+		lg.With(zap.Error(err)).Error("Failed to connect to cache server")
+		return
+	}
+	name := CacheNodeName(info)
+	c.addNode(info.UUID, name, cc)
+	lg.With(zap.String("name", name)).Info("Cache server joined the cluster")
+
+	<-ctx.Done()
+
+	c.removeNode(info.UUID)
+	lg.Info("Cache server left the cluster")
+}
+
+// Size returns the number of cache servers in the cluster.
+func (c *CacheCluster) Size() int {
+	c.nodesMutex.RLock()
+	defer c.nodesMutex.RUnlock()
+	return len(c.nodes)
+}
+
+// Rebalancing returns true while objects are being copied between cache
+// servers.
+func (c *CacheCluster) Rebalancing() bool {
+	return c.rebalancing.Load()
+}
+
+// cacheNode is a cache server which is connected to the monitor.
+type cacheNode struct {
+	clusterNode
+	name   string
+	conn   *grpc.ClientConn
+	joined time.Time
+}
+
+func (c *CacheCluster) addNode(uuid string, name string, cc *grpc.ClientConn) {
+	c.nodesMutex.Lock()
+	c.nodes[uuid] = &cacheNode{
+		clusterNode: clusterNode{
+			uuid:   uuid,
+			client: types.NewCacheClient(cc),
+		},
+		name:   name,
+		conn:   cc,
+		joined: time.Now(),
+	}
+	c.rebuildRing()
+	c.nodesMutex.Unlock()
+	c.requestRebalance()
+}
+
+func (c *CacheCluster) removeNode(uuid string) {
+	c.nodesMutex.Lock()
+	node, ok := c.nodes[uuid]
+	delete(c.nodes, uuid)
+	c.rebuildRing()
+	c.nodesMutex.Unlock()
+	if ok {
+		if err := node.conn.Close(); err != nil {
+			c.lg.With(
+				zap.Error(err),
+				types.ShortID(uuid),
+			).Debug("Error closing cache server connection")
+		}
+	}
+	c.requestRebalance()
+}
+
+// rebuildRing must be called with nodesMutex held. If several cache servers
+// have the same name, such as while a restarted cache server is replacing
+// its previous instance, the one which joined most recently is used.
+func (c *CacheCluster) rebuildRing() {
+	c.ringNodes = make(map[string]*cacheNode, len(c.nodes))
+	for _, node := range c.nodes {
+		if prev, ok := c.ringNodes[node.name]; ok && prev.joined.After(node.joined) {
+			continue
+		}
+		c.ringNodes[node.name] = node
+	}
+	names := make([]string, 0, len(c.ringNodes))
+	for name := range c.ringNodes {
+		names = append(names, name)
+	}
+	c.ring = newHashRing(names, c.virtualNodes)
+}
+
+type clusterNode struct {
+	uuid   string
+	client types.CacheClient
+}
+
+// owners returns the cache servers which should store the given key, in
+// order of preference.
+func (c *CacheCluster) owners(hash string) []clusterNode {
+	c.nodesMutex.RLock()
+	defer c.nodesMutex.RUnlock()
+	names := c.ring.Owners(hash, c.replicationFactor)
+	nodes := make([]clusterNode, len(names))
+	for i, name := range names {
+		nodes[i] = c.ringNodes[name].clusterNode
+	}
+	return nodes
+}
+
+// Push stores the object on each of its owners. It succeeds if the object
+// was stored on at least one of them.
+func (c *CacheCluster) Push(
+	ctx context.Context,
+	in *types.PushRequest,
+	opts ...grpc.CallOption,
+) (*types.Empty, error) {
+	owners := c.owners(in.GetKey().GetHash())
+	if len(owners) == 0 {
+		return nil, ErrNoCacheServers
+	}
+	var lastErr error
+	stored := false
+	for _, node := range owners {
+		_, err := node.client.Push(ctx, in, opts...)
+		switch status.Code(err) {
+		case codes.OK:
+			stored = true
+		default:
+			lastErr = err
+		}
+	}
+	if stored {
+		return &types.Empty{}, nil
+	}
+	return nil, lastErr
+}
+
+// Pull retrieves the object from the first owner which has it. If it was
+// found on a replica, it is copied to the preferred owners in the background.
+func (c *CacheCluster) Pull(
+	ctx context.Context,
+	in *types.PullRequest,
+	opts ...grpc.CallOption,
+) (*types.CacheObject, error) {
+	owners := c.owners(in.GetKey().GetHash())
+	if len(owners) == 0 {
+		return nil, ErrNoCacheServers
+	}
+	var lastErr error
+	for i, node := range owners {
+		obj, err := node.client.Pull(ctx, in, opts...)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if i > 0 {
+			go c.repair(owners[:i], in.GetKey(), proto.Clone(obj).(*types.CacheObject))
+		}
+		return obj, nil
+	}
+	return nil, lastErr
+}
+
+func (c *CacheCluster) repair(
+	nodes []clusterNode,
+	key *types.CacheKey,
+	obj *types.CacheObject,
+) {
+	for _, node := range nodes {
+		_, err := node.client.Push(c.ctx, &types.PushRequest{
+			Key:    key,
+			Object: obj,
+		})
+		if err != nil && status.Code(err) != codes.AlreadyExists {
+			c.lg.With(
+				zap.Error(err),
+				types.ShortID(node.uuid),
+			).Debug("Failed to copy object to its owner")
+		}
+	}
+}
+
+// Query returns object metadata for each key, checking replicas for any
+// keys not found on their preferred owner.
+func (c *CacheCluster) Query(
+	ctx context.Context,
+	in *types.QueryRequest,
+	opts ...grpc.CallOption,
+) (*types.QueryResponse, error) {
+	results := make([]*types.CacheObjectMeta, len(in.Keys))
+	owners := make([][]clusterNode, len(in.Keys))
+	for i, key := range in.Keys {
+		owners[i] = c.owners(key.GetHash())
+	}
+	for replica := 0; replica < c.replicationFactor; replica++ {
+		// Group the keys which have not been found by owner
+		groups := map[string][]int{}
+		clients := map[string]types.CacheClient{}
+		for i := range in.Keys {
+			if results[i] != nil || replica >= len(owners[i]) {
+				continue
+			}
+			node := owners[i][replica]
+			groups[node.uuid] = append(groups[node.uuid], i)
+			clients[node.uuid] = node.client
+		}
+		if len(groups) == 0 {
+			break
+		}
+		for uuid, indexes := range groups {
+			keys := make([]*types.CacheKey, len(indexes))
+			for j, i := range indexes {
+				keys[j] = in.Keys[i]
+			}
+			resp, err := clients[uuid].Query(ctx, &types.QueryRequest{
+				Keys: keys,
+			}, opts...)
+			if err != nil {
+				c.lg.With(
+					zap.Error(err),
+					types.ShortID(uuid),
+				).Debug("Error querying cache server")
+				continue
+			}
+			for j, md := range resp.GetResults() {
+				if j < len(indexes) && objectExists(md) {
+					results[indexes[j]] = md
+				}
+			}
+		}
+	}
+	return &types.QueryResponse{
+		Results: results,
+	}, nil
+}
+
+// Sync splits the desired cache size evenly across all cache servers and
+// streams the most valuable objects from each of them.
+func (c *CacheCluster) Sync(
+	ctx context.Context,
+	in *types.SyncRequest,
+	opts ...grpc.CallOption,
+) (types.Cache_SyncClient, error) {
+	c.nodesMutex.RLock()
+	clients := make([]types.CacheClient, 0, len(c.nodes))
+	for _, node := range c.nodes {
+		clients = append(clients, node.client)
+	}
+	c.nodesMutex.RUnlock()
+	if len(clients) == 0 {
+		return nil, ErrNoCacheServers
+	}
+	req := proto.Clone(in).(*types.SyncRequest)
+	if req.DesiredCacheSizeKb > 0 {
+		req.DesiredCacheSizeKb = int64(math.Max(1,
+			float64(req.DesiredCacheSizeKb)/float64(len(clients))))
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	streams := make([]types.Cache_SyncClient, 0, len(clients))
+	for _, client := range clients {
+		stream, err := client.Sync(ctx, req, opts...)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		streams = append(streams, stream)
+	}
+	return &clusterSyncClient{
+		ctx:     ctx,
+		cancel:  cancel,
+		streams: streams,
+	}, nil
+}
+
+// clusterSyncClient reads from several sync streams in sequence.
+type clusterSyncClient struct {
+	grpc.ClientStream
+	ctx     context.Context
+	cancel  context.CancelFunc
+	streams []types.Cache_SyncClient
+}
+
+func (s *clusterSyncClient) Recv() (*types.SyncObject, error) {
+	for len(s.streams) > 0 {
+		obj, err := s.streams[0].Recv()
+		if errors.Is(err, io.EOF) {
+			s.streams = s.streams[1:]
+			continue
+		}
+		if err != nil {
+			s.cancel()
+		}
+		return obj, err
+	}
+	s.cancel()
+	return nil, io.EOF
+}
+
+func (s *clusterSyncClient) Context() context.Context {
+	return s.ctx
+}
+
+func (s *clusterSyncClient) Header() (metadata.MD, error) {
+	return metadata.MD{}, nil
+}
+
+func (s *clusterSyncClient) Trailer() metadata.MD {
+	return metadata.MD{}
+}
+
+func (s *clusterSyncClient) CloseSend() error {
+	return nil
+}
+
+func (s *clusterSyncClient) RecvMsg(m interface{}) error {
+	obj, err := s.Recv()
+	if err != nil {
+		return err
+	}
+	proto.Merge(m.(*types.SyncObject), obj)
+	return nil
+}
+
+func (s *clusterSyncClient) SendMsg(m interface{}) error {
+	return status.Error(codes.Unimplemented, "Sync streams are receive-only")
+}
+
+// Export is not supported by the cluster client, since archives are created
+// by individual cache servers. Export from each cache server instead.
+func (c *CacheCluster) Export(
+	ctx context.Context,
+	in *types.ExportRequest,
+	opts ...grpc.CallOption,
+) (types.Cache_ExportClient, error) {
+	return nil, status.Error(codes.Unimplemented,
+		"Export must be called on an individual cache server")
+}
+
+// Import is not supported by the cluster client, since objects in an archive
+// are not sharded. Import into each cache server instead.
+func (c *CacheCluster) Import(
+	ctx context.Context,
+	opts ...grpc.CallOption,
+) (types.Cache_ImportClient, error) {
+	return nil, status.Error(codes.Unimplemented,
+		"Import must be called on an individual cache server")
+}
+
+// Stats is not supported by the cluster client. Statistics are reported by
+// individual cache servers.
+func (c *CacheCluster) Stats(
+	ctx context.Context,
+	in *types.Empty,
+	opts ...grpc.CallOption,
+) (*types.CacheStats, error) {
+	return nil, status.Error(codes.Unimplemented,
+		"Stats must be called on an individual cache server")
+}
+
+// Inspect returns the metadata of an object from the first of its owners
+// that contains it.
+func (c *CacheCluster) Inspect(
+	ctx context.Context,
+	in *types.CacheKey,
+	opts ...grpc.CallOption,
+) (*types.InspectResponse, error) {
+	owners := c.owners(in.GetHash())
+	if len(owners) == 0 {
+		return nil, ErrNoCacheServers
+	}
+	var lastErr error
+	for _, owner := range owners {
+		resp, err := owner.client.Inspect(ctx, in, opts...)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// Purge is not supported by the cluster client. Purge each cache server
+// instead.
+func (c *CacheCluster) Purge(
+	ctx context.Context,
+	in *types.PurgeRequest,
+	opts ...grpc.CallOption,
+) (*types.PurgeResponse, error) {
+	return nil, status.Error(codes.Unimplemented,
+		"Purge must be called on an individual cache server")
+}
+
+// List is not supported by the cluster client. List the objects on each
+// cache server instead.
+func (c *CacheCluster) List(
+	ctx context.Context,
+	in *types.Empty,
+	opts ...grpc.CallOption,
+) (types.Cache_ListClient, error) {
+	return nil, status.Error(codes.Unimplemented,
+		"List must be called on an individual cache server")
+}
+
+// objectExists returns true if the metadata returned by a query describes an
+// existing object. Missing objects are nil in the results of a storage
+// provider, but are received as empty messages over gRPC since repeated
+// fields can not contain nil elements.
+func objectExists(md *types.CacheObjectMeta) bool {
+	return md.GetManagedFields() != nil
+}
+
+func (c *CacheCluster) requestRebalance() {
+	select {
+	case c.rebalanceCh <- struct{}{}:
+	default:
+	}
+}
+
+// runRebalancer rebalances the cluster once membership has not changed for
+// the configured delay.
+func (c *CacheCluster) runRebalancer() {
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-c.rebalanceCh:
+		}
+		timer := time.NewTimer(c.rebalanceDelay)
+	settle:
+		for {
+			select {
+			case <-c.ctx.Done():
+				timer.Stop()
+				return
+			case <-c.rebalanceCh:
+				timer.Reset(c.rebalanceDelay)
+			case <-timer.C:
+				break settle
+			}
+		}
+		c.rebalance()
+	}
+}
+
+// rebalance copies the objects whose owners changed since the previous
+// rebalance to their new owners. Only object keys are listed, and objects are
+// only read from cache servers when they need to be copied. Copies are rate
+// limited, and at most maxRebalanceObjects objects are copied; any others
+// are copied to their owners when they are pulled from a replica. Objects
+// are not removed from cache servers which no longer own them, they will
+// expire or be evicted normally.
+func (c *CacheCluster) rebalance() {
+	c.rebalancing.Store(true)
+	defer c.rebalancing.Store(false)
+
+	c.nodesMutex.RLock()
+	ring := c.ring
+	names := make([]string, 0, len(c.ringNodes))
+	nodes := make(map[string]clusterNode, len(c.ringNodes))
+	for name, node := range c.ringNodes {
+		names = append(names, name)
+		nodes[name] = node.clusterNode
+	}
+	c.nodesMutex.RUnlock()
+
+	prevRing := c.balancedRing
+	c.balancedRing = ring
+	if prevRing == nil || len(nodes) < 2 {
+		// Objects were stored using the current ring
+		return
+	}
+	sort.Strings(names)
+
+	c.lg.With(
+		zap.Int("nodes", len(nodes)),
+	).Info("Rebalancing cache cluster")
+	copied := 0
+	for _, name := range names {
+		source := nodes[name]
+		stream, err := source.client.List(c.ctx, &types.Empty{})
+		if err != nil {
+			c.lg.With(
+				zap.Error(err),
+				types.ShortID(source.uuid),
+			).Warn("Failed to list objects for rebalancing")
+			continue
+		}
+		for {
+			key, err := stream.Recv()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					c.lg.With(
+						zap.Error(err),
+						types.ShortID(source.uuid),
+					).Warn("Error receiving objects for rebalancing")
+				}
+				break
+			}
+			hash := key.GetHash()
+			prevOwners := prevRing.Owners(hash, c.replicationFactor)
+			for _, owner := range ring.Owners(hash, c.replicationFactor) {
+				if owner == name || containsString(prevOwners, owner) {
+					continue
+				}
+				if copied >= c.maxRebalanceObjects {
+					c.lg.With(
+						zap.Int("copied", copied),
+					).Warn("Rebalancing stopped after copying the maximum number of objects")
+					return
+				}
+				if c.copyObject(source, nodes[owner], key) {
+					copied++
+				}
+			}
+		}
+	}
+	c.lg.With(
+		zap.Int("copied", copied),
+	).Info("Rebalancing complete")
+}
+
+// copyObject copies the object from the source to the given node if the node
+// does not already have it, and returns true if it was copied.
+func (c *CacheCluster) copyObject(
+	source clusterNode,
+	node clusterNode,
+	key *types.CacheKey,
+) bool {
+	resp, err := node.client.Query(c.ctx, &types.QueryRequest{
+		Keys: []*types.CacheKey{key},
+	})
+	if err == nil && len(resp.GetResults()) == 1 && objectExists(resp.GetResults()[0]) {
+		return false
+	}
+	if err := c.limiter.Wait(c.ctx); err != nil {
+		return false
+	}
+	obj, err := source.client.Pull(c.ctx, &types.PullRequest{
+		Key: key,
+	})
+	if err != nil {
+		c.lg.With(
+			zap.Error(err),
+			types.ShortID(source.uuid),
+		).Debug("Failed to read object during rebalancing")
+		return false
+	}
+	_, err = node.client.Push(c.ctx, &types.PushRequest{
+		Key:    key,
+		Object: obj,
+	})
+	if err != nil {
+		if status.Code(err) != codes.AlreadyExists {
+			c.lg.With(
+				zap.Error(err),
+				types.ShortID(node.uuid),
+			).Debug("Failed to copy object during rebalancing")
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clients_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+
+	"github.com/kubecc-io/kubecc/pkg/clients"
+	"github.com/kubecc-io/kubecc/pkg/test"
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+var _ = Describe("Cache Cluster", func() {
+	testEnv := test.NewBufconnEnvironmentWithLogLevel(zapcore.ErrorLevel)
+	var (
+		cluster *clients.CacheCluster
+		cancels = map[string]context.CancelFunc{}
+		direct  = map[string]types.CacheClient{}
+		keys    []*types.CacheKey
+	)
+	spawnCache := func(name string) {
+		ctx, cancel := test.SpawnCache(testEnv,
+			test.WithName(name),
+			test.WithUUID(uuid.NewString()),
+		)
+		cancels[name] = cancel
+		direct[name] = test.NewCacheClient(testEnv, ctx, name)
+	}
+	// copies returns the number of live cache servers storing the key
+	copies := func(key *types.CacheKey) int {
+		count := 0
+		for name, client := range direct {
+			if _, ok := cancels[name]; !ok {
+				continue
+			}
+			resp, err := client.Query(testEnv.Context(), &types.QueryRequest{
+				Keys: []*types.CacheKey{key},
+			})
+			if err == nil && resp.Results[0].GetManagedFields() != nil {
+				count++
+			}
+		}
+		return count
+	}
+	for i := 0; i < 30; i++ {
+		keys = append(keys, &types.CacheKey{
+			Hash: fmt.Sprintf("cluster-object-%d", i),
+		})
+	}
+
+	Specify("setup", func() {
+		test.SpawnMonitor(testEnv)
+		for _, name := range []string{"a", "b", "c"} {
+			spawnCache(name)
+		}
+		cluster = clients.NewCacheCluster(testEnv.Context(),
+			test.NewMonitorClient(testEnv, testEnv.Context()),
+			func(ctx context.Context, info *types.WhoisResponse) (*grpc.ClientConn, error) {
+				// Test cache servers report their name as their hostname
+				return testEnv.Dial(ctx, types.Cache, info.Hostname), nil
+			},
+			clients.WithReplicationFactor(2),
+			clients.WithRebalancing(100*time.Millisecond),
+		)
+		Eventually(cluster.Size).Should(Equal(3))
+		// Objects are only rebalanced after the initial membership settles
+		time.Sleep(500 * time.Millisecond)
+	})
+	It("should store each object on the configured number of replicas", func() {
+		for _, key := range keys {
+			_, err := cluster.Push(testEnv.Context(), &types.PushRequest{
+				Key: key,
+				Object: &types.CacheObject{
+					Data: []byte(key.Hash),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		for _, key := range keys {
+			Expect(copies(key)).To(Equal(2))
+		}
+	})
+	It("should distribute objects across all cache servers", func() {
+		for name, client := range direct {
+			resp, err := client.Query(testEnv.Context(), &types.QueryRequest{
+				Keys: keys,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			found := 0
+			for _, md := range resp.Results {
+				if md.GetManagedFields() != nil {
+					found++
+				}
+			}
+			Expect(found).To(BeNumerically(">", 0), name)
+		}
+	})
+	It("should query objects on any replica", func() {
+		resp, err := cluster.Query(testEnv.Context(), &types.QueryRequest{
+			Keys: append(keys, &types.CacheKey{Hash: "missing"}),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Results).To(HaveLen(len(keys) + 1))
+		for i := range keys {
+			Expect(resp.Results[i]).NotTo(BeNil())
+		}
+		Expect(resp.Results[len(keys)]).To(BeNil())
+	})
+	When("a cache server leaves the cluster", func() {
+		It("should continue serving all objects from replicas", func() {
+			cancels["c"]()
+			delete(cancels, "c")
+			Eventually(cluster.Size).Should(Equal(2))
+			for _, key := range keys {
+				obj, err := cluster.Pull(testEnv.Context(), &types.PullRequest{
+					Key: key,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(obj.Data).To(Equal([]byte(key.Hash)))
+			}
+		})
+		It("should restore the replication factor", func() {
+			Eventually(func() bool {
+				for _, key := range keys {
+					if copies(key) != 2 {
+						return false
+					}
+				}
+				return true
+			}, 10*time.Second).Should(BeTrue())
+		})
+	})
+	When("a cache server joins the cluster", func() {
+		It("should copy the objects it owns to it", func() {
+			spawnCache("d")
+			Eventually(cluster.Size).Should(Equal(3))
+			Eventually(func() int {
+				resp, err := direct["d"].Query(testEnv.Context(), &types.QueryRequest{
+					Keys: keys,
+				})
+				if err != nil {
+					return 0
+				}
+				found := 0
+				for _, md := range resp.Results {
+					if md.GetManagedFields() != nil {
+						found++
+					}
+				}
+				return found
+			}, 10*time.Second).Should(BeNumerically(">", 0))
+			Eventually(cluster.Rebalancing, 10*time.Second).Should(BeFalse())
+			for _, key := range keys {
+				_, err := cluster.Pull(testEnv.Context(), &types.PullRequest{
+					Key: key,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	})
+	When("a cache server restarts", func() {
+		It("should own the same objects as before", func() {
+			restartKeys := []*types.CacheKey{}
+			for i := 0; i < 30; i++ {
+				key := &types.CacheKey{
+					Hash: fmt.Sprintf("restart-object-%d", i),
+				}
+				restartKeys = append(restartKeys, key)
+				_, err := cluster.Push(testEnv.Context(), &types.PushRequest{
+					Key: key,
+					Object: &types.CacheObject{
+						Data: []byte(key.Hash),
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			stored := func() []string {
+				resp, err := direct["b"].Query(testEnv.Context(), &types.QueryRequest{
+					Keys: restartKeys,
+				})
+				if err != nil {
+					return nil
+				}
+				hashes := []string{}
+				for i, md := range resp.Results {
+					if md.GetManagedFields() != nil {
+						hashes = append(hashes, restartKeys[i].Hash)
+					}
+				}
+				return hashes
+			}
+			owned := stored()
+			Expect(owned).NotTo(BeEmpty())
+
+			// The restarted cache server has a new uuid and no objects. Its
+			// objects are copied back to it from the replicas they were
+			// copied to while it was gone.
+			cancels["b"]()
+			delete(cancels, "b")
+			Eventually(cluster.Size).Should(Equal(2))
+			Eventually(func() bool {
+				for _, key := range restartKeys {
+					if copies(key) != 2 {
+						return false
+					}
+				}
+				return true
+			}, 10*time.Second).Should(BeTrue())
+			spawnCache("b")
+			Eventually(cluster.Size).Should(Equal(3))
+			Eventually(stored, 10*time.Second).Should(ConsistOf(owned))
+			Eventually(cluster.Rebalancing, 10*time.Second).Should(BeFalse())
+			Expect(stored()).To(ConsistOf(owned))
+		})
+	})
+})
+
+var _ = Describe("Cache Cluster Rebalancing", func() {
+	testEnv := test.NewBufconnEnvironmentWithLogLevel(zapcore.ErrorLevel)
+	var (
+		cluster *clients.CacheCluster
+		direct  = map[string]types.CacheClient{}
+		keys    []*types.CacheKey
+	)
+	spawnCache := func(name string) {
+		ctx, _ := test.SpawnCache(testEnv,
+			test.WithName(name),
+			test.WithUUID(uuid.NewString()),
+		)
+		direct[name] = test.NewCacheClient(testEnv, ctx, name)
+	}
+	stored := func(name string) int {
+		resp, err := direct[name].Query(testEnv.Context(), &types.QueryRequest{
+			Keys: keys,
+		})
+		if err != nil {
+			return 0
+		}
+		count := 0
+		for _, md := range resp.Results {
+			if md.GetManagedFields() != nil {
+				count++
+			}
+		}
+		return count
+	}
+	for i := 0; i < 30; i++ {
+		keys = append(keys, &types.CacheKey{
+			Hash: fmt.Sprintf("limited-object-%d", i),
+		})
+	}
+
+	Specify("setup", func() {
+		test.SpawnMonitor(testEnv)
+		for _, name := range []string{"a", "b"} {
+			spawnCache(name)
+		}
+		cluster = clients.NewCacheCluster(testEnv.Context(),
+			test.NewMonitorClient(testEnv, testEnv.Context()),
+			func(ctx context.Context, info *types.WhoisResponse) (*grpc.ClientConn, error) {
+				return testEnv.Dial(ctx, types.Cache, info.Hostname), nil
+			},
+			clients.WithRebalancing(100*time.Millisecond),
+			clients.WithRebalanceLimits(1000, 3),
+		)
+		Eventually(cluster.Size).Should(Equal(2))
+		time.Sleep(500 * time.Millisecond)
+		for _, key := range keys {
+			_, err := cluster.Push(testEnv.Context(), &types.PushRequest{
+				Key: key,
+				Object: &types.CacheObject{
+					Data: []byte(key.Hash),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+	It("should stop copying objects after reaching the limit", func() {
+		spawnCache("c")
+		Eventually(cluster.Size).Should(Equal(3))
+		Eventually(func() int {
+			return stored("c")
+		}, 10*time.Second).Should(Equal(3))
+		Consistently(func() int {
+			return stored("c")
+		}, 500*time.Millisecond).Should(Equal(3))
+	})
+})
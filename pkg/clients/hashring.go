@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clients
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// defaultVirtualNodes is the number of points each node is assigned on the
+// hash ring. More points result in a more even distribution of keys.
+const defaultVirtualNodes = 128
+
+type ringPoint struct {
+	hash uint64
+	node string
+}
+
+// hashRing implements consistent hashing. Each node is placed on the ring at
+// several points, and a key is owned by the nodes at the first points
+// following the hash of the key. When a node is added or removed, only the
+// keys owned by that node change owners.
+type hashRing struct {
+	points []ringPoint
+	nodes  int
+}
+
+func newHashRing(nodes []string, virtualNodes int) *hashRing {
+	r := &hashRing{
+		points: make([]ringPoint, 0, len(nodes)*virtualNodes),
+		nodes:  len(nodes),
+	}
+	for _, node := range nodes {
+		for i := 0; i < virtualNodes; i++ {
+			r.points = append(r.points, ringPoint{
+				hash: xxhash.Sum64String(node + "#" + strconv.Itoa(i)),
+				node: node,
+			})
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool {
+		return r.points[i].hash < r.points[j].hash
+	})
+	return r
+}
+
+// Owners returns up to n distinct nodes which own the given key, in order of
+// preference.
+func (r *hashRing) Owners(key string, n int) []string {
+	if n > r.nodes {
+		n = r.nodes
+	}
+	if n == 0 {
+		return nil
+	}
+	h := xxhash.Sum64String(key)
+	start := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i].hash >= h
+	})
+	owners := make([]string, 0, n)
+	for i := 0; i < len(r.points) && len(owners) < n; i++ {
+		node := r.points[(start+i)%len(r.points)].node
+		if !containsString(owners, node) {
+			owners = append(owners, node)
+		}
+	}
+	return owners
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package clients
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/kubecc-io/kubecc/pkg/meta"
+	"github.com/kubecc-io/kubecc/pkg/metrics"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/resolver"
+)
+
+const leaderResolverScheme = "kubecc-leader"
+
+// LeaderResolver is a gRPC resolver which resolves to the address of the
+// replica of a component which currently reports itself as the leader to
+// the monitor. A ClientConn dialed using a LeaderResolver will move to the
+// new leader when leadership changes. If no leader is known, the resolver
+// falls back to a static address, if one is given.
+//
+// Example:
+// r := clients.NewLeaderResolver(ctx, monClient, types.Scheduler, "9090", addr)
+// cc, err := servers.Dial(ctx, r.Target(), servers.WithDialOpts(grpc.WithResolvers(r)))
+type LeaderResolver struct {
+	ctx       context.Context
+	lg        *zap.SugaredLogger
+	component types.Component
+	port      string
+	fallback  string
+
+	mu       sync.Mutex
+	leaders  map[string]*leaderInfo // uuid -> info
+	sequence int
+	current  string
+	conns    map[*leaderResolverConn]struct{}
+}
+
+type leaderInfo struct {
+	whois *types.WhoisResponse
+	// since orders leaders by when they became the leader. While leadership
+	// is changing, more than one replica may briefly report itself as the
+	// leader, in which case the newest one is used.
+	since int
+}
+
+// NewLeaderResolver creates a new LeaderResolver for the given component.
+// Leaders are dialed using the address reported by the monitor and the
+// given port.
+func NewLeaderResolver(
+	ctx context.Context,
+	monClient types.MonitorClient,
+	component types.Component,
+	port string,
+	fallback string,
+) *LeaderResolver {
+	r := &LeaderResolver{
+		ctx:       ctx,
+		lg:        meta.Log(ctx),
+		component: component,
+		port:      port,
+		fallback:  fallback,
+		leaders:   make(map[string]*leaderInfo),
+		current:   fallback,
+		conns:     make(map[*leaderResolverConn]struct{}),
+	}
+	l := NewMetricsListener(ctx, monClient, WithLogEvents(LogNone))
+	l.OnProviderAdded(func(pctx context.Context, uuid string) {
+		whois, err := monClient.Whois(ctx, &types.WhoisRequest{
+			UUID: uuid,
+		})
+		if err != nil || whois.Component != component {
+			return
+		}
+		l.OnValueChanged(uuid, func(status *metrics.LeaderStatus) {
+			if pctx.Err() != nil {
+				return
+			}
+			r.setLeader(whois, status.GetLeader())
+		})
+		<-pctx.Done()
+		r.setLeader(whois, false)
+	})
+	return r
+}
+
+// Target returns the dial target which should be used with this resolver.
+func (r *LeaderResolver) Target() string {
+	return leaderResolverScheme + ":///" + strings.ToLower(r.component.Name())
+}
+
+// Leader returns the current leader, or nil if there is no known leader.
+func (r *LeaderResolver) Leader() *types.WhoisResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if info := r.newestLeader(); info != nil {
+		return info.whois
+	}
+	return nil
+}
+
+func (r *LeaderResolver) newestLeader() *leaderInfo {
+	var newest *leaderInfo
+	for _, info := range r.leaders {
+		if newest == nil || info.since > newest.since {
+			newest = info
+		}
+	}
+	return newest
+}
+
+func (r *LeaderResolver) setLeader(whois *types.WhoisResponse, leader bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, known := r.leaders[whois.UUID]
+	switch {
+	case leader && !known:
+		r.sequence++
+		r.leaders[whois.UUID] = &leaderInfo{
+			whois: whois,
+			since: r.sequence,
+		}
+	case !leader && known:
+		delete(r.leaders, whois.UUID)
+	default:
+		return
+	}
+
+	addr := r.fallback
+	if info := r.newestLeader(); info != nil {
+		addr = net.JoinHostPort(info.whois.Address, r.port)
+		r.lg.With(
+			"component", r.component.Name(),
+			"uuid", info.whois.UUID,
+			"address", addr,
+		).Info("Leader changed")
+	} else if addr == "" {
+		return
+	}
+	if addr == r.current {
+		return
+	}
+	r.current = addr
+	for conn := range r.conns {
+		conn.update(addr)
+	}
+}
+
+// Build implements resolver.Builder
+func (r *LeaderResolver) Build(
+	_ resolver.Target,
+	cc resolver.ClientConn,
+	_ resolver.BuildOptions,
+) (resolver.Resolver, error) {
+	conn := &leaderResolverConn{
+		parent: r,
+		cc:     cc,
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.conns[conn] = struct{}{}
+	if r.current != "" {
+		conn.update(r.current)
+	}
+	return conn, nil
+}
+
+// Scheme implements resolver.Builder
+func (r *LeaderResolver) Scheme() string {
+	return leaderResolverScheme
+}
+
+type leaderResolverConn struct {
+	parent *LeaderResolver
+	cc     resolver.ClientConn
+}
+
+func (c *leaderResolverConn) update(addr string) {
+	err := c.cc.UpdateState(resolver.State{
+		Addresses: []resolver.Address{{Addr: addr}},
+	})
+	if err != nil {
+		c.parent.lg.With(zap.Error(err)).Debug("Error updating resolver state")
+	}
+}
+
+// ResolveNow implements resolver.Resolver. Addresses are pushed to the
+// ClientConn as soon as the leader changes, so there is nothing to do here.
+func (c *leaderResolverConn) ResolveNow(resolver.ResolveNowOptions) {}
+
+// Close implements resolver.Resolver
+func (c *leaderResolverConn) Close() {
+	c.parent.mu.Lock()
+	defer c.parent.mu.Unlock()
+	delete(c.parent.conns, c)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package clients_test
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kubecc-io/kubecc/pkg/clients"
+	"github.com/kubecc-io/kubecc/pkg/scheduler"
+	"github.com/kubecc-io/kubecc/pkg/test"
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+// manualElector makes a scheduler the leader for as long as the context
+// sent to it is not canceled.
+type manualElector struct {
+	lead chan context.Context
+}
+
+func (e *manualElector) Run(ctx context.Context, lead func(context.Context)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case leaderCtx := <-e.lead:
+			lead(leaderCtx)
+		}
+	}
+}
+
+var _ = Describe("Leader Resolver", func() {
+	testEnv := test.NewBufconnEnvironmentWithLogLevel(zapcore.ErrorLevel)
+	var (
+		resolver  *clients.LeaderResolver
+		electors  = map[string]*manualElector{}
+		ids       = map[string]string{}
+		schedCtxs = map[string]context.Context{}
+	)
+	leaderID := func() string {
+		if leader := resolver.Leader(); leader != nil {
+			return leader.UUID
+		}
+		return ""
+	}
+	routesErr := func(name string) func() codes.Code {
+		client := test.NewSchedulerClient(testEnv, schedCtxs[name], name)
+		return func() codes.Code {
+			_, err := client.GetRoutes(testEnv.Context(), &types.Empty{})
+			return status.Code(err)
+		}
+	}
+
+	Specify("setup", func() {
+		test.SpawnMonitor(testEnv)
+		for _, name := range []string{"a", "b"} {
+			electors[name] = &manualElector{
+				lead: make(chan context.Context),
+			}
+			ids[name] = uuid.NewString()
+			schedCtxs[name], _ = test.SpawnScheduler(testEnv,
+				test.WithName(name),
+				test.WithUUID(ids[name]),
+				test.WithSchedulerOptions(scheduler.WithElector(electors[name])),
+			)
+		}
+		resolver = clients.NewLeaderResolver(testEnv.Context(),
+			test.NewMonitorClient(testEnv, testEnv.Context()),
+			types.Scheduler, "9090", "")
+	})
+
+	It("should not have a leader before one is elected", func() {
+		Consistently(leaderID).Should(BeEmpty())
+		Expect(resolver.Target()).To(Equal("kubecc-leader:///scheduler"))
+	})
+	It("should reject requests on standby schedulers", func() {
+		Expect(routesErr("a")()).To(Equal(codes.Unavailable))
+		Expect(routesErr("b")()).To(Equal(codes.Unavailable))
+	})
+	var cancelA context.CancelFunc
+	It("should resolve to the elected leader", func() {
+		var leaderCtx context.Context
+		leaderCtx, cancelA = context.WithCancel(testEnv.Context())
+		electors["a"].lead <- leaderCtx
+		Eventually(leaderID).Should(Equal(ids["a"]))
+		Eventually(routesErr("a")).Should(Equal(codes.OK))
+		Expect(routesErr("b")()).To(Equal(codes.Unavailable))
+	})
+	It("should fail over when leadership changes", func() {
+		cancelA()
+		Eventually(leaderID).Should(BeEmpty())
+		Eventually(routesErr("a")).Should(Equal(codes.Unavailable))
+
+		electors["b"].lead <- testEnv.Context()
+		Eventually(leaderID).Should(Equal(ids["b"]))
+		Eventually(routesErr("b")).Should(Equal(codes.OK))
+	})
+	Specify("shutdown", func() {
+		testEnv.Shutdown()
+	})
+})
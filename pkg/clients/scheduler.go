@@ -21,11 +21,13 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/kubecc-io/kubecc/pkg/meta"
 	"github.com/kubecc-io/kubecc/pkg/metrics"
 	"github.com/kubecc-io/kubecc/pkg/run"
 	"github.com/kubecc-io/kubecc/pkg/types"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -34,24 +36,55 @@ var ErrStreamNotReady = errors.New("Stream is not ready yet")
 type CompileRequestClient struct {
 	ctx     context.Context
 	stream  types.Scheduler_StreamOutgoingTasksClient
-	pending sync.Map // map[string]chan response
+	pending sync.Map // map[string]*request
 	queue   chan request
 
 	streamLock   *sync.Mutex
 	streamActive *sync.Cond
+
+	requeueTimeout time.Duration
+	requeueTimer   *time.Timer
+}
+
+type CompileRequestClientOptions struct {
+	requeueTimeout time.Duration
+}
+
+type CompileRequestClientOption func(*CompileRequestClientOptions)
+
+func (o *CompileRequestClientOptions) Apply(opts ...CompileRequestClientOption) {
+	for _, op := range opts {
+		op(o)
+	}
+}
+
+// WithRequeueTimeout keeps requests which are in flight when the stream to
+// the scheduler breaks, and sends them again once a new stream is loaded
+// (for example, after failing over to a new scheduler leader). If no new
+// stream is loaded within the timeout, the requests fail with the original
+// stream error. By default, in-flight requests fail immediately.
+func WithRequeueTimeout(timeout time.Duration) CompileRequestClientOption {
+	return func(o *CompileRequestClientOptions) {
+		o.requeueTimeout = timeout
+	}
 }
 
 func NewCompileRequestClient(
 	ctx context.Context,
 	stream types.Scheduler_StreamOutgoingTasksClient,
+	opts ...CompileRequestClientOption,
 ) run.SchedulerClientStream {
+	options := CompileRequestClientOptions{}
+	options.Apply(opts...)
+
 	lock := &sync.Mutex{}
 	c := &CompileRequestClient{
-		ctx:          ctx,
-		stream:       stream,
-		queue:        make(chan request),
-		streamLock:   lock,
-		streamActive: sync.NewCond(lock),
+		ctx:            ctx,
+		stream:         stream,
+		queue:          make(chan request),
+		streamLock:     lock,
+		streamActive:   sync.NewCond(lock),
+		requeueTimeout: options.requeueTimeout,
 	}
 	go c.recvWorker()
 	return c
@@ -66,9 +99,15 @@ func (rc *CompileRequestClient) LoadNewStream(
 	stream types.Scheduler_StreamOutgoingTasksClient,
 ) {
 	rc.streamLock.Lock()
+	defer rc.streamLock.Unlock()
 	rc.stream = stream
 	rc.streamActive.Signal()
-	rc.streamLock.Unlock()
+	if stream != nil && rc.requeueTimer != nil {
+		if rc.requeueTimer.Stop() {
+			rc.requeuePending(stream)
+		}
+		rc.requeueTimer = nil
+	}
 }
 
 type request struct {
@@ -82,7 +121,7 @@ type response struct {
 }
 
 func (rc *CompileRequestClient) Compile(
-	request *types.CompileRequest,
+	req *types.CompileRequest,
 ) (*types.CompileResponse, error) {
 	rc.streamLock.Lock()
 	if rc.stream == nil {
@@ -90,18 +129,22 @@ func (rc *CompileRequestClient) Compile(
 		return nil, ErrStreamNotReady
 	}
 
-	wait := make(chan response)
-	defer close(wait)
-	err := rc.stream.Send(request)
+	wait := make(chan response, 1)
+	rc.pending.Store(req.GetRequestID(), &request{
+		C:       wait,
+		Request: req,
+	})
+	err := rc.stream.Send(req)
 	rc.streamLock.Unlock()
 	if err != nil {
+		rc.pending.Delete(req.GetRequestID())
 		return nil, err
 	}
-	rc.pending.Store(request.GetRequestID(), wait)
 	select {
 	case resp := <-wait:
 		return resp.Value, resp.Err
 	case <-rc.ctx.Done():
+		rc.pending.Delete(req.GetRequestID())
 		return nil, rc.ctx.Err()
 	}
 }
@@ -112,31 +155,94 @@ func (rc *CompileRequestClient) recvWorker() {
 		for rc.stream == nil {
 			rc.streamActive.Wait()
 		}
+		stream := rc.stream
 		rc.streamLock.Unlock()
 
 		for {
-			resp, err := rc.stream.Recv()
+			resp, err := stream.Recv()
 			if err != nil {
-				rc.pending.Range(func(key, value interface{}) bool {
-					defer rc.pending.Delete(key)
-					value.(chan response) <- response{
-						Value: nil,
-						Err:   err,
-					}
-					return true
-				})
+				rc.streamFailed(stream, err)
 				break
 			}
-			if ch, ok := rc.pending.LoadAndDelete(resp.GetRequestID()); ok {
-				ch.(chan response) <- response{Value: resp}
+			if req, ok := rc.pending.LoadAndDelete(resp.GetRequestID()); ok {
+				req.(*request).C <- response{Value: resp}
 			}
 		}
-		rc.streamLock.Lock()
+	}
+}
+
+// streamFailed handles requests which were in flight on a stream which
+// returned an error. Depending on the requeue timeout, they either fail
+// immediately or wait to be sent again on the next stream.
+func (rc *CompileRequestClient) streamFailed(
+	stream types.Scheduler_StreamOutgoingTasksClient,
+	err error,
+) {
+	rc.streamLock.Lock()
+	defer rc.streamLock.Unlock()
+	if rc.stream == stream {
 		rc.stream = nil
-		rc.streamLock.Unlock()
+	}
+	if rc.requeueTimeout <= 0 || rc.ctx.Err() != nil {
+		rc.failPending(err)
+		return
+	}
+	if rc.stream != nil {
+		// A new stream was loaded before the old one finished failing
+		rc.requeuePending(rc.stream)
+		return
+	}
+	if rc.requeueTimer == nil {
+		meta.Log(rc.ctx).With(
+			zap.Error(err),
+			"timeout", rc.requeueTimeout,
+		).Warn("Scheduler stream lost, waiting to requeue in-flight requests")
+		var timer *time.Timer
+		timer = time.AfterFunc(rc.requeueTimeout, func() {
+			rc.streamLock.Lock()
+			defer rc.streamLock.Unlock()
+			if rc.requeueTimer == timer {
+				rc.requeueTimer = nil
+			}
+			rc.failPending(err)
+		})
+		rc.requeueTimer = timer
+	}
+}
+
+// requeuePending sends all in-flight requests on the given stream. The
+// stream lock must be held.
+func (rc *CompileRequestClient) requeuePending(
+	stream types.Scheduler_StreamOutgoingTasksClient,
+) {
+	count := 0
+	rc.pending.Range(func(key, value interface{}) bool {
+		if err := stream.Send(value.(*request).Request); err != nil {
+			// The new stream is also broken, its recvWorker will handle the
+			// remaining requests.
+			return false
+		}
+		count++
+		return true
+	})
+	if count > 0 {
+		meta.Log(rc.ctx).With(
+			"count", count,
+		).Info("Requeued in-flight requests on new scheduler stream")
 	}
 }
 
+func (rc *CompileRequestClient) failPending(err error) {
+	rc.pending.Range(func(key, value interface{}) bool {
+		rc.pending.Delete(key)
+		value.(*request).C <- response{
+			Value: nil,
+			Err:   err,
+		}
+		return true
+	})
+}
+
 type RemoteUsageManager struct {
 	ctx    context.Context
 	client types.MonitorClient
@@ -168,13 +274,19 @@ func (m *RemoteUsageManager) Manage(resizer run.Resizer) {
 		if whois.Component != types.Scheduler {
 			return
 		}
+		// With several scheduler replicas, only the leader posts limits.
+		// Standby replicas coming and going should not reset them.
+		received := atomic.NewBool(false)
 		l.OnValueChanged(uuid, func(u *metrics.PreferredUsageLimits) {
+			received.Store(true)
 			lg.With(
 				"limit", u.GetConcurrentProcessLimit(),
 			).Info("Received new remote concurrent process limit")
 			resizer.Resize(u.GetConcurrentProcessLimit())
 		})
 		<-ctx.Done()
-		resizer.Resize(0)
+		if received.Load() {
+			resizer.Resize(0)
+		}
 	})
 }
@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package clients_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	"github.com/kubecc-io/kubecc/internal/logkc"
+	"github.com/kubecc-io/kubecc/pkg/clients"
+	"github.com/kubecc-io/kubecc/pkg/identity"
+	"github.com/kubecc-io/kubecc/pkg/meta"
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+var errStreamClosed = errors.New("stream closed")
+
+// fakeTaskStream is a scheduler stream which records sent requests and
+// returns responses or an error from its channels.
+type fakeTaskStream struct {
+	grpc.ClientStream
+	sent      chan *types.CompileRequest
+	responses chan *types.CompileResponse
+	closed    chan struct{}
+}
+
+func newFakeTaskStream() *fakeTaskStream {
+	return &fakeTaskStream{
+		sent:      make(chan *types.CompileRequest, 10),
+		responses: make(chan *types.CompileResponse),
+		closed:    make(chan struct{}),
+	}
+}
+
+func (s *fakeTaskStream) Send(req *types.CompileRequest) error {
+	select {
+	case <-s.closed:
+		return errStreamClosed
+	default:
+	}
+	s.sent <- req
+	return nil
+}
+
+func (s *fakeTaskStream) Recv() (*types.CompileResponse, error) {
+	select {
+	case resp := <-s.responses:
+		return resp, nil
+	case <-s.closed:
+		return nil, errStreamClosed
+	}
+}
+
+var _ = Describe("Compile Request Client", func() {
+	newContext := func() (context.Context, context.CancelFunc) {
+		return context.WithCancel(meta.NewContext(
+			meta.WithProvider(identity.Component, meta.WithValue(types.TestComponent)),
+			meta.WithProvider(identity.UUID),
+			meta.WithProvider(logkc.Logger, meta.WithValue(testLog)),
+		))
+	}
+	compile := func(
+		client interface {
+			Compile(*types.CompileRequest) (*types.CompileResponse, error)
+		},
+		id string,
+	) chan error {
+		done := make(chan error, 1)
+		go func() {
+			_, err := client.Compile(&types.CompileRequest{RequestID: id})
+			done <- err
+		}()
+		return done
+	}
+
+	When("no requeue timeout is set", func() {
+		It("should fail in-flight requests when the stream breaks", func() {
+			ctx, cancel := newContext()
+			defer cancel()
+			stream := newFakeTaskStream()
+			client := clients.NewCompileRequestClient(ctx, stream)
+			done := compile(client, "1")
+			Eventually(stream.sent).Should(Receive())
+			close(stream.closed)
+			Eventually(done).Should(Receive(MatchError(errStreamClosed)))
+		})
+	})
+	When("a requeue timeout is set", func() {
+		It("should resend in-flight requests on the next stream", func() {
+			ctx, cancel := newContext()
+			defer cancel()
+			oldStream := newFakeTaskStream()
+			client := clients.NewCompileRequestClient(ctx, oldStream,
+				clients.WithRequeueTimeout(5*time.Second))
+			done := compile(client, "1")
+			Eventually(oldStream.sent).Should(Receive())
+			close(oldStream.closed)
+			Consistently(done, 100*time.Millisecond).ShouldNot(Receive())
+
+			newStream := newFakeTaskStream()
+			client.LoadNewStream(newStream)
+			var req *types.CompileRequest
+			Eventually(newStream.sent).Should(Receive(&req))
+			Expect(req.RequestID).To(Equal("1"))
+			newStream.responses <- &types.CompileResponse{RequestID: "1"}
+			Eventually(done).Should(Receive(BeNil()))
+		})
+		It("should fail in-flight requests if no new stream is loaded in time", func() {
+			ctx, cancel := newContext()
+			defer cancel()
+			stream := newFakeTaskStream()
+			client := clients.NewCompileRequestClient(ctx, stream,
+				clients.WithRequeueTimeout(100*time.Millisecond))
+			done := compile(client, "1")
+			Eventually(stream.sent).Should(Receive())
+			close(stream.closed)
+			Eventually(done).Should(Receive(MatchError(errStreamClosed)))
+		})
+	})
+})
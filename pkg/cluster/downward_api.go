@@ -50,6 +50,12 @@ func GetNode() string {
 	return value
 }
 
+// LookupNode returns the current node from the downward API, or an empty
+// string if it is not available.
+func LookupNode() string {
+	return os.Getenv("KUBECC_NODE")
+}
+
 func MakeDownwardApi() []v1.EnvVar {
 	return []v1.EnvVar{
 		{
@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// ZoneLabel is the well-known node label containing the topology zone a node
+// is running in.
+const ZoneLabel = "topology.kubernetes.io/zone"
+
+// nodeLookupTimeout limits how long LookupZone waits for the API server.
+const nodeLookupTimeout = 5 * time.Second
+
+var (
+	zoneOnce sync.Once
+	zone     string
+	zoneErr  error
+)
+
+// LookupZone returns the topology zone of the node the current pod is running
+// on, or an empty string if it is not available. Kubernetes sets the
+// topology.kubernetes.io/zone label on nodes, not pods, so the label is read
+// from the node named by KUBECC_NODE, which requires permission to get nodes.
+// Outside of a cluster, KUBECC_ZONE can be set directly. The zone is only
+// looked up once, and any error from the lookup is returned on every call.
+func LookupZone() (string, error) {
+	zoneOnce.Do(func() {
+		if value, ok := os.LookupEnv("KUBECC_ZONE"); ok {
+			zone = value
+			return
+		}
+		node := LookupNode()
+		if node == "" || !InCluster() {
+			return
+		}
+		zone, zoneErr = lookupNodeZone(node)
+	})
+	return zone, zoneErr
+}
+
+func lookupNodeZone(name string) (string, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return "", err
+	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), nodeLookupTimeout)
+	defer cancel()
+	node, err := client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("could not look up node %s: %w", name, err)
+	}
+	return node.Labels[ZoneLabel], nil
+}
@@ -63,6 +63,9 @@ type AgentSpec struct {
 	UsageLimits      *UsageLimitsSpec `json:"usageLimits,omitempty"`
 	SchedulerAddress string           `json:"schedulerAddress,omitempty"`
 	MonitorAddress   string           `json:"monitorAddress,omitempty"`
+	// SchedulerFailover connects to whichever scheduler replica is currently
+	// the leader, as reported by the monitor, instead of SchedulerAddress.
+	SchedulerFailover *SchedulerFailoverSpec `json:"schedulerFailover,omitempty"`
 }
 
 type ConsumerSpec struct {
@@ -72,18 +75,101 @@ type ConsumerSpec struct {
 
 type ConsumerdSpec struct {
 	GlobalSpec
-	UsageLimits      *UsageLimitsSpec `json:"usageLimits,omitempty"`
-	SchedulerAddress string           `json:"schedulerAddress,omitempty"`
-	MonitorAddress   string           `json:"monitorAddress,omitempty"`
-	ListenAddress    string           `json:"listenAddress,omitempty"`
-	DisableTLS       bool             `json:"disableTLS,omitempty"`
+	UsageLimits      *UsageLimitsSpec     `json:"usageLimits,omitempty"`
+	SchedulerAddress string               `json:"schedulerAddress,omitempty"`
+	MonitorAddress   string               `json:"monitorAddress,omitempty"`
+	ListenAddress    string               `json:"listenAddress,omitempty"`
+	DisableTLS       bool                 `json:"disableTLS,omitempty"`
+	CacheAddress     string               `json:"cacheAddress,omitempty"`
+	VolatileCache    *VolatileStorageSpec `json:"volatileCache,omitempty"`
+	LocalCache       *LocalStorageSpec    `json:"localCache,omitempty"`
+	CacheRetention   RetentionSpec        `json:"cacheRetention,omitempty"`
+	// SchedulerFailover connects to whichever scheduler replica is currently
+	// the leader, as reported by the monitor, instead of SchedulerAddress.
+	SchedulerFailover *SchedulerFailoverSpec `json:"schedulerFailover,omitempty"`
+}
+
+type SchedulerFailoverSpec struct {
+	// Port is the port scheduler replicas are listening on. Defaults to the
+	// port of SchedulerAddress.
+	Port string `json:"port,omitempty"`
+	// RequeueTimeout is how long requests which were in flight when the
+	// leader went away wait for a new leader before failing. Only used by
+	// the consumerd. Defaults to 10s.
+	RequeueTimeout string `json:"requeueTimeout,omitempty"`
 }
 
 type SchedulerSpec struct {
 	GlobalSpec
-	MonitorAddress string `json:"monitorAddress,omitempty"`
-	CacheAddress   string `json:"cacheAddress,omitempty"`
-	ListenAddress  string `json:"listenAddress,omitempty"`
+	MonitorAddress   string  `json:"monitorAddress,omitempty"`
+	CacheAddress     string  `json:"cacheAddress,omitempty"`
+	ListenAddress    string  `json:"listenAddress,omitempty"`
+	ThrottlingTarget float64 `json:"throttlingTarget,omitempty"`
+	// CacheCluster enables sharding objects across all cache servers
+	// connected to the monitor, instead of using only CacheAddress.
+	CacheCluster *CacheClusterSpec `json:"cacheCluster,omitempty"`
+	// Scheduling configures how agents are shared between priority classes
+	// and tenants when there are more requests than agents can run at once.
+	Scheduling SchedulingSpec `json:"scheduling,omitempty"`
+	// LeaderElection allows running several scheduler replicas, of which
+	// only the one holding a Kubernetes Lease is active.
+	LeaderElection *LeaderElectionSpec `json:"leaderElection,omitempty"`
+}
+
+type LeaderElectionSpec struct {
+	// LeaseName is the name of the Lease object. Defaults to
+	// "kubecc-scheduler".
+	LeaseName string `json:"leaseName,omitempty"`
+	// LeaseNamespace is the namespace of the Lease object. Defaults to the
+	// namespace the scheduler is running in.
+	LeaseNamespace string `json:"leaseNamespace,omitempty"`
+	// LeaseDuration is how long standby replicas wait before taking over
+	// a lease which has not been renewed. Defaults to 15s.
+	LeaseDuration string `json:"leaseDuration,omitempty"`
+	// RenewDeadline is how long the leader keeps trying to renew the lease
+	// before giving up leadership. Defaults to 10s.
+	RenewDeadline string `json:"renewDeadline,omitempty"`
+	// RetryPeriod is how often replicas try to acquire or renew the lease.
+	// Defaults to 2s.
+	RetryPeriod string `json:"retryPeriod,omitempty"`
+}
+
+type SchedulingSpec struct {
+	// ClassWeights is the relative share of agents given to each priority
+	// class (interactive, ci, or batch). Defaults to 8, 2, and 1.
+	ClassWeights map[string]int `json:"classWeights,omitempty"`
+	// DefaultClass is the priority class of requests which do not specify
+	// one. Defaults to interactive.
+	DefaultClass string `json:"defaultClass,omitempty"`
+	// Locality configures how requests are sent to agents close to the
+	// consumerd they were sent from. Requests are only sent to agents further
+	// away if no closer agents have free tokens.
+	Locality LocalitySpec `json:"locality,omitempty"`
+}
+
+type LocalitySpec struct {
+	// RecheckInterval is how often a request held back from an agent, since
+	// closer agents have free tokens, is reconsidered. Defaults to 50ms.
+	RecheckInterval string `json:"recheckInterval,omitempty"`
+}
+
+type CacheClusterSpec struct {
+	// ReplicationFactor is the number of cache servers each object is stored
+	// on. Defaults to 1.
+	ReplicationFactor int `json:"replicationFactor,omitempty"`
+	// Port is the port cache servers are listening on. Defaults to the port
+	// of the scheduler's CacheAddress.
+	Port string `json:"port,omitempty"`
+	// Rebalance copies objects to the cache servers which should own them
+	// when cache servers join or leave the cluster.
+	Rebalance bool `json:"rebalance,omitempty"`
+	// RebalanceRate is the maximum number of objects copied per second while
+	// rebalancing. Defaults to 100.
+	RebalanceRate float64 `json:"rebalanceRate,omitempty"`
+	// MaxRebalanceObjects is the maximum number of objects copied each time
+	// the cluster is rebalanced. Other objects are copied to their owners
+	// when they are pulled from a replica. Defaults to 10000.
+	MaxRebalanceObjects int `json:"maxRebalanceObjects,omitempty"`
 }
 
 type MonitorSpec struct {
@@ -96,9 +182,71 @@ type CacheSpec struct {
 	GlobalSpec
 	VolatileStorage *VolatileStorageSpec `json:"volatileStorage,omitempty"`
 	LocalStorage    *LocalStorageSpec    `json:"localStorage,omitempty"`
+	RedisStorage    *RedisStorageSpec    `json:"redisStorage,omitempty"`
 	RemoteStorage   *RemoteStorageSpec   `json:"remoteStorage,omitempty"`
 	ListenAddress   string               `json:"listenAddress,omitempty"`
 	MonitorAddress  string               `json:"monitorAddress,omitempty"`
+	// Retention controls how long objects are kept, including compile results
+	// stored by the scheduler. If no default TTL is set, objects are kept for
+	// one hour.
+	Retention    RetentionSpec    `json:"retention,omitempty"`
+	StorageChain StorageChainSpec `json:"storageChain,omitempty"`
+	// REAPI enables the Bazel Remote Execution API cache services
+	// (ContentAddressableStorage, ActionCache, ByteStream and Capabilities)
+	// on the cache server's listen address.
+	REAPI *REAPISpec `json:"reapi,omitempty"`
+	// HTTP enables an HTTP endpoint compatible with the ccache HTTP remote
+	// storage backend and the sccache WebDAV backend.
+	HTTP *HTTPCacheSpec `json:"http,omitempty"`
+}
+
+type HTTPCacheSpec struct {
+	ListenAddress string `json:"listenAddress,omitempty"`
+	// MaxObjectSize limits the size of uploaded objects, as a resource
+	// quantity (e.g. "64Mi"). Defaults to 64Mi.
+	MaxObjectSize string `json:"maxObjectSize,omitempty"`
+}
+
+type REAPISpec struct {
+	// MaxBatchSize limits the total size of blobs in a single batch request,
+	// as a resource quantity (e.g. "4Mi"). Larger blobs must be transferred
+	// using the ByteStream service. Defaults to 4Mi.
+	MaxBatchSize string `json:"maxBatchSize,omitempty"`
+	// MaxObjectSize limits the size of blobs uploaded using the ByteStream
+	// service, as a resource quantity (e.g. "64Mi"). Defaults to 64Mi.
+	MaxObjectSize string `json:"maxObjectSize,omitempty"`
+}
+
+// StorageChainSpec controls how objects are written to and repaired across
+// the configured storage providers.
+type StorageChainSpec struct {
+	// WritePolicy is one of "writeThrough" (the default), "writeBack", or
+	// "writeAround". Write-back stores objects in the fastest provider and
+	// copies them to slower providers in the background. Write-around stores
+	// objects only in the slowest provider.
+	WritePolicy string `json:"writePolicy,omitempty"`
+	// WriteBackQueueSize limits the number of objects waiting to be written
+	// to each provider. Defaults to 1024.
+	WriteBackQueueSize int `json:"writeBackQueueSize,omitempty"`
+	// ReadRepairWorkers and ReadRepairRate limit how many objects found in a
+	// slower provider are copied into faster providers concurrently, and per
+	// second. Default to 4 and 100.
+	ReadRepairWorkers int `json:"readRepairWorkers,omitempty"`
+	ReadRepairRate    int `json:"readRepairRate,omitempty"`
+	// FailureThreshold is the number of consecutive errors after which a
+	// provider is skipped. It is retried once every RetryInterval. Default
+	// to 3 and "30s".
+	FailureThreshold int    `json:"failureThreshold,omitempty"`
+	RetryInterval    string `json:"retryInterval,omitempty"`
+}
+
+// RetentionSpec controls how long cached objects are kept. Durations are in
+// the format accepted by time.ParseDuration (e.g. "1h30m"). Toolchain TTLs
+// are keyed by toolchain kind (e.g. "gnu" or "clang").
+type RetentionSpec struct {
+	DefaultTTL        string            `json:"defaultTTL,omitempty"`
+	SlidingExpiration bool              `json:"slidingExpiration,omitempty"`
+	ToolchainTTLs     map[string]string `json:"toolchainTTLs,omitempty"`
 }
 
 type LocalStorageSpec struct {
@@ -110,6 +258,19 @@ type VolatileStorageSpec struct {
 	Limits StorageLimitsSpec `json:"limits,omitempty"`
 }
 
+// RedisStorageSpec configures a Redis server used as a shared storage tier.
+// Objects expire using Redis TTLs, and memory limits and eviction are left to
+// the server's maxmemory configuration.
+type RedisStorageSpec struct {
+	Address  string `json:"address,omitempty"`
+	Password string `json:"password,omitempty"`
+	DB       int    `json:"db,omitempty"`
+	TLS      bool   `json:"tls,omitempty"`
+	// KeyPrefix is prepended to all keys written by the cache server, which
+	// allows a Redis database to be shared. Defaults to "kubecc:".
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+}
+
 type RemoteStorageSpec struct {
 	Endpoint       string `json:"endpoint,omitempty"`
 	AccessKey      string `json:"accessKey,omitempty"`
@@ -124,12 +285,17 @@ type RemoteStorageSpec struct {
 type StorageLimitsSpec struct {
 	Memory string `json:"memory,omitempty"`
 	Disk   string `json:"disk,omitempty"`
+	// EvictionPolicy selects which objects are evicted when the storage limit
+	// is reached. One of "lru", "lfu", or "gdsf". If empty, local storage
+	// evicts objects closest to expiration and volatile storage uses LRU.
+	EvictionPolicy string `json:"evictionPolicy,omitempty"`
 }
 
 type KcctlSpec struct {
 	GlobalSpec
 	MonitorAddress   string `json:"monitorAddress,omitempty"`
 	SchedulerAddress string `json:"schedulerAddress,omitempty"`
+	CacheAddress     string `json:"cacheAddress,omitempty"`
 	DisableTLS       bool   `json:"disableTLS,omitempty"`
 }
 
@@ -30,6 +30,13 @@ import (
 	"google.golang.org/grpc"
 )
 
+// PriorityClassEnv and TenantEnv are the environment variables used to set
+// the priority class and tenant of requests sent by the consumer.
+const (
+	PriorityClassEnv = "KUBECC_PRIORITY_CLASS"
+	TenantEnv        = "KUBECC_TENANT"
+)
+
 func DispatchAndWait(ctx context.Context, cc *grpc.ClientConn) {
 	lg := meta.Log(ctx)
 
@@ -49,16 +56,25 @@ func DispatchAndWait(ctx context.Context, cc *grpc.ClientConn) {
 		}
 	}
 
+	priority, err := types.ParsePriorityClass(os.Getenv(PriorityClassEnv))
+	if err != nil {
+		lg.With(
+			zap.Error(err),
+		).Warn("Ignoring invalid " + PriorityClassEnv)
+	}
+
 	resp, err := consumerd.Run(ctx, &types.RunRequest{
 		Compiler: &types.RunRequest_Path{
 			Path: findCompilerOrDie(ctx),
 		},
-		Args:    os.Args[1:],
-		Env:     os.Environ(),
-		UID:     uint32(os.Getuid()),
-		GID:     uint32(os.Getgid()),
-		Stdin:   stdin.Bytes(),
-		WorkDir: wd,
+		Args:     os.Args[1:],
+		Env:      os.Environ(),
+		UID:      uint32(os.Getuid()),
+		GID:      uint32(os.Getgid()),
+		Stdin:    stdin.Bytes(),
+		WorkDir:  wd,
+		Priority: priority,
+		Tenant:   os.Getenv(TenantEnv),
 	})
 	if err != nil {
 		lg.With(
@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package consumerd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/kubecc-io/kubecc/pkg/meta"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"github.com/kubecc-io/kubecc/pkg/util"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LocalCacheSyncInterval is the interval at which the consumerd local cache
+// is synced with the central cache.
+var LocalCacheSyncInterval = 5 * time.Minute
+
+// localCacheSyncer keeps a local storage provider populated with the most
+// valuable objects from the central cache.
+type localCacheSyncer struct {
+	ctx           context.Context
+	lg            *zap.SugaredLogger
+	cacheClient   types.CacheClient
+	storage       storage.StorageProvider
+	desiredSizeKb int64
+	syncing       *atomic.Bool
+}
+
+func newLocalCacheSyncer(
+	ctx context.Context,
+	client types.CacheClient,
+	sp storage.StorageProvider,
+	desiredSizeKb int64,
+) *localCacheSyncer {
+	return &localCacheSyncer{
+		ctx:           ctx,
+		lg:            meta.Log(ctx),
+		cacheClient:   client,
+		storage:       sp,
+		desiredSizeKb: desiredSizeKb,
+		syncing:       atomic.NewBool(false),
+	}
+}
+
+// Run periodically syncs the local cache until the context is done.
+func (s *localCacheSyncer) Run() {
+	util.RunPeriodic(s.ctx, LocalCacheSyncInterval, 0.25, true, func() {
+		if err := s.Sync(); err != nil {
+			s.lg.With(zap.Error(err)).Warn("Failed to sync local cache")
+		}
+	})
+}
+
+// Sync performs a single sync with the central cache. Objects sent by the
+// cache server are stored in the local storage provider. If a sync is
+// already in progress, Sync returns immediately.
+func (s *localCacheSyncer) Sync() error {
+	if !s.syncing.CAS(false, true) {
+		return nil
+	}
+	defer s.syncing.Store(false)
+
+	keys, err := s.storage.List(s.ctx)
+	if err != nil {
+		return err
+	}
+	stream, err := s.cacheClient.Sync(s.ctx, &types.SyncRequest{
+		LocalCache:         keys,
+		DesiredCacheSizeKb: s.desiredSizeKb,
+	})
+	if err != nil {
+		return err
+	}
+	received := 0
+	for {
+		obj, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		received++
+		err = s.storage.Put(s.ctx, obj.GetKey(), obj.GetObject())
+		if err != nil && status.Code(err) != codes.AlreadyExists {
+			s.lg.With(zap.Error(err)).Error("Failed to store synced object")
+		}
+	}
+	s.lg.With(
+		"received", received,
+		"existing", len(keys),
+	).Debug("Local cache synced")
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/kubecc-io/kubecc/pkg/metrics"
 	"github.com/kubecc-io/kubecc/pkg/run"
 	"github.com/kubecc-io/kubecc/pkg/servers"
+	"github.com/kubecc-io/kubecc/pkg/storage"
 	"github.com/kubecc-io/kubecc/pkg/toolchains"
 	"github.com/kubecc-io/kubecc/pkg/types"
 	"github.com/kubecc-io/kubecc/pkg/util"
@@ -55,8 +56,10 @@ type consumerdServer struct {
 	metricsProvider clients.MetricsProvider
 	executor        run.Executor
 	numConsumers    *atomic.Int32
+	corruptResults  *atomic.Int64
 	requestClient   run.SchedulerClientStream
 	streamMgr       *clients.StreamManager
+	cacheSyncer     *localCacheSyncer
 }
 
 type ConsumerdServerOptions struct {
@@ -65,6 +68,10 @@ type ConsumerdServerOptions struct {
 	schedulerClient  types.SchedulerClient
 	monitorClient    types.MonitorClient
 	queueOpts        []SplitQueueOption
+	cacheClient      types.CacheClient
+	localCache       storage.StorageProvider
+	localCacheSizeKb int64
+	requestOpts      []clients.CompileRequestClientOption
 }
 
 type ConsumerdServerOption func(*ConsumerdServerOptions)
@@ -105,6 +112,41 @@ func WithMonitorClient(
 	}
 }
 
+// WithCacheClient sets the client used to sync the local cache with the
+// central cache. It has no effect unless a local cache is also configured.
+func WithCacheClient(
+	client types.CacheClient,
+) ConsumerdServerOption {
+	return func(o *ConsumerdServerOptions) {
+		o.cacheClient = client
+	}
+}
+
+// WithLocalCache configures a local cache which is checked for existing
+// results before sending compile requests to the scheduler, and which stores
+// the results of successful remote compiles. If a cache client is also
+// configured, the local cache will be periodically synced with up to sizeKb
+// kilobytes of the most valuable objects in the central cache.
+func WithLocalCache(
+	sp storage.StorageProvider,
+	sizeKb int64,
+) ConsumerdServerOption {
+	return func(o *ConsumerdServerOptions) {
+		o.localCache = sp
+		o.localCacheSizeKb = sizeKb
+	}
+}
+
+// WithRequestClientOptions configures the client used to send compile
+// requests to the scheduler.
+func WithRequestClientOptions(
+	opts ...clients.CompileRequestClientOption,
+) ConsumerdServerOption {
+	return func(o *ConsumerdServerOptions) {
+		o.requestOpts = append(o.requestOpts, opts...)
+	}
+}
+
 func WithQueueOptions(opts ...SplitQueueOption) ConsumerdServerOption {
 	return func(o *ConsumerdServerOptions) {
 		o.queueOpts = append(o.queueOpts, opts...)
@@ -130,10 +172,11 @@ func NewConsumerdServer(
 		tcRunStore:      runStore,
 		storeUpdateCh:   make(chan struct{}, 1),
 		numConsumers:    atomic.NewInt32(0),
+		corruptResults:  atomic.NewInt64(0),
 		executor:        NewSplitQueue(ctx, options.monitorClient, options.queueOpts...),
 		schedulerClient: options.schedulerClient,
 		monitorClient:   options.monitorClient,
-		requestClient:   clients.NewCompileRequestClient(ctx, nil),
+		requestClient:   clients.NewCompileRequestClient(ctx, nil, options.requestOpts...),
 	}
 	srv.BeginInitialize(ctx)
 	defer srv.EndInitialize()
@@ -152,6 +195,22 @@ func NewConsumerdServer(
 		srv.metricsProvider = clients.NewNoopMetricsProvider()
 	}
 
+	if options.localCache != nil {
+		if err := options.localCache.Configure(); err != nil {
+			srv.ApplyCondition(ctx, metrics.StatusConditions_InvalidConfiguration,
+				err.Error())
+			srv.lg.With(zap.Error(err)).Error("Could not configure local cache")
+		} else {
+			srv.requestClient = clients.NewCachingRequestClient(ctx,
+				srv.requestClient, options.localCache)
+			if options.cacheClient != nil {
+				srv.cacheSyncer = newLocalCacheSyncer(ctx, options.cacheClient,
+					options.localCache, options.localCacheSizeKb)
+				go srv.cacheSyncer.Run()
+			}
+		}
+	}
+
 	go srv.runRequestClient()
 	go srv.streamMgr.Run()
 	return srv
@@ -244,6 +303,9 @@ func (s *consumerdServer) postTotals() {
 	}
 	s.metricsProvider.Post(local)
 	s.metricsProvider.Post(remote)
+	s.metricsProvider.Post(&metrics.CorruptResultsTotal{
+		Total: s.corruptResults.Load(),
+	})
 }
 
 func (s *consumerdServer) postToolchains() {
@@ -353,6 +415,11 @@ func (c *consumerdServer) Run(
 				c.lg.Warn(err.Error())
 				exclusivity = Local
 				continue
+			} else if errors.Is(err, run.ErrCorruptResult) {
+				c.corruptResults.Inc()
+				c.lg.Warn(err.Error())
+				exclusivity = Local
+				continue
 			}
 			return nil, err
 		}
@@ -20,8 +20,10 @@ package host
 import (
 	"context"
 	"fmt"
+	"os"
 	"runtime"
 
+	"github.com/kubecc-io/kubecc/pkg/cluster"
 	"github.com/kubecc-io/kubecc/pkg/meta"
 	"github.com/kubecc-io/kubecc/pkg/meta/mdkeys"
 	"github.com/kubecc-io/kubecc/pkg/types"
@@ -31,11 +33,16 @@ import (
 func GetSystemInfo() *types.SystemInfo {
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
+	zone, _ := cluster.LookupZone()
+	hostname, _ := os.Hostname()
 
 	return &types.SystemInfo{
 		Arch:         runtime.GOARCH,
 		CpuThreads:   int32(runtime.NumCPU()),
 		SystemMemory: memStats.Sys,
+		Hostname:     hostname,
+		Node:         cluster.LookupNode(),
+		Zone:         zone,
 	}
 }
 
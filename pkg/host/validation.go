@@ -18,6 +18,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package host
 
 import (
+	"github.com/kubecc-io/kubecc/pkg/cluster"
 	"go.uber.org/zap"
 	validators "k8s.io/system-validators/validators"
 )
@@ -49,4 +50,9 @@ func RunPreflightChecks(lg *zap.SugaredLogger) {
 	for _, e := range errs {
 		lg.Error(e)
 	}
+
+	if _, err := cluster.LookupZone(); err != nil {
+		// Agents in the same zone can not be preferred
+		lg.With(zap.Error(err)).Warn("Could not determine topology zone")
+	}
 }
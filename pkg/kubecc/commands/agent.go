@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	. "github.com/kubecc-io/kubecc/pkg/kubecc/internal"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	schedulerAddress string
+	drainTimeout     time.Duration
+)
+
+// drainAgent sends a drain, cordon, or uncordon request to the scheduler and
+// prints the progress it reports.
+func drainAgent(uuid string, action types.DrainAction, timeout time.Duration) error {
+	ctx := CLIContext
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	stream, err := schedulerClient().DrainAgent(ctx, &types.DrainAgentRequest{
+		UUID:   uuid,
+		Action: action,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		progress, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		switch {
+		case progress.Drained:
+			fmt.Printf("agent %s drained\n", progress.UUID)
+		case action == types.Drain:
+			fmt.Printf("agent %s: waiting for %d running tasks\n",
+				progress.UUID, progress.RunningTasks)
+		case progress.Cordoned:
+			fmt.Printf("agent %s cordoned (%d running tasks)\n",
+				progress.UUID, progress.RunningTasks)
+		default:
+			fmt.Printf("agent %s uncordoned\n", progress.UUID)
+		}
+	}
+}
+
+// AgentDrainCmd represents the agent drain command.
+var AgentDrainCmd = &cobra.Command{
+	Use:   "drain uuid",
+	Short: "Stop sending tasks to an agent and wait for its running tasks",
+	Long: `Mark an agent as unschedulable, then wait until all of the tasks it is
+running have completed. The agent remains cordoned afterwards, use
+"kubecc agent uncordon" to allow it to receive tasks again.`,
+	Args:    cobra.ExactArgs(1),
+	PreRun:  InitCLIQuiet,
+	Example: "kubecc agent drain 3f0f1a43-6d0e-4b5c-8a3e-1f5c2f7d9b21 --timeout 5m",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return drainAgent(args[0], types.Drain, drainTimeout)
+	},
+}
+
+// AgentCordonCmd represents the agent cordon command.
+var AgentCordonCmd = &cobra.Command{
+	Use:     "cordon uuid",
+	Short:   "Stop sending new tasks to an agent",
+	Long:    `Mark an agent as unschedulable. Tasks it is already running are not affected.`,
+	Args:    cobra.ExactArgs(1),
+	PreRun:  InitCLIQuiet,
+	Example: "kubecc agent cordon 3f0f1a43-6d0e-4b5c-8a3e-1f5c2f7d9b21",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return drainAgent(args[0], types.Cordon, 0)
+	},
+}
+
+// AgentUncordonCmd represents the agent uncordon command.
+var AgentUncordonCmd = &cobra.Command{
+	Use:     "uncordon uuid",
+	Short:   "Allow a cordoned agent to receive tasks again",
+	Args:    cobra.ExactArgs(1),
+	PreRun:  InitCLIQuiet,
+	Example: "kubecc agent uncordon 3f0f1a43-6d0e-4b5c-8a3e-1f5c2f7d9b21",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return drainAgent(args[0], types.Uncordon, 0)
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{
+		AgentDrainCmd,
+		AgentCordonCmd,
+		AgentUncordonCmd,
+	} {
+		cmd.Flags().StringVar(&schedulerAddress, "address", "",
+			"Scheduler address (defaults to the configured scheduler address)")
+	}
+	AgentDrainCmd.Flags().DurationVar(&drainTimeout, "timeout", 0,
+		"Give up waiting after this long (the agent remains cordoned)")
+}
@@ -0,0 +1,412 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	ccctrl "github.com/kubecc-io/kubecc/pkg/cc/controller"
+	"github.com/kubecc-io/kubecc/pkg/clients"
+	. "github.com/kubecc-io/kubecc/pkg/kubecc/internal"
+	"github.com/kubecc-io/kubecc/pkg/run"
+	"github.com/kubecc-io/kubecc/pkg/servers"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/toolchains"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"github.com/kubecc-io/kubecc/pkg/util"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var (
+	cacheAddress   string
+	filterTools    []string
+	filterTags     map[string]string
+	filterMinAge   time.Duration
+	filterMaxAge   time.Duration
+	importLocation string
+	purgeDryRun    bool
+	purgeAll       bool
+)
+
+func cacheClient() types.CacheClient {
+	address := CLIConfig.CacheAddress
+	if cacheAddress != "" {
+		address = cacheAddress
+	}
+	if address == "" {
+		CLILog.Fatal("No cache server address configured")
+	}
+	cc, err := servers.Dial(CLIContext, address,
+		servers.WithTLS(!CLIConfig.DisableTLS))
+	if err != nil {
+		CLILog.Fatal(err)
+	}
+	return types.NewCacheClient(cc)
+}
+
+// addFilterFlags adds flags used to build a cache filter to the command.
+func addFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&filterTools, "toolchain", nil,
+		"Only include objects produced by these toolchains (e.g. gnu, clang)")
+	cmd.Flags().StringToStringVar(&filterTags, "tag", nil,
+		"Only include objects with these tags (key=value)")
+	cmd.Flags().DurationVar(&filterMinAge, "min-age", 0,
+		"Only include objects stored at least this long ago")
+	cmd.Flags().DurationVar(&filterMaxAge, "max-age", 0,
+		"Only include objects stored at most this long ago")
+}
+
+func cacheFilter() *types.CacheFilter {
+	return &types.CacheFilter{
+		Toolchains: filterTools,
+		Tags:       filterTags,
+		MinAge:     int64(filterMinAge),
+		MaxAge:     int64(filterMaxAge),
+	}
+}
+
+func parseStorageLocation(name string) (types.StorageLocation, error) {
+	if name == "" {
+		return types.Unknown, nil
+	}
+	for value, locName := range types.StorageLocation_name {
+		if strings.EqualFold(strings.TrimPrefix(locName, "StorageLocation_"), name) {
+			return types.StorageLocation(value), nil
+		}
+	}
+	return types.Unknown, fmt.Errorf("unknown storage location %q", name)
+}
+
+// CacheExportCmd represents the cache export command.
+var CacheExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export cached objects to an archive",
+	Long: `Export cached objects to a gzip-compressed tar archive, which can be
+imported into another cache server. If no file is given, or the file is "-",
+the archive is written to stdout.`,
+	Args:    cobra.MaximumNArgs(1),
+	PreRun:  InitCLIQuiet,
+	Example: "kubecc cache export --toolchain gnu --max-age 24h cache.tar.gz",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var out io.Writer = os.Stdout
+		if len(args) == 1 && args[0] != "-" {
+			f, err := os.Create(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+		}
+		return clients.ExportArchive(CLIContext, cacheClient(), cacheFilter(), out)
+	},
+}
+
+// CacheImportCmd represents the cache import command.
+var CacheImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import cached objects from an archive",
+	Long: `Import cached objects from an archive created by "kubecc cache export".
+If no file is given, or the file is "-", the archive is read from stdin.`,
+	Args:    cobra.MaximumNArgs(1),
+	PreRun:  InitCLIQuiet,
+	Example: "kubecc cache import --location disk cache.tar.gz",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		loc, err := parseStorageLocation(importLocation)
+		if err != nil {
+			return err
+		}
+		var in io.Reader = os.Stdin
+		if len(args) == 1 && args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			in = f
+		}
+		resp, err := clients.ImportArchive(CLIContext, cacheClient(), loc, in)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Imported %d objects (%d already existed, %d invalid)\n",
+			resp.Imported, resp.Skipped, resp.Invalid)
+		return nil
+	},
+}
+
+func formatSize(size int64) string {
+	return resource.NewQuantity(size, resource.BinarySI).String()
+}
+
+func locationName(loc types.StorageLocation) string {
+	return strings.ToLower(strings.TrimPrefix(loc.String(), "StorageLocation_"))
+}
+
+// CacheStatsCmd represents the cache stats command.
+var CacheStatsCmd = &cobra.Command{
+	Use:     "stats",
+	Short:   "Show cache usage statistics",
+	Long:    `Show usage and hit statistics for each storage tier, and the number and size of cached objects produced by each toolchain.`,
+	Args:    cobra.NoArgs,
+	PreRun:  InitCLIQuiet,
+	Example: "kubecc cache stats",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stats, err := cacheClient().Stats(CLIContext, &types.Empty{})
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "TIER\tOBJECTS\tSIZE\tUSAGE\tHITS\tMISSES\tCORRUPT")
+		for _, tier := range stats.Tiers {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%.1f%%\t%d\t%d\t%d\n",
+				locationName(tier.Location),
+				tier.ObjectCount,
+				formatSize(tier.TotalSize),
+				tier.UsagePercent,
+				tier.CacheHitsTotal,
+				tier.CacheMissesTotal,
+				tier.CorruptObjectsTotal,
+			)
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "TOOLCHAIN\tOBJECTS\tSIZE")
+		for _, tc := range stats.Toolchains {
+			fmt.Fprintf(w, "%s\t%d\t%s\n",
+				tc.Toolchain, tc.ObjectCount, formatSize(tc.TotalSize))
+		}
+		return w.Flush()
+	},
+}
+
+// CacheInspectCmd represents the cache inspect command.
+var CacheInspectCmd = &cobra.Command{
+	Use:     "inspect hash",
+	Short:   "Show the metadata of a cached object",
+	Long:    `Show the metadata, tags, and expiration of a cached object in each storage tier which contains it.`,
+	Args:    cobra.ExactArgs(1),
+	PreRun:  InitCLIQuiet,
+	Example: "kubecc cache inspect 8a6f2b6c3a8e1d2f0c5b9a7d4e3f2a1b",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := cacheClient().Inspect(CLIContext, &types.CacheKey{
+			Hash: args[0],
+		})
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		for i, md := range resp.Tiers {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			mf := md.GetManagedFields()
+			fmt.Fprintf(w, "Location:\t%s\n", locationName(mf.GetLocation()))
+			fmt.Fprintf(w, "Size:\t%s\n", formatSize(mf.GetSize()))
+			fmt.Fprintf(w, "Stored:\t%s\n",
+				time.Unix(mf.GetTimestamp(), 0).Format(time.RFC3339))
+			fmt.Fprintf(w, "Hits:\t%d\n", mf.GetHits())
+			fmt.Fprintf(w, "Score:\t%d\n", mf.GetScore())
+			if digest := mf.GetDigest(); digest != "" {
+				fmt.Fprintf(w, "Digest:\t%s\n", digest)
+			}
+			if exp := md.GetExpirationDate(); exp > 0 {
+				fmt.Fprintf(w, "Expires:\t%s\n",
+					time.Unix(0, exp).Format(time.RFC3339))
+			} else {
+				fmt.Fprintln(w, "Expires:\tnever")
+			}
+			if len(md.GetTags()) > 0 {
+				fmt.Fprintln(w, "Tags:")
+				keys := make([]string, 0, len(md.GetTags()))
+				for k := range md.GetTags() {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+				for _, k := range keys {
+					fmt.Fprintf(w, "  %s\t%s\n", k, md.GetTags()[k])
+				}
+			}
+		}
+		return w.Flush()
+	},
+}
+
+// CachePurgeCmd represents the cache purge command.
+var CachePurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Delete cached objects",
+	Long: `Delete cached objects matching the given filters from all storage tiers.
+To delete every object in the cache, use --all.`,
+	Args:    cobra.NoArgs,
+	PreRun:  InitCLIQuiet,
+	Example: "kubecc cache purge --toolchain clang --min-age 168h",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter := cacheFilter()
+		if !purgeAll && len(filter.Toolchains) == 0 && len(filter.Tags) == 0 &&
+			filter.MinAge == 0 && filter.MaxAge == 0 {
+			return errors.New("no filters given (use --all to purge all objects)")
+		}
+		resp, err := cacheClient().Purge(CLIContext, &types.PurgeRequest{
+			Filter: filter,
+			DryRun: purgeDryRun,
+		})
+		if err != nil {
+			return err
+		}
+		verb := "Purged"
+		if purgeDryRun {
+			verb = "Would purge"
+		}
+		fmt.Printf("%s %d objects (%s)\n",
+			verb, resp.ObjectCount, formatSize(resp.TotalSize))
+		return nil
+	},
+}
+
+var errRequestCaptured = errors.New("request captured")
+
+// captureClient is a scheduler client which records the compile request
+// it is given instead of sending it to the scheduler.
+type captureClient struct {
+	request *types.CompileRequest
+}
+
+func (c *captureClient) LoadNewStream(types.Scheduler_StreamOutgoingTasksClient) {}
+
+func (c *captureClient) Compile(
+	req *types.CompileRequest,
+) (*types.CompileResponse, error) {
+	c.request = req
+	return nil, errRequestCaptured
+}
+
+// compileRequestKey preprocesses the given compile command the same way the
+// consumer daemon would, and returns the cache key the scheduler would use
+// for the resulting compile request.
+func compileRequestKey(compiler string, args []string) (*types.CacheKey, error) {
+	path, err := exec.LookPath(compiler)
+	if err != nil {
+		return nil, err
+	}
+	tc, err := toolchains.NewStore().Add(path, toolchains.ExecQuerier{})
+	if err != nil {
+		return nil, err
+	}
+	runners := run.NewToolchainRunnerStore()
+	ccctrl.AddToStore(runners)
+	runner, err := runners.Get(tc.Kind)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported toolchain %s", storage.ToolchainName(tc.Kind))
+	}
+	ap := runner.NewArgParser(CLIContext, args)
+	ap.Parse()
+	if !ap.CanRunRemote() {
+		return nil, errors.New("this command would not be run remotely, and cannot be cached")
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	client := &captureClient{}
+	resp, err := runner.SendRemote(ap, client).Process(run.PairContext{
+		ServerContext: CLIContext,
+		ClientContext: CLIContext,
+	}, &types.RunRequest{
+		Compiler: &types.RunRequest_Toolchain{
+			Toolchain: tc,
+		},
+		Args:    args,
+		Env:     os.Environ(),
+		UID:     uint32(os.Getuid()),
+		GID:     uint32(os.Getgid()),
+		WorkDir: wd,
+	})
+	if client.request == nil {
+		if err != nil {
+			return nil, err
+		}
+		if rr, ok := resp.(*types.RunResponse); ok && len(rr.Stderr) > 0 {
+			return nil, errors.New(strings.TrimSpace(string(rr.Stderr)))
+		}
+		return nil, errors.New("the preprocessor failed")
+	}
+	return &types.CacheKey{
+		Hash: util.NewHashServer().Hash(client.request),
+	}, nil
+}
+
+// CacheQueryCmd represents the cache query command.
+var CacheQueryCmd = &cobra.Command{
+	Use:   "query -- compiler [args...]",
+	Short: "Check whether a compile command would hit the cache",
+	Long: `Preprocess a compile command and check whether its result is already
+cached. The command is not compiled, and no output files are written.`,
+	Args:    cobra.MinimumNArgs(1),
+	PreRun:  InitCLIQuiet,
+	Example: "kubecc cache query -- gcc -c -o main.o main.c",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, err := compileRequestKey(args[0], args[1:])
+		if err != nil {
+			return err
+		}
+		results, err := cacheClient().Query(CLIContext, &types.QueryRequest{
+			Keys: []*types.CacheKey{key},
+		})
+		if err != nil {
+			return err
+		}
+		if len(results.GetResults()) == 0 ||
+			results.GetResults()[0].GetManagedFields() == nil {
+			fmt.Printf("miss %s\n", key.Hash)
+			return nil
+		}
+		fmt.Printf("hit  %s (%s)\n", key.Hash,
+			locationName(results.GetResults()[0].GetManagedFields().GetLocation()))
+		return nil
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{
+		CacheExportCmd,
+		CacheImportCmd,
+		CacheStatsCmd,
+		CacheInspectCmd,
+		CachePurgeCmd,
+		CacheQueryCmd,
+	} {
+		cmd.Flags().StringVar(&cacheAddress, "address", "",
+			"Cache server address (defaults to the configured cache address)")
+	}
+	addFilterFlags(CacheExportCmd)
+	addFilterFlags(CachePurgeCmd)
+	CachePurgeCmd.Flags().BoolVar(&purgeDryRun, "dry-run", false,
+		"Show how many objects would be purged without deleting them")
+	CachePurgeCmd.Flags().BoolVar(&purgeAll, "all", false,
+		"Purge all objects if no filters are given")
+	CacheImportCmd.Flags().StringVar(&importLocation, "location", "",
+		"Only import into the storage provider with this location (memory, disk, redis, or s3)")
+}
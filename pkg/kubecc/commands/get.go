@@ -20,7 +20,10 @@ package commands
 import (
 	"context"
 	"fmt"
+	"os"
 	"reflect"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/kubecc-io/kubecc/pkg/clients"
@@ -28,7 +31,9 @@ import (
 	"github.com/kubecc-io/kubecc/pkg/metrics"
 	"github.com/kubecc-io/kubecc/pkg/servers"
 	"github.com/kubecc-io/kubecc/pkg/types"
+	"github.com/kubecc-io/kubecc/pkg/ui"
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
@@ -44,7 +49,11 @@ func monitorClient() types.MonitorClient {
 }
 
 func schedulerClient() types.SchedulerClient {
-	cc, err := servers.Dial(CLIContext, CLIConfig.SchedulerAddress,
+	address := CLIConfig.SchedulerAddress
+	if schedulerAddress != "" {
+		address = schedulerAddress
+	}
+	cc, err := servers.Dial(CLIContext, address,
 		servers.WithTLS(!CLIConfig.DisableTLS))
 	if err != nil {
 		CLILog.Fatal(err)
@@ -71,9 +80,13 @@ func init() {
 	GetCmd.AddCommand(getMetrics)
 	GetCmd.AddCommand(getRoutes)
 	GetCmd.AddCommand(getHealth)
+	GetCmd.AddCommand(getTasks)
 
 	GetCmd.PersistentFlags().StringVarP(&outputKind, "output", "o", "text",
 		"Output format. One of [text, json, jsonfmt]")
+
+	getTasks.Flags().BoolVarP(&watchTasks, "watch", "w", false,
+		"Continuously display the task list")
 }
 
 func getProviders() (*metrics.Providers, error) {
@@ -360,3 +373,43 @@ var getHealth = &cobra.Command{
 		}
 	},
 }
+
+var watchTasks bool
+var getTasks = &cobra.Command{
+	Use:     "tasks",
+	Aliases: []string{"task"},
+	Long: `Print the tasks the scheduler is waiting to send to an agent, or is waiting
+on a response for. With the text output format, tasks are printed as a table.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := schedulerClient()
+		src := ui.NewTasksDataSource(CLIContext, c)
+		if watchTasks {
+			*CLILog = *zap.NewNop().Sugar()
+			ui.NewTableDisplay(src).Run()
+			return
+		}
+		ctx, ca := context.WithTimeout(CLIContext, time.Second*5)
+		defer ca()
+		stream, err := c.ListTasks(ctx, &types.ListTasksRequest{})
+		if err != nil {
+			CLILog.Error(err)
+			return
+		}
+		tasks, err := stream.Recv()
+		if err != nil {
+			CLILog.Error(err)
+			return
+		}
+		if outputKind != "text" {
+			formatOutput([]proto.Message{tasks})
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, strings.ToUpper(strings.Join(src.Headers(), "\t")))
+		for _, row := range ui.TaskRows(tasks) {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		w.Flush()
+	},
+}
@@ -18,6 +18,9 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package components
 
 import (
+	"context"
+	"time"
+
 	"github.com/kubecc-io/kubecc/internal/logkc"
 	"github.com/kubecc-io/kubecc/pkg/agent"
 	"github.com/kubecc-io/kubecc/pkg/cc"
@@ -38,9 +41,14 @@ import (
 	_ "google.golang.org/grpc/encoding/gzip"
 )
 
+// agentDrainTimeout is how long the agent waits for its running tasks to
+// complete after receiving SIGTERM. This is less than the default pod
+// termination grace period of 30 seconds.
+const agentDrainTimeout = 25 * time.Second
+
 func runAgent(cmd *cobra.Command, args []string) {
 	conf := config.ConfigMapProvider.Load().Agent
-	ctx := meta.NewContext(
+	ctx, cancel := context.WithCancel(meta.NewContext(
 		meta.WithProvider(identity.Component, meta.WithValue(types.Agent)),
 		meta.WithProvider(identity.UUID),
 		meta.WithProvider(logkc.Logger, meta.WithValue(
@@ -51,25 +59,22 @@ func runAgent(cmd *cobra.Command, args []string) {
 		)),
 		meta.WithProvider(tracing.Tracer),
 		meta.WithProvider(host.SystemInfo),
-	)
+	))
+	defer cancel()
 	lg := meta.Log(ctx)
 
 	host.RunPreflightChecks(lg)
 
-	schedulerCC, err := servers.Dial(ctx, conf.SchedulerAddress)
-	lg.With("address", schedulerCC.Target()).Info("Dialing scheduler")
-	if err != nil {
-		lg.With(zap.Error(err)).Fatal("Error dialing scheduler")
-	}
-
 	monitorCC, err := servers.Dial(ctx, conf.MonitorAddress)
 	lg.With("address", monitorCC.Target()).Info("Dialing monitor")
 	if err != nil {
 		lg.With(zap.Error(err)).Fatal("Error dialing monitor")
 	}
+	monitorClient := types.NewMonitorClient(monitorCC)
 
+	schedulerCC := dialScheduler(ctx, conf.SchedulerAddress,
+		conf.SchedulerFailover, monitorClient, false)
 	schedulerClient := types.NewSchedulerClient(schedulerCC)
-	monitorClient := types.NewMonitorClient(monitorCC)
 
 	a := agent.NewAgentServer(ctx,
 		agent.WithUsageLimits(&metrics.UsageLimits{
@@ -88,6 +93,18 @@ func runAgent(cmd *cobra.Command, args []string) {
 		agent.WithMonitorClient(monitorClient),
 	)
 	go a.StartMetricsProvider()
+
+	// Stop receiving new tasks and let running tasks complete before the
+	// task stream is closed
+	onShutdown(func() {
+		lg.Info("Shutting down, draining agent")
+		drainCtx, drainCancel := context.WithTimeout(ctx, agentDrainTimeout)
+		defer drainCancel()
+		if err := a.Drain(drainCtx); err != nil {
+			lg.With(zap.Error(err)).Warn("Could not drain agent")
+		}
+		cancel()
+	})
 	<-ctx.Done()
 }
 
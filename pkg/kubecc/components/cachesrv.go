@@ -51,7 +51,8 @@ func runCache(cmd *cobra.Command, args []string) {
 	)
 	lg := meta.Log(ctx)
 
-	srv := servers.NewServer(ctx)
+	srv := servers.NewServer(ctx,
+		servers.WithExternalServices(cachesrv.REAPIServices...))
 	listener, err := net.Listen("tcp", conf.ListenAddress)
 	if err != nil {
 		lg.With(zap.Error(err)).Fatalw("Error listening on socket")
@@ -64,30 +65,62 @@ func runCache(cmd *cobra.Command, args []string) {
 		lg.With(zap.Error(err)).Fatal("Error dialing monitor")
 	}
 
+	retention, err := storage.NewRetentionPolicy(conf.Retention)
+	if err != nil {
+		lg.With(zap.Error(err)).Fatal("Invalid retention policy")
+	}
+	if conf.Retention.DefaultTTL == "" {
+		retention.DefaultTTL = storage.DefaultTTL
+	}
+
 	providers := []storage.StorageProvider{}
 	// order is important here, this is the priority order for the chain
 	// storage provider
 	if conf.VolatileStorage != nil {
 		providers = append(providers,
-			storage.NewVolatileStorageProvider(ctx, *conf.VolatileStorage))
+			storage.NewVolatileStorageProvider(ctx, *conf.VolatileStorage,
+				storage.WithRetentionPolicy(retention)))
 	}
 	if conf.LocalStorage != nil {
 		providers = append(providers,
-			storage.NewLocalStorageProvider(ctx, *conf.LocalStorage))
+			storage.NewLocalStorageProvider(ctx, *conf.LocalStorage,
+				storage.WithRetentionPolicy(retention)))
+	}
+	if conf.RedisStorage != nil {
+		providers = append(providers,
+			storage.NewRedisStorageProvider(ctx, *conf.RedisStorage,
+				storage.WithRetentionPolicy(retention)))
 	}
 	if conf.RemoteStorage != nil {
 		providers = append(providers,
-			storage.NewS3StorageProvider(ctx, *conf.RemoteStorage))
+			storage.NewS3StorageProvider(ctx, *conf.RemoteStorage,
+				storage.WithRetentionPolicy(retention)))
+	}
+	chainOptions, err := storage.ChainOptionsFromSpec(conf.StorageChain)
+	if err != nil {
+		lg.With(zap.Error(err)).Fatal("Invalid storage chain configuration")
 	}
+	chainOptions = append(chainOptions, storage.WithChainRetention(retention))
 	cacheSrv := cachesrv.NewCacheServer(ctx, conf,
 		cachesrv.WithStorageProvider(
-			storage.NewChainStorageProvider(ctx, providers...),
+			storage.NewChainStorageProvider(ctx, providers, chainOptions...),
 		),
 		cachesrv.WithMonitorClient(types.NewMonitorClient(monitorCC)),
 	)
 	types.RegisterCacheServer(srv, cacheSrv)
+	if reapi := cacheSrv.REAPI(); reapi != nil {
+		lg.Info("Serving Bazel Remote Execution API")
+		cachesrv.RegisterREAPIServer(srv, reapi)
+	}
 
 	go cacheSrv.StartMetricsProvider()
+	if httpSrv := cacheSrv.HTTP(); httpSrv != nil {
+		go func() {
+			if err := httpSrv.ListenAndServe(); err != nil {
+				lg.With(zap.Error(err)).Fatal("Error serving HTTP cache endpoint")
+			}
+		}()
+	}
 
 	err = srv.Serve(listener)
 	if err != nil {
@@ -19,6 +19,7 @@ package components
 
 import (
 	"net"
+	"time"
 
 	"github.com/kubecc-io/kubecc/internal/logkc"
 	"github.com/kubecc-io/kubecc/pkg/cc"
@@ -33,13 +34,19 @@ import (
 	"github.com/kubecc-io/kubecc/pkg/servers"
 	"github.com/kubecc-io/kubecc/pkg/sleep"
 	sleepctrl "github.com/kubecc-io/kubecc/pkg/sleep/controller"
+	"github.com/kubecc-io/kubecc/pkg/storage"
 	"github.com/kubecc-io/kubecc/pkg/toolchains"
 	"github.com/kubecc-io/kubecc/pkg/tracing"
 	"github.com/kubecc-io/kubecc/pkg/types"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+// defaultRequeueTimeout is how long in-flight requests wait for a new
+// scheduler leader if scheduler failover is enabled.
+const defaultRequeueTimeout = 10 * time.Second
+
 func runConsumerd(cmd *cobra.Command, args []string) {
 	conf := CLIConfigProvider.Load().Consumerd
 
@@ -59,23 +66,17 @@ func runConsumerd(cmd *cobra.Command, args []string) {
 
 	host.RunPreflightChecks(lg)
 
-	schedulerCC, err := servers.Dial(ctx, conf.SchedulerAddress,
-		servers.WithTLS(!conf.DisableTLS),
-	)
-	lg.With("address", schedulerCC.Target()).Info("Dialing scheduler")
-	if err != nil {
-		lg.With(zap.Error(err)).Fatal("Error dialing scheduler")
-	}
-
 	monitorCC, err := servers.Dial(ctx, conf.MonitorAddress,
 		servers.WithTLS(!conf.DisableTLS))
 	lg.With("address", monitorCC.Target()).Info("Dialing monitor")
 	if err != nil {
 		lg.With(zap.Error(err)).Fatal("Error dialing monitor")
 	}
+	monitorClient := types.NewMonitorClient(monitorCC)
 
+	schedulerCC := dialScheduler(ctx, conf.SchedulerAddress,
+		conf.SchedulerFailover, monitorClient, !conf.DisableTLS)
 	schedulerClient := types.NewSchedulerClient(schedulerCC)
-	monitorClient := types.NewMonitorClient(monitorCC)
 	srv := servers.NewServer(ctx)
 
 	var localUsageMgr run.ResizerManager
@@ -85,7 +86,7 @@ func runConsumerd(cmd *cobra.Command, args []string) {
 		localUsageMgr = consumerd.FixedUsageLimits(
 			int64(conf.UsageLimits.GetConcurrentProcessLimit()))
 	}
-	d := consumerd.NewConsumerdServer(ctx,
+	options := []consumerd.ConsumerdServerOption{
 		consumerd.WithQueueOptions(
 			consumerd.WithLocalUsageManager(localUsageMgr),
 			consumerd.WithRemoteUsageManager(
@@ -102,7 +103,67 @@ func runConsumerd(cmd *cobra.Command, args []string) {
 		consumerd.WithToolchainRunners(ccctrl.AddToStore, sleepctrl.AddToStore),
 		consumerd.WithSchedulerClient(schedulerClient),
 		consumerd.WithMonitorClient(monitorClient),
-	)
+	}
+	if conf.SchedulerFailover != nil {
+		timeout := defaultRequeueTimeout
+		if conf.SchedulerFailover.RequeueTimeout != "" {
+			timeout, err = time.ParseDuration(conf.SchedulerFailover.RequeueTimeout)
+			if err != nil {
+				lg.With(zap.Error(err)).Fatal("Invalid scheduler requeue timeout")
+			}
+		}
+		options = append(options, consumerd.WithRequestClientOptions(
+			clients.WithRequeueTimeout(timeout),
+		))
+	}
+	// order is important here, this is the priority order for the chain
+	// storage provider. The sync size is determined by the slowest (and
+	// presumably largest) provider.
+	cacheProviders := []storage.StorageProvider{}
+	var cacheLimit resource.Quantity
+	retention, err := storage.NewRetentionPolicy(conf.CacheRetention)
+	if err != nil {
+		lg.With(zap.Error(err)).Fatal("Invalid cache retention policy")
+	}
+	if conf.CacheRetention.DefaultTTL == "" {
+		retention.DefaultTTL = storage.DefaultTTL
+	}
+	if conf.VolatileCache != nil {
+		cacheLimit, err = resource.ParseQuantity(conf.VolatileCache.Limits.Memory)
+		if err != nil {
+			lg.With(zap.Error(err)).Fatal("Invalid volatile cache size limit")
+		}
+		cacheProviders = append(cacheProviders,
+			storage.NewVolatileStorageProvider(ctx, *conf.VolatileCache,
+				storage.WithRetentionPolicy(retention)))
+	}
+	if conf.LocalCache != nil {
+		cacheLimit, err = resource.ParseQuantity(conf.LocalCache.Limits.Disk)
+		if err != nil {
+			lg.With(zap.Error(err)).Fatal("Invalid local cache size limit")
+		}
+		cacheProviders = append(cacheProviders,
+			storage.NewLocalStorageProvider(ctx, *conf.LocalCache,
+				storage.WithRetentionPolicy(retention)))
+	}
+	if len(cacheProviders) > 0 {
+		options = append(options, consumerd.WithLocalCache(
+			storage.NewChainStorageProvider(ctx, cacheProviders),
+			cacheLimit.Value()/1024,
+		))
+		if conf.CacheAddress != "" {
+			cacheCC, err := servers.Dial(ctx, conf.CacheAddress,
+				servers.WithTLS(!conf.DisableTLS))
+			lg.With("address", cacheCC.Target()).Info("Dialing cache server")
+			if err != nil {
+				lg.With(zap.Error(err)).Fatal("Error dialing cache server")
+			}
+			options = append(options,
+				consumerd.WithCacheClient(types.NewCacheClient(cacheCC)))
+		}
+	}
+
+	d := consumerd.NewConsumerdServer(ctx, options...)
 	types.RegisterConsumerdServer(srv, d)
 	go d.StartMetricsProvider()
 
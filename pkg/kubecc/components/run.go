@@ -97,6 +97,7 @@ run all               # runs monitor, scheduler, agent, and consumerd`,
 			go cmd.Run(cmd, []string{})
 		}
 		<-ctrl.SetupSignalHandler().Done()
+		runShutdownHooks()
 		return nil
 	},
 }
@@ -18,9 +18,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package components
 
 import (
+	"context"
 	"net"
+	"time"
 
 	"github.com/kubecc-io/kubecc/internal/logkc"
+	"github.com/kubecc-io/kubecc/pkg/clients"
 	"github.com/kubecc-io/kubecc/pkg/config"
 	"github.com/kubecc-io/kubecc/pkg/identity"
 	"github.com/kubecc-io/kubecc/pkg/meta"
@@ -30,6 +33,7 @@ import (
 	"github.com/kubecc-io/kubecc/pkg/types"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	_ "google.golang.org/grpc/encoding/gzip"
 )
 
@@ -63,19 +67,52 @@ func runScheduler(cmd *cobra.Command, args []string) {
 	}
 	lg.With("address", monitorCC.Target()).Info("Dialing monitor")
 
-	cacheCC, err := servers.Dial(ctx, conf.CacheAddress)
+	monitorClient := types.NewMonitorClient(monitorCC)
+	var cacheClient types.CacheClient
+	if conf.CacheCluster != nil {
+		cacheClient = newCacheCluster(ctx, conf, monitorClient)
+	} else {
+		cacheCC, err := servers.Dial(ctx, conf.CacheAddress)
+		if err != nil {
+			lg.With(zap.Error(err)).Fatal("Error dialing cache server")
+		}
+		lg.With("address", cacheCC.Target()).Info("Dialing cache server")
+		cacheClient = types.NewCacheClient(cacheCC)
+	}
+
+	fairShare, err := scheduler.NewFairSharePolicy(conf.Scheduling)
 	if err != nil {
-		lg.With(zap.Error(err)).Fatal("Error dialing cache server")
+		lg.With(zap.Error(err)).Fatal("Invalid scheduling configuration")
 	}
-	lg.With("address", cacheCC.Target()).Info("Dialing cache server")
 
-	monitorClient := types.NewMonitorClient(monitorCC)
-	cacheClient := types.NewCacheClient(cacheCC)
+	locality, err := scheduler.NewLocalityPolicy(conf.Scheduling.Locality)
+	if err != nil {
+		lg.With(zap.Error(err)).Fatal("Invalid scheduling configuration")
+	}
 
-	sc := scheduler.NewSchedulerServer(ctx,
+	options := []scheduler.SchedulerServerOption{
 		scheduler.WithMonitorClient(monitorClient),
 		scheduler.WithCacheClient(cacheClient),
-	)
+		scheduler.WithBrokerOptions(
+			scheduler.FairShare(fairShare),
+			scheduler.Locality(locality),
+		),
+	}
+	if conf.ThrottlingTarget > 0 {
+		options = append(options, scheduler.WithOptimizerOptions(
+			scheduler.WithThrottlingTarget(conf.ThrottlingTarget),
+		))
+	}
+
+	if conf.LeaderElection != nil {
+		elector, err := scheduler.NewLeaseElector(*conf.LeaderElection)
+		if err != nil {
+			lg.With(zap.Error(err)).Fatal("Invalid leader election configuration")
+		}
+		options = append(options, scheduler.WithElector(elector))
+	}
+
+	sc := scheduler.NewSchedulerServer(ctx, options...)
 	types.RegisterSchedulerServer(srv, sc)
 	go sc.StartMetricsProvider()
 
@@ -85,6 +122,86 @@ func runScheduler(cmd *cobra.Command, args []string) {
 	}
 }
 
+// cacheRebalanceDelay is how long cache cluster membership must be stable
+// before objects are rebalanced.
+const cacheRebalanceDelay = 10 * time.Second
+
+func newCacheCluster(
+	ctx context.Context,
+	conf config.SchedulerSpec,
+	monitorClient types.MonitorClient,
+) *clients.CacheCluster {
+	lg := meta.Log(ctx)
+	port := conf.CacheCluster.Port
+	if port == "" {
+		_, p, err := net.SplitHostPort(conf.CacheAddress)
+		if err != nil {
+			lg.With(zap.Error(err)).Fatal("Could not determine cache server port")
+		}
+		port = p
+	}
+	opts := []clients.CacheClusterOption{
+		clients.WithReplicationFactor(conf.CacheCluster.ReplicationFactor),
+	}
+	if conf.CacheCluster.Rebalance {
+		opts = append(opts,
+			clients.WithRebalancing(cacheRebalanceDelay),
+			clients.WithRebalanceLimits(
+				conf.CacheCluster.RebalanceRate,
+				conf.CacheCluster.MaxRebalanceObjects,
+			),
+		)
+	}
+	lg.With(
+		"replicationFactor", conf.CacheCluster.ReplicationFactor,
+		"rebalance", conf.CacheCluster.Rebalance,
+	).Info("Using cache cluster")
+	return clients.NewCacheCluster(ctx, monitorClient,
+		func(ctx context.Context, info *types.WhoisResponse) (*grpc.ClientConn, error) {
+			return servers.Dial(ctx, net.JoinHostPort(info.Address, port))
+		}, opts...)
+}
+
+// dialScheduler dials the scheduler at the given address or, if failover is
+// configured, whichever scheduler replica the monitor reports as the leader.
+func dialScheduler(
+	ctx context.Context,
+	address string,
+	failover *config.SchedulerFailoverSpec,
+	monitorClient types.MonitorClient,
+	tls bool,
+) *grpc.ClientConn {
+	lg := meta.Log(ctx)
+	var dialOpts []grpc.DialOption
+	if failover != nil {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			lg.With(zap.Error(err)).Fatal("Invalid scheduler address")
+		}
+		if failover.Port != "" {
+			port = failover.Port
+		}
+		if tls {
+			// Verify certificates against the configured address rather than
+			// the resolver target
+			dialOpts = append(dialOpts, grpc.WithAuthority(host))
+		}
+		r := clients.NewLeaderResolver(ctx, monitorClient, types.Scheduler,
+			port, address)
+		address = r.Target()
+		dialOpts = append(dialOpts, grpc.WithResolvers(r))
+	}
+	cc, err := servers.Dial(ctx, address,
+		servers.WithTLS(tls),
+		servers.WithDialOpts(dialOpts...),
+	)
+	if err != nil {
+		lg.With(zap.Error(err)).Fatal("Error dialing scheduler")
+	}
+	lg.With("address", cc.Target()).Info("Dialing scheduler")
+	return cc
+}
+
 var SchedulerCmd = &cobra.Command{
 	Use:   "scheduler",
 	Short: "Run the scheduler server",
@@ -0,0 +1,52 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package components
+
+import "sync"
+
+var (
+	shutdownHooksMu sync.Mutex
+	shutdownHooks   []func()
+)
+
+// onShutdown registers a function which will be called when "kubecc run"
+// receives a termination signal. The process exits once all registered
+// functions have returned.
+func onShutdown(hook func()) {
+	shutdownHooksMu.Lock()
+	defer shutdownHooksMu.Unlock()
+	shutdownHooks = append(shutdownHooks, hook)
+}
+
+// runShutdownHooks calls all registered shutdown hooks concurrently and
+// waits for them to return.
+func runShutdownHooks() {
+	shutdownHooksMu.Lock()
+	hooks := shutdownHooks
+	shutdownHooks = nil
+	shutdownHooksMu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, hook := range hooks {
+		wg.Add(1)
+		go func(hook func()) {
+			defer wg.Done()
+			hook()
+		}(hook)
+	}
+	wg.Wait()
+}
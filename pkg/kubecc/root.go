@@ -80,6 +80,19 @@ func CreateRootCmd() *cobra.Command {
 			},
 		},
 	}
+	components.CacheCmd.AddCommand(
+		commands.CacheExportCmd,
+		commands.CacheImportCmd,
+		commands.CacheStatsCmd,
+		commands.CacheInspectCmd,
+		commands.CachePurgeCmd,
+		commands.CacheQueryCmd,
+	)
+	components.AgentCmd.AddCommand(
+		commands.AgentDrainCmd,
+		commands.AgentCordonCmd,
+		commands.AgentUncordonCmd,
+	)
 	groups.Add(rootCmd)
 	rootCmd.AddCommand(commands.CompletionCmd)
 	fe := templates.ActsAsRootCommand(rootCmd, nil, groups...)
@@ -20,7 +20,9 @@ package meta
 import (
 	"context"
 	"fmt"
+	"path"
 	"reflect"
+	"strings"
 
 	"github.com/kubecc-io/kubecc/pkg/meta/mdkeys"
 	"google.golang.org/grpc/codes"
@@ -61,6 +63,22 @@ type ImportOptions struct {
 	Required []Provider
 	Optional []Provider
 	Inherit  *InheritOptions
+	// ExternalServices contains the full names of services (such as
+	// "google.bytestream.ByteStream") which may be called by clients that are
+	// not kubecc components. Metadata is not imported for these services.
+	ExternalServices []string
+}
+
+// isExternal returns true if the given full method name (in the form
+// "/package.Service/Method") belongs to one of the external services.
+func (o ImportOptions) isExternal(fullMethod string) bool {
+	service := strings.TrimPrefix(path.Dir(fullMethod), "/")
+	for _, s := range o.ExternalServices {
+		if s == service {
+			return true
+		}
+	}
+	return false
 }
 
 func ImportFromIncoming(ctx context.Context, opts ImportOptions) (context.Context, error) {
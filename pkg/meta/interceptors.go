@@ -43,9 +43,12 @@ func ServerContextInterceptor(
 	return func(
 		ctx context.Context,
 		req interface{},
-		_ *grpc.UnaryServerInfo,
+		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (resp interface{}, err error) {
+		if options.isExternal(info.FullMethod) {
+			return handler(ctx, req)
+		}
 		ctx, err = ImportFromIncoming(ctx, options)
 		if err != nil {
 			return nil, err
@@ -86,6 +89,9 @@ func StreamServerContextInterceptor(
 		info *grpc.StreamServerInfo,
 		handler grpc.StreamHandler,
 	) error {
+		if options.isExternal(info.FullMethod) {
+			return handler(srv, ss)
+		}
 		ctx, err := ImportFromIncoming(ss.Context(), options)
 		if err != nil {
 			return err
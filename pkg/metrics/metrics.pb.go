@@ -740,6 +740,53 @@ func (x *SchedulingRequestsTotal) GetTotal() int64 {
 	return 0
 }
 
+type CoalescedRequestsTotal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total int64 `protobuf:"varint,1,opt,name=Total,proto3" json:"Total,omitempty"`
+}
+
+func (x *CoalescedRequestsTotal) Reset() {
+	*x = CoalescedRequestsTotal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CoalescedRequestsTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoalescedRequestsTotal) ProtoMessage() {}
+
+func (x *CoalescedRequestsTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoalescedRequestsTotal.ProtoReflect.Descriptor instead.
+func (*CoalescedRequestsTotal) Descriptor() ([]byte, []int) {
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CoalescedRequestsTotal) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
 type AgentCount struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -751,7 +798,7 @@ type AgentCount struct {
 func (x *AgentCount) Reset() {
 	*x = AgentCount{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[11]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -764,7 +811,7 @@ func (x *AgentCount) String() string {
 func (*AgentCount) ProtoMessage() {}
 
 func (x *AgentCount) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[11]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -777,7 +824,7 @@ func (x *AgentCount) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentCount.ProtoReflect.Descriptor instead.
 func (*AgentCount) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{11}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *AgentCount) GetCount() int64 {
@@ -798,7 +845,7 @@ type ConsumerdCount struct {
 func (x *ConsumerdCount) Reset() {
 	*x = ConsumerdCount{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[12]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -811,7 +858,7 @@ func (x *ConsumerdCount) String() string {
 func (*ConsumerdCount) ProtoMessage() {}
 
 func (x *ConsumerdCount) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[12]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -824,7 +871,7 @@ func (x *ConsumerdCount) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConsumerdCount.ProtoReflect.Descriptor instead.
 func (*ConsumerdCount) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{12}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ConsumerdCount) GetCount() int64 {
@@ -845,7 +892,7 @@ type Identifier struct {
 func (x *Identifier) Reset() {
 	*x = Identifier{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[13]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -858,7 +905,7 @@ func (x *Identifier) String() string {
 func (*Identifier) ProtoMessage() {}
 
 func (x *Identifier) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[13]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -871,7 +918,7 @@ func (x *Identifier) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Identifier.ProtoReflect.Descriptor instead.
 func (*Identifier) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{13}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Identifier) GetUUID() string {
@@ -893,7 +940,7 @@ type AgentTasksTotal struct {
 func (x *AgentTasksTotal) Reset() {
 	*x = AgentTasksTotal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[14]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -906,7 +953,7 @@ func (x *AgentTasksTotal) String() string {
 func (*AgentTasksTotal) ProtoMessage() {}
 
 func (x *AgentTasksTotal) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[14]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -919,7 +966,7 @@ func (x *AgentTasksTotal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentTasksTotal.ProtoReflect.Descriptor instead.
 func (*AgentTasksTotal) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{14}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *AgentTasksTotal) GetUUID() string {
@@ -948,7 +995,7 @@ type ConsumerdTasksTotal struct {
 func (x *ConsumerdTasksTotal) Reset() {
 	*x = ConsumerdTasksTotal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[15]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -961,7 +1008,7 @@ func (x *ConsumerdTasksTotal) String() string {
 func (*ConsumerdTasksTotal) ProtoMessage() {}
 
 func (x *ConsumerdTasksTotal) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[15]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -974,7 +1021,7 @@ func (x *ConsumerdTasksTotal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConsumerdTasksTotal.ProtoReflect.Descriptor instead.
 func (*ConsumerdTasksTotal) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{15}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ConsumerdTasksTotal) GetUUID() string {
@@ -1002,7 +1049,7 @@ type PreferredUsageLimits struct {
 func (x *PreferredUsageLimits) Reset() {
 	*x = PreferredUsageLimits{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[16]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1015,7 +1062,7 @@ func (x *PreferredUsageLimits) String() string {
 func (*PreferredUsageLimits) ProtoMessage() {}
 
 func (x *PreferredUsageLimits) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[16]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1028,7 +1075,7 @@ func (x *PreferredUsageLimits) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PreferredUsageLimits.ProtoReflect.Descriptor instead.
 func (*PreferredUsageLimits) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{16}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *PreferredUsageLimits) GetConcurrentProcessLimit() int64 {
@@ -1038,6 +1085,210 @@ func (x *PreferredUsageLimits) GetConcurrentProcessLimit() int64 {
 	return 0
 }
 
+type PriorityClassQueue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueueDepth       int64   `protobuf:"varint,1,opt,name=QueueDepth,proto3" json:"QueueDepth,omitempty"`
+	DequeuedTotal    int64   `protobuf:"varint,2,opt,name=DequeuedTotal,proto3" json:"DequeuedTotal,omitempty"`
+	WaitSecondsTotal float64 `protobuf:"fixed64,3,opt,name=WaitSecondsTotal,proto3" json:"WaitSecondsTotal,omitempty"`
+}
+
+func (x *PriorityClassQueue) Reset() {
+	*x = PriorityClassQueue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PriorityClassQueue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriorityClassQueue) ProtoMessage() {}
+
+func (x *PriorityClassQueue) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriorityClassQueue.ProtoReflect.Descriptor instead.
+func (*PriorityClassQueue) Descriptor() ([]byte, []int) {
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PriorityClassQueue) GetQueueDepth() int64 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *PriorityClassQueue) GetDequeuedTotal() int64 {
+	if x != nil {
+		return x.DequeuedTotal
+	}
+	return 0
+}
+
+func (x *PriorityClassQueue) GetWaitSecondsTotal() float64 {
+	if x != nil {
+		return x.WaitSecondsTotal
+	}
+	return 0
+}
+
+type PriorityClassQueues struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Classes map[string]*PriorityClassQueue `protobuf:"bytes,1,rep,name=Classes,proto3" json:"Classes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *PriorityClassQueues) Reset() {
+	*x = PriorityClassQueues{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PriorityClassQueues) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriorityClassQueues) ProtoMessage() {}
+
+func (x *PriorityClassQueues) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriorityClassQueues.ProtoReflect.Descriptor instead.
+func (*PriorityClassQueues) Descriptor() ([]byte, []int) {
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PriorityClassQueues) GetClasses() map[string]*PriorityClassQueue {
+	if x != nil {
+		return x.Classes
+	}
+	return nil
+}
+
+type LeaderStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Leader bool `protobuf:"varint,1,opt,name=Leader,proto3" json:"Leader,omitempty"`
+}
+
+func (x *LeaderStatus) Reset() {
+	*x = LeaderStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaderStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderStatus) ProtoMessage() {}
+
+func (x *LeaderStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderStatus.ProtoReflect.Descriptor instead.
+func (*LeaderStatus) Descriptor() ([]byte, []int) {
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *LeaderStatus) GetLeader() bool {
+	if x != nil {
+		return x.Leader
+	}
+	return false
+}
+
+type AgentUsageLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Agents map[string]*UsageLimits `protobuf:"bytes,1,rep,name=Agents,proto3" json:"Agents,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *AgentUsageLimits) Reset() {
+	*x = AgentUsageLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AgentUsageLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentUsageLimits) ProtoMessage() {}
+
+func (x *AgentUsageLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentUsageLimits.ProtoReflect.Descriptor instead.
+func (*AgentUsageLimits) Descriptor() ([]byte, []int) {
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AgentUsageLimits) GetAgents() map[string]*UsageLimits {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
 type MetricsPostedTotal struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1049,7 +1300,7 @@ type MetricsPostedTotal struct {
 func (x *MetricsPostedTotal) Reset() {
 	*x = MetricsPostedTotal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[17]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1062,7 +1313,7 @@ func (x *MetricsPostedTotal) String() string {
 func (*MetricsPostedTotal) ProtoMessage() {}
 
 func (x *MetricsPostedTotal) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[17]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1075,7 +1326,7 @@ func (x *MetricsPostedTotal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsPostedTotal.ProtoReflect.Descriptor instead.
 func (*MetricsPostedTotal) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{17}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *MetricsPostedTotal) GetTotal() int64 {
@@ -1096,7 +1347,7 @@ type ListenerCount struct {
 func (x *ListenerCount) Reset() {
 	*x = ListenerCount{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[18]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1109,7 +1360,7 @@ func (x *ListenerCount) String() string {
 func (*ListenerCount) ProtoMessage() {}
 
 func (x *ListenerCount) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[18]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1122,7 +1373,7 @@ func (x *ListenerCount) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListenerCount.ProtoReflect.Descriptor instead.
 func (*ListenerCount) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{18}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ListenerCount) GetCount() int32 {
@@ -1143,7 +1394,7 @@ type ProviderCount struct {
 func (x *ProviderCount) Reset() {
 	*x = ProviderCount{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[19]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1156,7 +1407,7 @@ func (x *ProviderCount) String() string {
 func (*ProviderCount) ProtoMessage() {}
 
 func (x *ProviderCount) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[19]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1169,7 +1420,7 @@ func (x *ProviderCount) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProviderCount.ProtoReflect.Descriptor instead.
 func (*ProviderCount) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{19}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ProviderCount) GetCount() int32 {
@@ -1187,12 +1438,13 @@ type ProviderInfo struct {
 	UUID      string          `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
 	Component types.Component `protobuf:"varint,2,opt,name=Component,proto3,enum=types.Component" json:"Component,omitempty"`
 	Address   string          `protobuf:"bytes,3,opt,name=Address,proto3" json:"Address,omitempty"`
+	Hostname  string          `protobuf:"bytes,4,opt,name=Hostname,proto3" json:"Hostname,omitempty"`
 }
 
 func (x *ProviderInfo) Reset() {
 	*x = ProviderInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[20]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1205,7 +1457,7 @@ func (x *ProviderInfo) String() string {
 func (*ProviderInfo) ProtoMessage() {}
 
 func (x *ProviderInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[20]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1218,7 +1470,7 @@ func (x *ProviderInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProviderInfo.ProtoReflect.Descriptor instead.
 func (*ProviderInfo) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{20}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ProviderInfo) GetUUID() string {
@@ -1242,6 +1494,13 @@ func (x *ProviderInfo) GetAddress() string {
 	return ""
 }
 
+func (x *ProviderInfo) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
 type Providers struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1253,7 +1512,7 @@ type Providers struct {
 func (x *Providers) Reset() {
 	*x = Providers{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[21]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1266,7 +1525,7 @@ func (x *Providers) String() string {
 func (*Providers) ProtoMessage() {}
 
 func (x *Providers) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[21]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1279,7 +1538,7 @@ func (x *Providers) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Providers.ProtoReflect.Descriptor instead.
 func (*Providers) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{21}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *Providers) GetItems() map[string]*ProviderInfo {
@@ -1301,7 +1560,7 @@ type BucketSpec struct {
 func (x *BucketSpec) Reset() {
 	*x = BucketSpec{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[22]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1314,7 +1573,7 @@ func (x *BucketSpec) String() string {
 func (*BucketSpec) ProtoMessage() {}
 
 func (x *BucketSpec) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[22]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1327,7 +1586,7 @@ func (x *BucketSpec) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketSpec.ProtoReflect.Descriptor instead.
 func (*BucketSpec) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{22}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *BucketSpec) GetName() string {
@@ -1355,7 +1614,7 @@ type LocalTasksCompleted struct {
 func (x *LocalTasksCompleted) Reset() {
 	*x = LocalTasksCompleted{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[23]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1368,7 +1627,7 @@ func (x *LocalTasksCompleted) String() string {
 func (*LocalTasksCompleted) ProtoMessage() {}
 
 func (x *LocalTasksCompleted) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[23]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1381,7 +1640,7 @@ func (x *LocalTasksCompleted) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LocalTasksCompleted.ProtoReflect.Descriptor instead.
 func (*LocalTasksCompleted) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{23}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *LocalTasksCompleted) GetTotal() int64 {
@@ -1402,7 +1661,7 @@ type DelegatedTasksCompleted struct {
 func (x *DelegatedTasksCompleted) Reset() {
 	*x = DelegatedTasksCompleted{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[24]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1415,7 +1674,7 @@ func (x *DelegatedTasksCompleted) String() string {
 func (*DelegatedTasksCompleted) ProtoMessage() {}
 
 func (x *DelegatedTasksCompleted) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[24]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1428,7 +1687,7 @@ func (x *DelegatedTasksCompleted) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DelegatedTasksCompleted.ProtoReflect.Descriptor instead.
 func (*DelegatedTasksCompleted) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{24}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *DelegatedTasksCompleted) GetTotal() int64 {
@@ -1438,6 +1697,53 @@ func (x *DelegatedTasksCompleted) GetTotal() int64 {
 	return 0
 }
 
+type CorruptResultsTotal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total int64 `protobuf:"varint,1,opt,name=Total,proto3" json:"Total,omitempty"`
+}
+
+func (x *CorruptResultsTotal) Reset() {
+	*x = CorruptResultsTotal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CorruptResultsTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorruptResultsTotal) ProtoMessage() {}
+
+func (x *CorruptResultsTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CorruptResultsTotal.ProtoReflect.Descriptor instead.
+func (*CorruptResultsTotal) Descriptor() ([]byte, []int) {
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CorruptResultsTotal) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
 type CacheUsage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1451,7 +1757,7 @@ type CacheUsage struct {
 func (x *CacheUsage) Reset() {
 	*x = CacheUsage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[25]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1464,7 +1770,7 @@ func (x *CacheUsage) String() string {
 func (*CacheUsage) ProtoMessage() {}
 
 func (x *CacheUsage) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[25]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1477,7 +1783,7 @@ func (x *CacheUsage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CacheUsage.ProtoReflect.Descriptor instead.
 func (*CacheUsage) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{25}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *CacheUsage) GetObjectCount() int64 {
@@ -1506,15 +1812,18 @@ type CacheHits struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CacheHitsTotal   int64   `protobuf:"varint,1,opt,name=CacheHitsTotal,proto3" json:"CacheHitsTotal,omitempty"`
-	CacheMissesTotal int64   `protobuf:"varint,2,opt,name=CacheMissesTotal,proto3" json:"CacheMissesTotal,omitempty"`
-	CacheHitPercent  float64 `protobuf:"fixed64,3,opt,name=CacheHitPercent,proto3" json:"CacheHitPercent,omitempty"`
+	CacheHitsTotal      int64   `protobuf:"varint,1,opt,name=CacheHitsTotal,proto3" json:"CacheHitsTotal,omitempty"`
+	CacheMissesTotal    int64   `protobuf:"varint,2,opt,name=CacheMissesTotal,proto3" json:"CacheMissesTotal,omitempty"`
+	CacheHitPercent     float64 `protobuf:"fixed64,3,opt,name=CacheHitPercent,proto3" json:"CacheHitPercent,omitempty"`
+	CorruptObjectsTotal int64   `protobuf:"varint,4,opt,name=CorruptObjectsTotal,proto3" json:"CorruptObjectsTotal,omitempty"`
+	HTTPHitsTotal       int64   `protobuf:"varint,5,opt,name=HTTPHitsTotal,proto3" json:"HTTPHitsTotal,omitempty"`
+	HTTPMissesTotal     int64   `protobuf:"varint,6,opt,name=HTTPMissesTotal,proto3" json:"HTTPMissesTotal,omitempty"`
 }
 
 func (x *CacheHits) Reset() {
 	*x = CacheHits{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[26]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1527,7 +1836,7 @@ func (x *CacheHits) String() string {
 func (*CacheHits) ProtoMessage() {}
 
 func (x *CacheHits) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[26]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1540,7 +1849,7 @@ func (x *CacheHits) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CacheHits.ProtoReflect.Descriptor instead.
 func (*CacheHits) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{26}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *CacheHits) GetCacheHitsTotal() int64 {
@@ -1564,6 +1873,27 @@ func (x *CacheHits) GetCacheHitPercent() float64 {
 	return 0
 }
 
+func (x *CacheHits) GetCorruptObjectsTotal() int64 {
+	if x != nil {
+		return x.CorruptObjectsTotal
+	}
+	return 0
+}
+
+func (x *CacheHits) GetHTTPHitsTotal() int64 {
+	if x != nil {
+		return x.HTTPHitsTotal
+	}
+	return 0
+}
+
+func (x *CacheHits) GetHTTPMissesTotal() int64 {
+	if x != nil {
+		return x.HTTPMissesTotal
+	}
+	return 0
+}
+
 type Health struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1576,7 +1906,7 @@ type Health struct {
 func (x *Health) Reset() {
 	*x = Health{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metrics_metrics_proto_msgTypes[27]
+		mi := &file_pkg_metrics_metrics_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1589,7 +1919,7 @@ func (x *Health) String() string {
 func (*Health) ProtoMessage() {}
 
 func (x *Health) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metrics_metrics_proto_msgTypes[27]
+	mi := &file_pkg_metrics_metrics_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1602,7 +1932,7 @@ func (x *Health) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Health.ProtoReflect.Descriptor instead.
 func (*Health) Descriptor() ([]byte, []int) {
-	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{27}
+	return file_pkg_metrics_metrics_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *Health) GetStatus() OverallStatus {
@@ -1673,96 +2003,137 @@ var file_pkg_metrics_metrics_proto_rawDesc = []byte{
 	0x3a, 0x00, 0x22, 0x2c, 0x0a, 0x17, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x69, 0x6e, 0x67,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x0f, 0x0a,
 	0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a, 0x00,
-	0x22, 0x1f, 0x0a, 0x0a, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0f,
-	0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a,
-	0x00, 0x22, 0x23, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x64, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x0f, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x1e, 0x0a, 0x0a, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x66, 0x69, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x34, 0x0a, 0x0f, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x54,
-	0x61, 0x73, 0x6b, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x0e, 0x0a, 0x04, 0x55, 0x55, 0x49,
-	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x0f, 0x0a, 0x05, 0x54, 0x6f, 0x74,
-	0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x38, 0x0a, 0x13,
-	0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x54, 0x6f,
-	0x74, 0x61, 0x6c, 0x12, 0x0e, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x42, 0x00, 0x12, 0x0f, 0x0a, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x3a, 0x0a, 0x14, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72,
-	0x72, 0x65, 0x64, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x20,
-	0x0a, 0x16, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x63,
-	0x65, 0x73, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00,
-	0x3a, 0x00, 0x22, 0x27, 0x0a, 0x12, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x50, 0x6f, 0x73,
-	0x74, 0x65, 0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x0f, 0x0a, 0x05, 0x54, 0x6f, 0x74, 0x61,
-	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x22, 0x0a, 0x0d, 0x4c,
-	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0f, 0x0a, 0x05,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x00, 0x3a, 0x00, 0x22,
-	0x22, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74,
-	0x12, 0x0f, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42,
-	0x00, 0x3a, 0x00, 0x22, 0x5a, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x49,
-	0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x42, 0x00, 0x12, 0x25, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43,
-	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x42, 0x00, 0x12, 0x11, 0x0a, 0x07, 0x41, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x3a, 0x00, 0x22,
-	0x8c, 0x01, 0x0a, 0x09, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x12, 0x2c, 0x0a,
-	0x05, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d,
-	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73,
-	0x2e, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x1a, 0x4f, 0x0a, 0x0a, 0x49,
-	0x74, 0x65, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x74,
-	0x72, 0x69, 0x63, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x3a, 0x00, 0x22, 0x9a,
-	0x01, 0x0a, 0x0a, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x12, 0x0e, 0x0a,
-	0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x2b, 0x0a,
-	0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x65,
-	0x74, 0x72, 0x69, 0x63, 0x73, 0x2e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63,
-	0x2e, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x1a, 0x4d, 0x0a, 0x09, 0x44, 0x61,
-	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x3a, 0x00, 0x22, 0x28, 0x0a, 0x13, 0x4c,
-	0x6f, 0x63, 0x61, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
-	0x65, 0x64, 0x12, 0x0f, 0x0a, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x2c, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74,
-	0x65, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x22, 0x2b, 0x0a, 0x16, 0x43, 0x6f, 0x61, 0x6c, 0x65, 0x73, 0x63, 0x65, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x0f, 0x0a, 0x05, 0x54, 0x6f,
+	0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x1f, 0x0a,
+	0x0a, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0f, 0x0a, 0x05, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x23,
+	0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x0f, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42,
+	0x00, 0x3a, 0x00, 0x22, 0x1e, 0x0a, 0x0a, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x12, 0x0e, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x00, 0x3a, 0x00, 0x22, 0x34, 0x0a, 0x0f, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x73, 0x6b,
+	0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x0e, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x0f, 0x0a, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x38, 0x0a, 0x13, 0x43, 0x6f, 0x6e,
+	0x73, 0x75, 0x6d, 0x65, 0x72, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c,
+	0x12, 0x0e, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00,
+	0x12, 0x0f, 0x0a, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42,
+	0x00, 0x3a, 0x00, 0x22, 0x3a, 0x0a, 0x14, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x16, 0x43,
+	0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22,
+	0x61, 0x0a, 0x12, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43, 0x6c, 0x61, 0x73, 0x73,
+	0x51, 0x75, 0x65, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65,
+	0x70, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x17, 0x0a, 0x0d, 0x44,
+	0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x42, 0x00, 0x12, 0x1a, 0x0a, 0x10, 0x57, 0x61, 0x69, 0x74, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x42, 0x00,
+	0x3a, 0x00, 0x22, 0xac, 0x01, 0x0a, 0x13, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x51, 0x75, 0x65, 0x75, 0x65, 0x73, 0x12, 0x3a, 0x0a, 0x07, 0x43, 0x6c,
+	0x61, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43, 0x6c,
+	0x61, 0x73, 0x73, 0x51, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x1a, 0x57, 0x0a, 0x0c, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x73, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x51,
+	0x75, 0x65, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x3a,
+	0x00, 0x22, 0x22, 0x0a, 0x0c, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x10, 0x0a, 0x06, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x9c, 0x01, 0x0a, 0x10, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x35, 0x0a, 0x06, 0x41, 0x67,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x1a, 0x4f, 0x0a, 0x0b, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2e, 0x55, 0x73, 0x61, 0x67,
+	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x3a, 0x00, 0x22, 0x27, 0x0a, 0x12, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x50,
+	0x6f, 0x73, 0x74, 0x65, 0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x0f, 0x0a, 0x05, 0x54, 0x6f,
+	0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x22, 0x0a,
+	0x0d, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0f,
+	0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x00, 0x3a,
+	0x00, 0x22, 0x22, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x0f, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x6e, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x25, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x42, 0x00, 0x12, 0x11, 0x0a, 0x07,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12,
+	0x12, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x8c, 0x01, 0x0a, 0x09, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x73, 0x12, 0x2c, 0x0a, 0x05, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2e, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x1a, 0x4f, 0x0a, 0x0a, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x2b, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x3a, 0x00, 0x22, 0x9a, 0x01, 0x0a, 0x0a, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x53,
+	0x70, 0x65, 0x63, 0x12, 0x0e, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x00, 0x12, 0x2b, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2e, 0x42, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x1a, 0x4d, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x3a,
+	0x00, 0x22, 0x28, 0x0a, 0x13, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x43,
+	0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x0f, 0x0a, 0x05, 0x54, 0x6f, 0x74, 0x61,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x2c, 0x0a, 0x17, 0x44,
+	0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x43, 0x6f, 0x6d,
+	0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x0f, 0x0a, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x28, 0x0a, 0x13, 0x43, 0x6f, 0x72,
+	0x72, 0x75, 0x70, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c,
 	0x12, 0x0f, 0x0a, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42,
 	0x00, 0x3a, 0x00, 0x22, 0x52, 0x0a, 0x0a, 0x43, 0x61, 0x63, 0x68, 0x65, 0x55, 0x73, 0x61, 0x67,
 	0x65, 0x12, 0x15, 0x0a, 0x0b, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x13, 0x0a, 0x09, 0x54, 0x6f, 0x74, 0x61,
 	0x6c, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x16, 0x0a,
 	0x0c, 0x55, 0x73, 0x61, 0x67, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x01, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x5e, 0x0a, 0x09, 0x43, 0x61, 0x63, 0x68, 0x65,
-	0x48, 0x69, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x0e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74,
-	0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x1a,
-	0x0a, 0x10, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x54, 0x6f, 0x74,
-	0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x19, 0x0a, 0x0f, 0x43, 0x61,
-	0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x01, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x48, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x6c, 0x74,
-	0x68, 0x12, 0x28, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2e, 0x4f, 0x76, 0x65, 0x72,
-	0x61, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x00, 0x12, 0x12, 0x0a, 0x08, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x42, 0x00, 0x3a,
-	0x00, 0x2a, 0x60, 0x0a, 0x0d, 0x4f, 0x76, 0x65, 0x72, 0x61, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x11, 0x0a, 0x0d, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x65, 0x61, 0x64, 0x79, 0x10, 0x01,
-	0x12, 0x10, 0x0a, 0x0c, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x69, 0x6e, 0x67,
-	0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x65, 0x67, 0x72, 0x61, 0x64, 0x65, 0x64, 0x10, 0x03,
-	0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x10,
-	0x04, 0x1a, 0x00, 0x2a, 0x9c, 0x01, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f,
-	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x10, 0x0a, 0x0c, 0x4e, 0x6f, 0x43, 0x6f,
-	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x65,
-	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x69, 0x73, 0x73, 0x69,
-	0x6e, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e,
-	0x65, 0x6e, 0x74, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67,
-	0x43, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
-	0x74, 0x10, 0x03, 0x12, 0x18, 0x0a, 0x14, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x04, 0x12, 0x11, 0x0a,
-	0x0d, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x05,
-	0x1a, 0x00, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x6b, 0x75, 0x62, 0x65, 0x63, 0x63, 0x2d, 0x69, 0x6f, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x63,
-	0x63, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x01, 0x28, 0x01, 0x42, 0x00, 0x3a, 0x00, 0x22, 0xb1, 0x01, 0x0a, 0x09, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x48, 0x69, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x0e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69,
+	0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12,
+	0x1a, 0x0a, 0x10, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x54, 0x6f,
+	0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x19, 0x0a, 0x0f, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x42, 0x00, 0x12, 0x1d, 0x0a, 0x13, 0x43, 0x6f, 0x72, 0x72, 0x75, 0x70,
+	0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x17, 0x0a, 0x0d, 0x48, 0x54, 0x54, 0x50, 0x48, 0x69, 0x74,
+	0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x19,
+	0x0a, 0x0f, 0x48, 0x54, 0x54, 0x50, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61,
+	0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x48, 0x0a, 0x06, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x28, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2e,
+	0x4f, 0x76, 0x65, 0x72, 0x61, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x00, 0x12,
+	0x12, 0x0a, 0x08, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x42, 0x00, 0x3a, 0x00, 0x2a, 0x60, 0x0a, 0x0d, 0x4f, 0x76, 0x65, 0x72, 0x61, 0x6c, 0x6c,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x11, 0x0a, 0x0d, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x65, 0x61,
+	0x64, 0x79, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69,
+	0x7a, 0x69, 0x6e, 0x67, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x65, 0x67, 0x72, 0x61, 0x64,
+	0x65, 0x64, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61,
+	0x62, 0x6c, 0x65, 0x10, 0x04, 0x1a, 0x00, 0x2a, 0x9c, 0x01, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x10, 0x0a, 0x0c,
+	0x4e, 0x6f, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x10, 0x00, 0x12, 0x0b,
+	0x0a, 0x07, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x4d,
+	0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x43, 0x6f,
+	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x69, 0x73,
+	0x73, 0x69, 0x6e, 0x67, 0x43, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x6d, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x10, 0x03, 0x12, 0x18, 0x0a, 0x14, 0x49, 0x6e, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x10,
+	0x04, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x10, 0x05, 0x1a, 0x00, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x63, 0x63, 0x2d, 0x69, 0x6f, 0x2f, 0x6b,
+	0x75, 0x62, 0x65, 0x63, 0x63, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1778,7 +2149,7 @@ func file_pkg_metrics_metrics_proto_rawDescGZIP() []byte {
 }
 
 var file_pkg_metrics_metrics_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_pkg_metrics_metrics_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_pkg_metrics_metrics_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_pkg_metrics_metrics_proto_goTypes = []interface{}{
 	(OverallStatus)(0),              // 0: metrics.OverallStatus
 	(StatusConditions)(0),           // 1: metrics.StatusConditions
@@ -1793,44 +2164,56 @@ var file_pkg_metrics_metrics_proto_goTypes = []interface{}{
 	(*TasksCompletedTotal)(nil),     // 10: metrics.TasksCompletedTotal
 	(*TasksFailedTotal)(nil),        // 11: metrics.TasksFailedTotal
 	(*SchedulingRequestsTotal)(nil), // 12: metrics.SchedulingRequestsTotal
-	(*AgentCount)(nil),              // 13: metrics.AgentCount
-	(*ConsumerdCount)(nil),          // 14: metrics.ConsumerdCount
-	(*Identifier)(nil),              // 15: metrics.Identifier
-	(*AgentTasksTotal)(nil),         // 16: metrics.AgentTasksTotal
-	(*ConsumerdTasksTotal)(nil),     // 17: metrics.ConsumerdTasksTotal
-	(*PreferredUsageLimits)(nil),    // 18: metrics.PreferredUsageLimits
-	(*MetricsPostedTotal)(nil),      // 19: metrics.MetricsPostedTotal
-	(*ListenerCount)(nil),           // 20: metrics.ListenerCount
-	(*ProviderCount)(nil),           // 21: metrics.ProviderCount
-	(*ProviderInfo)(nil),            // 22: metrics.ProviderInfo
-	(*Providers)(nil),               // 23: metrics.Providers
-	(*BucketSpec)(nil),              // 24: metrics.BucketSpec
-	(*LocalTasksCompleted)(nil),     // 25: metrics.LocalTasksCompleted
-	(*DelegatedTasksCompleted)(nil), // 26: metrics.DelegatedTasksCompleted
-	(*CacheUsage)(nil),              // 27: metrics.CacheUsage
-	(*CacheHits)(nil),               // 28: metrics.CacheHits
-	(*Health)(nil),                  // 29: metrics.Health
-	nil,                             // 30: metrics.Providers.ItemsEntry
-	nil,                             // 31: metrics.BucketSpec.DataEntry
-	(*types.Toolchain)(nil),         // 32: types.Toolchain
-	(types.Component)(0),            // 33: types.Component
-	(*anypb.Any)(nil),               // 34: google.protobuf.Any
+	(*CoalescedRequestsTotal)(nil),  // 13: metrics.CoalescedRequestsTotal
+	(*AgentCount)(nil),              // 14: metrics.AgentCount
+	(*ConsumerdCount)(nil),          // 15: metrics.ConsumerdCount
+	(*Identifier)(nil),              // 16: metrics.Identifier
+	(*AgentTasksTotal)(nil),         // 17: metrics.AgentTasksTotal
+	(*ConsumerdTasksTotal)(nil),     // 18: metrics.ConsumerdTasksTotal
+	(*PreferredUsageLimits)(nil),    // 19: metrics.PreferredUsageLimits
+	(*PriorityClassQueue)(nil),      // 20: metrics.PriorityClassQueue
+	(*PriorityClassQueues)(nil),     // 21: metrics.PriorityClassQueues
+	(*LeaderStatus)(nil),            // 22: metrics.LeaderStatus
+	(*AgentUsageLimits)(nil),        // 23: metrics.AgentUsageLimits
+	(*MetricsPostedTotal)(nil),      // 24: metrics.MetricsPostedTotal
+	(*ListenerCount)(nil),           // 25: metrics.ListenerCount
+	(*ProviderCount)(nil),           // 26: metrics.ProviderCount
+	(*ProviderInfo)(nil),            // 27: metrics.ProviderInfo
+	(*Providers)(nil),               // 28: metrics.Providers
+	(*BucketSpec)(nil),              // 29: metrics.BucketSpec
+	(*LocalTasksCompleted)(nil),     // 30: metrics.LocalTasksCompleted
+	(*DelegatedTasksCompleted)(nil), // 31: metrics.DelegatedTasksCompleted
+	(*CorruptResultsTotal)(nil),     // 32: metrics.CorruptResultsTotal
+	(*CacheUsage)(nil),              // 33: metrics.CacheUsage
+	(*CacheHits)(nil),               // 34: metrics.CacheHits
+	(*Health)(nil),                  // 35: metrics.Health
+	nil,                             // 36: metrics.PriorityClassQueues.ClassesEntry
+	nil,                             // 37: metrics.AgentUsageLimits.AgentsEntry
+	nil,                             // 38: metrics.Providers.ItemsEntry
+	nil,                             // 39: metrics.BucketSpec.DataEntry
+	(*types.Toolchain)(nil),         // 40: types.Toolchain
+	(types.Component)(0),            // 41: types.Component
+	(*anypb.Any)(nil),               // 42: google.protobuf.Any
 }
 var file_pkg_metrics_metrics_proto_depIdxs = []int32{
-	32, // 0: metrics.Toolchains.Items:type_name -> types.Toolchain
+	40, // 0: metrics.Toolchains.Items:type_name -> types.Toolchain
 	8,  // 1: metrics.CpuStats.CpuUsage:type_name -> metrics.CpuUsage
 	9,  // 2: metrics.CpuStats.ThrottlingData:type_name -> metrics.ThrottlingData
-	33, // 3: metrics.ProviderInfo.Component:type_name -> types.Component
-	30, // 4: metrics.Providers.Items:type_name -> metrics.Providers.ItemsEntry
-	31, // 5: metrics.BucketSpec.Data:type_name -> metrics.BucketSpec.DataEntry
-	0,  // 6: metrics.Health.Status:type_name -> metrics.OverallStatus
-	22, // 7: metrics.Providers.ItemsEntry.value:type_name -> metrics.ProviderInfo
-	34, // 8: metrics.BucketSpec.DataEntry.value:type_name -> google.protobuf.Any
-	9,  // [9:9] is the sub-list for method output_type
-	9,  // [9:9] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	36, // 3: metrics.PriorityClassQueues.Classes:type_name -> metrics.PriorityClassQueues.ClassesEntry
+	37, // 4: metrics.AgentUsageLimits.Agents:type_name -> metrics.AgentUsageLimits.AgentsEntry
+	41, // 5: metrics.ProviderInfo.Component:type_name -> types.Component
+	38, // 6: metrics.Providers.Items:type_name -> metrics.Providers.ItemsEntry
+	39, // 7: metrics.BucketSpec.Data:type_name -> metrics.BucketSpec.DataEntry
+	0,  // 8: metrics.Health.Status:type_name -> metrics.OverallStatus
+	20, // 9: metrics.PriorityClassQueues.ClassesEntry.value:type_name -> metrics.PriorityClassQueue
+	4,  // 10: metrics.AgentUsageLimits.AgentsEntry.value:type_name -> metrics.UsageLimits
+	27, // 11: metrics.Providers.ItemsEntry.value:type_name -> metrics.ProviderInfo
+	42, // 12: metrics.BucketSpec.DataEntry.value:type_name -> google.protobuf.Any
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_pkg_metrics_metrics_proto_init() }
@@ -1972,7 +2355,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AgentCount); i {
+			switch v := v.(*CoalescedRequestsTotal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1984,7 +2367,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConsumerdCount); i {
+			switch v := v.(*AgentCount); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1996,7 +2379,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Identifier); i {
+			switch v := v.(*ConsumerdCount); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2008,7 +2391,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AgentTasksTotal); i {
+			switch v := v.(*Identifier); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2020,7 +2403,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConsumerdTasksTotal); i {
+			switch v := v.(*AgentTasksTotal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2032,7 +2415,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PreferredUsageLimits); i {
+			switch v := v.(*ConsumerdTasksTotal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2044,7 +2427,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MetricsPostedTotal); i {
+			switch v := v.(*PreferredUsageLimits); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2056,7 +2439,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListenerCount); i {
+			switch v := v.(*PriorityClassQueue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2068,7 +2451,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProviderCount); i {
+			switch v := v.(*PriorityClassQueues); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2080,7 +2463,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProviderInfo); i {
+			switch v := v.(*LeaderStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2092,7 +2475,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Providers); i {
+			switch v := v.(*AgentUsageLimits); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2104,7 +2487,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BucketSpec); i {
+			switch v := v.(*MetricsPostedTotal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2116,7 +2499,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LocalTasksCompleted); i {
+			switch v := v.(*ListenerCount); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2128,7 +2511,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DelegatedTasksCompleted); i {
+			switch v := v.(*ProviderCount); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2140,7 +2523,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CacheUsage); i {
+			switch v := v.(*ProviderInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2152,7 +2535,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CacheHits); i {
+			switch v := v.(*Providers); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2164,6 +2547,78 @@ func file_pkg_metrics_metrics_proto_init() {
 			}
 		}
 		file_pkg_metrics_metrics_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BucketSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_metrics_metrics_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LocalTasksCompleted); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_metrics_metrics_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DelegatedTasksCompleted); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_metrics_metrics_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CorruptResultsTotal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_metrics_metrics_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CacheUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_metrics_metrics_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CacheHits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_metrics_metrics_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Health); i {
 			case 0:
 				return &v.state
@@ -2182,7 +2637,7 @@ func file_pkg_metrics_metrics_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_metrics_metrics_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   30,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -109,6 +109,11 @@ func (a *SchedulingRequestsTotal) MarshalLogObject(enc zapcore.ObjectEncoder) er
 	return nil
 }
 
+func (a *CoalescedRequestsTotal) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt64("t", a.GetTotal())
+	return nil
+}
+
 func (a *AgentCount) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddInt64("c", a.GetCount())
 	return nil
@@ -139,6 +144,23 @@ func (a *PreferredUsageLimits) MarshalLogObject(enc zapcore.ObjectEncoder) error
 	return nil
 }
 
+func (a *PriorityClassQueues) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for name, class := range a.GetClasses() {
+		enc.AddInt64(name, class.GetQueueDepth())
+	}
+	return nil
+}
+
+func (a *LeaderStatus) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddBool("leader", a.GetLeader())
+	return nil
+}
+
+func (a *AgentUsageLimits) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("agents", len(a.GetAgents()))
+	return nil
+}
+
 func (a *MetricsPostedTotal) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddInt64("t", a.GetTotal())
 	return nil
@@ -181,6 +203,11 @@ func (a *DelegatedTasksCompleted) MarshalLogObject(enc zapcore.ObjectEncoder) er
 	return nil
 }
 
+func (a *CorruptResultsTotal) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt64("t", a.GetTotal())
+	return nil
+}
+
 func (a *CacheUsage) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddInt64("ct", a.GetObjectCount())
 	enc.AddInt64("sz", a.GetTotalSize())
@@ -191,6 +218,11 @@ func (a *CacheUsage) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 func (a *CacheHits) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("h/m/%", fmt.Sprintf("%d/%d/%f",
 		a.GetCacheHitsTotal(), a.GetCacheMissesTotal(), a.GetCacheHitPercent()))
+	enc.AddInt64("corrupt", a.GetCorruptObjectsTotal())
+	if a.GetHTTPHitsTotal()+a.GetHTTPMissesTotal() > 0 {
+		enc.AddString("http h/m", fmt.Sprintf("%d/%d",
+			a.GetHTTPHitsTotal(), a.GetHTTPMissesTotal()))
+	}
 	return nil
 }
 
@@ -29,6 +29,7 @@ Scheduler:
 - Number of completed remote tasks: kubecc_tasks_completed_total (counter)
 - Number of failed remote tasks: kubecc_tasks_failed_total (counter)
 - Number of scheduling requests: kubecc_scheduling_requests_total (counter)
+- Number of coalesced requests: kubecc_coalesced_requests_total (counter)
 - Number of agents: kubecc_agent_count (gauge)
 - Number of consumer daemons: kubecc_cd_count (gauge)
 - Agent Scheduling weight: kubecc_agent_weight (gauge)
@@ -45,6 +46,12 @@ Consumerd:
 - Max concurrent tasks: kubecc_cd_tasks_max (gauge)
 - Current number of local tasks: kubecc_cd_local_tasks_active (gauge)
 - Current number of remote tasks: kubecc_cd_remote_tasks_active (gauge)
+- Total corrupt remote results: kubecc_cd_corrupt_results_total (counter)
+
+Cache:
+- Total corrupt objects quarantined: kubecc_cache_corrupt_objects_total (counter)
+- Total HTTP endpoint cache hits: kubecc_cache_http_hits_total (counter)
+- Total HTTP endpoint cache misses: kubecc_cache_http_misses_total (counter)
 
 */
 
@@ -99,6 +106,11 @@ var (
 		Name:      "scheduling_requests_total",
 		Help:      "Total number of requests handled by the scheduler",
 	})
+	coalescedRequestsTotal = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kubecc",
+		Name:      "coalesced_requests_total",
+		Help:      "Total number of requests that were coalesced with an identical in-flight request",
+	})
 	agentCount = promauto.NewGauge(prometheus.GaugeOpts{
 		Namespace: "kubecc",
 		Name:      "agent_count",
@@ -130,6 +142,27 @@ var (
 	}, []string{
 		"agent",
 	})
+	schedulerQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubecc",
+		Name:      "scheduler_queue_depth",
+		Help:      "Current number of requests waiting for an agent",
+	}, []string{
+		"class",
+	})
+	schedulerDequeuedTotal = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubecc",
+		Name:      "scheduler_dequeued_total",
+		Help:      "Total number of requests sent to an agent after waiting in the queue",
+	}, []string{
+		"class",
+	})
+	schedulerWaitSecondsTotal = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubecc",
+		Name:      "scheduler_wait_seconds_total",
+		Help:      "Total time requests have spent waiting for an agent",
+	}, []string{
+		"class",
+	})
 )
 
 // Agent
@@ -194,6 +227,32 @@ var (
 	}, []string{
 		"consumerd",
 	})
+	cdCorruptResultsTotal = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubecc",
+		Name:      "cd_corrupt_results_total",
+		Help:      "Total number of remote results which failed digest verification",
+	}, []string{
+		"consumerd",
+	})
+)
+
+// Cache
+var (
+	cacheCorruptObjectsTotal = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kubecc",
+		Name:      "cache_corrupt_objects_total",
+		Help:      "Total number of corrupt objects quarantined by the cache server",
+	})
+	cacheHTTPHitsTotal = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kubecc",
+		Name:      "cache_http_hits_total",
+		Help:      "Total number of objects found by HTTP cache requests",
+	})
+	cacheHTTPMissesTotal = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kubecc",
+		Name:      "cache_http_misses_total",
+		Help:      "Total number of objects not found by HTTP cache requests",
+	})
 )
 
 var (
@@ -244,6 +303,8 @@ func servePrometheusMetrics(
 			providerInfo[uuid] = info
 			infoMutex.Unlock()
 			watchConsumerdKeys(listener, info)
+		case types.Cache:
+			watchCacheKeys(listener, info)
 		}
 		<-ctx.Done()
 
@@ -299,6 +360,19 @@ func watchSchedulerKeys(
 	listener.OnValueChanged(info.UUID, func(value *metrics.SchedulingRequestsTotal) {
 		schedulingRequestsTotal.Set(float64(value.Total))
 	})
+	listener.OnValueChanged(info.UUID, func(value *metrics.CoalescedRequestsTotal) {
+		coalescedRequestsTotal.Set(float64(value.Total))
+	})
+	listener.OnValueChanged(info.UUID, func(value *metrics.PriorityClassQueues) {
+		for class, queue := range value.GetClasses() {
+			schedulerQueueDepth.WithLabelValues(class).
+				Set(float64(queue.GetQueueDepth()))
+			schedulerDequeuedTotal.WithLabelValues(class).
+				Set(float64(queue.GetDequeuedTotal()))
+			schedulerWaitSecondsTotal.WithLabelValues(class).
+				Set(queue.GetWaitSecondsTotal())
+		}
+	})
 	listener.OnValueChanged(info.UUID, func(value *metrics.TasksCompletedTotal) {
 		tasksCompletedTotal.Set(float64(value.Total))
 	})
@@ -325,4 +399,18 @@ func watchConsumerdKeys(
 	listener.OnValueChanged(info.UUID, func(value *metrics.LocalTasksCompleted) {
 		cdLocalTasksTotal.With(labels).Set(float64(value.Total))
 	})
+	listener.OnValueChanged(info.UUID, func(value *metrics.CorruptResultsTotal) {
+		cdCorruptResultsTotal.With(labels).Set(float64(value.Total))
+	})
+}
+
+func watchCacheKeys(
+	listener clients.MetricsListener,
+	info *types.WhoisResponse,
+) {
+	listener.OnValueChanged(info.UUID, func(value *metrics.CacheHits) {
+		cacheCorruptObjectsTotal.Set(float64(value.CorruptObjectsTotal))
+		cacheHTTPHitsTotal.Set(float64(value.HTTPHitsTotal))
+		cacheHTTPMissesTotal.Set(float64(value.HTTPMissesTotal))
+	})
 }
@@ -30,6 +30,7 @@ import (
 	"github.com/kubecc-io/kubecc/pkg/clients"
 	"github.com/kubecc-io/kubecc/pkg/config"
 	"github.com/kubecc-io/kubecc/pkg/meta"
+	"github.com/kubecc-io/kubecc/pkg/meta/mdkeys"
 	"github.com/kubecc-io/kubecc/pkg/metrics"
 	"github.com/kubecc-io/kubecc/pkg/servers"
 	"github.com/kubecc-io/kubecc/pkg/types"
@@ -287,6 +288,10 @@ func (m *MonitorServer) Stream(
 	}
 	uuid := meta.UUID(ctx)
 	component := meta.Component(ctx)
+	hostname := ""
+	if info, ok := ctx.Value(mdkeys.SystemInfoKey).(*types.SystemInfo); ok {
+		hostname = info.Hostname
+	}
 
 	m.providerMutex.Lock()
 	if _, ok := m.buckets[uuid]; ok {
@@ -301,6 +306,7 @@ func (m *MonitorServer) Stream(
 		UUID:      uuid,
 		Component: component,
 		Address:   addr,
+		Hostname:  hostname,
 	}
 	providerCount.Inc()
 	m.providersUpdated()
@@ -492,6 +498,7 @@ func (m *MonitorServer) Whois(
 			UUID:      req.GetUUID(),
 			Address:   info.Address,
 			Component: info.Component,
+			Hostname:  info.Hostname,
 		}, nil
 	}
 	return nil, status.Error(codes.NotFound,
@@ -47,6 +47,21 @@ func (r *Reconciler) Reconcile() (*reconcile.Result, error) {
 	lg := meta.Log(r.ctx)
 	items := []resources.Resource{}
 
+	if !r.buildCluster.DeletionTimestamp.IsZero() {
+		if err := r.finalizeRBAC(); err != nil {
+			lg.Error(err)
+			return nil, err
+		}
+		return &reconcile.Result{}, nil
+	}
+
+	if modified, err := r.addRBACFinalizer(); err != nil {
+		lg.Error(err)
+		return nil, err
+	} else if modified {
+		return &reconcile.Result{Requeue: true}, nil
+	}
+
 	if modified, err := r.configureDefaultImage(); err != nil {
 		lg.Error(err)
 		return nil, err
@@ -68,6 +83,13 @@ func (r *Reconciler) Reconcile() (*reconcile.Result, error) {
 	}
 	items = append(items, cm...)
 
+	rbac, err := r.rbac()
+	if err != nil {
+		lg.Error(err)
+		return nil, err
+	}
+	items = append(items, rbac...)
+
 	monitor, err := r.monitor()
 	if err != nil {
 		lg.Error(err)
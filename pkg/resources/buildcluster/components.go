@@ -66,6 +66,17 @@ func (r *Reconciler) configMap() ([]resources.Resource, error) {
 			DisableTLS:       true,
 		},
 	}
+	if r.schedulerReplicas() > 1 {
+		// Only the leader is active, so agents find it through the monitor
+		// instead of the scheduler service.
+		conf.Scheduler.LeaderElection = &config.LeaderElectionSpec{}
+		conf.Agent.SchedulerFailover = &config.SchedulerFailoverSpec{}
+	}
+	if r.buildCluster.Spec.Components.Cache.Enabled && r.cacheReplicas() > 1 {
+		conf.Scheduler.CacheCluster = &config.CacheClusterSpec{
+			Rebalance: true,
+		}
+	}
 	if vs := r.buildCluster.Spec.Components.Cache.VolatileStorage; vs != nil {
 		conf.Cache.VolatileStorage = vs
 	}
@@ -163,12 +174,14 @@ func (r *Reconciler) scheduler() ([]resources.Resource, error) {
 		"kubecc-role": "control-plane",
 	}
 	svc := genericService("kubecc-scheduler", r.buildCluster.Namespace, labels)
+	replicas := r.schedulerReplicas()
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "kubecc-scheduler",
 			Namespace: r.buildCluster.Namespace,
 		},
 		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -177,7 +190,8 @@ func (r *Reconciler) scheduler() ([]resources.Resource, error) {
 					Labels: labels,
 				},
 				Spec: corev1.PodSpec{
-					PriorityClassName: "kubecc-low-priority",
+					PriorityClassName:  "kubecc-low-priority",
+					ServiceAccountName: schedulerServiceAccountName,
 					Affinity: &corev1.Affinity{
 						PodAntiAffinity: agentAntiAffinity(),
 					},
@@ -212,6 +226,14 @@ func (r *Reconciler) scheduler() ([]resources.Resource, error) {
 	}, nil
 }
 
+// schedulerReplicas returns the number of scheduler replicas to deploy.
+func (r *Reconciler) schedulerReplicas() int32 {
+	if replicas := r.buildCluster.Spec.Components.Scheduler.Replicas; replicas != nil {
+		return *replicas
+	}
+	return 1
+}
+
 func (r *Reconciler) cacheSrv() ([]resources.Resource, error) {
 	img, pullPolicy, err := r.kubeccImage()
 	if err != nil {
@@ -222,12 +244,18 @@ func (r *Reconciler) cacheSrv() ([]resources.Resource, error) {
 		"kubecc-role": "control-plane",
 	}
 	svc := genericService("kubecc-cache", r.buildCluster.Namespace, labels)
-	deployment := &appsv1.Deployment{
+	replicas := r.cacheReplicas()
+	// Cache servers are identified by their hostname when sharding objects,
+	// which is stable for pods in a StatefulSet.
+	statefulSet := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "kubecc-cache",
 			Namespace: r.buildCluster.Namespace,
 		},
-		Spec: appsv1.DeploymentSpec{
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:            &replicas,
+			ServiceName:         "kubecc-cache",
+			PodManagementPolicy: appsv1.ParallelPodManagement,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -263,17 +291,33 @@ func (r *Reconciler) cacheSrv() ([]resources.Resource, error) {
 			},
 		},
 	}
+	// Cache servers were previously deployed as a Deployment
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kubecc-cache",
+			Namespace: r.buildCluster.Namespace,
+		},
+	}
 	ctrl.SetControllerReference(r.buildCluster, svc, r.client.Scheme())
-	ctrl.SetControllerReference(r.buildCluster, deployment, r.client.Scheme())
+	ctrl.SetControllerReference(r.buildCluster, statefulSet, r.client.Scheme())
 
 	items := []resources.Resource{
 		resources.Created(svc),
+		resources.Absent(deployment),
 	}
 
 	if r.buildCluster.Spec.Components.Cache.Enabled {
-		items = append(items, resources.Present(deployment))
+		items = append(items, resources.Present(statefulSet))
 	} else {
-		items = append(items, resources.Absent(deployment))
+		items = append(items, resources.Absent(statefulSet))
 	}
 	return items, nil
 }
+
+// cacheReplicas returns the number of cache servers to deploy.
+func (r *Reconciler) cacheReplicas() int32 {
+	if replicas := r.buildCluster.Spec.Components.Cache.Replicas; replicas != nil {
+		return *replicas
+	}
+	return 1
+}
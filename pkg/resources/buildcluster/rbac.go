@@ -0,0 +1,188 @@
+package buildcluster
+
+import (
+	"fmt"
+
+	"github.com/kubecc-io/kubecc/api/v1alpha1"
+	"github.com/kubecc-io/kubecc/pkg/meta"
+	"github.com/kubecc-io/kubecc/pkg/resources"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// serviceAccountName is the service account used by agents. It is allowed
+	// to read nodes, since the topology zone of a pod is only available from
+	// the labels of its node.
+	serviceAccountName = "kubecc"
+	// schedulerServiceAccountName is the service account used by the
+	// scheduler. It is allowed to manage the lease used for leader election.
+	schedulerServiceAccountName = "kubecc-scheduler"
+	// rbacFinalizer is added to build clusters so that the cluster-scoped RBAC
+	// objects created for them, which can not be owned by the build cluster,
+	// are deleted along with it.
+	rbacFinalizer = "kubecc.io/cluster-rbac"
+)
+
+func (r *Reconciler) rbac() ([]resources.Resource, error) {
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceAccountName,
+			Namespace: r.buildCluster.Namespace,
+		},
+	}
+	nodeReader, nodeReaderBinding := r.nodeReaderRBAC()
+	schedulerSA := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      schedulerServiceAccountName,
+			Namespace: r.buildCluster.Namespace,
+		},
+	}
+	leaderElection := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kubecc-scheduler-leader-election",
+			Namespace: r.buildCluster.Namespace,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{coordinationv1.GroupName},
+				Resources: []string{"leases"},
+				Verbs:     []string{"get", "create", "update"},
+			},
+		},
+	}
+	leaderElectionBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      leaderElection.Name,
+			Namespace: r.buildCluster.Namespace,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     leaderElection.Name,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      schedulerSA.Name,
+				Namespace: schedulerSA.Namespace,
+			},
+		},
+	}
+	ctrl.SetControllerReference(r.buildCluster, sa, r.client.Scheme())
+	ctrl.SetControllerReference(r.buildCluster, schedulerSA, r.client.Scheme())
+	ctrl.SetControllerReference(r.buildCluster, leaderElection, r.client.Scheme())
+	ctrl.SetControllerReference(r.buildCluster, leaderElectionBinding, r.client.Scheme())
+	return []resources.Resource{
+		resources.Present(sa),
+		resources.Present(nodeReader),
+		resources.Present(nodeReaderBinding),
+		resources.Present(schedulerSA),
+		resources.Present(leaderElection),
+		resources.Present(leaderElectionBinding),
+	}, nil
+}
+
+// nodeReaderRBAC returns the cluster role allowing agents to read nodes, which
+// is shared by all build clusters, and the binding of the build cluster's
+// service account to it.
+func (r *Reconciler) nodeReaderRBAC() (*rbacv1.ClusterRole, *rbacv1.ClusterRoleBinding) {
+	nodeReader := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kubecc-node-reader",
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"nodes"},
+				Verbs:     []string{"get"},
+			},
+		},
+	}
+	// Cluster-scoped objects can not be owned by the build cluster, so the
+	// binding name includes the namespace to keep it unique.
+	nodeReaderBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("kubecc-node-reader-%s", r.buildCluster.Namespace),
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     nodeReader.Name,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      serviceAccountName,
+				Namespace: r.buildCluster.Namespace,
+			},
+		},
+	}
+	return nodeReader, nodeReaderBinding
+}
+
+// addRBACFinalizer adds the finalizer which cleans up cluster-scoped RBAC
+// objects to the build cluster, and returns true if it was added.
+func (r *Reconciler) addRBACFinalizer() (bool, error) {
+	if controllerutil.ContainsFinalizer(r.buildCluster, rbacFinalizer) {
+		return false, nil
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.client.Get(r.ctx, client.ObjectKeyFromObject(r.buildCluster), r.buildCluster)
+		if err != nil {
+			return err
+		}
+		controllerutil.AddFinalizer(r.buildCluster, rbacFinalizer)
+		return r.client.Update(r.ctx, r.buildCluster)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// finalizeRBAC deletes the cluster-scoped RBAC objects created for the build
+// cluster and removes its finalizer. The node reader cluster role is only
+// deleted along with the last build cluster, since it is shared by all of
+// them.
+func (r *Reconciler) finalizeRBAC() error {
+	lg := meta.Log(r.ctx)
+	if !controllerutil.ContainsFinalizer(r.buildCluster, rbacFinalizer) {
+		return nil
+	}
+	nodeReader, nodeReaderBinding := r.nodeReaderRBAC()
+	if err := r.client.Delete(r.ctx, nodeReaderBinding); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	clusters := &v1alpha1.BuildClusterList{}
+	if err := r.client.List(r.ctx, clusters); err != nil {
+		return err
+	}
+	inUse := false
+	for _, cluster := range clusters.Items {
+		if cluster.UID != r.buildCluster.UID && cluster.DeletionTimestamp.IsZero() {
+			inUse = true
+			break
+		}
+	}
+	if !inUse {
+		if err := r.client.Delete(r.ctx, nodeReader); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	lg.Info("Deleted cluster-scoped RBAC objects")
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.client.Get(r.ctx, client.ObjectKeyFromObject(r.buildCluster), r.buildCluster)
+		if err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		controllerutil.RemoveFinalizer(r.buildCluster, rbacFinalizer)
+		return r.client.Update(r.ctx, r.buildCluster)
+	})
+}
@@ -33,6 +33,12 @@ func (r *Reconciler) toolchain(toolchain v1alpha1.ToolchainSpec) ([]resources.Re
 		} else {
 			compilerImage = *toolchain.CustomImage
 		}
+	case "clang":
+		if toolchain.CustomImage == nil {
+			compilerImage = fmt.Sprintf("docker.io/silkeh/clang:%s", toolchain.Version)
+		} else {
+			compilerImage = *toolchain.CustomImage
+		}
 	case "custom":
 		if toolchain.CustomImage == nil {
 			return nil, errors.New("toolchain kind is custom, but customImage is not set")
@@ -62,7 +68,8 @@ func (r *Reconciler) toolchain(toolchain v1alpha1.ToolchainSpec) ([]resources.Re
 					Labels: labels,
 				},
 				Spec: corev1.PodSpec{
-					PriorityClassName: "kubecc-high-priority",
+					PriorityClassName:  "kubecc-high-priority",
+					ServiceAccountName: serviceAccountName,
 					Affinity: &corev1.Affinity{
 						PodAntiAffinity: controlPlaneAntiAffinity(),
 						NodeAffinity:    r.buildCluster.Spec.Components.Agent.NodeAffinity,
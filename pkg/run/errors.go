@@ -17,16 +17,24 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package run
 
-import "errors"
+import (
+	"errors"
+	"os/exec"
+	"syscall"
+)
 
 type CompilerError struct {
 	error
-	text string
+	text     string
+	exitCode int32
 }
 
-func NewCompilerError(text string) *CompilerError {
+// NewCompilerError creates a new CompilerError containing the compiler's
+// output and the exit code it returned.
+func NewCompilerError(text string, exitCode int32) *CompilerError {
 	return &CompilerError{
-		text: text,
+		text:     text,
+		exitCode: exitCode,
 	}
 }
 
@@ -34,10 +42,43 @@ func (e *CompilerError) Error() string {
 	return e.text
 }
 
+// ExitCode returns the exit code of the compiler.
+func (e *CompilerError) ExitCode() int32 {
+	return e.exitCode
+}
+
 func IsCompilerError(err error) bool {
 	var e *CompilerError
 	return errors.As(err, &e)
 }
 
+// ExitCode returns the exit code of a process given the error returned by
+// exec.Cmd.Wait. If the process was terminated by a signal, the exit code
+// follows the shell convention of 128 plus the signal number. If the exit
+// code cannot be determined, 1 is returned.
+func ExitCode(err error) int32 {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			return 128 + int32(ws.Signal())
+		}
+		if code := exitErr.ExitCode(); code > 0 {
+			return int32(code)
+		}
+	}
+	return 1
+}
+
+// CompilerExitCode returns the exit code contained in a CompilerError, or 1
+// if the error is not a CompilerError.
+func CompilerExitCode(err error) int32 {
+	var e *CompilerError
+	if errors.As(err, &e) && e.exitCode != 0 {
+		return e.exitCode
+	}
+	return 1
+}
+
 var ErrNoAgentsRetry = errors.New("No agents available to handle the request; retrying")
 var ErrNoAgentsRunLocal = errors.New("No agents available to handle the request; running locally")
+var ErrCorruptResult = errors.New("Remote compile result is corrupt; running locally")
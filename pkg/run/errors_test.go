@@ -0,0 +1,48 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package run_test
+
+import (
+	"errors"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/kubecc-io/kubecc/pkg/run"
+)
+
+var _ = Describe("Exit codes", func() {
+	It("should return the exit code of a process", func() {
+		err := exec.Command("sh", "-c", "exit 3").Run()
+		Expect(run.ExitCode(err)).To(BeEquivalentTo(3))
+	})
+	It("should return 128+signal for signaled processes", func() {
+		err := exec.Command("sh", "-c", "kill -SEGV $$").Run()
+		Expect(run.ExitCode(err)).To(BeEquivalentTo(139))
+	})
+	It("should default to 1 for unknown errors", func() {
+		Expect(run.ExitCode(errors.New("test"))).To(BeEquivalentTo(1))
+		Expect(run.CompilerExitCode(errors.New("test"))).To(BeEquivalentTo(1))
+	})
+	It("should preserve the exit code in compiler errors", func() {
+		err := run.NewCompilerError("error", 4)
+		Expect(run.IsCompilerError(err)).To(BeTrue())
+		Expect(run.CompilerExitCode(err)).To(BeEquivalentTo(4))
+	})
+})
@@ -21,12 +21,14 @@ import (
 	"context"
 	"errors"
 	"io"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/kubecc-io/kubecc/pkg/clients"
 	"github.com/kubecc-io/kubecc/pkg/meta"
 	"github.com/kubecc-io/kubecc/pkg/metrics"
+	"github.com/kubecc-io/kubecc/pkg/storage"
 	"github.com/kubecc-io/kubecc/pkg/types"
 	"github.com/kubecc-io/kubecc/pkg/util"
 	"github.com/onsi/ginkgo"
@@ -34,6 +36,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type Broker struct {
@@ -42,6 +45,7 @@ type Broker struct {
 	completedTasks   *atomic.Int64
 	failedTasks      *atomic.Int64
 	requestCount     *atomic.Int64
+	coalescedCount   *atomic.Int64
 	requestQueue     chan *types.CompileRequest
 	responseQueue    chan *types.CompileResponse
 	agents           map[string]*Agent
@@ -56,21 +60,56 @@ type Broker struct {
 	inflightRequests sync.Map // map[uuid string]inflightRequest
 	tcWatcher        ToolchainWatcher
 	cacheAvailable   *atomic.Bool
+	flights          map[string]*flight // map[hash string]*flight
+	flightsMutex     *sync.Mutex
+}
+
+// A flight tracks the requests waiting on the result of an in-flight request
+// (the leader) that has the same hash.
+type flight struct {
+	leader    string
+	followers []pendingRequest
 }
 
 type pendingRequest struct {
 	request   *types.CompileRequest
 	requester *Consumerd
+	// The retry action sent to the consumerd if the request cannot be routed
+	ifRouteFails types.RetryAction
+	// The number of times this request has been sent to an agent
+	attempts int
+	// The time the request was received from the consumerd
+	queuedAt time.Time
 }
 
 type inflightRequest struct {
 	pendingRequest
 	agent *Agent
+	// The time the request was sent to the agent
+	startedAt time.Time
+}
+
+func (pr pendingRequest) taskInfo(state types.TaskState, now time.Time) *types.TaskInfo {
+	info := &types.TaskInfo{
+		RequestID: pr.request.GetRequestID(),
+		State:     state,
+		Toolchain: pr.request.GetToolchain(),
+		Priority:  pr.request.GetPriority(),
+		Tenant:    pr.request.GetTenant(),
+		Attempts:  int32(pr.attempts),
+		QueueTime: int64(now.Sub(pr.queuedAt)),
+	}
+	if pr.requester != nil {
+		info.Consumerd = pr.requester.UUID
+	}
+	return info
 }
 
 type BrokerOptions struct {
 	cacheClient types.CacheClient
 	monClient   types.MonitorClient
+	fairShare   FairSharePolicy
+	locality    LocalityPolicy
 }
 
 type BrokerOption func(*BrokerOptions)
@@ -93,12 +132,31 @@ func MonitorClient(client types.MonitorClient) BrokerOption {
 	}
 }
 
+// FairShare sets the policy used to share agents between priority classes
+// and tenants. Defaults to DefaultFairSharePolicy().
+func FairShare(policy FairSharePolicy) BrokerOption {
+	return func(o *BrokerOptions) {
+		o.fairShare = policy
+	}
+}
+
+// Locality sets the policy used to prefer agents close to the consumerd a
+// request was sent from. Defaults to DefaultLocalityPolicy.
+func Locality(policy LocalityPolicy) BrokerOption {
+	return func(o *BrokerOptions) {
+		o.locality = policy
+	}
+}
+
 func NewBroker(
 	ctx context.Context,
 	tcw ToolchainWatcher,
 	opts ...BrokerOption,
 ) *Broker {
-	options := BrokerOptions{}
+	options := BrokerOptions{
+		fairShare: DefaultFairSharePolicy(),
+		locality:  DefaultLocalityPolicy,
+	}
 	options.Apply(opts...)
 
 	b := &Broker{
@@ -107,6 +165,7 @@ func NewBroker(
 		completedTasks:  atomic.NewInt64(0),
 		failedTasks:     atomic.NewInt64(0),
 		requestCount:    atomic.NewInt64(0),
+		coalescedCount:  atomic.NewInt64(0),
 		requestQueue:    make(chan *types.CompileRequest),
 		responseQueue:   make(chan *types.CompileResponse),
 		agents:          make(map[string]*Agent),
@@ -118,9 +177,15 @@ func NewBroker(
 		cacheClient:     options.cacheClient,
 		monClient:       options.monClient,
 		cacheAvailable:  atomic.NewBool(false),
+		flights:         make(map[string]*flight),
+		flightsMutex:    &sync.Mutex{},
 	}
 
-	routerOptions := []RouterOption{}
+	routerOptions := []RouterOption{
+		WithOrphanHandler(b.handleOrphanedRequest),
+		WithFairSharePolicy(options.fairShare),
+		WithLocalityPolicy(options.locality),
+	}
 	if options.cacheClient != nil {
 		routerOptions = append(routerOptions, WithHooks(b))
 	} else {
@@ -157,27 +222,37 @@ func (b *Broker) watchCacheAvailability() {
 	}()
 }
 
+// handleAgentStream starts sending requests to and receiving responses from
+// the agent. The returned channel is closed once no more requests will be
+// sent to the agent.
 func (b *Broker) handleAgentStream(
 	stream types.Scheduler_StreamIncomingTasksServer,
 	filterOutput <-chan *types.CompileRequest,
-) {
+) <-chan struct{} {
 	b.agentsMutex.RLock()
 	uuid := meta.UUID(stream.Context())
 	agent := b.agents[uuid]
 	b.agentsMutex.RUnlock()
 	agent.AvailableTokens = make(chan struct{}, MaxTokens)
 	agent.LockedTokens = make(chan struct{}, MaxTokens)
-	agent.Lock()
-	for i := 0; i < int(agent.UsageLimits.GetConcurrentProcessLimit()); i++ {
-		agent.AvailableTokens <- struct{}{}
-	}
-	agent.Unlock()
+	agent.RLock()
+	agent.ResizeTokenPool(int(agent.UsageLimits.GetConcurrentProcessLimit()))
+	agent.RUnlock()
 
+	sendDone := make(chan struct{})
 	go func() {
 		defer ginkgo.GinkgoRecover()
+		defer close(sendDone)
 		b.lg.Debug("Handling agent stream (send)")
 		defer b.lg.Debug("Agent stream done (send)")
 		for {
+			// Don't hold a token while the agent is cordoned, so that the locked
+			// tokens only count requests the agent is actually running.
+			select {
+			case <-agent.Schedulable():
+			case <-stream.Context().Done():
+				return
+			}
 			// Attempt to remove a token from the agent's token pool. This represents
 			// exclusive access to a share of the agent's resources. The token will
 			// be put back into the buffered channel once a response has been
@@ -186,19 +261,35 @@ func (b *Broker) handleAgentStream(
 			select {
 			case token := <-agent.AvailableTokens:
 				agent.LockedTokens <- token
-				req, ok := <-filterOutput
-				if !ok {
-					// Output closed
+				agent.awaitingRequest.Store(true)
+				var req *types.CompileRequest
+				select {
+				case r, ok := <-filterOutput:
+					agent.awaitingRequest.Store(false)
+					if !ok {
+						// Output closed
+						return
+					}
+					req = r
+				case <-agent.Unschedulable():
+					agent.awaitingRequest.Store(false)
+					agent.ReturnToken()
+					continue
+				case <-stream.Context().Done():
+					agent.awaitingRequest.Store(false)
 					return
 				}
-				pending, ok := b.pendingRequests.LoadAndDelete(req.RequestID)
+				value, ok := b.pendingRequests.LoadAndDelete(req.RequestID)
 				if !ok {
 					b.lg.DPanic("Tried to run a nonexistent task")
 					continue
 				}
+				pending := value.(pendingRequest)
+				pending.attempts++
 				b.inflightRequests.Store(req.RequestID, inflightRequest{
-					pendingRequest: pending.(pendingRequest),
+					pendingRequest: pending,
 					agent:          agent,
+					startedAt:      time.Now(),
 				})
 				err := stream.Send(req)
 				if err != nil {
@@ -233,10 +324,7 @@ func (b *Broker) handleAgentStream(
 				return
 			}
 
-			select {
-			case token := <-agent.LockedTokens:
-				agent.AvailableTokens <- token
-			default:
+			if !agent.ReturnToken() {
 				b.lg.With(
 					types.ShortID(agent.UUID),
 				).DPanic("Token Imbalance")
@@ -245,6 +333,7 @@ func (b *Broker) handleAgentStream(
 			b.responseQueue <- resp
 		}
 	}()
+	return sendDone
 }
 
 func (b *Broker) routeNewRequest(
@@ -254,24 +343,115 @@ func (b *Broker) routeNewRequest(
 	ifRouteFails types.RetryAction,
 ) {
 	b.requestCount.Inc()
-	b.pendingRequests.Store(req.RequestID, pendingRequest{
-		request:   req,
-		requester: cd,
-	})
-	if err := b.router.Route(ctx, req); err != nil {
+	if req.Tenant == "" {
+		// Requests without a tenant share agents fairly between consumerds
+		req.Tenant = cd.UUID
+	}
+	pr := pendingRequest{
+		request:      req,
+		requester:    cd,
+		ifRouteFails: ifRouteFails,
+		queuedAt:     time.Now(),
+	}
+	hash := b.hashSrv.Hash(req)
+	req.ManagedFields = &types.CompileRequestManaged{
+		ComputedHash: hash,
+	}
+	if b.joinFlight(hash, pr) {
+		b.coalescedCount.Inc()
+		b.lg.With(
+			zap.String("request", req.GetRequestID()),
+			"hash", types.FormatShortID(hash, 6, types.ElideCenter),
+		).Debug("Coalescing request with an identical in-flight request")
+		return
+	}
+	if err := b.dispatch(ctx, pr, ifRouteFails); err != nil {
+		b.requestCount.Dec()
+	}
+}
+
+// joinFlight adds the request to the flight for the given hash if there is
+// one, in which case it will receive the response sent to the flight's
+// leader. Otherwise, a new flight is started with the request as its leader.
+// Returns true if the request joined an existing flight.
+func (b *Broker) joinFlight(hash string, pr pendingRequest) bool {
+	b.flightsMutex.Lock()
+	defer b.flightsMutex.Unlock()
+	if f, ok := b.flights[hash]; ok {
+		f.followers = append(f.followers, pr)
+		return true
+	}
+	b.flights[hash] = &flight{
+		leader: pr.request.GetRequestID(),
+	}
+	return false
+}
+
+// landFlight ends the flight led by the given request, and returns the
+// requests that were waiting on its result.
+func (b *Broker) landFlight(request *types.CompileRequest) []pendingRequest {
+	hash := request.GetManagedFields().GetComputedHash()
+	b.flightsMutex.Lock()
+	defer b.flightsMutex.Unlock()
+	f, ok := b.flights[hash]
+	if !ok || f.leader != request.GetRequestID() {
+		return nil
+	}
+	delete(b.flights, hash)
+	return f.followers
+}
+
+// notifyFollowers sends the response for a flight's leader to each of the
+// requests waiting on it. Compile results are only shared if the compile
+// actually ran, otherwise the followers are routed individually using their
+// own retry action.
+func (b *Broker) notifyFollowers(
+	followers []pendingRequest,
+	resp *types.CompileResponse,
+) {
+	for _, follower := range followers {
+		switch resp.CompileResult {
+		case types.CompileResponse_Success, types.CompileResponse_Fail:
+		default:
+			go b.dispatch(follower.requester.Stream.Context(), follower,
+				follower.ifRouteFails)
+			continue
+		}
+		fresp := proto.Clone(resp).(*types.CompileResponse)
+		fresp.RequestID = follower.request.GetRequestID()
+		follower.requester.CompletedTasks.Inc()
+		if err := follower.requester.Stream.Send(fresp); err != nil {
+			b.lg.With(
+				zap.Error(err),
+			).Error("Error sending response")
+		}
+	}
+}
+
+// dispatch routes a pending request to an agent. If the request could not be
+// routed, a Retry response with the given action is sent to the consumerd
+// and the routing error is returned.
+func (b *Broker) dispatch(
+	ctx context.Context,
+	pr pendingRequest,
+	ifRouteFails types.RetryAction,
+) error {
+	req := pr.request
+	b.pendingRequests.Store(req.RequestID, pr)
+	err := b.router.Route(ctx, req, pr.requester.Topology())
+	if err != nil {
 		b.lg.With(
 			zap.Error(err),
 		).Warn("Failed to route compile request")
 		pending, ok := b.pendingRequests.LoadAndDelete(req.RequestID)
 		if !ok {
 			b.lg.DPanic("Tried to load a nonexistent task")
-			return
+			return err
 		}
 		b.inflightRequests.Store(req.RequestID, inflightRequest{
 			pendingRequest: pending.(pendingRequest),
 			agent:          nil,
 		})
-		b.requestCount.Dec()
 		b.responseQueue <- &types.CompileResponse{
 			RequestID:     req.GetRequestID(),
 			CompileResult: types.CompileResponse_Retry,
@@ -280,6 +460,23 @@ func (b *Broker) routeNewRequest(
 			},
 		}
 	}
+	return err
+}
+
+// handleOrphanedRequest routes a request again if the agent it was routed to
+// became unavailable before the request could be sent to it.
+func (b *Broker) handleOrphanedRequest(req *types.CompileRequest) {
+	value, ok := b.pendingRequests.LoadAndDelete(req.RequestID)
+	if !ok {
+		b.lg.DPanic("Orphaned request is not pending")
+		return
+	}
+	pending := value.(pendingRequest)
+	b.lg.With(
+		zap.String("request", req.GetRequestID()),
+	).Debug("Re-routing orphaned request")
+	go b.dispatch(pending.requester.Stream.Context(), pending,
+		types.RetryAction_DoNotRetry)
 }
 
 func (b *Broker) handleConsumerdStream(
@@ -326,18 +523,34 @@ func (b *Broker) handleResponseQueue() {
 				b.failedTasks.Inc()
 			case types.CompileResponse_Success:
 				b.completedTasks.Inc()
-				if managed := request.GetManagedFields(); managed != nil {
-					go b.cacheTransaction(managed.GetComputedHash(), resp)
+				if ir.agent != nil && b.cacheClient != nil && b.cacheAvailable.Load() {
+					go b.cacheTransaction(request, resp)
 				}
 			case types.CompileResponse_Defunct:
-				// Try to requeue the defunct task
+				if ir.attempts < MaxDispatchAttempts {
+					// Try to send the defunct task to a different agent. If there are
+					// no other agents that can run it, the consumerd will run it
+					// locally instead.
+					b.lg.With(
+						zap.String("request", resp.GetRequestID()),
+						zap.String("error", resp.GetError()),
+						zap.Int("attempt", ir.attempts),
+					).Warn("Requeueing defunct task")
+					go b.dispatch(consumerd.Stream.Context(), ir.pendingRequest,
+						types.RetryAction_DoNotRetry)
+					continue
+				}
 				b.lg.With(
 					zap.String("request", resp.GetRequestID()),
 					zap.String("error", resp.GetError()),
-				).Warn("Requeueing defunct task")
-				go b.routeNewRequest(consumerd.Stream.Context(), consumerd, request,
-					types.RetryAction_Retry)
-				continue
+				).Warn("Defunct task exceeded max attempts, it will be run locally")
+				resp = &types.CompileResponse{
+					RequestID:     resp.GetRequestID(),
+					CompileResult: types.CompileResponse_Retry,
+					Data: &types.CompileResponse_RetryAction{
+						RetryAction: types.RetryAction_DoNotRetry,
+					},
+				}
 			}
 			b.lg.With(
 				zap.String("request", resp.RequestID),
@@ -349,6 +562,7 @@ func (b *Broker) handleResponseQueue() {
 					zap.Error(err),
 				).Error("Error sending response")
 			}
+			b.notifyFollowers(b.landFlight(request), resp)
 		} else {
 			b.lg.With(
 				"id", resp.RequestID,
@@ -361,8 +575,7 @@ func (b *Broker) cleanupAgent(a *Agent) {
 	a.Lock()
 	defer a.Unlock()
 	// If the agent is gone, any tasks it was working on are now defunct.
-	// We can look at its locked tokens which will contain request IDs, and
-	// re-queue or cancel those requests.
+	// They will be sent to another agent if possible (see handleResponseQueue).
 	b.inflightRequests.Range(func(key, value interface{}) bool {
 		req := value.(inflightRequest)
 		if req.agent == nil {
@@ -416,10 +629,13 @@ func (b *Broker) NewAgentTaskStream(
 	b.agentsMutex.Unlock()
 
 	filterOutput := b.router.AddReceiver(agent)
-	b.handleAgentStream(stream, filterOutput)
+	sendDone := b.handleAgentStream(stream, filterOutput)
 
 	go func() {
 		<-streamCtx.Done()
+		// Wait until no more requests can be sent to the agent, so that all
+		// of its in-flight requests can be found
+		<-sendDone
 
 		b.agentsMutex.Lock()
 		defer b.agentsMutex.Unlock()
@@ -543,7 +759,67 @@ func (b *Broker) TaskStats() taskStats {
 		requestsTotal: &metrics.SchedulingRequestsTotal{
 			Total: b.requestCount.Load(),
 		},
+		coalescedTotal: &metrics.CoalescedRequestsTotal{
+			Total: b.coalescedCount.Load(),
+		},
+	}
+}
+
+// Tasks returns the requests that are waiting to be sent to an agent, running
+// on an agent, or waiting on an identical running request, ordered by the time
+// they were received. Requests that change state while the list is being
+// built may be omitted.
+func (b *Broker) Tasks() []*types.TaskInfo {
+	type task struct {
+		info     *types.TaskInfo
+		queuedAt time.Time
+	}
+	now := time.Now()
+	tasks := []task{}
+	seen := map[string]struct{}{}
+	b.inflightRequests.Range(func(key, value interface{}) bool {
+		ir := value.(inflightRequest)
+		if ir.agent == nil {
+			// A response has already been queued for this request
+			return true
+		}
+		info := ir.taskInfo(types.TaskRunning, ir.startedAt)
+		info.Agent = ir.agent.UUID
+		info.ElapsedTime = int64(now.Sub(ir.startedAt))
+		tasks = append(tasks, task{info: info, queuedAt: ir.queuedAt})
+		seen[key.(string)] = struct{}{}
+		return true
+	})
+	b.pendingRequests.Range(func(key, value interface{}) bool {
+		if _, ok := seen[key.(string)]; ok {
+			return true
+		}
+		pr := value.(pendingRequest)
+		tasks = append(tasks, task{
+			info:     pr.taskInfo(types.TaskPending, now),
+			queuedAt: pr.queuedAt,
+		})
+		return true
+	})
+	b.flightsMutex.Lock()
+	for _, f := range b.flights {
+		for _, pr := range f.followers {
+			tasks = append(tasks, task{
+				info:     pr.taskInfo(types.TaskCoalesced, now),
+				queuedAt: pr.queuedAt,
+			})
+		}
+	}
+	b.flightsMutex.Unlock()
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].queuedAt.Before(tasks[j].queuedAt)
+	})
+	infos := make([]*types.TaskInfo, len(tasks))
+	for i, t := range tasks {
+		infos[i] = t.info
 	}
+	return infos
 }
 
 func (b *Broker) PreReceive(
@@ -564,12 +840,24 @@ func (b *Broker) PreReceive(
 		}
 	}(&action)
 
-	reqHash := b.hashSrv.Hash(req)
+	reqHash := req.GetManagedFields().GetComputedHash()
+	if reqHash == "" {
+		reqHash = b.hashSrv.Hash(req)
+	}
 	obj, err := b.cacheClient.Pull(b.srvContext, &types.PullRequest{
 		Key: &types.CacheKey{
 			Hash: reqHash,
 		},
 	})
+	var output *types.CompileOutput
+	if err == nil {
+		if output, err = storage.DecodeCompileOutput(obj); err != nil {
+			b.lg.With(
+				zap.Error(err),
+			).Warn("Cache entry is not a valid compile output")
+			err = status.Error(codes.NotFound, err.Error())
+		}
+	}
 	switch status.Code(err) {
 	case codes.OK:
 		value, ok := b.pendingRequests.LoadAndDelete(req.GetRequestID())
@@ -586,15 +874,22 @@ func (b *Broker) PreReceive(
 			RequestID:     req.GetRequestID(),
 			CompileResult: types.CompileResponse_Success,
 			Data: &types.CompileResponse_CompiledSource{
-				CompiledSource: obj.GetData(),
+				CompiledSource: output.GetCompiledSource(),
 			},
+			Stdout: output.GetStdout(),
+			Stderr: output.GetStderr(),
+			Digest: output.GetDigest(),
 		}
 		action = RequestIntercepted
 		return
-	case codes.NotFound:
+	case codes.NotFound, codes.DataLoss:
+		// A corrupt entry is treated as a miss, so that it will be replaced
+		// by the result of this request.
 		b.lg.Debug("Cache entry not found")
-		req.ManagedFields = &types.CompileRequestManaged{
-			ComputedHash: reqHash,
+		if req.ManagedFields == nil {
+			req.ManagedFields = &types.CompileRequestManaged{
+				ComputedHash: reqHash,
+			}
 		}
 	default:
 		b.lg.With(
@@ -606,21 +901,42 @@ func (b *Broker) PreReceive(
 }
 
 func (b *Broker) cacheTransaction(
-	requestHash string,
+	request *types.CompileRequest,
 	resp *types.CompileResponse,
 ) {
+	requestHash := request.GetManagedFields().GetComputedHash()
 	if requestHash == "" {
 		b.lg.Warn("Tried to cache transaction with empty request hash")
 		return
 	}
-	_, err := b.cacheClient.Push(b.srvContext, &types.PushRequest{
+	// Compiler output is stored along with the object so that warnings are
+	// not lost on a cache hit.
+	data, err := storage.EncodeCompileOutput(&types.CompileOutput{
+		CompiledSource: resp.GetCompiledSource(),
+		Stdout:         resp.GetStdout(),
+		Stderr:         resp.GetStderr(),
+		Digest:         resp.GetDigest(),
+	})
+	if err != nil {
+		b.lg.With(
+			zap.Error(err),
+		).Error("Error encoding compile output")
+		return
+	}
+	kind := request.GetToolchain().GetKind()
+	_, err = b.cacheClient.Push(b.srvContext, &types.PushRequest{
 		Key: &types.CacheKey{
 			Hash: requestHash,
 		},
 		Object: &types.CacheObject{
-			Data: resp.GetCompiledSource(),
+			Data: data,
+			// The expiration date is left unset so that it is determined by the
+			// retention policy of the cache server.
 			Metadata: &types.CacheObjectMeta{
-				ExpirationDate: time.Now().Add(1 * time.Hour).UnixNano(),
+				Tags: map[string]string{
+					storage.ToolchainTag: storage.ToolchainName(kind),
+					storage.FormatTag:    storage.CompileOutputFormat,
+				},
 			},
 		},
 	})
@@ -644,6 +960,9 @@ func (b *Broker) calcPreferredUsageLimits() (total int64) {
 	b.agentsMutex.RLock()
 	defer b.agentsMutex.RUnlock()
 	for _, a := range b.agents {
+		if a.Cordoned() {
+			continue
+		}
 		total += int64(a.remoteInfo.UsageLimits.ConcurrentProcessLimit)
 	}
 	return
@@ -24,17 +24,22 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/kubecc-io/kubecc/internal/logkc"
 	"github.com/kubecc-io/kubecc/pkg/host"
 	"github.com/kubecc-io/kubecc/pkg/identity"
 	"github.com/kubecc-io/kubecc/pkg/meta"
 	"github.com/kubecc-io/kubecc/pkg/metrics"
+	"github.com/kubecc-io/kubecc/pkg/storage"
 	"github.com/kubecc-io/kubecc/pkg/test/mock_types"
 	"github.com/kubecc-io/kubecc/pkg/tracing"
 	"github.com/kubecc-io/kubecc/pkg/types"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gmeasure"
 	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
 var (
@@ -277,3 +282,257 @@ Sequence 2:
 		AddReportEntry(experiment.Name, experiment)
 	})
 })
+
+var _ = Describe("Broker cache hook", func() {
+	It("should return compiler output along with cached objects", func() {
+		ctrl = gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+
+		ctx := makeCtx(types.Scheduler)
+		cacheClient := mock_types.NewMockCacheClient(ctrl)
+		broker := NewBroker(ctx, mockTcWatcher{}, CacheClient(cacheClient))
+		broker.cacheAvailable.Store(true)
+
+		var pushed *types.CacheObject
+		cacheClient.EXPECT().
+			Push(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *types.PushRequest, _ ...grpc.CallOption) (*types.Empty, error) {
+				pushed = req.Object
+				return &types.Empty{}, nil
+			})
+		hashed := proto.Clone(sample_req1).(*types.CompileRequest)
+		hashed.ManagedFields = &types.CompileRequestManaged{
+			ComputedHash: "hash",
+		}
+		broker.cacheTransaction(hashed, &types.CompileResponse{
+			RequestID:     sample_req1.RequestID,
+			CompileResult: types.CompileResponse_Success,
+			Data: &types.CompileResponse_CompiledSource{
+				CompiledSource: []byte("object"),
+			},
+			Stdout: []byte("stdout"),
+			Stderr: []byte("warning: test"),
+		})
+		Expect(pushed).NotTo(BeNil())
+
+		cacheClient.EXPECT().
+			Pull(gomock.Any(), gomock.Any()).
+			Return(pushed, nil)
+		cdCtx := makeCtx(types.Consumerd)
+		stream := mock_types.NewMockScheduler_StreamOutgoingTasksServer(ctrl)
+		received := make(chan *types.CompileResponse, 1)
+		stream.EXPECT().
+			Send(gomock.Any()).
+			DoAndReturn(func(resp *types.CompileResponse) error {
+				received <- resp
+				return nil
+			})
+		cd := &Consumerd{
+			remoteInfo: remoteInfoFromContext(cdCtx),
+			RWMutex:    &sync.RWMutex{},
+			Stream:     stream,
+		}
+		req := proto.Clone(sample_req1).(*types.CompileRequest)
+		broker.pendingRequests.Store(req.RequestID, pendingRequest{
+			request:   req,
+			requester: cd,
+		})
+		Expect(broker.PreReceive(nil, req)).To(Equal(RequestIntercepted))
+
+		var resp *types.CompileResponse
+		Eventually(received).Should(Receive(&resp))
+		Expect(resp.CompileResult).To(Equal(types.CompileResponse_Success))
+		Expect(resp.GetCompiledSource()).To(BeEquivalentTo("object"))
+		Expect(resp.GetStdout()).To(BeEquivalentTo("stdout"))
+		Expect(resp.GetStderr()).To(BeEquivalentTo("warning: test"))
+	})
+	It("should leave the expiration date to the cache's retention policy", func() {
+		ctrl = gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+
+		ctx := makeCtx(types.Scheduler)
+		cacheClient := mock_types.NewMockCacheClient(ctrl)
+		broker := NewBroker(ctx, mockTcWatcher{}, CacheClient(cacheClient))
+
+		pushed := make(chan *types.CacheObject, 2)
+		cacheClient.EXPECT().
+			Push(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *types.PushRequest, _ ...grpc.CallOption) (*types.Empty, error) {
+				pushed <- req.Object
+				return &types.Empty{}, nil
+			}).Times(2)
+		for _, req := range []*types.CompileRequest{sample_req1, sample_req2} {
+			hashed := proto.Clone(req).(*types.CompileRequest)
+			hashed.ManagedFields = &types.CompileRequestManaged{
+				ComputedHash: req.RequestID,
+			}
+			broker.cacheTransaction(hashed, sample_resp1)
+		}
+		clangObj := <-pushed
+		gnuObj := <-pushed
+		Expect(clangObj.Metadata.Tags).To(HaveKeyWithValue(storage.ToolchainTag, "clang"))
+		Expect(gnuObj.Metadata.Tags).To(HaveKeyWithValue(storage.ToolchainTag, "gnu"))
+		Expect(clangObj.Metadata.ExpirationDate).To(BeZero())
+		Expect(gnuObj.Metadata.ExpirationDate).To(BeZero())
+	})
+})
+
+var _ = Describe("Broker request coalescing", func() {
+	var broker *Broker
+	var leader, follower pendingRequest
+	var leaderC, followerC chan *types.CompileResponse
+
+	makeConsumerd := func(received chan *types.CompileResponse) *Consumerd {
+		cdCtx := makeCtx(types.Consumerd)
+		stream := mock_types.NewMockScheduler_StreamOutgoingTasksServer(ctrl)
+		stream.EXPECT().Context().Return(cdCtx).AnyTimes()
+		stream.EXPECT().
+			Send(gomock.Any()).
+			DoAndReturn(func(resp *types.CompileResponse) error {
+				received <- resp
+				return nil
+			}).AnyTimes()
+		return &Consumerd{
+			remoteInfo: remoteInfoFromContext(cdCtx),
+			RWMutex:    &sync.RWMutex{},
+			Stream:     stream,
+		}
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		broker = NewBroker(makeCtx(types.Scheduler), mockTcWatcher{})
+		leaderC = make(chan *types.CompileResponse, 1)
+		followerC = make(chan *types.CompileResponse, 1)
+		leader = pendingRequest{
+			request:   proto.Clone(sample_req1).(*types.CompileRequest),
+			requester: makeConsumerd(leaderC),
+		}
+		follower = pendingRequest{
+			request:      proto.Clone(sample_req1).(*types.CompileRequest),
+			requester:    makeConsumerd(followerC),
+			ifRouteFails: types.RetryAction_Retry,
+		}
+		follower.request.RequestID = uuid.NewString()
+
+		hash := broker.hashSrv.Hash(leader.request)
+		for _, pr := range []pendingRequest{leader, follower} {
+			pr.request.ManagedFields = &types.CompileRequestManaged{
+				ComputedHash: hash,
+			}
+		}
+		Expect(broker.joinFlight(hash, leader)).To(BeFalse())
+		Expect(broker.joinFlight(hash, follower)).To(BeTrue())
+		broker.inflightRequests.Store(leader.request.RequestID, inflightRequest{
+			pendingRequest: leader,
+		})
+	})
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should send the leader's result to coalesced requests", func() {
+		broker.responseQueue <- &types.CompileResponse{
+			RequestID:     leader.request.RequestID,
+			CompileResult: types.CompileResponse_Success,
+			Data: &types.CompileResponse_CompiledSource{
+				CompiledSource: []byte("object"),
+			},
+		}
+		var resp *types.CompileResponse
+		Eventually(leaderC).Should(Receive(&resp))
+		Expect(resp.RequestID).To(Equal(leader.request.RequestID))
+		Eventually(followerC).Should(Receive(&resp))
+		Expect(resp.RequestID).To(Equal(follower.request.RequestID))
+		Expect(resp.CompileResult).To(Equal(types.CompileResponse_Success))
+		Expect(resp.GetCompiledSource()).To(BeEquivalentTo("object"))
+
+		By("starting a new flight once the leader's result is received")
+		Expect(broker.joinFlight(
+			leader.request.ManagedFields.ComputedHash, leader)).To(BeFalse())
+	})
+	It("should route coalesced requests individually if the leader did not run", func() {
+		broker.responseQueue <- &types.CompileResponse{
+			RequestID:     leader.request.RequestID,
+			CompileResult: types.CompileResponse_Retry,
+			Data: &types.CompileResponse_RetryAction{
+				RetryAction: types.RetryAction_DoNotRetry,
+			},
+		}
+		var resp *types.CompileResponse
+		Eventually(leaderC).Should(Receive(&resp))
+		Expect(resp.CompileResult).To(Equal(types.CompileResponse_Retry))
+		// There are no agents, so the follower will also be told to retry,
+		// using its own retry action
+		Eventually(followerC).Should(Receive(&resp))
+		Expect(resp.RequestID).To(Equal(follower.request.RequestID))
+		Expect(resp.CompileResult).To(Equal(types.CompileResponse_Retry))
+		Expect(resp.GetRetryAction()).To(Equal(types.RetryAction_Retry))
+	})
+})
+
+var _ = Describe("Broker task list", func() {
+	It("should list pending, running, and coalesced tasks", func() {
+		broker := NewBroker(makeCtx(types.Scheduler), mockTcWatcher{})
+		cd := &Consumerd{
+			remoteInfo: remoteInfoFromContext(makeCtx(types.Consumerd)),
+			RWMutex:    &sync.RWMutex{},
+		}
+		agent := &Agent{
+			remoteInfo: remoteInfoFromContext(makeCtx(types.Agent)),
+			RWMutex:    &sync.RWMutex{},
+		}
+		newRequest := func(age time.Duration) pendingRequest {
+			req := proto.Clone(sample_req1).(*types.CompileRequest)
+			req.RequestID = uuid.NewString()
+			return pendingRequest{
+				request:   req,
+				requester: cd,
+				queuedAt:  time.Now().Add(-age),
+			}
+		}
+		running := newRequest(3 * time.Second)
+		running.attempts = 1
+		pending := newRequest(2 * time.Second)
+		follower := newRequest(1 * time.Second)
+		retrying := newRequest(0)
+
+		broker.inflightRequests.Store(running.request.RequestID, inflightRequest{
+			pendingRequest: running,
+			agent:          agent,
+			startedAt:      running.queuedAt.Add(time.Second),
+		})
+		// Requests with a queued response are not listed
+		broker.inflightRequests.Store(retrying.request.RequestID, inflightRequest{
+			pendingRequest: retrying,
+		})
+		broker.pendingRequests.Store(pending.request.RequestID, pending)
+		Expect(broker.joinFlight("hash", running)).To(BeFalse())
+		Expect(broker.joinFlight("hash", follower)).To(BeTrue())
+
+		tasks := broker.Tasks()
+		Expect(tasks).To(HaveLen(3))
+
+		Expect(tasks[0].RequestID).To(Equal(running.request.RequestID))
+		Expect(tasks[0].State).To(Equal(types.TaskRunning))
+		Expect(tasks[0].Consumerd).To(Equal(cd.UUID))
+		Expect(tasks[0].Agent).To(Equal(agent.UUID))
+		Expect(tasks[0].Attempts).To(BeEquivalentTo(1))
+		Expect(proto.Equal(tasks[0].Toolchain, sample_req1.Toolchain)).To(BeTrue())
+		Expect(time.Duration(tasks[0].QueueTime)).To(Equal(time.Second))
+		Expect(time.Duration(tasks[0].ElapsedTime)).
+			To(BeNumerically("~", 2*time.Second, 100*time.Millisecond))
+
+		Expect(tasks[1].RequestID).To(Equal(pending.request.RequestID))
+		Expect(tasks[1].State).To(Equal(types.TaskPending))
+		Expect(tasks[1].Agent).To(BeEmpty())
+		Expect(time.Duration(tasks[1].QueueTime)).
+			To(BeNumerically("~", 2*time.Second, 100*time.Millisecond))
+		Expect(tasks[1].ElapsedTime).To(BeZero())
+
+		Expect(tasks[2].RequestID).To(Equal(follower.request.RequestID))
+		Expect(tasks[2].State).To(Equal(types.TaskCoalesced))
+		Expect(time.Duration(tasks[2].QueueTime)).
+			To(BeNumerically("~", 1*time.Second, 100*time.Millisecond))
+	})
+})
@@ -0,0 +1,163 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package scheduler_test
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/atomic"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kubecc-io/kubecc/pkg/agent"
+	"github.com/kubecc-io/kubecc/pkg/consumerd"
+	"github.com/kubecc-io/kubecc/pkg/meta"
+	"github.com/kubecc-io/kubecc/pkg/metrics"
+	"github.com/kubecc-io/kubecc/pkg/test"
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+var _ = Describe("Agent Drain", func() {
+	var testEnv test.Environment
+	var schedClient types.SchedulerClient
+	var cdClient types.ConsumerdClient
+	var agentID string
+
+	drain := func(action types.DrainAction) ([]*types.DrainAgentProgress, error) {
+		stream, err := schedClient.DrainAgent(testEnv.Context(), &types.DrainAgentRequest{
+			UUID:   agentID,
+			Action: action,
+		})
+		if err != nil {
+			return nil, err
+		}
+		progress := []*types.DrainAgentProgress{}
+		for {
+			p, err := stream.Recv()
+			if err == io.EOF {
+				return progress, nil
+			}
+			if err != nil {
+				return progress, err
+			}
+			progress = append(progress, p)
+		}
+	}
+	runningTasks := func() int32 {
+		// Uncordoning an agent that is not cordoned only reports its status
+		progress, err := drain(types.Uncordon)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(progress).To(HaveLen(1))
+		return progress[0].RunningTasks
+	}
+
+	Specify("setup", func() {
+		testEnv = test.NewBufconnEnvironmentWithLogLevel(zapcore.ErrorLevel)
+		test.SpawnMonitor(testEnv)
+		test.SpawnScheduler(testEnv, test.WaitForReady())
+		ctx, _ := test.SpawnAgent(testEnv, test.WaitForReady(), test.WithAgentOptions(
+			agent.WithUsageLimits(&metrics.UsageLimits{
+				ConcurrentProcessLimit: 10,
+			}),
+		))
+		agentID = meta.UUID(ctx)
+		test.SpawnConsumerd(testEnv, test.WithName("drain"), test.WaitForReady(),
+			test.WithConsumerdOptions(
+				consumerd.WithQueueOptions(
+					consumerd.WithLocalUsageManager(consumerd.FixedUsageLimits(1)),
+					consumerd.WithRemoteUsageManager(consumerd.FixedUsageLimits(10)),
+				),
+			))
+		schedClient = test.NewSchedulerClient(testEnv, testEnv.Context())
+		cdClient = test.NewConsumerdClient(testEnv, testEnv.Context(), "drain")
+	})
+
+	It("should return an error for unknown agents", func() {
+		stream, err := schedClient.DrainAgent(testEnv.Context(), &types.DrainAgentRequest{
+			UUID:   "nonexistent",
+			Action: types.Cordon,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = stream.Recv()
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+
+	It("should cordon and uncordon agents", func() {
+		progress, err := drain(types.Cordon)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(progress).To(HaveLen(1))
+		Expect(progress[0].UUID).To(Equal(agentID))
+		Expect(progress[0].Cordoned).To(BeTrue())
+
+		progress, err = drain(types.Uncordon)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(progress).To(HaveLen(1))
+		Expect(progress[0].Cordoned).To(BeFalse())
+		Expect(progress[0].Drained).To(BeFalse())
+	})
+
+	It("should wait for running tasks to complete when draining", func() {
+		numTasks := 3
+		completed := atomic.NewInt32(0)
+		for i := 0; i < numTasks; i++ {
+			// Use distinct durations so that the requests are not coalesced
+			duration := fmt.Sprintf("%dms", 500+i)
+			go func() {
+				defer GinkgoRecover()
+				resp, err := cdClient.Run(testEnv.Context(), &types.RunRequest{
+					Compiler: &types.RunRequest_Path{Path: test.TestToolchainExecutable},
+					Args:     []string{"-sleep", duration},
+					UID:      1000,
+					GID:      1000,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.ReturnCode).To(BeEquivalentTo(0))
+				completed.Inc()
+			}()
+		}
+		Eventually(runningTasks, 2*time.Second, 10*time.Millisecond).
+			Should(BeNumerically(">", 0))
+
+		progress, err := drain(types.Drain)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(len(progress)).To(BeNumerically(">=", 2))
+		Expect(progress[0].Cordoned).To(BeTrue())
+		Expect(progress[0].RunningTasks).To(BeNumerically(">", 0))
+		last := progress[len(progress)-1]
+		Expect(last.Drained).To(BeTrue())
+		Expect(last.RunningTasks).To(BeEquivalentTo(0))
+
+		// Tasks that could not be scheduled while the agent was cordoned are
+		// run locally by the consumerd
+		Eventually(completed.Load, 5*time.Second, 10*time.Millisecond).
+			Should(BeEquivalentTo(numTasks))
+
+		progress, err = drain(types.Uncordon)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(progress[0].Cordoned).To(BeFalse())
+	})
+
+	Specify("shutdown", func() {
+		testEnv.Shutdown()
+	})
+})
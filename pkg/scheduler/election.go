@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubecc-io/kubecc/pkg/cluster"
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// An Elector decides which of several scheduler replicas is the leader.
+// Only the leader accepts agent and consumerd streams.
+type Elector interface {
+	// Run blocks until ctx is done. Each time this replica becomes the
+	// leader, lead is called with a context which is canceled when
+	// leadership is lost. Run continues trying to become the leader again
+	// after lead returns.
+	Run(ctx context.Context, lead func(context.Context))
+}
+
+const DefaultLeaseName = "kubecc-scheduler"
+
+// LeaseElector is an Elector which uses a Kubernetes Lease object.
+type LeaseElector struct {
+	client        kubernetes.Interface
+	name          string
+	namespace     string
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+}
+
+// NewLeaseElector creates a LeaseElector using the in-cluster Kubernetes
+// configuration. Unset fields in the spec use the same defaults as
+// controller-runtime.
+func NewLeaseElector(spec config.LeaderElectionSpec) (*LeaseElector, error) {
+	e := &LeaseElector{
+		name:          spec.LeaseName,
+		namespace:     spec.LeaseNamespace,
+		leaseDuration: 15 * time.Second,
+		renewDeadline: 10 * time.Second,
+		retryPeriod:   2 * time.Second,
+	}
+	if e.name == "" {
+		e.name = DefaultLeaseName
+	}
+	if e.namespace == "" {
+		if !cluster.InCluster() {
+			return nil, fmt.Errorf("lease namespace is required outside of a cluster")
+		}
+		e.namespace = cluster.GetNamespace()
+	}
+	for _, d := range []struct {
+		value string
+		dest  *time.Duration
+		name  string
+	}{
+		{spec.LeaseDuration, &e.leaseDuration, "lease duration"},
+		{spec.RenewDeadline, &e.renewDeadline, "renew deadline"},
+		{spec.RetryPeriod, &e.retryPeriod, "retry period"},
+	} {
+		if d.value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(d.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", d.name, err)
+		}
+		*d.dest = parsed
+	}
+	if e.leaseDuration <= e.renewDeadline {
+		return nil, fmt.Errorf("lease duration must be greater than renew deadline")
+	}
+	if e.renewDeadline <= time.Duration(leaderelection.JitterFactor*float64(e.retryPeriod)) {
+		return nil, fmt.Errorf("renew deadline must be greater than %.1f times the retry period",
+			leaderelection.JitterFactor)
+	}
+
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	e.client, err = kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *LeaseElector) Run(ctx context.Context, lead func(context.Context)) {
+	lg := meta.Log(ctx)
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      e.name,
+			Namespace: e.namespace,
+		},
+		Client: e.client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: meta.UUID(ctx),
+		},
+	}
+	for ctx.Err() == nil {
+		le, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			LeaseDuration:   e.leaseDuration,
+			RenewDeadline:   e.renewDeadline,
+			RetryPeriod:     e.retryPeriod,
+			ReleaseOnCancel: true,
+			Name:            e.name,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: lead,
+				OnStoppedLeading: func() {},
+				OnNewLeader: func(identity string) {
+					lg.With("leader", identity).Info("Scheduler leader changed")
+				},
+			},
+		})
+		if err != nil {
+			// The configuration is validated in NewLeaseElector
+			panic(err)
+		}
+		le.Run(ctx)
+	}
+}
@@ -0,0 +1,400 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package scheduler
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/metrics"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"go.uber.org/atomic"
+)
+
+// DefaultClassWeights are the relative shares of agents given to each
+// priority class if none are configured.
+var DefaultClassWeights = map[types.PriorityClass]int{
+	types.Interactive: 8,
+	types.CI:          2,
+	types.Batch:       1,
+}
+
+// FairSharePolicy controls how agents are shared between requests when there
+// are more requests than agents can run at once. Requests are grouped by
+// priority class, and each class receives a share of agents proportional to
+// its weight. Within a class, each tenant receives an equal share.
+type FairSharePolicy struct {
+	// ClassWeights is the relative share of agents given to each priority
+	// class. Classes without a weight are given a weight of 1.
+	ClassWeights map[types.PriorityClass]int
+	// DefaultClass is the priority class of requests which do not have one.
+	DefaultClass types.PriorityClass
+}
+
+// DefaultFairSharePolicy returns the policy used if none is configured.
+func DefaultFairSharePolicy() FairSharePolicy {
+	weights := make(map[types.PriorityClass]int, len(DefaultClassWeights))
+	for class, weight := range DefaultClassWeights {
+		weights[class] = weight
+	}
+	return FairSharePolicy{
+		ClassWeights: weights,
+		DefaultClass: types.Interactive,
+	}
+}
+
+// NewFairSharePolicy creates a FairSharePolicy from its configuration.
+// Unset fields use the values from DefaultFairSharePolicy.
+func NewFairSharePolicy(spec config.SchedulingSpec) (FairSharePolicy, error) {
+	policy := DefaultFairSharePolicy()
+	for name, weight := range spec.ClassWeights {
+		class, err := types.ParsePriorityClass(name)
+		if err != nil || class == types.DefaultPriority {
+			return policy, fmt.Errorf("unknown priority class %q", name)
+		}
+		if weight <= 0 {
+			return policy, fmt.Errorf("weight for priority class %q must be positive", name)
+		}
+		policy.ClassWeights[class] = weight
+	}
+	if spec.DefaultClass != "" {
+		class, err := types.ParsePriorityClass(spec.DefaultClass)
+		if err != nil {
+			return policy, err
+		}
+		policy.DefaultClass = class
+	}
+	return policy, nil
+}
+
+func (p FairSharePolicy) weight(class types.PriorityClass) float64 {
+	if w, ok := p.ClassWeights[class]; ok && w > 0 {
+		return float64(w)
+	}
+	return 1
+}
+
+// queueMetrics tracks the number of waiting requests and the time spent
+// waiting in each priority class, across all queues that share it.
+type queueMetrics struct {
+	classes map[types.PriorityClass]*classMetrics
+}
+
+type classMetrics struct {
+	depth    *atomic.Int64
+	dequeued *atomic.Int64
+	waitTime *atomic.Duration
+}
+
+func newQueueMetrics() *queueMetrics {
+	m := &queueMetrics{
+		classes: make(map[types.PriorityClass]*classMetrics),
+	}
+	for _, class := range types.PriorityClasses {
+		m.classes[class] = &classMetrics{
+			depth:    atomic.NewInt64(0),
+			dequeued: atomic.NewInt64(0),
+			waitTime: atomic.NewDuration(0),
+		}
+	}
+	return m
+}
+
+// Stats returns the current queue statistics for each priority class.
+func (m *queueMetrics) Stats() *metrics.PriorityClassQueues {
+	stats := &metrics.PriorityClassQueues{
+		Classes: make(map[string]*metrics.PriorityClassQueue, len(m.classes)),
+	}
+	for class, cm := range m.classes {
+		stats.Classes[class.Name()] = &metrics.PriorityClassQueue{
+			QueueDepth:       cm.depth.Load(),
+			DequeuedTotal:    cm.dequeued.Load(),
+			WaitSecondsTotal: cm.waitTime.Load().Seconds(),
+		}
+	}
+	return stats
+}
+
+type queuedRequest struct {
+	req      request
+	origin   Topology
+	class    types.PriorityClass
+	tenant   string
+	enqueued time.Time
+	elem     *list.Element
+	dequeued chan struct{}
+}
+
+type tenantQueue struct {
+	vtime float64
+	items *list.List
+}
+
+type classQueue struct {
+	vtime   float64
+	depth   int
+	tenants map[string]*tenantQueue
+	// Virtual time of the tenant most recently dequeued from
+	tenantVtime float64
+}
+
+// A fairQueue holds requests waiting to be sent to an agent, and implements
+// weighted fair queueing between priority classes and between tenants
+// within each class. Each class and tenant is assigned a virtual time which
+// advances by the inverse of its weight each time one of its requests is
+// dequeued; the request from the class and tenant with the lowest virtual
+// time is dequeued first. Classes and tenants which were idle start at the
+// current virtual time, so they cannot save up credit while idle.
+type fairQueue struct {
+	mu      sync.Mutex
+	policy  FairSharePolicy
+	metrics *queueMetrics
+	classes map[types.PriorityClass]*classQueue
+	vtime   float64
+	signal  chan struct{}
+	closed  bool
+}
+
+func newFairQueue(policy FairSharePolicy, m *queueMetrics) *fairQueue {
+	q := &fairQueue{
+		policy:  policy,
+		metrics: m,
+		classes: make(map[types.PriorityClass]*classQueue),
+		signal:  make(chan struct{}),
+	}
+	for _, class := range types.PriorityClasses {
+		q.classes[class] = &classQueue{
+			tenants: make(map[string]*tenantQueue),
+		}
+	}
+	return q
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// An acceptFunc is used by Pop to decide whether a request can be dequeued.
+// If it cannot, the time at which it can be dequeued is returned instead,
+// or the zero time if it never can be.
+type acceptFunc func(item *queuedRequest, now time.Time) (bool, time.Time)
+
+// Push adds a request sent from the given origin to the queue. The returned
+// request's dequeued channel is closed when it is taken off the queue by Pop.
+func (q *fairQueue) Push(req request, origin Topology) *queuedRequest {
+	class := req.GetPriority()
+	if class == types.DefaultPriority {
+		class = q.policy.DefaultClass
+	}
+	if _, ok := q.classes[class]; !ok {
+		class = types.Interactive
+	}
+	item := &queuedRequest{
+		req:      req,
+		origin:   origin,
+		class:    class,
+		tenant:   req.GetTenant(),
+		enqueued: time.Now(),
+		dequeued: make(chan struct{}),
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	cq := q.classes[class]
+	if cq.depth == 0 {
+		cq.vtime = maxFloat(cq.vtime, q.vtime)
+	}
+	tq, ok := cq.tenants[item.tenant]
+	if !ok {
+		tq = &tenantQueue{
+			vtime: cq.tenantVtime,
+			items: list.New(),
+		}
+		cq.tenants[item.tenant] = tq
+	}
+	item.elem = tq.items.PushBack(item)
+	cq.depth++
+	q.metrics.classes[class].depth.Inc()
+
+	// Wake up any goroutines waiting in Pop
+	close(q.signal)
+	q.signal = make(chan struct{})
+	return item
+}
+
+// first returns the first request in the tenant queue which can be accepted,
+// and the earliest time at which any other request in the queue can be.
+func (tq *tenantQueue) first(
+	accept acceptFunc,
+	now time.Time,
+) (*queuedRequest, time.Time) {
+	if accept == nil {
+		return tq.items.Front().Value.(*queuedRequest), time.Time{}
+	}
+	var retry time.Time
+	for e := tq.items.Front(); e != nil; e = e.Next() {
+		item := e.Value.(*queuedRequest)
+		ok, at := accept(item, now)
+		if ok {
+			return item, retry
+		}
+		if !at.IsZero() && (retry.IsZero() || at.Before(retry)) {
+			retry = at
+		}
+	}
+	return nil, retry
+}
+
+// next removes and returns the next request which can be accepted. If there
+// is none, it returns nil and the earliest time at which a request can be
+// accepted, or the zero time if none can be. q.mu must be held.
+func (q *fairQueue) next(accept acceptFunc) (*queuedRequest, time.Time) {
+	now := time.Now()
+	var retry time.Time
+	var cq *classQueue
+	var class types.PriorityClass
+	var tq *tenantQueue
+	var item *queuedRequest
+	// PriorityClasses is ordered from highest to lowest priority, so ties
+	// are broken in favor of higher priority classes.
+	for _, c := range types.PriorityClasses {
+		candidate := q.classes[c]
+		if candidate.depth == 0 || (cq != nil && candidate.vtime >= cq.vtime) {
+			continue
+		}
+		var tenant string
+		var ctq *tenantQueue
+		var citem *queuedRequest
+		for name, t := range candidate.tenants {
+			if ctq != nil && (t.vtime > ctq.vtime ||
+				(t.vtime == ctq.vtime && name > tenant)) {
+				continue
+			}
+			first, at := t.first(accept, now)
+			if !at.IsZero() && (retry.IsZero() || at.Before(retry)) {
+				retry = at
+			}
+			if first != nil {
+				ctq, citem, tenant = t, first, name
+			}
+		}
+		if citem != nil {
+			cq, class, tq, item = candidate, c, ctq, citem
+		}
+	}
+	if item == nil {
+		return nil, retry
+	}
+
+	q.vtime = cq.vtime
+	cq.vtime += 1 / q.policy.weight(class)
+	cq.tenantVtime = tq.vtime
+	tq.vtime++
+
+	q.remove(item)
+	cm := q.metrics.classes[class]
+	cm.dequeued.Inc()
+	cm.waitTime.Add(time.Since(item.enqueued))
+	return item, time.Time{}
+}
+
+// remove removes the item from its tenant queue. q.mu must be held.
+func (q *fairQueue) remove(item *queuedRequest) *queuedRequest {
+	cq := q.classes[item.class]
+	tq := cq.tenants[item.tenant]
+	tq.items.Remove(item.elem)
+	item.elem = nil
+	if tq.items.Len() == 0 {
+		delete(cq.tenants, item.tenant)
+	}
+	cq.depth--
+	q.metrics.classes[item.class].depth.Dec()
+	return item
+}
+
+// Pop blocks until a request which can be accepted is available and returns
+// it, or returns false if the context is done or the queue is closed. If
+// accept is nil, all requests can be accepted.
+func (q *fairQueue) Pop(ctx context.Context, accept acceptFunc) (request, bool) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return nil, false
+		}
+		item, retry := q.next(accept)
+		if item != nil {
+			q.mu.Unlock()
+			close(item.dequeued)
+			return item.req, true
+		}
+		signal := q.signal
+		q.mu.Unlock()
+
+		var retryC <-chan time.Time
+		if !retry.IsZero() {
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(time.Until(retry))
+			retryC = timer.C
+		}
+		select {
+		case <-signal:
+		case <-retryC:
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+}
+
+// Remove removes a request from the queue before it is dequeued. It returns
+// false if the request has already been dequeued.
+func (q *fairQueue) Remove(item *queuedRequest) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if item.elem == nil {
+		return false
+	}
+	q.remove(item)
+	return true
+}
+
+// Close wakes up all goroutines waiting in Pop. Requests which are still
+// queued are left in the queue, and can be removed using Remove.
+func (q *fairQueue) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		close(q.signal)
+		q.signal = make(chan struct{})
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func queueRequest(class types.PriorityClass, tenant string) request {
+	return &types.CompileRequest{
+		RequestID: fmt.Sprintf("%s-%s", class.Name(), tenant),
+		Priority:  class,
+		Tenant:    tenant,
+	}
+}
+
+func popN(q *fairQueue, n int) []request {
+	reqs := make([]request, 0, n)
+	for i := 0; i < n; i++ {
+		req, ok := q.Pop(context.Background(), nil)
+		Expect(ok).To(BeTrue())
+		reqs = append(reqs, req)
+	}
+	return reqs
+}
+
+func countBy(reqs []request, key func(request) string) map[string]int {
+	counts := map[string]int{}
+	for _, req := range reqs {
+		counts[key(req)]++
+	}
+	return counts
+}
+
+func byClass(req request) string {
+	return req.GetPriority().Name()
+}
+
+func byTenant(req request) string {
+	return req.GetTenant()
+}
+
+var _ = Describe("Fair Queue", func() {
+	var q *fairQueue
+	var m *queueMetrics
+	BeforeEach(func() {
+		m = newQueueMetrics()
+		q = newFairQueue(DefaultFairSharePolicy(), m)
+	})
+	It("should share requests between classes according to their weights", func() {
+		for i := 0; i < 100; i++ {
+			q.Push(queueRequest(types.Batch, "nightly"), Topology{})
+			q.Push(queueRequest(types.CI, "ci"), Topology{})
+			q.Push(queueRequest(types.Interactive, "dev"), Topology{})
+		}
+		counts := countBy(popN(q, 110), byClass)
+		Expect(counts["interactive"]).To(BeNumerically("~", 80, 1))
+		Expect(counts["ci"]).To(BeNumerically("~", 20, 1))
+		Expect(counts["batch"]).To(BeNumerically("~", 10, 1))
+	})
+	It("should share requests equally between tenants in a class", func() {
+		for i := 0; i < 100; i++ {
+			q.Push(queueRequest(types.CI, "large"), Topology{})
+		}
+		for i := 0; i < 10; i++ {
+			q.Push(queueRequest(types.CI, "small"), Topology{})
+		}
+		counts := countBy(popN(q, 20), byTenant)
+		Expect(counts["small"]).To(Equal(10))
+		Expect(counts["large"]).To(Equal(10))
+	})
+	It("should not let idle classes build up credit", func() {
+		for i := 0; i < 100; i++ {
+			q.Push(queueRequest(types.Batch, "nightly"), Topology{})
+		}
+		popN(q, 50)
+		for i := 0; i < 100; i++ {
+			q.Push(queueRequest(types.Interactive, "dev"), Topology{})
+		}
+		counts := countBy(popN(q, 18), byClass)
+		Expect(counts["interactive"]).To(BeNumerically("~", 16, 1))
+		Expect(counts["batch"]).To(BeNumerically("~", 2, 1))
+	})
+	It("should assign the default class to requests without one", func() {
+		q = newFairQueue(FairSharePolicy{
+			ClassWeights: DefaultClassWeights,
+			DefaultClass: types.Batch,
+		}, m)
+		q.Push(queueRequest(types.DefaultPriority, "dev"), Topology{})
+		Expect(m.Stats().Classes["batch"].QueueDepth).To(BeEquivalentTo(1))
+	})
+	It("should track queue depth and wait time", func() {
+		item := q.Push(queueRequest(types.CI, "ci"), Topology{})
+		q.Push(queueRequest(types.CI, "ci"), Topology{})
+		stats := m.Stats()
+		Expect(stats.Classes["ci"].QueueDepth).To(BeEquivalentTo(2))
+
+		Expect(q.Remove(item)).To(BeTrue())
+		Expect(q.Remove(item)).To(BeFalse())
+		popN(q, 1)
+		stats = m.Stats()
+		Expect(stats.Classes["ci"].QueueDepth).To(BeEquivalentTo(0))
+		Expect(stats.Classes["ci"].DequeuedTotal).To(BeEquivalentTo(1))
+		Expect(stats.Classes["ci"].WaitSecondsTotal).To(BeNumerically(">", 0))
+	})
+	It("should stop waiting when the context is done or the queue is closed", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, ok := q.Pop(ctx, nil)
+		Expect(ok).To(BeFalse())
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, ok := q.Pop(context.Background(), nil)
+			Expect(ok).To(BeFalse())
+		}()
+		q.Close()
+		Eventually(done).Should(BeClosed())
+	})
+	It("should parse the fair share policy configuration", func() {
+		policy, err := NewFairSharePolicy(config.SchedulingSpec{
+			ClassWeights: map[string]int{
+				"batch": 4,
+			},
+			DefaultClass: "ci",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy.ClassWeights[types.Batch]).To(Equal(4))
+		Expect(policy.ClassWeights[types.Interactive]).To(Equal(8))
+		Expect(policy.DefaultClass).To(Equal(types.CI))
+
+		_, err = NewFairSharePolicy(config.SchedulingSpec{
+			ClassWeights: map[string]int{
+				"urgent": 4,
+			},
+		})
+		Expect(err).To(HaveOccurred())
+		_, err = NewFairSharePolicy(config.SchedulingSpec{
+			ClassWeights: map[string]int{
+				"ci": 0,
+			},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kubecc-io/kubecc/pkg/config"
+)
+
+// Topology describes where a consumerd or agent is running.
+type Topology struct {
+	Node string
+	Zone string
+}
+
+// Topology returns the node and zone reported by the remote component.
+func (ri *remoteInfo) Topology() Topology {
+	return Topology{
+		Node: ri.SystemInfo.GetNode(),
+		Zone: ri.SystemInfo.GetZone(),
+	}
+}
+
+type locality int
+
+const (
+	sameNode locality = iota
+	sameZone
+	remote
+)
+
+// localityOf returns how close an agent is to the consumerd a request was
+// sent from.
+func localityOf(agent, origin Topology) locality {
+	switch {
+	case origin.Node != "" && agent.Node == origin.Node:
+		return sameNode
+	case origin.Zone != "" && agent.Zone == origin.Zone:
+		return sameZone
+	default:
+		return remote
+	}
+}
+
+// DefaultLocalityPolicy is used if no locality policy is configured.
+var DefaultLocalityPolicy = LocalityPolicy{
+	RecheckInterval: 50 * time.Millisecond,
+}
+
+// LocalityPolicy controls how requests are sent to agents close to the
+// consumerd they were sent from. A request is only sent to an agent in
+// another zone if no agents in its consumerd's zone have free tokens, and
+// only sent to an agent on another node if no agents on its consumerd's node
+// have free tokens.
+type LocalityPolicy struct {
+	// RecheckInterval is how often a request held back from an agent is
+	// reconsidered. Closer agents with free tokens normally take the request
+	// right away, so this only limits how long a request can be held back
+	// after they have become busy.
+	RecheckInterval time.Duration
+}
+
+// NewLocalityPolicy creates a LocalityPolicy from its configuration. Unset
+// fields use the values from DefaultLocalityPolicy.
+func NewLocalityPolicy(spec config.LocalitySpec) (LocalityPolicy, error) {
+	policy := DefaultLocalityPolicy
+	if spec.RecheckInterval != "" {
+		d, err := time.ParseDuration(spec.RecheckInterval)
+		if err != nil {
+			return policy, fmt.Errorf("invalid recheck interval: %w", err)
+		}
+		policy.RecheckInterval = d
+	}
+	return policy, nil
+}
+
+// A tokenPool reports whether an agent can take another request. It is
+// implemented by Agent.
+type tokenPool interface {
+	HasFreeTokens() bool
+}
+
+// agentTopology tracks the agents on each node and in each zone.
+type agentTopology struct {
+	mu    sync.RWMutex
+	nodes map[string]map[tokenPool]struct{}
+	zones map[string]map[tokenPool]struct{}
+}
+
+func newAgentTopology() *agentTopology {
+	return &agentTopology{
+		nodes: make(map[string]map[tokenPool]struct{}),
+		zones: make(map[string]map[tokenPool]struct{}),
+	}
+}
+
+func (c *agentTopology) add(agent tokenPool, t Topology) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	addAgent(c.nodes, t.Node, agent)
+	addAgent(c.zones, t.Zone, agent)
+}
+
+func (c *agentTopology) remove(agent tokenPool, t Topology) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	removeAgent(c.nodes, t.Node, agent)
+	removeAgent(c.zones, t.Zone, agent)
+}
+
+func addAgent(agents map[string]map[tokenPool]struct{}, key string, agent tokenPool) {
+	if key == "" {
+		return
+	}
+	if _, ok := agents[key]; !ok {
+		agents[key] = make(map[tokenPool]struct{})
+	}
+	agents[key][agent] = struct{}{}
+}
+
+func removeAgent(agents map[string]map[tokenPool]struct{}, key string, agent tokenPool) {
+	if key == "" {
+		return
+	}
+	delete(agents[key], agent)
+	if len(agents[key]) == 0 {
+		delete(agents, key)
+	}
+}
+
+func anyFreeTokens(agents map[tokenPool]struct{}) bool {
+	for agent := range agents {
+		if agent.HasFreeTokens() {
+			return true
+		}
+	}
+	return false
+}
+
+// closerAgentsFree returns true if any agents closer to the origin than the
+// given locality have free tokens.
+func (c *agentTopology) closerAgentsFree(origin Topology, loc locality) bool {
+	if loc == sameNode {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if origin.Node != "" && anyFreeTokens(c.nodes[origin.Node]) {
+		return true
+	}
+	return loc == remote && origin.Zone != "" && anyFreeTokens(c.zones[origin.Zone])
+}
+
+// acceptFunc returns a function used by an agent to decide which queued
+// requests it can take. Requests are held back while agents closer to their
+// consumerd have free tokens, and reconsidered after the policy's recheck
+// interval.
+func (c *agentTopology) acceptFunc(
+	policy LocalityPolicy,
+	agent Topology,
+) acceptFunc {
+	return func(item *queuedRequest, now time.Time) (bool, time.Time) {
+		if c.closerAgentsFree(item.origin, localityOf(agent, item.origin)) {
+			return false, now.Add(policy.RecheckInterval)
+		}
+		return true, time.Time{}
+	}
+}
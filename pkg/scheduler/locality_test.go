@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/atomic"
+)
+
+// testAgent is a tokenPool whose free tokens are controlled by the test.
+type testAgent struct {
+	free *atomic.Bool
+}
+
+func newTestAgent() testAgent {
+	return testAgent{free: atomic.NewBool(true)}
+}
+
+func (a testAgent) HasFreeTokens() bool {
+	return a.free.Load()
+}
+
+var _ = Describe("Locality", func() {
+	nodeA1 := Topology{Node: "node-1", Zone: "zone-a"}
+	nodeA2 := Topology{Node: "node-2", Zone: "zone-a"}
+	nodeB3 := Topology{Node: "node-3", Zone: "zone-b"}
+	policy := LocalityPolicy{
+		RecheckInterval: 10 * time.Millisecond,
+	}
+
+	It("should determine the locality of an agent", func() {
+		Expect(localityOf(nodeA1, nodeA1)).To(Equal(sameNode))
+		Expect(localityOf(nodeA2, nodeA1)).To(Equal(sameZone))
+		Expect(localityOf(nodeB3, nodeA1)).To(Equal(remote))
+		Expect(localityOf(nodeA1, Topology{})).To(Equal(remote))
+		Expect(localityOf(Topology{}, nodeA1)).To(Equal(remote))
+	})
+	It("should only hold requests back if closer agents have free tokens", func() {
+		agents := newAgentTopology()
+		local := newTestAgent()
+		agents.add(local, nodeA1)
+		agents.add(newTestAgent(), nodeB3)
+
+		Expect(agents.closerAgentsFree(nodeA1, sameNode)).To(BeFalse())
+		Expect(agents.closerAgentsFree(nodeA1, sameZone)).To(BeTrue())
+		Expect(agents.closerAgentsFree(nodeA1, remote)).To(BeTrue())
+		Expect(agents.closerAgentsFree(nodeA2, sameZone)).To(BeFalse())
+		Expect(agents.closerAgentsFree(nodeA2, remote)).To(BeTrue())
+		Expect(agents.closerAgentsFree(Topology{Zone: "zone-c"}, remote)).To(BeFalse())
+
+		local.free.Store(false)
+		Expect(agents.closerAgentsFree(nodeA1, sameZone)).To(BeFalse())
+		Expect(agents.closerAgentsFree(nodeA2, remote)).To(BeFalse())
+
+		local.free.Store(true)
+		agents.remove(local, nodeA1)
+		Expect(agents.closerAgentsFree(nodeA2, remote)).To(BeFalse())
+	})
+	It("should prefer local agents and spill over to remote agents", func() {
+		agents := newAgentTopology()
+		localAgent := newTestAgent()
+		agents.add(localAgent, nodeA1)
+		agents.add(newTestAgent(), nodeB3)
+		q := newFairQueue(DefaultFairSharePolicy(), newQueueMetrics())
+		local := agents.acceptFunc(policy, nodeA1)
+		remote := agents.acceptFunc(policy, nodeB3)
+
+		By("sending requests to a local agent immediately")
+		q.Push(queueRequest(types.Interactive, "dev"), nodeA1)
+		_, ok := q.Pop(context.Background(), local)
+		Expect(ok).To(BeTrue())
+
+		By("holding requests back from remote agents while local agents have free tokens")
+		q.Push(queueRequest(types.Interactive, "dev"), nodeA1)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		_, ok = q.Pop(ctx, remote)
+		cancel()
+		Expect(ok).To(BeFalse())
+
+		By("sending requests to remote agents once the local agents are busy")
+		done := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+			_, ok := q.Pop(context.Background(), remote)
+			Expect(ok).To(BeTrue())
+		}()
+		Consistently(done, 50*time.Millisecond).ShouldNot(BeClosed())
+		localAgent.free.Store(false)
+		Eventually(done, 10*policy.RecheckInterval).Should(BeClosed())
+	})
+	It("should parse the locality policy configuration", func() {
+		p, err := NewLocalityPolicy(config.LocalitySpec{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(Equal(DefaultLocalityPolicy))
+
+		p, err = NewLocalityPolicy(config.LocalitySpec{
+			RecheckInterval: "5ms",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p.RecheckInterval).To(Equal(5 * time.Millisecond))
+
+		_, err = NewLocalityPolicy(config.LocalitySpec{
+			RecheckInterval: "soon",
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -19,6 +19,7 @@ package scheduler
 
 import (
 	"context"
+	"math"
 	"sync"
 	"time"
 
@@ -50,25 +51,75 @@ import (
 // maximizing cpu usage (or rather, minimizing unused cpu) and minimizing
 // throttling. Too much throttling means the cgroup is overloaded but too
 // little cpu usage means the agent is not being used to its full capacity.
+// The result is used to resize the agent's token pool. Resized limits are
+// posted as AgentUsageLimits, which each agent will then post as its own
+// UsageLimits.
 type Optimizer struct {
+	OptimizerOptions
 	ctx    context.Context
 	lg     *zap.SugaredLogger
 	client types.MonitorClient
 	broker *Broker
 	usageC chan float64
+
+	agentLimitsMu sync.Mutex
+	agentLimits   map[string]*metrics.UsageLimits
+}
+
+// DefaultThrottlingTarget is the default fraction of CFS periods in which an
+// agent is allowed to be throttled before its token pool is shrunk.
+const DefaultThrottlingTarget = 0.05
+
+type OptimizerOptions struct {
+	throttlingTarget float64
+	agentInterval    time.Duration
+}
+
+type OptimizerOption func(*OptimizerOptions)
+
+func (o *OptimizerOptions) Apply(opts ...OptimizerOption) {
+	for _, op := range opts {
+		op(o)
+	}
+}
+
+// WithThrottlingTarget sets the fraction of CFS periods (between 0 and 1) in
+// which an agent is allowed to be throttled. The agent optimizer will try to
+// maximize each agent's cpu usage while keeping throttling below this target.
+func WithThrottlingTarget(target float64) OptimizerOption {
+	return func(o *OptimizerOptions) {
+		o.throttlingTarget = target
+	}
+}
+
+// WithAgentOptimizerInterval sets how often each agent's token pool is
+// adjusted. The actual interval is jittered by up to 50%.
+func WithAgentOptimizerInterval(interval time.Duration) OptimizerOption {
+	return func(o *OptimizerOptions) {
+		o.agentInterval = interval
+	}
 }
 
 func NewOptimizer(
 	ctx context.Context,
 	client types.MonitorClient,
 	broker *Broker,
+	opts ...OptimizerOption,
 ) *Optimizer {
+	options := OptimizerOptions{
+		throttlingTarget: DefaultThrottlingTarget,
+		agentInterval:    8 * time.Second,
+	}
+	options.Apply(opts...)
+
 	o := &Optimizer{
-		ctx:    ctx,
-		lg:     meta.Log(ctx),
-		client: client,
-		broker: broker,
-		usageC: make(chan float64, 1),
+		OptimizerOptions: options,
+		ctx:              ctx,
+		lg:               meta.Log(ctx),
+		client:           client,
+		broker:           broker,
+		usageC:           make(chan float64, 1),
+		agentLimits:      make(map[string]*metrics.UsageLimits),
 	}
 	o.usageC <- 1.0
 	go o.run()
@@ -94,31 +145,92 @@ func (o *Optimizer) runCacheOptimizer(
 
 type snapshot struct {
 	wallTime    uint64
+	cpus        int32
 	tokenCount  int
 	tokensInUse int
 	stats       *metrics.CpuStats
 }
 
+// UsageFactor returns the fraction of the agent's cpu quota that was used
+// between the previous snapshot and this one.
 func (s snapshot) UsageFactor(prev snapshot) float64 {
-	quota := s.stats.CpuUsage.CfsQuota
-	if s.stats.CpuUsage.CfsQuota < 0 {
-		quota = s.stats.CpuUsage.CfsPeriod
+	period := float64(s.stats.CpuUsage.CfsPeriod)
+	quota := float64(s.stats.CpuUsage.CfsQuota)
+	if quota < 0 {
+		// No quota, the agent can use all of the cpus on its node
+		quota = period * float64(s.cpus)
 	}
 	Δt := float64(s.wallTime - prev.wallTime)
 	Δu := float64(s.stats.CpuUsage.TotalUsage - prev.stats.CpuUsage.TotalUsage)
-	max := (Δt / float64(s.stats.CpuUsage.CfsPeriod)) * float64(quota)
+	max := (Δt / period) * quota
+	if max <= 0 {
+		return 0
+	}
 	return Δu / max
 }
 
-func (s snapshot) ThrottlingFactor(next snapshot) float64 {
-	Δt := float64(next.wallTime - s.wallTime)
-	return 1.0 - (float64(s.stats.ThrottlingData.ThrottledTime) / Δt)
+// ThrottlingFactor returns the fraction of CFS periods in which the agent was
+// throttled between the previous snapshot and this one.
+func (s snapshot) ThrottlingFactor(prev snapshot) float64 {
+	Δp := float64(s.stats.ThrottlingData.Periods - prev.stats.ThrottlingData.Periods)
+	if Δp <= 0 {
+		return 0
+	}
+	Δtp := float64(s.stats.ThrottlingData.ThrottledPeriods -
+		prev.stats.ThrottlingData.ThrottledPeriods)
+	return Δtp / Δp
 }
 
 func (s snapshot) TokenUsage() float64 {
+	if s.tokenCount == 0 {
+		return 0
+	}
 	return float64(s.tokensInUse) / float64(s.tokenCount)
 }
 
+// tokenSaturation is the average token usage above which an agent is
+// considered to be kept busy by the scheduler. The token pool will only grow
+// if the agent is busy, otherwise low cpu usage is due to a lack of tasks.
+const tokenSaturation = 0.75
+
+// optimalTokenCount computes a new token count for an agent based on the
+// averages observed since the last optimization. If the agent was throttled
+// more than the target allows, the token count is reduced in proportion to
+// the amount of excess throttling. If the agent was busy but did not use all
+// of its cpu quota, the token count is increased to the number of tokens
+// that is estimated to fully use the quota, up to double the current count.
+func optimalTokenCount(
+	current int,
+	usage, throttling, tokenUsage, target float64,
+) int {
+	next := current
+	switch {
+	case throttling > target:
+		next = int(math.Floor(float64(current) * target / throttling))
+		if next >= current {
+			next = current - 1
+		}
+	case tokenUsage >= tokenSaturation && usage < 1.0:
+		next = current * 2
+		if perToken := usage / (tokenUsage * float64(current)); perToken > 0 {
+			next = int(math.Ceil(1.0 / perToken))
+		}
+		if next > current*2 {
+			next = current * 2
+		}
+		if next <= current {
+			next = current + 1
+		}
+	}
+	if next < 1 {
+		next = 1
+	}
+	if next > MaxTokens {
+		next = MaxTokens
+	}
+	return next
+}
+
 func (o *Optimizer) runAgentOptimizer(
 	listener clients.MetricsListener,
 	pctx context.Context,
@@ -126,28 +238,34 @@ func (o *Optimizer) runAgentOptimizer(
 ) {
 	snapshots := []snapshot{}
 	lock := &sync.Mutex{}
-	agent, ok := o.broker.GetAgent(uuid)
-	if !ok {
-		o.lg.With(
-			"uuid", uuid,
-		).Error("Could not find agent")
-		return
-	}
+	var agent *Agent
 	listener.OnValueChanged(uuid, func(s *metrics.CpuStats) {
+		a, ok := o.broker.GetAgent(uuid)
+		if !ok {
+			// The agent has not yet connected to the scheduler
+			return
+		}
 		lock.Lock()
 		defer lock.Unlock()
-		available := len(agent.AvailableTokens)
-		locked := len(agent.LockedTokens)
+		if a != agent {
+			// The agent (re)connected, previous snapshots are no longer relevant
+			agent = a
+			snapshots = snapshots[:0]
+		}
 		snapshots = append(snapshots, snapshot{
 			wallTime:    s.WallTime,
-			tokenCount:  available + locked,
-			tokensInUse: locked,
+			cpus:        a.SystemInfo.GetCpuThreads(),
+			tokenCount:  a.TokenCount(),
+			tokensInUse: len(a.LockedTokens),
 			stats:       proto.Clone(s).(*metrics.CpuStats),
 		})
 	})
-	util.RunPeriodic(pctx, 8*time.Second, 0.5, false, func() { // every 8-12s
+	util.RunPeriodic(pctx, o.agentInterval, 0.5, false, func() {
 		lock.Lock()
 		defer lock.Unlock()
+		if len(snapshots) < 2 {
+			return
+		}
 		usage := 0.0
 		throttling := 0.0
 		tokens := 0.0
@@ -163,15 +281,58 @@ func (o *Optimizer) runAgentOptimizer(
 		usage /= count
 		throttling /= count
 		tokens /= count
-		// todo: math
+		// Keep the most recent snapshot as the baseline for the next interval
+		snapshots = snapshots[len(snapshots)-1:]
+
+		current := agent.TokenCount()
+		next := optimalTokenCount(current, usage, throttling, tokens,
+			o.throttlingTarget)
 		o.lg.With(
 			types.ShortID(uuid),
 			"usage", usage,
 			"throttling", throttling,
 			"tokens", tokens,
-		).Info("Agent Optimization")
+			"current", current,
+			"next", next,
+		).Debug("Agent Optimization")
+		if next == current {
+			return
+		}
+		o.lg.With(
+			types.ShortID(uuid),
+			"from", current,
+			"to", next,
+		).Info("Resizing agent token pool")
+		agent.ResizeTokenPool(next)
+		agent.Lock()
+		agent.UsageLimits = &metrics.UsageLimits{
+			ConcurrentProcessLimit: int32(next),
+		}
+		agent.Unlock()
+		o.agentLimitsMu.Lock()
+		o.agentLimits[uuid] = &metrics.UsageLimits{
+			ConcurrentProcessLimit: int32(next),
+		}
+		o.agentLimitsMu.Unlock()
 	})
 	<-pctx.Done()
+	o.agentLimitsMu.Lock()
+	delete(o.agentLimits, uuid)
+	o.agentLimitsMu.Unlock()
+}
+
+// AgentUsageLimits returns the usage limits computed for each agent whose
+// token pool has been adjusted by the optimizer.
+func (o *Optimizer) AgentUsageLimits() *metrics.AgentUsageLimits {
+	o.agentLimitsMu.Lock()
+	defer o.agentLimitsMu.Unlock()
+	limits := &metrics.AgentUsageLimits{
+		Agents: make(map[string]*metrics.UsageLimits, len(o.agentLimits)),
+	}
+	for uuid, l := range o.agentLimits {
+		limits.Agents[uuid] = proto.Clone(l).(*metrics.UsageLimits)
+	}
+	return limits
 }
 
 func (o *Optimizer) run() {
@@ -189,8 +350,7 @@ func (o *Optimizer) run() {
 		case types.Cache:
 			o.runCacheOptimizer(listener, c, s)
 		case types.Agent:
-			// todo
-			// o.runAgentOptimizer(listener, c, s)
+			o.runAgentOptimizer(listener, c, s)
 		}
 	})
 }
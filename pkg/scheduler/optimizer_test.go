@@ -0,0 +1,137 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package scheduler
+
+import (
+	"github.com/kubecc-io/kubecc/pkg/metrics"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Optimizer", func() {
+	Context("Token pools", func() {
+		var agent *Agent
+		BeforeEach(func() {
+			agent = &Agent{
+				AvailableTokens: make(chan struct{}, MaxTokens),
+				LockedTokens:    make(chan struct{}, MaxTokens),
+			}
+			agent.ResizeTokenPool(4)
+		})
+		lock := func(n int) {
+			for i := 0; i < n; i++ {
+				agent.LockedTokens <- <-agent.AvailableTokens
+			}
+		}
+		It("should grow the token pool", func() {
+			agent.ResizeTokenPool(6)
+			Expect(agent.TokenCount()).To(Equal(6))
+			Expect(agent.AvailableTokens).To(HaveLen(6))
+		})
+		It("should shrink the token pool using available tokens", func() {
+			lock(1)
+			agent.ResizeTokenPool(2)
+			Expect(agent.TokenCount()).To(Equal(2))
+			Expect(agent.AvailableTokens).To(HaveLen(1))
+			Expect(agent.ReturnToken()).To(BeTrue())
+			Expect(agent.AvailableTokens).To(HaveLen(2))
+		})
+		It("should discard locked tokens when they are returned", func() {
+			lock(4)
+			agent.ResizeTokenPool(1)
+			Expect(agent.ReturnToken()).To(BeTrue())
+			Expect(agent.ReturnToken()).To(BeTrue())
+			Expect(agent.ReturnToken()).To(BeTrue())
+			Expect(agent.AvailableTokens).To(BeEmpty())
+			Expect(agent.ReturnToken()).To(BeTrue())
+			Expect(agent.AvailableTokens).To(HaveLen(1))
+			Expect(agent.ReturnToken()).To(BeFalse())
+		})
+		It("should cancel pending removals when growing", func() {
+			lock(4)
+			agent.ResizeTokenPool(2)
+			agent.ResizeTokenPool(5)
+			Expect(agent.AvailableTokens).To(HaveLen(1))
+			for i := 0; i < 4; i++ {
+				Expect(agent.ReturnToken()).To(BeTrue())
+			}
+			Expect(agent.AvailableTokens).To(HaveLen(5))
+		})
+	})
+	Context("Snapshots", func() {
+		makeSnapshot := func(wallTime, usage, periods, throttled uint64) snapshot {
+			return snapshot{
+				wallTime: wallTime,
+				cpus:     4,
+				stats: &metrics.CpuStats{
+					WallTime: wallTime,
+					CpuUsage: &metrics.CpuUsage{
+						CfsQuota:   200000,
+						CfsPeriod:  100000,
+						TotalUsage: usage,
+					},
+					ThrottlingData: &metrics.ThrottlingData{
+						Periods:          periods,
+						ThrottledPeriods: throttled,
+					},
+				},
+			}
+		}
+		It("should compute usage relative to the cpu quota", func() {
+			prev := makeSnapshot(0, 0, 0, 0)
+			next := makeSnapshot(1e9, 1e9, 10, 0)
+			Expect(next.UsageFactor(prev)).To(BeNumerically("~", 0.5))
+		})
+		It("should compute usage relative to the node's cpus with no quota", func() {
+			prev := makeSnapshot(0, 0, 0, 0)
+			next := makeSnapshot(1e9, 1e9, 10, 0)
+			prev.stats.CpuUsage.CfsQuota = -1
+			next.stats.CpuUsage.CfsQuota = -1
+			Expect(next.UsageFactor(prev)).To(BeNumerically("~", 0.25))
+		})
+		It("should compute the fraction of throttled periods", func() {
+			prev := makeSnapshot(0, 0, 10, 2)
+			next := makeSnapshot(1e9, 1e9, 20, 7)
+			Expect(next.ThrottlingFactor(prev)).To(BeNumerically("~", 0.5))
+		})
+	})
+	Context("Token count optimization", func() {
+		target := DefaultThrottlingTarget
+		It("should shrink the pool when throttling exceeds the target", func() {
+			Expect(optimalTokenCount(10, 1.0, 0.5, 1.0, target)).To(Equal(1))
+			Expect(optimalTokenCount(10, 1.0, 0.06, 1.0, target)).To(Equal(8))
+			Expect(optimalTokenCount(10, 1.0, 0.051, 1.0, target)).To(Equal(9))
+		})
+		It("should grow the pool when a busy agent has unused cpu", func() {
+			Expect(optimalTokenCount(4, 0.5, 0, 1.0, target)).To(Equal(8))
+			Expect(optimalTokenCount(4, 0.8, 0, 1.0, target)).To(Equal(5))
+			Expect(optimalTokenCount(4, 0.1, 0, 1.0, target)).To(Equal(8))
+			Expect(optimalTokenCount(4, 0, 0, 1.0, target)).To(Equal(8))
+		})
+		It("should not grow the pool when the agent is idle", func() {
+			Expect(optimalTokenCount(4, 0.2, 0, 0.25, target)).To(Equal(4))
+		})
+		It("should not change the pool when the agent is fully used", func() {
+			Expect(optimalTokenCount(4, 1.0, 0.01, 1.0, target)).To(Equal(4))
+		})
+		It("should keep the pool within bounds", func() {
+			Expect(optimalTokenCount(1, 1.0, 1.0, 1.0, target)).To(Equal(1))
+			Expect(optimalTokenCount(MaxTokens, 0.1, 0, 1.0, target)).To(Equal(MaxTokens))
+		})
+	})
+})
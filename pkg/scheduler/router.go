@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	mapset "github.com/deckarep/golang-set"
 	"github.com/kubecc-io/kubecc/pkg/metrics"
@@ -50,16 +51,44 @@ type receiver struct {
 type route struct {
 	tc         *types.Toolchain
 	hash       string
-	C          chan request
+	queue      *fairQueue
+	locality   LocalityPolicy
+	agents     *agentTopology
 	rxRefCount *atomic.Int32
 	txRefCount *atomic.Int32
-	senders    mapset.Set
-	receivers  mapset.Set
-	cancel     context.CancelFunc
+	// The number of receivers whose agents are not cordoned
+	ready     *atomic.Int32
+	senders   mapset.Set
+	receivers mapset.Set
+	cancel    context.CancelFunc
+	orphaned  func(request)
 }
 
+// CanSend returns true if there are any schedulable agents on the route.
 func (rt *route) CanSend() bool {
-	return rt.rxRefCount.Load() > 0
+	return rt.ready.Load() > 0
+}
+
+// awaitSchedulable blocks until the agent is schedulable. While waiting, the
+// agent does not count towards the agents that can receive requests from
+// the route, or towards the closer agents requests are held back for.
+// It returns false if the agent went away while waiting.
+func (rt *route) awaitSchedulable(agent *Agent, topology Topology) bool {
+	select {
+	case <-agent.Schedulable():
+		return true
+	default:
+	}
+	rt.ready.Dec()
+	rt.agents.remove(agent, topology)
+	defer rt.ready.Inc()
+	defer rt.agents.add(agent, topology)
+	select {
+	case <-agent.Schedulable():
+		return true
+	case <-agent.Context.Done():
+		return false
+	}
 }
 
 // todo: unsure if we still need the ref counting here
@@ -101,20 +130,39 @@ func (rt *route) attachSender(s *sender) {
 
 func (rt *route) attachReceiver(r *receiver) {
 	uuid := r.agent.UUID
+	topology := r.agent.Topology()
 	rt.receivers.Add(uuid)
+	rt.agents.add(r.agent, topology)
 	rt.incRxRefCount()
+	rt.ready.Inc()
+	accept := rt.agents.acceptFunc(rt.locality, topology)
 	go func(uuid string) {
 		defer rt.receivers.Remove(uuid)
+		defer rt.agents.remove(r.agent, topology)
 		defer rt.decRxRefCount()
+		defer rt.ready.Dec()
 		for {
-			select {
-			case i, open := <-rt.C:
-				if !open {
-					// Channel closed
-					return
+			if !rt.awaitSchedulable(r.agent, topology) {
+				return
+			}
+			popCtx, cancel := r.agent.schedulableContext()
+			i, ok := rt.queue.Pop(popCtx, accept)
+			cancel()
+			if !ok {
+				if r.agent.Context.Err() == nil && r.agent.Cordoned() {
+					continue
 				}
-				r.filteredOutput <- i
+				// Queue closed or agent gone
+				return
+			}
+			select {
+			case r.filteredOutput <- i:
+			case <-r.agent.Unschedulable():
+				// The agent was cordoned before it could accept the request
+				rt.orphaned(i)
 			case <-r.agent.Context.Done():
+				// The agent went away before it could accept the request
+				rt.orphaned(i)
 				return
 			}
 		}
@@ -134,11 +182,30 @@ type RouterHook interface {
 }
 
 type RouterOptions struct {
-	hooks []RouterHook
+	hooks         []RouterHook
+	orphanHandler func(request)
+	fairShare     FairSharePolicy
+	locality      LocalityPolicy
 }
 
 type RouterOption func(*RouterOptions)
 
+// WithFairSharePolicy sets the policy used to order requests waiting for an
+// agent. Defaults to DefaultFairSharePolicy().
+func WithFairSharePolicy(policy FairSharePolicy) RouterOption {
+	return func(o *RouterOptions) {
+		o.fairShare = policy
+	}
+}
+
+// WithLocalityPolicy sets the policy used to prefer agents close to the
+// consumerd a request was sent from. Defaults to DefaultLocalityPolicy.
+func WithLocalityPolicy(policy LocalityPolicy) RouterOption {
+	return func(o *RouterOptions) {
+		o.locality = policy
+	}
+}
+
 func (o *RouterOptions) Apply(opts ...RouterOption) {
 	for _, op := range opts {
 		op(o)
@@ -151,6 +218,16 @@ func WithHooks(hooks ...RouterHook) RouterOption {
 	}
 }
 
+// WithOrphanHandler sets a function which will be called with any request
+// that was taken off a route by an agent which became unavailable before
+// the request could be sent to it. The handler is responsible for routing
+// the request again.
+func WithOrphanHandler(handler func(request)) RouterOption {
+	return func(o *RouterOptions) {
+		o.orphanHandler = handler
+	}
+}
+
 type Router struct {
 	ctx            context.Context
 	senders        map[string]*sender   // key = uuid
@@ -160,11 +237,18 @@ type Router struct {
 	sendersMutex   *sync.RWMutex
 	receiversMutex *sync.RWMutex
 	hooks          []RouterHook
+	orphanHandler  func(request)
+	fairShare      FairSharePolicy
+	locality       LocalityPolicy
+	queueMetrics   *queueMetrics
 }
 
 func NewRouter(ctx context.Context, opts ...RouterOption) *Router {
 	options := RouterOptions{
-		hooks: []RouterHook{},
+		hooks:         []RouterHook{},
+		orphanHandler: func(request) {},
+		fairShare:     DefaultFairSharePolicy(),
+		locality:      DefaultLocalityPolicy,
 	}
 	options.Apply(opts...)
 
@@ -177,6 +261,10 @@ func NewRouter(ctx context.Context, opts ...RouterOption) *Router {
 		sendersMutex:   &sync.RWMutex{},
 		receiversMutex: &sync.RWMutex{},
 		hooks:          options.hooks,
+		orphanHandler:  options.orphanHandler,
+		fairShare:      options.fairShare,
+		locality:       options.locality,
+		queueMetrics:   newQueueMetrics(),
 	}
 }
 
@@ -194,19 +282,23 @@ func (r *Router) newRoute(tc *types.Toolchain, hash string) *route {
 	rt := &route{
 		tc:         tc,
 		hash:       hash,
-		C:          make(chan request),
+		queue:      newFairQueue(r.fairShare, r.queueMetrics),
+		locality:   r.locality,
+		agents:     newAgentTopology(),
 		rxRefCount: atomic.NewInt32(0),
 		txRefCount: atomic.NewInt32(0),
+		ready:      atomic.NewInt32(0),
 		senders:    mapset.NewSet(),
 		receivers:  mapset.NewSet(),
 		cancel:     cancel,
+		orphaned:   r.orphanHandler,
 	}
 	go func() {
 		<-ctx.Done()
-		// Ref count hit 0, clean up the channel to avoid a resource leak
+		// Ref count hit 0, stop any receivers waiting on the queue
 		r.routesMutex.Lock()
 		defer r.routesMutex.Unlock()
-		close(rt.C)
+		rt.queue.Close()
 		delete(r.routes, hash)
 	}()
 	return rt
@@ -289,6 +381,7 @@ func (r *Router) UpdateSenderToolchains(
 			}
 		}
 		if isNew {
+			newTc := newTc
 			defer func() {
 				r.routeForToolchain(newTc).attachSender(sender)
 			}()
@@ -298,7 +391,10 @@ func (r *Router) UpdateSenderToolchains(
 	sender.cd.Toolchains = newToolchains
 }
 
-func (r *Router) Route(ctx context.Context, req request) error {
+// Route sends a request to an agent which can run it, preferring agents close
+// to the origin of the request. It blocks until the request has been taken
+// by an agent.
+func (r *Router) Route(ctx context.Context, req request, origin Topology) error {
 	tc := req.GetToolchain()
 	if tc == nil {
 		return ErrInvalidToolchain
@@ -313,17 +409,38 @@ func (r *Router) Route(ctx context.Context, req request) error {
 			return nil
 		}
 	}
-	if rt.rxRefCount.Load() == 0 {
+	if !rt.CanSend() {
 		return ErrNoAgents
 	}
-	select {
-	case rt.C <- req:
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
+	item := rt.queue.Push(req, origin)
+	// If all agents on the route go away while waiting, stop waiting so that
+	// the request can be handled elsewhere.
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-item.dequeued:
+			return nil
+		case <-ticker.C:
+			if !rt.CanSend() && rt.queue.Remove(item) {
+				return ErrNoAgents
+			}
+		case <-ctx.Done():
+			if rt.queue.Remove(item) {
+				return ctx.Err()
+			}
+			// Already taken by an agent
+			return nil
+		}
 	}
 }
 
+// QueueStats returns the number of requests waiting for an agent and the
+// total time requests have spent waiting, for each priority class.
+func (r *Router) QueueStats() *metrics.PriorityClassQueues {
+	return r.queueMetrics.Stats()
+}
+
 func stringSlice(interfaces []interface{}) []string {
 	s := make([]string, len(interfaces))
 	for i, v := range interfaces {
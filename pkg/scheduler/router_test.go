@@ -20,6 +20,7 @@ package scheduler
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kubecc-io/kubecc/pkg/metrics"
@@ -104,13 +105,13 @@ var _ = Describe("Router", func() {
 			})
 			It("should not be able to send on that channel", func() {
 				Expect(rt.CanSend()).To(BeFalse())
-				err := router.Route(context.Background(), sample_req1)
+				err := router.Route(context.Background(), sample_req1, Topology{})
 				Expect(err).To(MatchError(ErrNoAgents))
 			})
 		})
 		When("sending an invalid request", func() {
 			It("should return the correct errors", func() {
-				err := router.Route(context.Background(), &types.CompileRequest{})
+				err := router.Route(context.Background(), &types.CompileRequest{}, Topology{})
 				Expect(err).To(MatchError(ErrInvalidToolchain))
 			})
 		})
@@ -125,7 +126,7 @@ var _ = Describe("Router", func() {
 			It("should be able to send on that channel", func() {
 				rt := router.routeForToolchain(clang_c)
 				Eventually(rt.CanSend).Should(BeTrue())
-				Expect(router.Route(context.Background(), sample_req1)).To(Succeed())
+				Expect(router.Route(context.Background(), sample_req1, Topology{})).To(Succeed())
 				Eventually(rx).Should(Receive(Equal(sample_req1)))
 			})
 		})
@@ -139,7 +140,7 @@ var _ = Describe("Router", func() {
 			It("should not be able to send", func() {
 				rt := router.routeForToolchain(gnu_c)
 				Expect(rt.CanSend()).To(BeFalse())
-				err := router.Route(context.Background(), sample_req2)
+				err := router.Route(context.Background(), sample_req2, Topology{})
 				Expect(err).To(MatchError(ErrNoAgents))
 			})
 		})
@@ -154,9 +155,60 @@ var _ = Describe("Router", func() {
 			It("should be able to send on that channel", func() {
 				rt := router.routeForToolchain(gnu_c)
 				Eventually(rt.CanSend).Should(BeTrue())
-				Expect(router.Route(context.Background(), sample_req2)).To(Succeed())
+				Expect(router.Route(context.Background(), sample_req2, Topology{})).To(Succeed())
 				Eventually(rx).Should(Receive(Equal(sample_req2)))
 			})
 		})
 	})
+	Context("Cordoned agents", func() {
+		router := NewRouter(testCtx)
+		newAgent := func() *Agent {
+			return &Agent{
+				remoteInfo: remoteInfo{
+					Context: context.Background(),
+					UUID:    uuid.NewString(),
+				},
+				Toolchains: &metrics.Toolchains{
+					Items: []*types.Toolchain{
+						clang_c,
+					},
+				},
+			}
+		}
+		agentA, agentB := newAgent(), newAgent()
+		var rxA, rxB <-chan request
+		var rt *route
+		It("should set up the route", func() {
+			router.AddSender(testCd1)
+			rxA = router.AddReceiver(agentA)
+			rxB = router.AddReceiver(agentB)
+			rt = router.routeForToolchain(clang_c)
+			Eventually(rt.ready.Load).Should(BeEquivalentTo(2))
+		})
+		It("should not send requests to a cordoned agent", func() {
+			Expect(agentA.Cordon()).To(BeTrue())
+			Expect(agentA.Cordon()).To(BeFalse())
+			Eventually(rt.ready.Load).Should(BeEquivalentTo(1))
+			Expect(rt.CanSend()).To(BeTrue())
+			for i := 0; i < 5; i++ {
+				Expect(router.Route(context.Background(), sample_req1, Topology{})).To(Succeed())
+				Eventually(rxB).Should(Receive(Equal(sample_req1)))
+			}
+			Consistently(rxA, 100*time.Millisecond).ShouldNot(Receive())
+		})
+		It("should not be able to send if all agents are cordoned", func() {
+			Expect(agentB.Cordon()).To(BeTrue())
+			Eventually(rt.CanSend).Should(BeFalse())
+			err := router.Route(context.Background(), sample_req1, Topology{})
+			Expect(err).To(MatchError(ErrNoAgents))
+		})
+		It("should send requests to an agent once it is uncordoned", func() {
+			Expect(agentA.Uncordon()).To(BeTrue())
+			Expect(agentA.Uncordon()).To(BeFalse())
+			Eventually(rt.CanSend).Should(BeTrue())
+			Expect(router.Route(context.Background(), sample_req1, Topology{})).To(Succeed())
+			Eventually(rxA).Should(Receive(Equal(sample_req1)))
+			Expect(rxB).NotTo(Receive())
+		})
+	})
 })
@@ -30,6 +30,8 @@ import (
 	"github.com/kubecc-io/kubecc/pkg/util"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type schedulerServer struct {
@@ -54,11 +56,19 @@ type schedulerServer struct {
 
 	optimizer            *Optimizer
 	usageLimitMultiplier *atomic.Float64
+
+	// leaderCtx is canceled when this replica stops being the leader, and
+	// is nil while it is a standby.
+	leaderCtx     context.Context
+	leaderCtxLock sync.Mutex
 }
 
 type SchedulerServerOptions struct {
-	monClient   types.MonitorClient
-	cacheClient types.CacheClient
+	monClient        types.MonitorClient
+	cacheClient      types.CacheClient
+	optimizerOptions []OptimizerOption
+	brokerOptions    []BrokerOption
+	elector          Elector
 }
 
 type SchedulerServerOption func(*SchedulerServerOptions)
@@ -81,6 +91,27 @@ func WithCacheClient(cacheClient types.CacheClient) SchedulerServerOption {
 	}
 }
 
+func WithOptimizerOptions(opts ...OptimizerOption) SchedulerServerOption {
+	return func(o *SchedulerServerOptions) {
+		o.optimizerOptions = append(o.optimizerOptions, opts...)
+	}
+}
+
+func WithBrokerOptions(opts ...BrokerOption) SchedulerServerOption {
+	return func(o *SchedulerServerOptions) {
+		o.brokerOptions = append(o.brokerOptions, opts...)
+	}
+}
+
+// WithElector runs the scheduler as one of several replicas. The scheduler
+// only accepts agent and consumerd streams while the elector has chosen it
+// as the leader. Without an elector, the scheduler is always the leader.
+func WithElector(elector Elector) SchedulerServerOption {
+	return func(o *SchedulerServerOptions) {
+		o.elector = elector
+	}
+}
+
 var SchedulerServerContext context.Context
 
 func NewSchedulerServer(
@@ -100,12 +131,15 @@ func NewSchedulerServer(
 		consumerdCount: atomic.NewInt64(0),
 		broker: NewBroker(ctx,
 			NewDefaultToolchainWatcher(ctx, options.monClient),
-			CacheClient(options.cacheClient),
-			MonitorClient(options.monClient),
+			append([]BrokerOption{
+				CacheClient(options.cacheClient),
+				MonitorClient(options.monClient),
+			}, options.brokerOptions...)...,
 		),
 		usageLimitMultiplier: atomic.NewFloat64(0.0),
 	}
-	srv.optimizer = NewOptimizer(ctx, options.monClient, srv.broker)
+	srv.optimizer = NewOptimizer(ctx, options.monClient, srv.broker,
+		options.optimizerOptions...)
 	srv.BeginInitialize(ctx)
 	srv.applyNoAgentsCond()
 	srv.applyNoCdsCond()
@@ -118,9 +152,51 @@ func NewSchedulerServer(
 	} else {
 		srv.metricsProvider = clients.NewNoopMetricsProvider()
 	}
+
+	if options.elector != nil {
+		srv.lg.Info("Waiting to become the leader")
+		go options.elector.Run(ctx, srv.lead)
+	} else {
+		srv.leaderCtx = ctx
+	}
 	return srv
 }
 
+// lead is called by the elector when this replica becomes the leader, and
+// blocks until leadership is lost. Agent and consumerd streams are closed
+// when leadership is lost so that they reconnect to the new leader.
+func (s *schedulerServer) lead(ctx context.Context) {
+	s.lg.Info("Became the leader")
+	s.leaderCtxLock.Lock()
+	s.leaderCtx = ctx
+	s.leaderCtxLock.Unlock()
+	s.postLeaderStatus()
+
+	<-ctx.Done()
+
+	s.lg.Warn("Lost leadership")
+	s.leaderCtxLock.Lock()
+	s.leaderCtx = nil
+	s.leaderCtxLock.Unlock()
+	s.postLeaderStatus()
+}
+
+// leaderContext returns a context which is canceled when this replica stops
+// being the leader, or an Unavailable error if it is not the leader.
+func (s *schedulerServer) leaderContext() (context.Context, error) {
+	s.leaderCtxLock.Lock()
+	defer s.leaderCtxLock.Unlock()
+	if s.leaderCtx == nil || s.leaderCtx.Err() != nil {
+		return nil, status.Error(codes.Unavailable, "This scheduler is not the leader")
+	}
+	return s.leaderCtx, nil
+}
+
+func (s *schedulerServer) isLeader() bool {
+	_, err := s.leaderContext()
+	return err == nil
+}
+
 func (s *schedulerServer) applyNoAgentsCond() {
 	s.lg.Debug("Applying status condition [no agents]")
 	ctx, cancel := context.WithCancel(context.Background())
@@ -150,8 +226,12 @@ func (s *schedulerServer) StreamIncomingTasks(
 		s.lg.Error(err)
 		return err
 	}
+	leaderCtx, err := s.leaderContext()
+	if err != nil {
+		return err
+	}
 
-	err := s.broker.NewAgentTaskStream(srv)
+	err = s.broker.NewAgentTaskStream(srv)
 	if err != nil {
 		s.lg.With(zap.Error(err)).Error("Agent error")
 	}
@@ -173,6 +253,7 @@ func (s *schedulerServer) StreamIncomingTasks(
 	select {
 	case <-srv.Context().Done():
 	case <-s.srvContext.Done():
+	case <-leaderCtx.Done():
 	}
 
 	return nil
@@ -187,8 +268,12 @@ func (s *schedulerServer) StreamOutgoingTasks(
 		s.lg.Error(err)
 		return err
 	}
+	leaderCtx, err := s.leaderContext()
+	if err != nil {
+		return err
+	}
 
-	err := s.broker.NewConsumerdTaskStream(srv)
+	err = s.broker.NewConsumerdTaskStream(srv)
 	if err != nil {
 		s.lg.With(zap.Error(err)).Error("Consumerd error")
 	}
@@ -213,6 +298,7 @@ func (s *schedulerServer) StreamOutgoingTasks(
 	select {
 	case <-srv.Context().Done():
 	case <-s.srvContext.Done():
+	case <-leaderCtx.Done():
 	}
 
 	return nil
@@ -232,6 +318,11 @@ func (s *schedulerServer) postTotals() {
 	s.metricsProvider.Post(stats.completedTotal)
 	s.metricsProvider.Post(stats.failedTotal)
 	s.metricsProvider.Post(stats.requestsTotal)
+	s.metricsProvider.Post(stats.coalescedTotal)
+}
+
+func (s *schedulerServer) postQueueStats() {
+	s.metricsProvider.Post(s.broker.router.QueueStats())
 }
 
 func (s *schedulerServer) postAgentStats() {
@@ -246,7 +337,18 @@ func (s *schedulerServer) postConsumerdStats() {
 	}
 }
 
+func (s *schedulerServer) postLeaderStatus() {
+	s.metricsProvider.Post(&metrics.LeaderStatus{
+		Leader: s.isLeader(),
+	})
+}
+
 func (s *schedulerServer) postPreferredUsageLimits() {
+	// Consumerds use the limits posted by any scheduler, so only the
+	// leader (which is the only replica with agents) posts them.
+	if !s.isLeader() {
+		return
+	}
 	s.metricsProvider.Post(&metrics.PreferredUsageLimits{
 		ConcurrentProcessLimit: int64(math.Round(
 			float64(s.broker.calcPreferredUsageLimits()) *
@@ -255,6 +357,10 @@ func (s *schedulerServer) postPreferredUsageLimits() {
 	})
 }
 
+func (s *schedulerServer) postAgentUsageLimits() {
+	s.metricsProvider.Post(s.optimizer.AgentUsageLimits())
+}
+
 func (s *schedulerServer) StartMetricsProvider() {
 	s.lg.Info("Starting metrics provider")
 	go func() {
@@ -268,9 +374,12 @@ func (s *schedulerServer) StartMetricsProvider() {
 	}()
 
 	util.RunPeriodic(s.srvContext, 5*time.Second, 0.5, true, // 5-7.5 sec
+		s.postLeaderStatus,
 		s.postPreferredUsageLimits,
+		s.postAgentUsageLimits,
 		s.postCounts,
 		s.postTotals,
+		s.postQueueStats,
 		s.postAgentStats,
 		s.postConsumerdStats)
 }
@@ -279,5 +388,134 @@ func (s *schedulerServer) GetRoutes(
 	ctx context.Context,
 	_ *types.Empty,
 ) (*types.RouteList, error) {
+	if _, err := s.leaderContext(); err != nil {
+		return nil, err
+	}
 	return s.broker.router.GetRoutes(), nil
 }
+
+// drainPollInterval is how often the number of running tasks is checked
+// while draining an agent.
+const drainPollInterval = 250 * time.Millisecond
+
+// DrainAgent cordons, uncordons, or drains an agent. Draining an agent
+// cordons it, then reports progress until all of its running tasks have
+// completed. The agent remains cordoned after it has been drained.
+func (s *schedulerServer) DrainAgent(
+	req *types.DrainAgentRequest,
+	srv types.Scheduler_DrainAgentServer,
+) error {
+	if _, err := s.leaderContext(); err != nil {
+		return err
+	}
+	agent, ok := s.broker.GetAgent(req.GetUUID())
+	if !ok {
+		return status.Errorf(codes.NotFound, "Agent %s is not connected", req.GetUUID())
+	}
+	lg := s.lg.With(types.ShortID(agent.UUID))
+	switch req.GetAction() {
+	case types.Drain, types.Cordon:
+		if agent.Cordon() {
+			lg.Info("Agent cordoned")
+			s.postPreferredUsageLimits()
+		}
+	case types.Uncordon:
+		if agent.Uncordon() {
+			lg.Info("Agent uncordoned")
+			s.postPreferredUsageLimits()
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "Unknown action %v", req.GetAction())
+	}
+
+	progress := func() *types.DrainAgentProgress {
+		running := agent.RunningTasks()
+		return &types.DrainAgentProgress{
+			UUID:         agent.UUID,
+			Cordoned:     agent.Cordoned(),
+			RunningTasks: int32(running),
+			Drained:      agent.Cordoned() && running == 0,
+		}
+	}
+	if req.GetAction() != types.Drain {
+		return srv.Send(progress())
+	}
+
+	lg.Info("Draining agent")
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	lastRunning := int32(-1)
+	for {
+		p := progress()
+		if p.Drained {
+			lg.Info("Agent drained")
+			return srv.Send(p)
+		}
+		if !p.Cordoned {
+			return status.Error(codes.Aborted, "Agent was uncordoned while draining")
+		}
+		if p.RunningTasks != lastRunning {
+			if err := srv.Send(p); err != nil {
+				return err
+			}
+			lastRunning = p.RunningTasks
+		}
+		select {
+		case <-ticker.C:
+		case <-agent.Context.Done():
+			return status.Error(codes.Aborted, "Agent disconnected while draining")
+		case <-srv.Context().Done():
+			return srv.Context().Err()
+		}
+	}
+}
+
+// defaultListTasksInterval is how often task lists are sent to clients
+// watching the task list, if they did not request a specific interval.
+const defaultListTasksInterval = 1 * time.Second
+
+// minListTasksInterval limits how often task lists can be sent to a client.
+const minListTasksInterval = 100 * time.Millisecond
+
+// ListTasks sends the list of tasks the scheduler is tracking. If the request
+// is a watch request, an updated list is sent periodically until the client
+// closes the stream or this scheduler stops being the leader.
+func (s *schedulerServer) ListTasks(
+	req *types.ListTasksRequest,
+	srv types.Scheduler_ListTasksServer,
+) error {
+	leaderCtx, err := s.leaderContext()
+	if err != nil {
+		return err
+	}
+	send := func() error {
+		return srv.Send(&types.TaskList{
+			Items: s.broker.Tasks(),
+		})
+	}
+	if !req.GetWatch() {
+		return send()
+	}
+
+	interval := time.Duration(req.GetInterval())
+	switch {
+	case interval == 0:
+		interval = defaultListTasksInterval
+	case interval < minListTasksInterval:
+		interval = minListTasksInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := send(); err != nil {
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-leaderCtx.Done():
+			return status.Error(codes.Unavailable, "This scheduler is no longer the leader")
+		case <-srv.Context().Done():
+			return srv.Context().Err()
+		}
+	}
+}
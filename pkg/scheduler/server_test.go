@@ -20,6 +20,7 @@ package scheduler_test
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -31,6 +32,7 @@ import (
 	"github.com/kubecc-io/kubecc/pkg/meta"
 	"github.com/kubecc-io/kubecc/pkg/metrics"
 	"github.com/kubecc-io/kubecc/pkg/test"
+	"github.com/kubecc-io/kubecc/pkg/types"
 )
 
 var _ = Describe("Scheduler Server", func() {
@@ -84,6 +86,7 @@ var _ = Describe("Scheduler Server", func() {
 
 		agentTasks := testEnv.MetricF(schedCtx, &metrics.AgentTasksTotal{})
 		cdTasks := testEnv.MetricF(schedCtx, &metrics.ConsumerdTasksTotal{})
+		coalesced := testEnv.MetricF(schedCtx, &metrics.CoalescedRequestsTotal{})
 		Eventually(
 			func() string {
 				a, err := agentTasks()
@@ -94,17 +97,24 @@ var _ = Describe("Scheduler Server", func() {
 				if err != nil {
 					return err.Error()
 				}
+				co, err := coalesced()
+				if err != nil {
+					return err.Error()
+				}
 				agent := a.(*metrics.AgentTasksTotal)
 				cd := c.(*metrics.ConsumerdTasksTotal)
+				// Identical requests are coalesced, so they are only sent to the
+				// agent once but are counted separately by the consumerd.
+				coalescedTotal := co.(*metrics.CoalescedRequestsTotal).Total
 				if agent.UUID != agentID {
 					return "Agent UUIDs do not match"
 				}
 				if cd.UUID != consumerdID {
 					return "Consumerd UUIDs do not match"
 				}
-				if agent.Total != cd.Total {
-					return fmt.Sprintf("Task counts do not match (Agent (%d) != Consumerd: (%d)",
-						agent.Total, cd.Total)
+				if agent.Total+coalescedTotal != cd.Total {
+					return fmt.Sprintf("Task counts do not match (Agent (%d) + Coalesced (%d) != Consumerd: (%d)",
+						agent.Total, coalescedTotal, cd.Total)
 				}
 				return ""
 			},
@@ -112,6 +122,29 @@ var _ = Describe("Scheduler Server", func() {
 		).Should(BeEmpty())
 	})
 
+	It("should list tasks", func() {
+		client := test.NewSchedulerClient(testEnv, testEnv.Context())
+		stream, err := client.ListTasks(testEnv.Context(), &types.ListTasksRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = stream.Recv()
+		Expect(err).NotTo(HaveOccurred())
+		_, err = stream.Recv()
+		Expect(err).To(Equal(io.EOF))
+
+		By("sending updated task lists when watching")
+		ctx, cancel := context.WithCancel(testEnv.Context())
+		defer cancel()
+		stream, err = client.ListTasks(ctx, &types.ListTasksRequest{
+			Watch:    true,
+			Interval: int64(100 * time.Millisecond),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		for i := 0; i < 3; i++ {
+			_, err = stream.Recv()
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
 	Specify("shutdown", func() {
 		testEnv.Shutdown()
 	})
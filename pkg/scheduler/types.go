@@ -47,6 +47,12 @@ type Consumerd struct {
 // an agent can run.
 const MaxTokens = 1000
 
+// MaxDispatchAttempts is the number of times a request will be sent to an
+// agent before giving up and telling the consumerd to run it locally. A
+// request is only sent to an agent more than once if the agent it was
+// previously sent to became unavailable while processing it.
+const MaxDispatchAttempts = 3
+
 type Agent struct {
 	remoteInfo
 	*sync.RWMutex
@@ -56,6 +62,172 @@ type Agent struct {
 
 	AvailableTokens chan struct{}
 	LockedTokens    chan struct{}
+
+	tokensMu     sync.Mutex
+	tokenCount   int
+	excessTokens int
+	// awaitingRequest is true while a token has been taken from the pool for
+	// the next request, but no request has been sent yet.
+	awaitingRequest atomic.Bool
+
+	scheduling schedulingState
+}
+
+// schedulingState tracks whether new requests can be sent to an agent.
+// Agents start out schedulable.
+type schedulingState struct {
+	mu            sync.Mutex
+	cordoned      bool
+	cordonedCh    chan struct{} // closed while the agent is cordoned
+	schedulableCh chan struct{} // closed while the agent is schedulable
+}
+
+func (s *schedulingState) init() {
+	if s.cordonedCh == nil {
+		s.cordonedCh = make(chan struct{})
+		s.schedulableCh = make(chan struct{})
+		close(s.schedulableCh)
+	}
+}
+
+// Cordon marks the agent as unschedulable. Requests which have already been
+// sent to the agent will continue running, but no new requests will be sent
+// to it until it is uncordoned. It returns false if the agent was already
+// cordoned.
+func (a *Agent) Cordon() bool {
+	s := &a.scheduling
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.init()
+	if s.cordoned {
+		return false
+	}
+	s.cordoned = true
+	close(s.cordonedCh)
+	s.schedulableCh = make(chan struct{})
+	return true
+}
+
+// Uncordon marks the agent as schedulable again. It returns false if the
+// agent was not cordoned.
+func (a *Agent) Uncordon() bool {
+	s := &a.scheduling
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.init()
+	if !s.cordoned {
+		return false
+	}
+	s.cordoned = false
+	close(s.schedulableCh)
+	s.cordonedCh = make(chan struct{})
+	return true
+}
+
+// Cordoned returns true if the agent is unschedulable.
+func (a *Agent) Cordoned() bool {
+	a.scheduling.mu.Lock()
+	defer a.scheduling.mu.Unlock()
+	return a.scheduling.cordoned
+}
+
+// Schedulable returns a channel which is closed while the agent is
+// schedulable.
+func (a *Agent) Schedulable() <-chan struct{} {
+	a.scheduling.mu.Lock()
+	defer a.scheduling.mu.Unlock()
+	a.scheduling.init()
+	return a.scheduling.schedulableCh
+}
+
+// Unschedulable returns a channel which is closed while the agent is
+// cordoned.
+func (a *Agent) Unschedulable() <-chan struct{} {
+	a.scheduling.mu.Lock()
+	defer a.scheduling.mu.Unlock()
+	a.scheduling.init()
+	return a.scheduling.cordonedCh
+}
+
+// schedulableContext returns a context which is canceled when the agent is
+// cordoned or goes away.
+func (a *Agent) schedulableContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(a.Context)
+	unschedulable := a.Unschedulable()
+	go func() {
+		select {
+		case <-unschedulable:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// RunningTasks returns the number of requests the agent is currently
+// running.
+func (a *Agent) RunningTasks() int {
+	return len(a.LockedTokens)
+}
+
+// HasFreeTokens returns true if the agent can take another request without
+// waiting for a running request to complete.
+func (a *Agent) HasFreeTokens() bool {
+	return len(a.AvailableTokens) > 0 || a.awaitingRequest.Load()
+}
+
+// TokenCount returns the number of tokens the agent's token pool is
+// configured to hold.
+func (a *Agent) TokenCount() int {
+	a.tokensMu.Lock()
+	defer a.tokensMu.Unlock()
+	return a.tokenCount
+}
+
+// ResizeTokenPool changes the number of tokens in the agent's token pool.
+// When shrinking the pool, available tokens are removed first. If there are
+// not enough available tokens, the remaining tokens will be removed as they
+// are returned.
+func (a *Agent) ResizeTokenPool(count int) {
+	if count > MaxTokens {
+		count = MaxTokens
+	}
+	a.tokensMu.Lock()
+	defer a.tokensMu.Unlock()
+	delta := count - a.tokenCount
+	a.tokenCount = count
+	for ; delta > 0 && a.excessTokens > 0; delta-- {
+		a.excessTokens--
+	}
+	for ; delta > 0; delta-- {
+		a.AvailableTokens <- struct{}{}
+	}
+	for ; delta < 0; delta++ {
+		select {
+		case <-a.AvailableTokens:
+		default:
+			a.excessTokens++
+		}
+	}
+}
+
+// ReturnToken moves a locked token back into the agent's token pool, or
+// discards it if the pool has since been shrunk. It returns false if there
+// were no locked tokens.
+func (a *Agent) ReturnToken() bool {
+	a.tokensMu.Lock()
+	defer a.tokensMu.Unlock()
+	select {
+	case token := <-a.LockedTokens:
+		if a.excessTokens > 0 {
+			a.excessTokens--
+			return true
+		}
+		a.AvailableTokens <- token
+		return true
+	default:
+		return false
+	}
 }
 
 func remoteInfoFromContext(ctx context.Context) remoteInfo {
@@ -81,4 +253,5 @@ type taskStats struct {
 	completedTotal *metrics.TasksCompletedTotal
 	failedTotal    *metrics.TasksFailedTotal
 	requestsTotal  *metrics.SchedulingRequestsTotal
+	coalescedTotal *metrics.CoalescedRequestsTotal
 }
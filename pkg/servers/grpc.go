@@ -39,9 +39,10 @@ import (
 )
 
 type GRPCOptions struct {
-	tls           bool
-	dialOptions   []grpc.DialOption
-	serverOptions []grpc.ServerOption
+	tls              bool
+	dialOptions      []grpc.DialOption
+	serverOptions    []grpc.ServerOption
+	externalServices []string
 }
 type grpcOption func(*GRPCOptions)
 
@@ -69,6 +70,15 @@ func WithServerOpts(serverOptions ...grpc.ServerOption) grpcOption {
 	}
 }
 
+// WithExternalServices allows clients which are not kubecc components to
+// call the named services (e.g. "google.bytestream.ByteStream"). Requests to
+// these services are not required to contain kubecc metadata.
+func WithExternalServices(serviceNames ...string) grpcOption {
+	return func(op *GRPCOptions) {
+		op.externalServices = append(op.externalServices, serviceNames...)
+	}
+}
+
 func NewServer(ctx context.Context, opts ...grpcOption) *grpc.Server {
 	options := GRPCOptions{
 		tls: false,
@@ -90,6 +100,7 @@ func NewServer(ctx context.Context, opts ...grpcOption) *grpc.Server {
 				tracing.Tracer,
 			},
 		},
+		ExternalServices: options.externalServices,
 	}
 
 	return grpc.NewServer(
@@ -0,0 +1,220 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/kubecc-io/kubecc/pkg/meta"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Cache archives are gzip-compressed tar files containing one entry per
+// object. Entries are named after the object's hash, and the object metadata
+// is stored as JSON in a PAX record of the entry.
+const archiveMetadataRecord = "KUBECC.metadata"
+
+// ErrInvalidArchive indicates that a cache archive could not be read.
+var ErrInvalidArchive = errors.New("invalid cache archive")
+
+// ArchiveWriter writes cached objects to a cache archive.
+type ArchiveWriter struct {
+	gz *gzip.Writer
+	tw *tar.Writer
+}
+
+func NewArchiveWriter(w io.Writer) *ArchiveWriter {
+	gz := gzip.NewWriter(w)
+	return &ArchiveWriter{
+		gz: gz,
+		tw: tar.NewWriter(gz),
+	}
+}
+
+// WriteObject adds an object to the archive.
+func (aw *ArchiveWriter) WriteObject(key *types.CacheKey, object *types.CacheObject) error {
+	md, err := protojson.Marshal(object.GetMetadata())
+	if err != nil {
+		return err
+	}
+	modTime := time.Now()
+	if ts := object.GetMetadata().GetManagedFields().GetTimestamp(); ts > 0 {
+		modTime = time.Unix(ts, 0)
+	}
+	if err := aw.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     key.GetHash(),
+		Size:     int64(len(object.Data)),
+		Mode:     0o644,
+		ModTime:  modTime,
+		Format:   tar.FormatPAX,
+		PAXRecords: map[string]string{
+			archiveMetadataRecord: string(md),
+		},
+	}); err != nil {
+		return err
+	}
+	_, err = aw.tw.Write(object.Data)
+	return err
+}
+
+// Close finishes writing the archive. It does not close the underlying
+// writer.
+func (aw *ArchiveWriter) Close() error {
+	if err := aw.tw.Close(); err != nil {
+		return err
+	}
+	return aw.gz.Close()
+}
+
+// ArchiveReader reads cached objects from a cache archive.
+type ArchiveReader struct {
+	tr *tar.Reader
+}
+
+func NewArchiveReader(r io.Reader) (*ArchiveReader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidArchive, err.Error())
+	}
+	return &ArchiveReader{
+		tr: tar.NewReader(gz),
+	}, nil
+}
+
+// Next returns the next object in the archive, or io.EOF if there are no
+// more objects.
+func (ar *ArchiveReader) Next() (*types.CacheKey, *types.CacheObject, error) {
+	hdr, err := ar.tr.Next()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, io.EOF
+		}
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidArchive, err.Error())
+	}
+	if hdr.Typeflag != tar.TypeReg || len(hdr.Name) < 2 ||
+		strings.ContainsAny(hdr.Name, "/\\") || strings.HasPrefix(hdr.Name, ".") {
+		return nil, nil, fmt.Errorf("%w: unexpected entry %q",
+			ErrInvalidArchive, hdr.Name)
+	}
+	md := &types.CacheObjectMeta{}
+	if err := protojson.Unmarshal(
+		[]byte(hdr.PAXRecords[archiveMetadataRecord]), md); err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid metadata for %q: %s",
+			ErrInvalidArchive, hdr.Name, err.Error())
+	}
+	data, err := io.ReadAll(ar.tr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidArchive, err.Error())
+	}
+	key := &types.CacheKey{
+		Hash: hdr.Name,
+	}
+	return key, &types.CacheObject{
+		Data:     data,
+		Metadata: md,
+	}, nil
+}
+
+// ExportObjects writes all objects in the storage provider matching the
+// filter to a cache archive, and returns the number of objects written.
+// Objects are read using Peek, so exporting does not affect their retention.
+func ExportObjects(
+	ctx context.Context,
+	sp StorageProvider,
+	filter *types.CacheFilter,
+	w io.Writer,
+) (int64, error) {
+	aw := NewArchiveWriter(w)
+	var count int64
+	err := ForEachObject(ctx, sp, filter,
+		func(key *types.CacheKey, _ *types.CacheObjectMeta) error {
+			object, err := sp.Peek(ctx, key)
+			if err != nil {
+				switch status.Code(err) {
+				case codes.NotFound, codes.DataLoss:
+					// Expired or corrupt since it was listed
+					return nil
+				}
+				return err
+			}
+			if err := aw.WriteObject(key, object); err != nil {
+				return err
+			}
+			count++
+			return nil
+		})
+	if err != nil {
+		return count, err
+	}
+	return count, aw.Close()
+}
+
+// ImportObjects stores all objects in a cache archive in the storage
+// provider. Expiration dates are cleared so that the retention policy of the
+// storage provider applies to imported objects.
+func ImportObjects(
+	ctx context.Context,
+	sp StorageProvider,
+	r io.Reader,
+) (*types.ImportResponse, error) {
+	lg := meta.Log(ctx)
+	resp := &types.ImportResponse{}
+	ar, err := NewArchiveReader(r)
+	if err != nil {
+		return resp, err
+	}
+	for {
+		key, object, err := ar.Next()
+		if errors.Is(err, io.EOF) {
+			return resp, nil
+		}
+		if err != nil {
+			return resp, err
+		}
+		if err := VerifyObject(object); err != nil {
+			lg.With(
+				zap.Error(err),
+				"hash", key.GetHash(),
+			).Warn("Skipping corrupt object in archive")
+			resp.Invalid++
+			continue
+		}
+		object.Metadata.ExpirationDate = 0
+		object.Metadata.ManagedFields = nil
+		switch err := sp.Put(ctx, key, object); status.Code(err) {
+		case codes.OK:
+			resp.Imported++
+		case codes.AlreadyExists:
+			resp.Skipped++
+		default:
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+var _ = Describe("Cache Archives", func() {
+	It("Should export and import objects", func() {
+		src := storage.NewVolatileStorageProvider(testCtx, config.VolatileStorageSpec{
+			Limits: config.StorageLimitsSpec{
+				Memory: "1Mi",
+			},
+		})
+		Expect(src.Configure()).To(Succeed())
+		for _, tc := range []string{"gnu", "clang"} {
+			Expect(src.Put(testCtx, &types.CacheKey{Hash: tc}, &types.CacheObject{
+				Data: []byte(tc),
+				Metadata: &types.CacheObjectMeta{
+					Tags: map[string]string{storage.ToolchainTag: tc},
+				},
+			})).To(Succeed())
+		}
+		buf := &bytes.Buffer{}
+		count, err := storage.ExportObjects(testCtx, src, &types.CacheFilter{
+			Toolchains: []string{"clang"},
+		}, buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(BeEquivalentTo(1))
+
+		ar, err := storage.NewArchiveReader(bytes.NewReader(buf.Bytes()))
+		Expect(err).NotTo(HaveOccurred())
+		key, obj, err := ar.Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key.GetHash()).To(Equal("clang"))
+		Expect(obj.Data).To(BeEquivalentTo("clang"))
+		_, _, err = ar.Next()
+		Expect(errors.Is(err, io.EOF)).To(BeTrue())
+
+		dst := storage.NewVolatileStorageProvider(testCtx, config.VolatileStorageSpec{
+			Limits: config.StorageLimitsSpec{
+				Memory: "1Mi",
+			},
+		})
+		Expect(dst.Configure()).To(Succeed())
+		resp, err := storage.ImportObjects(testCtx, dst, bytes.NewReader(buf.Bytes()))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Imported).To(BeEquivalentTo(1))
+		obj, err = dst.Get(testCtx, &types.CacheKey{Hash: "clang"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obj.Metadata.Tags).To(HaveKeyWithValue(storage.ToolchainTag, "clang"))
+	})
+	It("Should export objects without affecting their retention", func() {
+		newProvider := func() storage.StorageProvider {
+			sp := storage.NewVolatileStorageProvider(testCtx, config.VolatileStorageSpec{
+				Limits: config.StorageLimitsSpec{
+					Memory: "1Mi",
+				},
+			}, storage.WithRetentionPolicy(storage.RetentionPolicy{
+				DefaultTTL:        time.Hour,
+				SlidingExpiration: true,
+			}))
+			Expect(sp.Configure()).To(Succeed())
+			return sp
+		}
+		fast, slow := newProvider(), newProvider()
+		chain := storage.NewChainStorageProvider(testCtx,
+			[]storage.StorageProvider{fast, slow})
+		Expect(chain.Configure()).To(Succeed())
+		expiration := time.Now().Add(10 * time.Minute).UnixNano()
+		key := &types.CacheKey{Hash: "export"}
+		Expect(slow.Put(testCtx, key, &types.CacheObject{
+			Data: []byte("export"),
+			Metadata: &types.CacheObjectMeta{
+				ExpirationDate: expiration,
+			},
+		})).To(Succeed())
+
+		count, err := storage.ExportObjects(testCtx, chain, nil, &bytes.Buffer{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(BeEquivalentTo(1))
+
+		Expect(chain.CacheHits().CacheHitsTotal).To(BeZero())
+		Expect(slow.CacheHits().CacheHitsTotal).To(BeZero())
+		results, err := slow.Query(testCtx, []*types.CacheKey{key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results[0].GetManagedFields().GetHits()).To(BeZero())
+		Expect(results[0].GetExpirationDate()).To(Equal(expiration))
+		Consistently(func() *types.CacheObjectMeta {
+			results, err := fast.Query(testCtx, []*types.CacheKey{key})
+			Expect(err).NotTo(HaveOccurred())
+			return results[0]
+		}, 200*time.Millisecond).Should(BeNil())
+	})
+	It("Should reject invalid archives", func() {
+		_, err := storage.NewArchiveReader(bytes.NewReader([]byte("invalid")))
+		Expect(err).To(MatchError(storage.ErrInvalidArchive))
+	})
+})
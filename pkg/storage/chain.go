@@ -19,24 +19,237 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/kubecc-io/kubecc/pkg/config"
 	"github.com/kubecc-io/kubecc/pkg/meta"
 	"github.com/kubecc-io/kubecc/pkg/metrics"
 	"github.com/kubecc-io/kubecc/pkg/types"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
+// WritePolicy controls which storage providers in a chain objects are
+// written to when they are stored.
+type WritePolicy int
+
+const (
+	// WriteThrough writes objects to every provider before returning.
+	WriteThrough WritePolicy = iota
+	// WriteBack writes objects to the fastest provider before returning, and
+	// writes them to the remaining providers in the background.
+	WriteBack
+	// WriteAround writes objects only to the slowest provider. Faster
+	// providers are populated by read-repair when objects are retrieved.
+	WriteAround
+)
+
+func (p WritePolicy) String() string {
+	switch p {
+	case WriteThrough:
+		return "writeThrough"
+	case WriteBack:
+		return "writeBack"
+	case WriteAround:
+		return "writeAround"
+	}
+	return fmt.Sprintf("WritePolicy(%d)", int(p))
+}
+
+// ParseWritePolicy returns the write policy with the given name. An empty
+// name selects WriteThrough.
+func ParseWritePolicy(name string) (WritePolicy, error) {
+	if name == "" {
+		return WriteThrough, nil
+	}
+	for _, p := range []WritePolicy{WriteThrough, WriteBack, WriteAround} {
+		if strings.EqualFold(p.String(), name) {
+			return p, nil
+		}
+	}
+	return WriteThrough, fmt.Errorf("%w: unknown write policy %q",
+		ConfigurationError, name)
+}
+
+// StatusReporter receives status conditions describing storage providers
+// that are failing. It is implemented by metrics.StatusController.
+type StatusReporter interface {
+	ApplyCondition(while context.Context, cond metrics.StatusConditions, msgs ...string)
+}
+
+// StatusReportingProvider is implemented by storage providers that report
+// the health of the providers they wrap.
+type StatusReportingProvider interface {
+	SetStatusReporter(StatusReporter)
+}
+
+type ChainStorageProviderOptions struct {
+	writePolicy        WritePolicy
+	writeBackQueueSize int
+	readRepairWorkers  int
+	readRepairRate     rate.Limit
+	failureThreshold   int
+	retryInterval      time.Duration
+	retention          RetentionPolicy
+}
+
+type ChainStorageProviderOption func(*ChainStorageProviderOptions)
+
+func (o *ChainStorageProviderOptions) Apply(opts ...ChainStorageProviderOption) {
+	for _, op := range opts {
+		op(o)
+	}
+}
+
+// WithWritePolicy sets the write policy used by the chain storage provider.
+// The default is WriteThrough.
+func WithWritePolicy(policy WritePolicy) ChainStorageProviderOption {
+	return func(o *ChainStorageProviderOptions) {
+		o.writePolicy = policy
+	}
+}
+
+// WithWriteBackQueueSize sets the maximum number of objects waiting to be
+// written to each provider when using the WriteBack policy. Objects are
+// dropped if the queue is full.
+func WithWriteBackQueueSize(size int) ChainStorageProviderOption {
+	return func(o *ChainStorageProviderOptions) {
+		o.writeBackQueueSize = size
+	}
+}
+
+// WithReadRepair sets the number of workers copying retrieved objects into
+// faster providers, and the maximum number of objects copied per second.
+func WithReadRepair(workers int, perSecond float64) ChainStorageProviderOption {
+	return func(o *ChainStorageProviderOptions) {
+		o.readRepairWorkers = workers
+		o.readRepairRate = rate.Limit(perSecond)
+	}
+}
+
+// WithFailureThreshold sets the number of consecutive failures after which a
+// provider is skipped, and how long it is skipped for before being retried.
+func WithFailureThreshold(failures int, retryInterval time.Duration) ChainStorageProviderOption {
+	return func(o *ChainStorageProviderOptions) {
+		o.failureThreshold = failures
+		o.retryInterval = retryInterval
+	}
+}
+
+// WithChainRetention sets the retention policy applied to objects before
+// they are written to any provider, so that every provider is given the same
+// expiration date, including providers which do not enforce a retention
+// policy of their own.
+func WithChainRetention(policy RetentionPolicy) ChainStorageProviderOption {
+	return func(o *ChainStorageProviderOptions) {
+		o.retention = policy
+	}
+}
+
+// ChainOptionsFromSpec returns the chain storage provider options described
+// by the given configuration.
+func ChainOptionsFromSpec(spec config.StorageChainSpec) ([]ChainStorageProviderOption, error) {
+	policy, err := ParseWritePolicy(spec.WritePolicy)
+	if err != nil {
+		return nil, err
+	}
+	opts := []ChainStorageProviderOption{WithWritePolicy(policy)}
+	if spec.WriteBackQueueSize > 0 {
+		opts = append(opts, WithWriteBackQueueSize(spec.WriteBackQueueSize))
+	}
+	if spec.ReadRepairWorkers > 0 || spec.ReadRepairRate > 0 {
+		defaults := defaultChainOptions()
+		workers, perSecond := spec.ReadRepairWorkers, float64(spec.ReadRepairRate)
+		if workers <= 0 {
+			workers = defaults.readRepairWorkers
+		}
+		if perSecond <= 0 {
+			perSecond = float64(defaults.readRepairRate)
+		}
+		opts = append(opts, WithReadRepair(workers, perSecond))
+	}
+	if spec.FailureThreshold > 0 || spec.RetryInterval != "" {
+		defaults := defaultChainOptions()
+		failures, retry := spec.FailureThreshold, defaults.retryInterval
+		if failures <= 0 {
+			failures = defaults.failureThreshold
+		}
+		if spec.RetryInterval != "" {
+			retry, err = time.ParseDuration(spec.RetryInterval)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid retry interval: %s",
+					ConfigurationError, err.Error())
+			}
+		}
+		opts = append(opts, WithFailureThreshold(failures, retry))
+	}
+	return opts, nil
+}
+
+func defaultChainOptions() ChainStorageProviderOptions {
+	return ChainStorageProviderOptions{
+		writePolicy:        WriteThrough,
+		writeBackQueueSize: 1024,
+		readRepairWorkers:  4,
+		readRepairRate:     100,
+		failureThreshold:   3,
+		retryInterval:      30 * time.Second,
+	}
+}
+
+// readRepairQueueSize is the maximum number of objects waiting to be copied
+// into faster providers. Objects are dropped if the queue is full.
+const readRepairQueueSize = 1024
+
+type chainWrite struct {
+	key    *types.CacheKey
+	object *types.CacheObject
+}
+
+type readRepair struct {
+	key    *types.CacheKey
+	object *types.CacheObject
+	// providers contains the indexes of the providers to write to, in order.
+	providers []int
+}
+
+// tierHealth tracks consecutive failures of a provider in the chain.
+type tierHealth struct {
+	mu       sync.Mutex
+	failures int
+	retryAt  time.Time
+	// clearCondition clears the status condition applied while the provider
+	// is failing.
+	clearCondition context.CancelFunc
+}
+
 // ChainStorageProvider is a chained/layered storage provider which queries
 // one or more other storage providers as to increase performance when
 // available. It is assumed that the provider list is sorted in order
 // from fastest to slowest.
+//
+// Providers that fail repeatedly are skipped until their retry interval has
+// passed, so that a single unavailable provider does not fail the chain.
 type ChainStorageProvider struct {
-	ctx       context.Context
-	lg        *zap.SugaredLogger
-	providers []StorageProvider
+	ChainStorageProviderOptions
+	ctx        context.Context
+	lg         *zap.SugaredLogger
+	providers  []StorageProvider
+	health     []*tierHealth
+	writeBack  []chan chainWrite
+	readRepair chan readRepair
+	limiter    *rate.Limiter
+	startOnce  sync.Once
+
+	reporterMu sync.Mutex
+	reporter   StatusReporter
 }
 
 // NewChainStorageProvider creates a new ChainStorageProvider with the
@@ -44,16 +257,54 @@ type ChainStorageProvider struct {
 // to slowest.
 func NewChainStorageProvider(
 	ctx context.Context,
-	providers ...StorageProvider,
-) StorageProvider {
+	providers []StorageProvider,
+	opts ...ChainStorageProviderOption,
+) *ChainStorageProvider {
+	options := defaultChainOptions()
+	options.Apply(opts...)
+
 	sp := &ChainStorageProvider{
-		ctx:       ctx,
-		lg:        meta.Log(ctx),
-		providers: providers,
+		ChainStorageProviderOptions: options,
+		ctx:                         ctx,
+		lg:                          meta.Log(ctx),
+		providers:                   providers,
+		health:                      make([]*tierHealth, len(providers)),
+		writeBack:                   make([]chan chainWrite, len(providers)),
+		readRepair:                  make(chan readRepair, readRepairQueueSize),
+		limiter:                     rate.NewLimiter(options.readRepairRate, 1),
+	}
+	for i := range providers {
+		sp.health[i] = &tierHealth{}
+		if options.writePolicy == WriteBack {
+			sp.writeBack[i] = make(chan chainWrite, options.writeBackQueueSize)
+		}
 	}
 	return sp
 }
 
+// SetStatusReporter sets the reporter notified when providers start or stop
+// failing.
+func (sp *ChainStorageProvider) SetStatusReporter(reporter StatusReporter) {
+	sp.reporterMu.Lock()
+	defer sp.reporterMu.Unlock()
+	sp.reporter = reporter
+}
+
+// Providers returns the providers in the chain, from fastest to slowest.
+func (sp *ChainStorageProvider) Providers() []StorageProvider {
+	return sp.providers
+}
+
+// Provider returns the provider in the chain with the given location.
+func (sp *ChainStorageProvider) Provider(loc types.StorageLocation) (StorageProvider, bool) {
+	for _, p := range sp.providers {
+		if p.Location() == loc {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
 func (sp *ChainStorageProvider) Location() types.StorageLocation {
 	return types.Memory
 }
@@ -64,38 +315,258 @@ func (sp *ChainStorageProvider) Configure() error {
 		if err := p.Configure(); err != nil {
 			return err
 		}
-		locations = append(locations,
-			strings.Replace(p.Location().String(), "StorageLocation_", "", 1))
+		locations = append(locations, locationName(p.Location()))
 	}
-	sp.lg.Infof("Cache order: %s", strings.Join(locations, " => "))
+	sp.lg.With(
+		"writePolicy", sp.writePolicy.String(),
+	).Infof("Cache order: %s", strings.Join(locations, " => "))
+	sp.startOnce.Do(sp.startWorkers)
 	return nil
 }
 
+func locationName(loc types.StorageLocation) string {
+	return strings.Replace(loc.String(), "StorageLocation_", "", 1)
+}
+
+func (sp *ChainStorageProvider) startWorkers() {
+	for i, queue := range sp.writeBack {
+		if queue != nil {
+			go sp.writeBackWorker(i, queue)
+		}
+	}
+	for i := 0; i < sp.readRepairWorkers; i++ {
+		go sp.readRepairWorker()
+	}
+}
+
+// available returns true if the provider at index i should be used. Failing
+// providers are retried once per retry interval.
+func (sp *ChainStorageProvider) available(i int) bool {
+	h := sp.health[i]
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.failures < sp.failureThreshold {
+		return true
+	}
+	if now := time.Now(); now.After(h.retryAt) {
+		h.retryAt = now.Add(sp.retryInterval)
+		return true
+	}
+	return false
+}
+
+// isProviderFailure returns true if the error indicates a problem with the
+// provider rather than with the requested object.
+func isProviderFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.NotFound, codes.AlreadyExists, codes.DataLoss,
+		codes.InvalidArgument, codes.Canceled:
+		return false
+	}
+	return true
+}
+
+// report records the result of an operation on the provider at index i.
+func (sp *ChainStorageProvider) report(ctx context.Context, i int, err error) {
+	if ctx.Err() != nil {
+		// The caller gave up, which says nothing about the provider.
+		return
+	}
+	h := sp.health[i]
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	name := locationName(sp.providers[i].Location())
+	if !isProviderFailure(ctx, err) {
+		if h.failures >= sp.failureThreshold {
+			sp.lg.With(
+				"provider", name,
+			).Info("Storage provider recovered")
+		}
+		h.failures = 0
+		if h.clearCondition != nil {
+			h.clearCondition()
+			h.clearCondition = nil
+		}
+		return
+	}
+	h.failures++
+	if h.failures < sp.failureThreshold {
+		return
+	}
+	h.retryAt = time.Now().Add(sp.retryInterval)
+	if h.failures > sp.failureThreshold {
+		return
+	}
+	sp.lg.With(
+		zap.Error(err),
+		"provider", name,
+		"retryInterval", sp.retryInterval,
+	).Warn("Storage provider is failing, skipping it")
+	sp.reporterMu.Lock()
+	reporter := sp.reporter
+	sp.reporterMu.Unlock()
+	if reporter != nil {
+		condCtx, cancel := context.WithCancel(sp.ctx)
+		h.clearCondition = cancel
+		reporter.ApplyCondition(condCtx, metrics.StatusConditions_MissingOptionalComponent,
+			fmt.Sprintf("%s storage is failing: %s", name, err.Error()))
+	}
+}
+
+// Healthy returns true if the provider at index i is not being skipped due
+// to repeated failures.
+func (sp *ChainStorageProvider) Healthy(i int) bool {
+	h := sp.health[i]
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.failures < sp.failureThreshold
+}
+
+func (sp *ChainStorageProvider) put(
+	ctx context.Context,
+	i int,
+	key *types.CacheKey,
+	object *types.CacheObject,
+) error {
+	err := sp.providers[i].Put(ctx, key, object)
+	sp.report(ctx, i, err)
+	return err
+}
+
+// Put stores the object according to the write policy. Failing providers
+// are skipped, and the object is stored successfully as long as at least
+// one provider accepts it.
 func (sp *ChainStorageProvider) Put(
 	ctx context.Context,
 	key *types.CacheKey,
 	object *types.CacheObject,
 ) error {
-	for _, p := range sp.providers {
-		if err := p.Put(ctx, key, object); err != nil {
-			return err
+	if object.Metadata == nil {
+		object.Metadata = &types.CacheObjectMeta{}
+	}
+	sp.retention.Apply(object.Metadata)
+	var lastErr error = status.Error(codes.Unavailable,
+		"No storage providers are available")
+	stored := false
+	switch sp.writePolicy {
+	case WriteThrough:
+		for i := range sp.providers {
+			if !sp.available(i) {
+				continue
+			}
+			if err := sp.put(ctx, i, key, object); err != nil {
+				if !stored {
+					lastErr = err
+				}
+				continue
+			}
+			stored = true
+		}
+	case WriteBack:
+		for i := range sp.providers {
+			if !sp.available(i) {
+				continue
+			}
+			if err := sp.put(ctx, i, key, object); err != nil {
+				lastErr = err
+				if isProviderFailure(ctx, err) {
+					continue
+				}
+				// The object already exists in the fastest provider, so it
+				// has already been written back.
+				return err
+			}
+			sp.enqueueWriteBack(i+1, key, object)
+			return nil
+		}
+	case WriteAround:
+		for i := len(sp.providers) - 1; i >= 0; i-- {
+			if !sp.available(i) {
+				continue
+			}
+			if err := sp.put(ctx, i, key, object); err != nil {
+				lastErr = err
+				if isProviderFailure(ctx, err) {
+					continue
+				}
+				return err
+			}
+			return nil
+		}
+	}
+	if stored {
+		return nil
+	}
+	return lastErr
+}
+
+// enqueueWriteBack queues the object to be written to each provider starting
+// at index first.
+func (sp *ChainStorageProvider) enqueueWriteBack(
+	first int,
+	key *types.CacheKey,
+	object *types.CacheObject,
+) {
+	for i := first; i < len(sp.providers); i++ {
+		// Providers may keep a reference to the object, so each provider
+		// receives its own copy.
+		write := chainWrite{
+			key:    proto.Clone(key).(*types.CacheKey),
+			object: proto.Clone(object).(*types.CacheObject),
+		}
+		select {
+		case sp.writeBack[i] <- write:
+		default:
+			sp.lg.With(
+				"provider", locationName(sp.providers[i].Location()),
+				"hash", key.GetHash(),
+			).Warn("Write-back queue is full, dropping object")
+		}
+	}
+}
+
+func (sp *ChainStorageProvider) writeBackWorker(i int, queue chan chainWrite) {
+	for {
+		select {
+		case <-sp.ctx.Done():
+			return
+		case write := <-queue:
+			if !sp.available(i) {
+				continue
+			}
+			err := sp.put(sp.ctx, i, write.key, write.object)
+			if err != nil && status.Code(err) != codes.AlreadyExists {
+				sp.lg.With(
+					zap.Error(err),
+					"provider", locationName(sp.providers[i].Location()),
+				).Debug("Write-back failed")
+			}
 		}
 	}
-	return nil
 }
 
 func (sp *ChainStorageProvider) Get(
 	ctx context.Context,
 	key *types.CacheKey,
 ) (object *types.CacheObject, err error) {
-	i := 0
+	err = status.Error(codes.NotFound, "Object not found")
+	missing := []int{}
 	// Find the first provider containing the object
-	for ; i < len(sp.providers); i++ {
-		p := sp.providers[i]
-		if object, err = p.Get(ctx, key); err == nil {
+	for i := range sp.providers {
+		if !sp.available(i) {
+			continue
+		}
+		object, err = sp.providers[i].Get(ctx, key)
+		sp.report(ctx, i, err)
+		if err == nil {
 			// Found
 			break
 		}
+		missing = append(missing, i)
 	}
 
 	if err != nil {
@@ -103,23 +574,76 @@ func (sp *ChainStorageProvider) Get(
 		return nil, err
 	}
 
-	// Loop backwards through providers missing the key and store them
-	go func(k *types.CacheKey, obj *types.CacheObject) {
-		for i--; i >= 0; i-- {
-			p := sp.providers[i]
-			if err := p.Put(sp.ctx, k, obj); err != nil {
-				// Something bad happened
-				sp.lg.Error(err)
-				break
-			}
+	if len(missing) > 0 {
+		// Store the object in the providers missing it, from slowest to
+		// fastest
+		for l, r := 0, len(missing)-1; l < r; l, r = l+1, r-1 {
+			missing[l], missing[r] = missing[r], missing[l]
+		}
+		select {
+		case sp.readRepair <- readRepair{
+			key:       proto.Clone(key).(*types.CacheKey),
+			object:    proto.Clone(object).(*types.CacheObject),
+			providers: missing,
+		}:
+		default:
+			sp.lg.With(
+				"hash", key.GetHash(),
+			).Debug("Read-repair queue is full, skipping")
 		}
-	}(
-		proto.Clone(key).(*types.CacheKey),
-		proto.Clone(object).(*types.CacheObject),
-	)
+	}
 	return
 }
 
+func (sp *ChainStorageProvider) readRepairWorker() {
+	for {
+		select {
+		case <-sp.ctx.Done():
+			return
+		case repair := <-sp.readRepair:
+			if err := sp.limiter.Wait(sp.ctx); err != nil {
+				return
+			}
+			for n, i := range repair.providers {
+				if !sp.available(i) {
+					continue
+				}
+				object := repair.object
+				if n < len(repair.providers)-1 {
+					object = proto.Clone(object).(*types.CacheObject)
+				}
+				err := sp.put(sp.ctx, i, repair.key, object)
+				if err != nil && status.Code(err) != codes.AlreadyExists {
+					sp.lg.With(
+						zap.Error(err),
+						"provider", locationName(sp.providers[i].Location()),
+					).Debug("Read-repair failed")
+				}
+			}
+		}
+	}
+}
+
+// Peek returns the object from the first provider containing it. Unlike
+// Get, the object is not copied to the providers missing it.
+func (sp *ChainStorageProvider) Peek(
+	ctx context.Context,
+	key *types.CacheKey,
+) (object *types.CacheObject, err error) {
+	err = status.Error(codes.NotFound, "Object not found")
+	for i := range sp.providers {
+		if !sp.available(i) {
+			continue
+		}
+		object, err = sp.providers[i].Peek(ctx, key)
+		sp.report(ctx, i, err)
+		if err == nil {
+			return
+		}
+	}
+	return nil, err
+}
+
 func (sp *ChainStorageProvider) Query(
 	ctx context.Context,
 	keys []*types.CacheKey,
@@ -131,8 +655,14 @@ func (sp *ChainStorageProvider) Query(
 		go func(i int) {
 			defer wg.Done()
 			for p := 0; p < len(sp.providers) && results[i] == nil; p++ {
-				result, _ := sp.providers[i].Query(ctx, keys)
-				results[i] = result[0]
+				if !sp.available(p) {
+					continue
+				}
+				result, err := sp.providers[p].Query(ctx, keys[i:i+1])
+				sp.report(ctx, p, err)
+				if err == nil && len(result) > 0 {
+					results[i] = result[0]
+				}
 			}
 		}(i)
 	}
@@ -140,6 +670,66 @@ func (sp *ChainStorageProvider) Query(
 	return results, nil
 }
 
+// Delete removes the object from every available provider. It returns a
+// NotFound error if no provider contained the object.
+func (sp *ChainStorageProvider) Delete(
+	ctx context.Context,
+	key *types.CacheKey,
+) error {
+	var failed error
+	deleted := false
+	for i, p := range sp.providers {
+		if !sp.available(i) {
+			continue
+		}
+		err := p.Delete(ctx, key)
+		sp.report(ctx, i, err)
+		switch status.Code(err) {
+		case codes.OK:
+			deleted = true
+		case codes.NotFound:
+		default:
+			failed = err
+		}
+	}
+	switch {
+	case failed != nil:
+		return failed
+	case !deleted:
+		return status.Error(codes.NotFound, "Object not found")
+	}
+	return nil
+}
+
+// List returns the union of the keys stored in each available provider.
+func (sp *ChainStorageProvider) List(
+	ctx context.Context,
+) ([]*types.CacheKey, error) {
+	seen := map[string]struct{}{}
+	keys := []*types.CacheKey{}
+	for i, p := range sp.providers {
+		if !sp.available(i) {
+			continue
+		}
+		list, err := p.List(ctx)
+		sp.report(ctx, i, err)
+		if err != nil {
+			if isProviderFailure(ctx, err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, key := range list {
+			if _, ok := seen[key.GetHash()]; ok {
+				continue
+			}
+			seen[key.GetHash()] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
 func (sp *ChainStorageProvider) UsageInfo() *metrics.CacheUsage {
 	if len(sp.providers) == 0 {
 		return &metrics.CacheUsage{}
@@ -151,5 +741,15 @@ func (sp *ChainStorageProvider) CacheHits() *metrics.CacheHits {
 	if len(sp.providers) == 0 {
 		return &metrics.CacheHits{}
 	}
-	return sp.providers[0].CacheHits() // todo
+	// Providers may not return any stats until they have been used
+	hits := &metrics.CacheHits{}
+	if first := sp.providers[0].CacheHits(); first != nil {
+		hits = proto.Clone(first).(*metrics.CacheHits)
+	}
+	// Corrupt objects are counted across all providers, since they are
+	// quarantined by whichever provider detected them.
+	for _, p := range sp.providers[1:] {
+		hits.CorruptObjectsTotal += p.CacheHits().GetCorruptObjectsTotal()
+	}
+	return hits
 }
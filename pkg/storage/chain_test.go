@@ -0,0 +1,285 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/metrics"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+// flakyProvider wraps a storage provider and fails all requests while
+// failing is set.
+type flakyProvider struct {
+	storage.StorageProvider
+	failing *atomic.Bool
+	calls   *atomic.Int64
+}
+
+func newFlakyProvider() *flakyProvider {
+	return &flakyProvider{
+		StorageProvider: storage.NewVolatileStorageProvider(testCtx,
+			config.VolatileStorageSpec{
+				Limits: config.StorageLimitsSpec{
+					Memory: "1Mi",
+				},
+			}),
+		failing: atomic.NewBool(false),
+		calls:   atomic.NewInt64(0),
+	}
+}
+
+func (p *flakyProvider) err() error {
+	p.calls.Inc()
+	if p.failing.Load() {
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+	return nil
+}
+
+func (p *flakyProvider) Put(
+	ctx context.Context,
+	key *types.CacheKey,
+	object *types.CacheObject,
+) error {
+	if err := p.err(); err != nil {
+		return err
+	}
+	return p.StorageProvider.Put(ctx, key, object)
+}
+
+func (p *flakyProvider) Get(
+	ctx context.Context,
+	key *types.CacheKey,
+) (*types.CacheObject, error) {
+	if err := p.err(); err != nil {
+		return nil, err
+	}
+	return p.StorageProvider.Get(ctx, key)
+}
+
+func (p *flakyProvider) has(hash string) bool {
+	results, err := p.StorageProvider.Query(testCtx, []*types.CacheKey{{Hash: hash}})
+	return err == nil && results[0] != nil
+}
+
+type testStatusReporter struct {
+	mu         sync.Mutex
+	conditions []context.Context
+}
+
+func (r *testStatusReporter) ApplyCondition(
+	while context.Context,
+	cond metrics.StatusConditions,
+	msgs ...string,
+) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.conditions = append(r.conditions, while)
+}
+
+func (r *testStatusReporter) active() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	count := 0
+	for _, ctx := range r.conditions {
+		if ctx.Err() == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// unusedProvider reports no cache hit stats, like a provider that has not
+// been used yet.
+type unusedProvider struct {
+	*flakyProvider
+}
+
+func (unusedProvider) CacheHits() *metrics.CacheHits {
+	return nil
+}
+
+var _ = Describe("Chain Storage Provider", func() {
+	object := func() *types.CacheObject {
+		return &types.CacheObject{
+			Data: []byte("12345"),
+		}
+	}
+	newChain := func(
+		opts ...storage.ChainStorageProviderOption,
+	) (*storage.ChainStorageProvider, *flakyProvider, *flakyProvider) {
+		fast, slow := newFlakyProvider(), newFlakyProvider()
+		chain := storage.NewChainStorageProvider(testCtx,
+			[]storage.StorageProvider{fast, slow}, opts...)
+		Expect(chain.Configure()).To(Succeed())
+		return chain, fast, slow
+	}
+
+	It("Should parse write policies", func() {
+		for name, policy := range map[string]storage.WritePolicy{
+			"":             storage.WriteThrough,
+			"writeThrough": storage.WriteThrough,
+			"writeback":    storage.WriteBack,
+			"WriteAround":  storage.WriteAround,
+		} {
+			p, err := storage.ParseWritePolicy(name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p).To(Equal(policy))
+		}
+		_, err := storage.ParseWritePolicy("writeSometimes")
+		Expect(err).To(MatchError(storage.ConfigurationError))
+	})
+	Context("Write-through", func() {
+		It("Should write to all providers", func() {
+			chain, fast, slow := newChain()
+			Expect(chain.Put(testCtx, &types.CacheKey{Hash: "aa"}, object())).To(Succeed())
+			Expect(fast.has("aa")).To(BeTrue())
+			Expect(slow.has("aa")).To(BeTrue())
+		})
+		It("Should skip failing providers and report their status", func() {
+			chain, fast, slow := newChain(
+				storage.WithFailureThreshold(2, 100*time.Millisecond))
+			reporter := &testStatusReporter{}
+			chain.SetStatusReporter(reporter)
+
+			slow.failing.Store(true)
+			for _, hash := range []string{"a1", "a2", "a3", "a4"} {
+				Expect(chain.Put(testCtx, &types.CacheKey{Hash: hash}, object())).To(Succeed())
+				Expect(fast.has(hash)).To(BeTrue())
+			}
+			By("Skipping the provider after it fails repeatedly")
+			Expect(slow.calls.Load()).To(Equal(int64(2)))
+			Expect(chain.Healthy(0)).To(BeTrue())
+			Expect(chain.Healthy(1)).To(BeFalse())
+			Expect(reporter.active()).To(Equal(1))
+
+			By("Retrying the provider after the retry interval")
+			slow.failing.Store(false)
+			time.Sleep(150 * time.Millisecond)
+			Expect(chain.Put(testCtx, &types.CacheKey{Hash: "a5"}, object())).To(Succeed())
+			Expect(slow.has("a5")).To(BeTrue())
+			Expect(chain.Healthy(1)).To(BeTrue())
+			Expect(reporter.active()).To(Equal(0))
+		})
+		It("Should fail if no provider accepts the object", func() {
+			chain, fast, slow := newChain()
+			fast.failing.Store(true)
+			slow.failing.Store(true)
+			err := chain.Put(testCtx, &types.CacheKey{Hash: "aa"}, object())
+			Expect(status.Code(err)).To(Equal(codes.Unavailable))
+		})
+	})
+	Context("Write-back", func() {
+		It("Should write to slower providers in the background", func() {
+			chain, fast, slow := newChain(storage.WithWritePolicy(storage.WriteBack))
+			Expect(chain.Put(testCtx, &types.CacheKey{Hash: "bb"}, object())).To(Succeed())
+			Expect(fast.has("bb")).To(BeTrue())
+			Eventually(func() bool {
+				return slow.has("bb")
+			}).Should(BeTrue())
+		})
+		It("Should write to the next provider if the fastest one fails", func() {
+			chain, fast, slow := newChain(storage.WithWritePolicy(storage.WriteBack))
+			fast.failing.Store(true)
+			Expect(chain.Put(testCtx, &types.CacheKey{Hash: "bb"}, object())).To(Succeed())
+			Expect(slow.has("bb")).To(BeTrue())
+		})
+	})
+	Context("Write-around", func() {
+		It("Should only write to the slowest provider", func() {
+			chain, fast, slow := newChain(storage.WithWritePolicy(storage.WriteAround))
+			Expect(chain.Put(testCtx, &types.CacheKey{Hash: "cc"}, object())).To(Succeed())
+			Expect(fast.has("cc")).To(BeFalse())
+			Expect(slow.has("cc")).To(BeTrue())
+
+			By("Repairing faster providers when the object is retrieved")
+			obj, err := chain.Get(testCtx, &types.CacheKey{Hash: "cc"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obj.Data).To(BeEquivalentTo("12345"))
+			Eventually(func() bool {
+				return fast.has("cc")
+			}).Should(BeTrue())
+		})
+	})
+	Context("Retention", func() {
+		It("Should give every provider the same expiration date", func() {
+			chain, fast, slow := newChain(storage.WithChainRetention(
+				storage.RetentionPolicy{
+					DefaultTTL: time.Hour,
+				}))
+			Expect(chain.Put(testCtx, &types.CacheKey{Hash: "ee"}, object())).To(Succeed())
+			key := []*types.CacheKey{{Hash: "ee"}}
+			fastMeta, err := fast.Query(testCtx, key)
+			Expect(err).NotTo(HaveOccurred())
+			slowMeta, err := slow.Query(testCtx, key)
+			Expect(err).NotTo(HaveOccurred())
+			// The providers in the chain have no retention policy of their own
+			Expect(time.Unix(0, slowMeta[0].GetExpirationDate())).
+				To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+			Expect(fastMeta[0].GetExpirationDate()).
+				To(Equal(slowMeta[0].GetExpirationDate()))
+		})
+	})
+	Context("Read-repair", func() {
+		It("Should limit the rate of repairs", func() {
+			chain, fast, slow := newChain(storage.WithReadRepair(2, 10))
+			hashes := []string{"d1", "d2", "d3", "d4", "d5", "d6"}
+			for _, hash := range hashes {
+				Expect(slow.Put(testCtx, &types.CacheKey{Hash: hash}, object())).To(Succeed())
+			}
+			start := time.Now()
+			for _, hash := range hashes {
+				_, err := chain.Get(testCtx, &types.CacheKey{Hash: hash})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Eventually(func() bool {
+				for _, hash := range hashes {
+					if !fast.has(hash) {
+						return false
+					}
+				}
+				return true
+			}).Should(BeTrue())
+			Expect(time.Since(start)).To(BeNumerically(">=", 400*time.Millisecond))
+		})
+	})
+	Context("Stats", func() {
+		It("Should report stats before the first provider has been used", func() {
+			fast, slow := newFlakyProvider(), newFlakyProvider()
+			chain := storage.NewChainStorageProvider(testCtx,
+				[]storage.StorageProvider{unusedProvider{fast}, slow})
+			Expect(chain.Configure()).To(Succeed())
+			hits := chain.CacheHits()
+			Expect(hits).NotTo(BeNil())
+			Expect(hits.CacheHitsTotal).To(BeZero())
+			Expect(hits.CorruptObjectsTotal).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,56 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"github.com/kubecc-io/kubecc/pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// QuarantineDir is the name of the directory (or key prefix) that storage
+// providers move corrupt objects into so that they can be inspected later.
+const QuarantineDir = "quarantine"
+
+// ErrCorruptObject indicates that the data of an object does not match the
+// digest it was stored with.
+var ErrCorruptObject = errors.New("object data does not match its digest")
+
+// VerifyObject checks the data of an object against its digest. Objects
+// stored without a digest cannot be verified and are assumed to be valid.
+func VerifyObject(object *types.CacheObject) error {
+	expected := object.GetMetadata().GetManagedFields().GetDigest()
+	if expected == "" {
+		return nil
+	}
+	if actual := util.Digest(object.GetData()); actual != expected {
+		return fmt.Errorf("%w (expected %s, got %s)",
+			ErrCorruptObject, expected, actual)
+	}
+	return nil
+}
+
+// corruptObjectError returns the error returned by Get when an object is
+// found to be corrupt. It is treated as a cache miss by callers.
+func corruptObjectError(err error) error {
+	return status.Error(codes.DataLoss, err.Error())
+}
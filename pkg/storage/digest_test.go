@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"github.com/kubecc-io/kubecc/pkg/util"
+)
+
+var _ = Describe("Object Integrity", func() {
+	Specify("objects without a digest should not be verified", func() {
+		Expect(storage.VerifyObject(&types.CacheObject{
+			Data: []byte("data"),
+		})).To(Succeed())
+	})
+	Specify("objects with a matching digest should be verified", func() {
+		Expect(storage.VerifyObject(&types.CacheObject{
+			Data: []byte("data"),
+			Metadata: &types.CacheObjectMeta{
+				ManagedFields: &types.CacheObjectManaged{
+					Digest: util.Digest([]byte("data")),
+				},
+			},
+		})).To(Succeed())
+		Expect(storage.VerifyObject(&types.CacheObject{
+			Data: []byte("date"),
+			Metadata: &types.CacheObjectMeta{
+				ManagedFields: &types.CacheObjectManaged{
+					Digest: util.Digest([]byte("data")),
+				},
+			},
+		})).To(MatchError(storage.ErrCorruptObject))
+	})
+
+	When("an object in volatile storage is corrupted", func() {
+		It("should be removed and reported as corrupt", func() {
+			vsp := storage.NewVolatileStorageProvider(testCtx,
+				config.VolatileStorageSpec{
+					Limits: config.StorageLimitsSpec{
+						Memory: "1Ki",
+					},
+				})
+			Expect(vsp.Configure()).To(Succeed())
+			key := &types.CacheKey{Hash: "corrupt-object"}
+			object := &types.CacheObject{
+				Data: []byte("original data"),
+			}
+			Expect(vsp.Put(testCtx, key, object)).To(Succeed())
+			Expect(vsp.Get(testCtx, key)).NotTo(BeNil())
+
+			object.Data[0] = 'O'
+			_, err := vsp.Get(testCtx, key)
+			Expect(status.Code(err)).To(Equal(codes.DataLoss))
+			Expect(vsp.CacheHits().CorruptObjectsTotal).To(BeEquivalentTo(1))
+
+			_, err = vsp.Get(testCtx, key)
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+	})
+
+	When("an object in local storage is corrupted", func() {
+		var (
+			tempDir string
+			lsp     *storage.LocalStorageProvider
+			cancel  context.CancelFunc
+		)
+		BeforeEach(func() {
+			var err error
+			tempDir, err = ioutil.TempDir("", "digest-test")
+			Expect(err).NotTo(HaveOccurred())
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(testCtx)
+			lsp = storage.NewLocalStorageProvider(ctx, config.LocalStorageSpec{
+				Path: tempDir,
+				Limits: config.StorageLimitsSpec{
+					Disk: "10Ki",
+				},
+			}).(*storage.LocalStorageProvider)
+			Expect(lsp.Configure()).To(Succeed())
+		})
+		AfterEach(func() {
+			cancel()
+			Expect(os.RemoveAll(tempDir)).To(Succeed())
+		})
+		It("should be quarantined and reported as corrupt", func() {
+			key := &types.CacheKey{Hash: "corrupt-object"}
+			Expect(lsp.Put(testCtx, key, &types.CacheObject{
+				Data: []byte("original data"),
+			})).To(Succeed())
+			Expect(lsp.Get(testCtx, key)).NotTo(BeNil())
+
+			By("flipping bits in the stored object")
+			path := filepath.Join(tempDir, key.Hash[0:2], key.Hash)
+			data, err := ioutil.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			stored := &types.CacheObject{}
+			Expect(proto.Unmarshal(data, stored)).To(Succeed())
+			stored.Data[0] ^= 0xFF
+			data, err = proto.Marshal(stored)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ioutil.WriteFile(path, data, 0644)).To(Succeed())
+
+			_, err = lsp.Get(testCtx, key)
+			Expect(status.Code(err)).To(Equal(codes.DataLoss))
+			Expect(lsp.CacheHits().CorruptObjectsTotal).To(BeEquivalentTo(1))
+			Expect(filepath.Join(tempDir, storage.QuarantineDir, key.Hash)).
+				To(BeARegularFile())
+			Expect(path).NotTo(BeAnExistingFile())
+
+			keys, err := lsp.List(testCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(BeEmpty())
+			Expect(lsp.UsageInfo().ObjectCount).To(BeEquivalentTo(0))
+
+			_, err = lsp.Get(testCtx, key)
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+	})
+})
@@ -0,0 +1,265 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage
+
+import (
+	"container/heap"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"go.uber.org/atomic"
+)
+
+// Names of the eviction policies which can be selected in StorageLimitsSpec.
+const (
+	EvictionLRU  = "lru"
+	EvictionLFU  = "lfu"
+	EvictionGDSF = "gdsf"
+)
+
+// gdsfScale is used to keep GDSF scores as integers. An object of size
+// gdsfScale bytes gains a score of 1 for each hit.
+const gdsfScale = 1 << 20
+
+// EvictionPolicy determines which objects are evicted from a storage provider
+// when it runs out of space. Objects with the lowest score are evicted first.
+type EvictionPolicy interface {
+	// Score returns the eviction score of an object given the number of times
+	// it has been retrieved, the last time it was accessed, and its size.
+	Score(hits int64, lastAccess time.Time, size int64) int64
+	// Evicted is called with the score of each object that is evicted.
+	Evicted(score int64)
+}
+
+// NewEvictionPolicy returns the eviction policy with the given name. If the
+// name is empty, nil is returned and the provider's default eviction
+// behavior is used.
+func NewEvictionPolicy(name string) (EvictionPolicy, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case EvictionLRU:
+		return LRUPolicy{}, nil
+	case EvictionLFU:
+		return LFUPolicy{}, nil
+	case EvictionGDSF:
+		return &GDSFPolicy{
+			inflation: atomic.NewInt64(0),
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown eviction policy %q",
+			ConfigurationError, name)
+	}
+}
+
+// LRUPolicy evicts the least recently used objects first.
+type LRUPolicy struct{}
+
+func (LRUPolicy) Score(hits int64, lastAccess time.Time, size int64) int64 {
+	return lastAccess.UnixNano()
+}
+
+func (LRUPolicy) Evicted(int64) {}
+
+// LFUPolicy evicts the least frequently used objects first. Objects with the
+// same number of hits are evicted in LRU order.
+type LFUPolicy struct{}
+
+func (LFUPolicy) Score(hits int64, lastAccess time.Time, size int64) int64 {
+	return hits
+}
+
+func (LFUPolicy) Evicted(int64) {}
+
+// GDSFPolicy implements Greedy-Dual-Size-Frequency eviction. Objects are
+// scored by their hit count relative to their size, so that small objects
+// which are used often (such as the results of compiling headers-heavy
+// sources) are kept over large objects which are rarely used. An inflation
+// value, set to the score of the last evicted object, is added to each score
+// so that objects which were popular in the past eventually age out.
+type GDSFPolicy struct {
+	inflation *atomic.Int64
+}
+
+func (p *GDSFPolicy) Score(hits int64, lastAccess time.Time, size int64) int64 {
+	if size < 1 {
+		size = 1
+	}
+	return p.inflation.Load() + (hits+1)*gdsfScale/size
+}
+
+func (p *GDSFPolicy) Evicted(score int64) {
+	for {
+		current := p.inflation.Load()
+		if score <= current || p.inflation.CAS(current, score) {
+			return
+		}
+	}
+}
+
+type evictionEntry struct {
+	hash       string
+	hits       int64
+	lastAccess time.Time
+	size       int64
+	score      int64
+	index      int
+}
+
+type evictionHeap []*evictionEntry
+
+func (h evictionHeap) Len() int { return len(h) }
+
+func (h evictionHeap) Less(i, j int) bool {
+	if h[i].score == h[j].score {
+		return h[i].lastAccess.Before(h[j].lastAccess)
+	}
+	return h[i].score < h[j].score
+}
+
+func (h evictionHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *evictionHeap) Push(x interface{}) {
+	entry := x.(*evictionEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *evictionHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return entry
+}
+
+// EvictionTracker records the hit counts and access times of objects in a
+// storage provider and orders them according to an eviction policy. If the
+// policy is nil, hits are still recorded but objects are not scored.
+type EvictionTracker struct {
+	policy  EvictionPolicy
+	entries map[string]*evictionEntry
+	heap    evictionHeap
+	lock    sync.Mutex
+}
+
+// NewEvictionTracker creates a new EvictionTracker using the given policy.
+func NewEvictionTracker(policy EvictionPolicy) *EvictionTracker {
+	return &EvictionTracker{
+		policy:  policy,
+		entries: make(map[string]*evictionEntry),
+	}
+}
+
+// The lock must be held when calling this function.
+func (t *EvictionTracker) rescore(entry *evictionEntry) {
+	if t.policy != nil {
+		entry.score = t.policy.Score(entry.hits, entry.lastAccess, entry.size)
+	}
+}
+
+// Add starts tracking an object using its managed fields, or updates the
+// object if it is already being tracked. The object's score is written back
+// to the managed fields.
+func (t *EvictionTracker) Add(hash string, managed *types.CacheObjectManaged) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	entry, ok := t.entries[hash]
+	if !ok {
+		entry = &evictionEntry{
+			hash: hash,
+		}
+	}
+	entry.hits = managed.GetHits()
+	entry.lastAccess = time.Now()
+	entry.size = managed.GetSize()
+	t.rescore(entry)
+	if ok {
+		heap.Fix(&t.heap, entry.index)
+	} else {
+		t.entries[hash] = entry
+		heap.Push(&t.heap, entry)
+	}
+	managed.Score = entry.score
+}
+
+// Hit records a hit for the given object and writes its updated hit count
+// and score to the managed fields. Returns false if the object is not being
+// tracked.
+func (t *EvictionTracker) Hit(hash string, managed *types.CacheObjectManaged) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	entry, ok := t.entries[hash]
+	if !ok {
+		return false
+	}
+	entry.hits++
+	entry.lastAccess = time.Now()
+	t.rescore(entry)
+	heap.Fix(&t.heap, entry.index)
+	managed.Hits = entry.hits
+	managed.Score = entry.score
+	return true
+}
+
+// Remove stops tracking the given object and returns its size. Returns false
+// if the object was not being tracked.
+func (t *EvictionTracker) Remove(hash string) (int64, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	entry, ok := t.entries[hash]
+	if !ok {
+		return 0, false
+	}
+	heap.Remove(&t.heap, entry.index)
+	delete(t.entries, hash)
+	return entry.size, true
+}
+
+// Evict stops tracking the object with the lowest score and returns its hash
+// and size. The caller is responsible for deleting the object. Returns false
+// if there are no objects to evict or if the tracker has no policy.
+func (t *EvictionTracker) Evict() (string, int64, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.policy == nil || len(t.heap) == 0 {
+		return "", 0, false
+	}
+	entry := heap.Pop(&t.heap).(*evictionEntry)
+	delete(t.entries, entry.hash)
+	t.policy.Evicted(entry.score)
+	return entry.hash, entry.size, true
+}
+
+// Len returns the number of objects being tracked.
+func (t *EvictionTracker) Len() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return len(t.heap)
+}
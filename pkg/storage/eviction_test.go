@@ -0,0 +1,253 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+var _ = Describe("Eviction", func() {
+	Context("Policies", func() {
+		It("should reject unknown policies", func() {
+			_, err := storage.NewEvictionPolicy("fifo")
+			Expect(err).To(MatchError(storage.ConfigurationError))
+			policy, err := storage.NewEvictionPolicy("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy).To(BeNil())
+		})
+		It("should order objects by LRU", func() {
+			policy, err := storage.NewEvictionPolicy(storage.EvictionLRU)
+			Expect(err).NotTo(HaveOccurred())
+			tracker := storage.NewEvictionTracker(policy)
+			for i := 0; i < 3; i++ {
+				tracker.Add(fmt.Sprint(i), &types.CacheObjectManaged{Size: 1})
+			}
+			tracker.Hit("0", &types.CacheObjectManaged{})
+			for _, expected := range []string{"1", "2", "0"} {
+				hash, _, ok := tracker.Evict()
+				Expect(ok).To(BeTrue())
+				Expect(hash).To(Equal(expected))
+			}
+			_, _, ok := tracker.Evict()
+			Expect(ok).To(BeFalse())
+		})
+		It("should order objects by LFU", func() {
+			policy, err := storage.NewEvictionPolicy(storage.EvictionLFU)
+			Expect(err).NotTo(HaveOccurred())
+			tracker := storage.NewEvictionTracker(policy)
+			for i := 0; i < 3; i++ {
+				tracker.Add(fmt.Sprint(i), &types.CacheObjectManaged{Size: 1})
+			}
+			managed := &types.CacheObjectManaged{}
+			for i := 0; i < 3; i++ {
+				Expect(tracker.Hit("0", managed)).To(BeTrue())
+			}
+			Expect(managed.Hits).To(BeEquivalentTo(3))
+			Expect(managed.Score).To(BeEquivalentTo(3))
+			tracker.Hit("2", managed)
+			for _, expected := range []string{"1", "2", "0"} {
+				hash, _, _ := tracker.Evict()
+				Expect(hash).To(Equal(expected))
+			}
+		})
+		It("should order objects by GDSF", func() {
+			policy, err := storage.NewEvictionPolicy(storage.EvictionGDSF)
+			Expect(err).NotTo(HaveOccurred())
+			tracker := storage.NewEvictionTracker(policy)
+			tracker.Add("small", &types.CacheObjectManaged{Size: 1024})
+			tracker.Add("large", &types.CacheObjectManaged{Size: 1024 * 1024})
+			tracker.Add("popular-large", &types.CacheObjectManaged{Size: 1024 * 1024})
+			for i := 0; i < 10; i++ {
+				tracker.Hit("popular-large", &types.CacheObjectManaged{})
+			}
+			hash, size, _ := tracker.Evict()
+			Expect(hash).To(Equal("large"))
+			Expect(size).To(BeEquivalentTo(1024 * 1024))
+
+			By("aging out previously popular objects")
+			// New objects are scored relative to the last evicted object
+			tracker.Add("new-large", &types.CacheObjectManaged{Size: 1024 * 1024})
+			managed := &types.CacheObjectManaged{}
+			tracker.Hit("new-large", managed)
+			Expect(managed.Score).To(BeNumerically(">", 2))
+		})
+		It("should record hits without a policy", func() {
+			tracker := storage.NewEvictionTracker(nil)
+			tracker.Add("1", &types.CacheObjectManaged{Size: 1})
+			managed := &types.CacheObjectManaged{}
+			Expect(tracker.Hit("1", managed)).To(BeTrue())
+			Expect(managed.Hits).To(BeEquivalentTo(1))
+			Expect(tracker.Hit("2", managed)).To(BeFalse())
+			_, _, ok := tracker.Evict()
+			Expect(ok).To(BeFalse())
+			tracker.Remove("1")
+			Expect(tracker.Len()).To(BeZero())
+		})
+	})
+	Context("Storage providers", func() {
+		put := func(sp storage.StorageProvider, hash string, size int) {
+			ExpectWithOffset(1, sp.Put(testCtx, &types.CacheKey{
+				Hash: hash,
+			}, &types.CacheObject{
+				Data: make([]byte, size),
+			})).To(Succeed())
+		}
+		get := func(sp storage.StorageProvider, hash string) (*types.CacheObject, error) {
+			return sp.Get(testCtx, &types.CacheKey{
+				Hash: hash,
+			})
+		}
+		It("should keep frequently used objects in volatile storage", func() {
+			vsp := storage.NewVolatileStorageProvider(testCtx, config.VolatileStorageSpec{
+				Limits: config.StorageLimitsSpec{
+					Memory:         "1Ki",
+					EvictionPolicy: storage.EvictionLFU,
+				},
+			})
+			Expect(vsp.Configure()).To(Succeed())
+			put(vsp, "hot", 256)
+			for i := 0; i < 3; i++ {
+				obj, err := get(vsp, "hot")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(obj.Metadata.ManagedFields.Hits).To(BeEquivalentTo(i + 1))
+			}
+			for i := 0; i < 4; i++ {
+				put(vsp, fmt.Sprintf("cold%d", i), 256)
+			}
+			Eventually(func() int64 {
+				return vsp.UsageInfo().TotalSize
+			}).Should(BeNumerically("<=", 1024))
+			_, err := get(vsp, "hot")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = get(vsp, "cold0")
+			Expect(err).To(HaveOccurred())
+		})
+		It("should record hits in the metadata of stored volatile objects", func() {
+			vsp := storage.NewVolatileStorageProvider(testCtx, config.VolatileStorageSpec{
+				Limits: config.StorageLimitsSpec{
+					Memory:         "1Ki",
+					EvictionPolicy: storage.EvictionLFU,
+				},
+			})
+			Expect(vsp.Configure()).To(Succeed())
+			put(vsp, "object", 256)
+			for i := 0; i < 2; i++ {
+				_, err := get(vsp, "object")
+				Expect(err).NotTo(HaveOccurred())
+			}
+			query := func() *types.CacheObjectMeta {
+				results, err := vsp.Query(testCtx, []*types.CacheKey{{Hash: "object"}})
+				ExpectWithOffset(1, err).NotTo(HaveOccurred())
+				return results[0]
+			}
+			Expect(query().ManagedFields.Hits).To(BeEquivalentTo(2))
+			Expect(query().ManagedFields.Score).To(BeEquivalentTo(2))
+
+			// Metadata returned to callers is a copy
+			obj, err := get(vsp, "object")
+			Expect(err).NotTo(HaveOccurred())
+			obj.Metadata.ManagedFields.Hits = 100
+			query().ManagedFields.Hits = 100
+			Expect(query().ManagedFields.Hits).To(BeEquivalentTo(3))
+		})
+		It("should delete expired volatile objects before evicting others", func() {
+			vsp := storage.NewVolatileStorageProvider(testCtx, config.VolatileStorageSpec{
+				Limits: config.StorageLimitsSpec{
+					Memory:         "1Ki",
+					EvictionPolicy: storage.EvictionLFU,
+				},
+			})
+			Expect(vsp.Configure()).To(Succeed())
+			Expect(vsp.Put(testCtx, &types.CacheKey{
+				Hash: "expiring",
+			}, &types.CacheObject{
+				Data: make([]byte, 256),
+				Metadata: &types.CacheObjectMeta{
+					ExpirationDate: time.Now().Add(100 * time.Millisecond).UnixNano(),
+				},
+			})).To(Succeed())
+			// The expiring object has the highest score
+			for i := 0; i < 3; i++ {
+				_, err := get(vsp, "expiring")
+				Expect(err).NotTo(HaveOccurred())
+			}
+			for i := 0; i < 3; i++ {
+				put(vsp, fmt.Sprintf("object%d", i), 256)
+			}
+			time.Sleep(200 * time.Millisecond)
+			put(vsp, "object3", 256)
+
+			Expect(vsp.UsageInfo().TotalSize).To(BeEquivalentTo(1024))
+			for i := 0; i < 4; i++ {
+				_, err := get(vsp, fmt.Sprintf("object%d", i))
+				Expect(err).NotTo(HaveOccurred())
+			}
+			keys, err := vsp.List(testCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(4))
+		})
+		It("should keep frequently used objects in local storage", func() {
+			tempDir, err := ioutil.TempDir("", "eviction-test")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(tempDir)
+			ctx, cancel := context.WithCancel(testCtx)
+			defer cancel()
+
+			lsp := storage.NewLocalStorageProvider(ctx, config.LocalStorageSpec{
+				Path: tempDir,
+				Limits: config.StorageLimitsSpec{
+					Disk:           "10Ki",
+					EvictionPolicy: storage.EvictionLFU,
+				},
+			}).(*storage.LocalStorageProvider)
+			Expect(lsp.Configure()).To(Succeed())
+			for i := 0; i < 12; i++ {
+				put(lsp, fmt.Sprintf("object%02d", i), 1024)
+				// Use every other object
+				if i%2 == 0 {
+					_, err := get(lsp, fmt.Sprintf("object%02d", i))
+					Expect(err).NotTo(HaveOccurred())
+				}
+			}
+			lsp.DeleteObjectsWithLowestScore()
+			Expect(lsp.UsageInfo().TotalSize).To(BeNumerically("<", 9*1024))
+			count := lsp.UsageInfo().ObjectCount
+			Expect(count).To(BeNumerically("<", 12))
+			for i := 0; i < 12; i += 2 {
+				obj, err := get(lsp, fmt.Sprintf("object%02d", i))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(obj.Metadata.ManagedFields.Hits).To(BeEquivalentTo(2))
+			}
+			// Objects deleted by the eviction policy should not expire later
+			Consistently(func() int64 {
+				return lsp.UsageInfo().ObjectCount
+			}, 100*time.Millisecond).Should(Equal(count))
+		})
+	})
+})
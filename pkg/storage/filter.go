@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MatchesFilter returns true if an object with the given metadata is selected
+// by the filter. A nil filter matches all objects.
+func MatchesFilter(filter *types.CacheFilter, md *types.CacheObjectMeta) bool {
+	if filter == nil {
+		return true
+	}
+	tags := md.GetTags()
+	if len(filter.Toolchains) > 0 {
+		found := false
+		for _, tc := range filter.Toolchains {
+			if strings.EqualFold(tags[ToolchainTag], tc) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for k, v := range filter.Tags {
+		if value, ok := tags[k]; !ok || value != v {
+			return false
+		}
+	}
+	if filter.MinAge > 0 || filter.MaxAge > 0 {
+		stored := time.Unix(md.GetManagedFields().GetTimestamp(), 0)
+		age := time.Since(stored)
+		if filter.MinAge > 0 && age < time.Duration(filter.MinAge) {
+			return false
+		}
+		if filter.MaxAge > 0 && age > time.Duration(filter.MaxAge) {
+			return false
+		}
+	}
+	return true
+}
+
+// queryBatchSize is the number of keys queried at once by ForEachObject.
+const queryBatchSize = 100
+
+// ForEachObject calls fn with the key and metadata of each object in the
+// storage provider matching the filter. Iteration stops if fn returns an
+// error.
+func ForEachObject(
+	ctx context.Context,
+	sp StorageProvider,
+	filter *types.CacheFilter,
+	fn func(*types.CacheKey, *types.CacheObjectMeta) error,
+) error {
+	keys, err := sp.List(ctx)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(keys); start += queryBatchSize {
+		end := start + queryBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := keys[start:end]
+		results, err := sp.Query(ctx, batch)
+		if err != nil {
+			return err
+		}
+		for i, md := range results {
+			if md == nil || !MatchesFilter(filter, md) {
+				continue
+			}
+			if err := fn(batch[i], md); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// PurgeObjects deletes all objects in the storage provider matching the
+// filter. If dryRun is true, matching objects are counted but not deleted.
+func PurgeObjects(
+	ctx context.Context,
+	sp StorageProvider,
+	filter *types.CacheFilter,
+	dryRun bool,
+) (*types.PurgeResponse, error) {
+	resp := &types.PurgeResponse{}
+	err := ForEachObject(ctx, sp, filter,
+		func(key *types.CacheKey, md *types.CacheObjectMeta) error {
+			if !dryRun {
+				if err := sp.Delete(ctx, key); err != nil {
+					if status.Code(err) == codes.NotFound {
+						return nil
+					}
+					return err
+				}
+			}
+			resp.ObjectCount++
+			resp.TotalSize += md.GetManagedFields().GetSize()
+			return nil
+		})
+	return resp, err
+}
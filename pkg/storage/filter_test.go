@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+var _ = Describe("Cache Filters", func() {
+	It("Should match objects against filters", func() {
+		md := &types.CacheObjectMeta{
+			Tags: map[string]string{
+				storage.ToolchainTag: "gnu",
+				"project":            "kubecc",
+			},
+			ManagedFields: &types.CacheObjectManaged{
+				Timestamp: time.Now().Add(-2 * time.Hour).Unix(),
+			},
+		}
+		Expect(storage.MatchesFilter(nil, md)).To(BeTrue())
+		Expect(storage.MatchesFilter(&types.CacheFilter{}, md)).To(BeTrue())
+		Expect(storage.MatchesFilter(&types.CacheFilter{
+			Toolchains: []string{"clang", "GNU"},
+		}, md)).To(BeTrue())
+		Expect(storage.MatchesFilter(&types.CacheFilter{
+			Toolchains: []string{"clang"},
+		}, md)).To(BeFalse())
+		Expect(storage.MatchesFilter(&types.CacheFilter{
+			Tags: map[string]string{"project": "kubecc"},
+		}, md)).To(BeTrue())
+		Expect(storage.MatchesFilter(&types.CacheFilter{
+			Tags: map[string]string{"project": "other"},
+		}, md)).To(BeFalse())
+		Expect(storage.MatchesFilter(&types.CacheFilter{
+			MinAge: int64(time.Hour),
+		}, md)).To(BeTrue())
+		Expect(storage.MatchesFilter(&types.CacheFilter{
+			MaxAge: int64(time.Hour),
+		}, md)).To(BeFalse())
+	})
+	It("Should purge objects matching a filter", func() {
+		sp := storage.NewVolatileStorageProvider(testCtx, config.VolatileStorageSpec{
+			Limits: config.StorageLimitsSpec{
+				Memory: "1Mi",
+			},
+		})
+		Expect(sp.Configure()).To(Succeed())
+		for _, tc := range []string{"gnu", "clang"} {
+			Expect(sp.Put(testCtx, &types.CacheKey{Hash: tc}, &types.CacheObject{
+				Data: []byte(tc),
+				Metadata: &types.CacheObjectMeta{
+					Tags: map[string]string{storage.ToolchainTag: tc},
+				},
+			})).To(Succeed())
+		}
+		filter := &types.CacheFilter{
+			Toolchains: []string{"gnu"},
+		}
+
+		By("Counting matching objects in a dry run")
+		resp, err := storage.PurgeObjects(testCtx, sp, filter, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.ObjectCount).To(BeEquivalentTo(1))
+		Expect(resp.TotalSize).To(BeEquivalentTo(len("gnu")))
+		Expect(sp.UsageInfo().ObjectCount).To(BeEquivalentTo(2))
+
+		By("Deleting matching objects")
+		resp, err = storage.PurgeObjects(testCtx, sp, filter, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.ObjectCount).To(BeEquivalentTo(1))
+		_, err = sp.Get(testCtx, &types.CacheKey{Hash: "gnu"})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+		_, err = sp.Get(testCtx, &types.CacheKey{Hash: "clang"})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Deleting objects which do not exist")
+		err = sp.Delete(testCtx, &types.CacheKey{Hash: "gnu"})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+})
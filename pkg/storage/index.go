@@ -0,0 +1,196 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kubecc-io/kubecc/pkg/types"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+// IndexFilename is the name of the index file stored in the root directory of
+// a local storage provider.
+const IndexFilename = "index.db"
+
+const localIndexVersion uint64 = 1
+
+var (
+	objectsBucket = []byte("objects")
+	infoBucket    = []byte("info")
+	versionKey    = []byte("version")
+)
+
+// LocalIndex is a persistent index of the objects stored by a local storage
+// provider. It stores the metadata of each object, including its expiration
+// date and eviction score, so that the provider's state can be restored
+// without reading every object when it restarts.
+//
+// Updates which do not need to be durable immediately (such as hit counts)
+// can be deferred and are written in a single transaction by Flush.
+type LocalIndex struct {
+	db *bolt.DB
+
+	// Deferred updates, indexed by object hash.
+	pending     map[string]*types.CacheIndexEntry
+	pendingLock sync.Mutex
+}
+
+// OpenLocalIndex opens or creates the index at the given path. If the index
+// did not previously exist, created will be true.
+func OpenLocalIndex(path string) (index *LocalIndex, created bool, err error) {
+	db, err := bolt.Open(path, 0644, &bolt.Options{
+		Timeout: 5 * time.Second,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open index: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(objectsBucket); err != nil {
+			return err
+		}
+		info, err := tx.CreateBucketIfNotExists(infoBucket)
+		if err != nil {
+			return err
+		}
+		if version := info.Get(versionKey); version != nil {
+			if v := binary.BigEndian.Uint64(version); v != localIndexVersion {
+				return fmt.Errorf("unsupported index version %d", v)
+			}
+			return nil
+		}
+		created = true
+		version := make([]byte, 8)
+		binary.BigEndian.PutUint64(version, localIndexVersion)
+		return info.Put(versionKey, version)
+	})
+	if err != nil {
+		db.Close()
+		return nil, false, err
+	}
+	return &LocalIndex{
+		db:      db,
+		pending: make(map[string]*types.CacheIndexEntry),
+	}, created, nil
+}
+
+// Put stores the entry for an object. The entry is durable once Put returns.
+func (i *LocalIndex) Put(hash string, entry *types.CacheIndexEntry) error {
+	data, err := proto.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	i.pendingLock.Lock()
+	delete(i.pending, hash)
+	i.pendingLock.Unlock()
+	return i.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(objectsBucket).Put([]byte(hash), data)
+	})
+}
+
+// PutDeferred updates the entry for an existing object the next time the
+// index is flushed.
+func (i *LocalIndex) PutDeferred(hash string, entry *types.CacheIndexEntry) {
+	i.pendingLock.Lock()
+	defer i.pendingLock.Unlock()
+	i.pending[hash] = entry
+}
+
+// Get returns the entry for an object, or nil if it is not in the index.
+func (i *LocalIndex) Get(hash string) (*types.CacheIndexEntry, error) {
+	i.pendingLock.Lock()
+	entry, ok := i.pending[hash]
+	i.pendingLock.Unlock()
+	if ok {
+		return proto.Clone(entry).(*types.CacheIndexEntry), nil
+	}
+	err := i.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(objectsBucket).Get([]byte(hash))
+		if data == nil {
+			return nil
+		}
+		entry = &types.CacheIndexEntry{}
+		return proto.Unmarshal(data, entry)
+	})
+	return entry, err
+}
+
+// Delete removes the entry for an object.
+func (i *LocalIndex) Delete(hash string) error {
+	i.pendingLock.Lock()
+	delete(i.pending, hash)
+	i.pendingLock.Unlock()
+	return i.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(objectsBucket).Delete([]byte(hash))
+	})
+}
+
+// ForEach calls fn for each entry in the index. Entries which cannot be
+// decoded are passed to fn as nil.
+func (i *LocalIndex) ForEach(fn func(hash string, entry *types.CacheIndexEntry) error) error {
+	return i.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(objectsBucket).ForEach(func(k, v []byte) error {
+			entry := &types.CacheIndexEntry{}
+			if err := proto.Unmarshal(v, entry); err != nil {
+				entry = nil
+			}
+			return fn(string(k), entry)
+		})
+	})
+}
+
+// Flush writes all deferred updates to the index. Updates to objects which
+// have since been deleted are discarded.
+func (i *LocalIndex) Flush() error {
+	i.pendingLock.Lock()
+	pending := i.pending
+	i.pending = make(map[string]*types.CacheIndexEntry)
+	i.pendingLock.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+	return i.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(objectsBucket)
+		for hash, entry := range pending {
+			if bucket.Get([]byte(hash)) == nil {
+				continue
+			}
+			data, err := proto.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put([]byte(hash), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Close flushes deferred updates and closes the index.
+func (i *LocalIndex) Close() error {
+	if err := i.Flush(); err != nil {
+		i.db.Close()
+		return err
+	}
+	return i.db.Close()
+}
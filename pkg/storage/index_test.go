@@ -0,0 +1,191 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+var _ = Describe("Local Storage Index", func() {
+	var (
+		tempDir string
+		cancel  context.CancelFunc
+	)
+	newProvider := func() *storage.LocalStorageProvider {
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(testCtx)
+		lsp := storage.NewLocalStorageProvider(ctx, config.LocalStorageSpec{
+			Path: tempDir,
+			Limits: config.StorageLimitsSpec{
+				Disk:           "10Ki",
+				EvictionPolicy: storage.EvictionLFU,
+			},
+		}).(*storage.LocalStorageProvider)
+		ExpectWithOffset(1, lsp.Configure()).To(Succeed())
+		return lsp
+	}
+	restart := func() *storage.LocalStorageProvider {
+		cancel()
+		// The index is closed asynchronously, opening it again will wait
+		// until it is available.
+		return newProvider()
+	}
+	put := func(lsp *storage.LocalStorageProvider, hash string, expiration time.Time) {
+		ExpectWithOffset(1, lsp.Put(testCtx, &types.CacheKey{
+			Hash: hash,
+		}, &types.CacheObject{
+			Data: []byte(hash),
+			Metadata: &types.CacheObjectMeta{
+				ExpirationDate: expiration.UnixNano(),
+				Tags: map[string]string{
+					"hash": hash,
+				},
+			},
+		})).To(Succeed())
+	}
+	objectPath := func(hash string) string {
+		return filepath.Join(tempDir, hash[0:2], hash)
+	}
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "index-test")
+		Expect(err).NotTo(HaveOccurred())
+	})
+	AfterEach(func() {
+		cancel()
+		Expect(os.RemoveAll(tempDir)).To(Succeed())
+	})
+
+	It("should restore objects after a restart", func() {
+		lsp := newProvider()
+		expiration := time.Now().Add(time.Hour)
+		put(lsp, "object1", expiration)
+		put(lsp, "object2", expiration)
+		for i := 0; i < 3; i++ {
+			_, err := lsp.Get(testCtx, &types.CacheKey{
+				Hash: "object1",
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		usage := lsp.UsageInfo()
+
+		lsp = restart()
+		Expect(lsp.UsageInfo()).To(Equal(usage))
+		keys, err := lsp.List(testCtx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(ConsistOf(
+			&types.CacheKey{Hash: "object1"},
+			&types.CacheKey{Hash: "object2"},
+		))
+		results, err := lsp.Query(testCtx, []*types.CacheKey{
+			{Hash: "object1"},
+			{Hash: "object2"},
+			{Hash: "object3"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results[0].GetExpirationDate()).To(Equal(expiration.UnixNano()))
+		Expect(results[0].GetTags()).To(HaveKeyWithValue("hash", "object1"))
+		Expect(results[0].GetManagedFields().GetHits()).To(BeEquivalentTo(3))
+		Expect(results[1].GetManagedFields().GetHits()).To(BeEquivalentTo(0))
+		Expect(results[2]).To(BeNil())
+
+		By("continuing to count hits from the restored value")
+		obj, err := lsp.Get(testCtx, &types.CacheKey{
+			Hash: "object1",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obj.Metadata.ManagedFields.Hits).To(BeEquivalentTo(4))
+		Expect(obj.Metadata.ManagedFields.Score).To(BeEquivalentTo(4))
+	})
+	It("should expire objects after a restart", func() {
+		lsp := newProvider()
+		put(lsp, "object1", time.Now().Add(500*time.Millisecond))
+		lsp = restart()
+		Expect(lsp.UsageInfo().ObjectCount).To(BeEquivalentTo(1))
+		Eventually(func() int64 {
+			return lsp.UsageInfo().ObjectCount
+		}, 2*time.Second, 50*time.Millisecond).Should(BeZero())
+		lsp = restart()
+		Expect(lsp.UsageInfo().ObjectCount).To(BeZero())
+	})
+	It("should repair the cache when starting", func() {
+		lsp := newProvider()
+		expiration := time.Now().Add(time.Hour)
+		for _, hash := range []string{"valid", "missing", "truncated"} {
+			put(lsp, hash, expiration)
+		}
+		cancel()
+
+		By("simulating interrupted writes and deletes")
+		Expect(os.Remove(objectPath("missing"))).To(Succeed())
+		data, err := ioutil.ReadFile(objectPath("truncated"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ioutil.WriteFile(objectPath("truncated"), data[:len(data)/2], 0644)).To(Succeed())
+		for _, hash := range []string{"orphaned", "partial"} {
+			Expect(os.MkdirAll(filepath.Dir(objectPath(hash)), 0755)).To(Succeed())
+		}
+		Expect(ioutil.WriteFile(objectPath("orphaned"), []byte("orphaned"), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(objectPath("partial")+".tmp", []byte("partial"), 0644)).To(Succeed())
+
+		lsp = newProvider()
+		keys, err := lsp.List(testCtx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(ConsistOf(&types.CacheKey{Hash: "valid"}))
+		Expect(lsp.UsageInfo().ObjectCount).To(BeEquivalentTo(1))
+		Expect(lsp.UsageInfo().TotalSize).To(BeEquivalentTo(len("valid")))
+		for _, hash := range []string{"missing", "truncated", "orphaned"} {
+			Expect(objectPath(hash)).NotTo(BeAnExistingFile())
+		}
+		Expect(objectPath("partial") + ".tmp").NotTo(BeAnExistingFile())
+		Expect(objectPath("valid")).To(BeAnExistingFile())
+	})
+	It("should index objects stored before the index existed", func() {
+		object := &types.CacheObject{
+			Data: []byte("legacy"),
+			Metadata: &types.CacheObjectMeta{
+				ExpirationDate: time.Now().Add(time.Hour).UnixNano(),
+			},
+		}
+		data, err := proto.Marshal(object)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.MkdirAll(filepath.Dir(objectPath("legacy")), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(objectPath("legacy"), data, 0644)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Dir(objectPath("corrupt")), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(objectPath("corrupt"), []byte{0xff}, 0644)).To(Succeed())
+
+		lsp := newProvider()
+		keys, err := lsp.List(testCtx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(ConsistOf(&types.CacheKey{Hash: "legacy"}))
+		Expect(lsp.UsageInfo().TotalSize).To(BeEquivalentTo(len("legacy")))
+		Expect(objectPath("corrupt")).NotTo(BeAnExistingFile())
+	})
+})
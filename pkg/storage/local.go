@@ -8,6 +8,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/kubecc-io/kubecc/pkg/meta"
 	"github.com/kubecc-io/kubecc/pkg/metrics"
 	"github.com/kubecc-io/kubecc/pkg/types"
+	"github.com/kubecc-io/kubecc/pkg/util"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -33,18 +35,27 @@ type LocalStorageProvider struct {
 	ctx                context.Context
 	lg                 *zap.SugaredLogger
 	root               string
+	cfg                config.LocalStorageSpec
 	numObjects         *atomic.Int64
 	totalSize          *atomic.Int64
 	sizeLimit          int64
 	cacheHitsTotal     *atomic.Int64
 	cacheMissesTotal   *atomic.Int64
+	corruptTotal       *atomic.Int64
 	expirationNotifier *ExpirationNotifier
+	evictionTracker    *EvictionTracker
+	index              *LocalIndex
+	retention          RetentionPolicy
 }
 
 func NewLocalStorageProvider(
 	ctx context.Context,
 	cfg config.LocalStorageSpec,
+	opts ...StorageProviderOption,
 ) StorageProvider {
+	options := StorageProviderOptions{}
+	options.Apply(opts...)
+
 	// Parse size limit from the configuration and convert it to bytes.
 	limit := resource.MustParse(cfg.Limits.Disk)
 	return &LocalStorageProvider{
@@ -53,74 +64,68 @@ func NewLocalStorageProvider(
 		numObjects:         atomic.NewInt64(0),
 		totalSize:          atomic.NewInt64(0),
 		root:               cfg.Path,
+		cfg:                cfg,
 		sizeLimit:          limit.Value(),
 		cacheHitsTotal:     atomic.NewInt64(0),
 		cacheMissesTotal:   atomic.NewInt64(0),
+		corruptTotal:       atomic.NewInt64(0),
 		expirationNotifier: NewExpirationNotifier(),
+		retention:          options.retention,
 	}
 }
 
 var ForceExpirationFailedErr = errors.New("failed to force expiration of current object")
 
+// Objects are written to files with this suffix before being renamed.
+const tempFileSuffix = ".tmp"
+
 func (p *LocalStorageProvider) Location() types.StorageLocation {
 	return types.Disk
 }
 
 func (p *LocalStorageProvider) Configure() error {
+	policy, err := NewEvictionPolicy(p.cfg.Limits.EvictionPolicy)
+	if err != nil {
+		return err
+	}
+	p.evictionTracker = NewEvictionTracker(policy)
+
 	// Ensure that the root directory exists.
 	if err := os.MkdirAll(p.root, 0755); err != nil {
 		return err
 	}
 
-	// Read all existing objects from disk and store the object count and total
-	// size of all objects.
+	// Open the index. If it did not exist, objects stored before the index
+	// was created are added to it by the consistency check.
+	index, created, err := OpenLocalIndex(filepath.Join(p.root, IndexFilename))
+	if err != nil {
+		return err
+	}
+	p.index = index
+	if err := p.fsck(created); err != nil {
+		index.Close()
+		return err
+	}
+
+	// Restore the state of the cache from the index.
 	p.numObjects.Store(0)
 	p.totalSize.Store(0)
-	toDelete := []string{}
-	if err := filepath.Walk(p.root, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if info.IsDir() {
-			return nil
+	if err := index.ForEach(func(hash string, entry *types.CacheIndexEntry) error {
+		managed := entry.GetMetadata().GetManagedFields()
+		if managed == nil {
+			managed = &types.CacheObjectManaged{}
 		}
 		p.numObjects.Inc()
-		p.totalSize.Add(info.Size())
-
-		// Unmarshal the object to get the expiration date.
-		object := &types.CacheObject{}
-		data, err := ioutil.ReadFile(path)
-		if err != nil {
-			return err
-		}
-		if err := proto.Unmarshal(data, object); err != nil {
-			p.lg.With(
-				zap.String("path", path),
-				zap.Error(err),
-			).Error("failed to unmarshal object, deleting it from the cache")
-			toDelete = append(toDelete, path)
-			return nil
-		}
-		// Get the object's hash from the path.
-		hash := filepath.Base(path)
-		// Add the object to the expiration notifier.
-		p.expirationNotifier.Add(hash, time.Unix(0, object.Metadata.GetExpirationDate()))
+		p.totalSize.Add(managed.GetSize())
+		p.expirationNotifier.Add(hash,
+			time.Unix(0, entry.GetMetadata().GetExpirationDate()))
+		p.evictionTracker.Add(hash, managed)
 		return nil
 	}); err != nil {
+		index.Close()
 		return err
 	}
 
-	// Delete the objects that failed to unmarshal.
-	for _, path := range toDelete {
-		if err := os.Remove(path); err != nil {
-			// Log the error, but don't exit.
-			p.lg.With(
-				zap.String("path", path),
-				zap.Error(err),
-			).Error("failed to delete object from the cache")
-		}
-	}
-
 	// Log the current state of the cache.
 	p.lg.With(
 		"objects", p.numObjects.Load(),
@@ -131,6 +136,24 @@ func (p *LocalStorageProvider) Configure() error {
 	// Start the expiration notifier.
 	go p.expirationNotifier.Monitor(p.ctx)
 
+	// Set up a goroutine to periodically write deferred updates to the index,
+	// and close it when the context is done.
+	go func() {
+		for {
+			select {
+			case <-p.ctx.Done():
+				if err := p.index.Close(); err != nil {
+					p.lg.With(zap.Error(err)).Error("failed to close index")
+				}
+				return
+			case <-time.After(10 * time.Second):
+				if err := p.index.Flush(); err != nil {
+					p.lg.With(zap.Error(err)).Error("failed to update index")
+				}
+			}
+		}
+	}()
+
 	// Set up a goroutine to process expiration notifications.
 	go func() {
 		for {
@@ -138,21 +161,11 @@ func (p *LocalStorageProvider) Configure() error {
 			case <-p.ctx.Done():
 				return
 			case hash := <-p.expirationNotifier.Notify():
-				// Read the object's size and delete it.
-				path := path.Join(p.root, hash[0:2], hash)
-				info, err := os.Stat(path)
-				if err != nil {
-					p.lg.Error(err)
-					continue
-				}
-				if err := os.Remove(path); err != nil {
+				if err := p.deleteObject(hash); err != nil {
 					p.lg.With(
 						zap.String("hash", hash),
 					).Error("failed to delete expired object", zap.Error(err))
-					continue
 				}
-				p.totalSize.Sub(info.Size())
-				p.numObjects.Dec()
 			}
 		}
 	}()
@@ -166,7 +179,11 @@ func (p *LocalStorageProvider) Configure() error {
 				return
 			case <-time.After(time.Minute):
 				if p.totalSize.Load() > p.sizeLimit {
-					p.DeleteObjectsClosestToExpiration()
+					if p.cfg.Limits.EvictionPolicy == "" {
+						p.DeleteObjectsClosestToExpiration()
+					} else {
+						p.DeleteObjectsWithLowestScore()
+					}
 				}
 			}
 		}
@@ -174,6 +191,212 @@ func (p *LocalStorageProvider) Configure() error {
 	return nil
 }
 
+// quarantineObject moves a corrupt object into the quarantine directory and
+// stops tracking it.
+func (p *LocalStorageProvider) quarantineObject(hash string, reason error) {
+	size, ok := p.evictionTracker.Remove(hash)
+	if !ok {
+		// Already removed
+		return
+	}
+	p.corruptTotal.Inc()
+	lg := p.lg.With(
+		zap.String("hash", hash),
+		zap.Error(reason),
+	)
+	lg.Error("Quarantining corrupt object")
+	if err := p.index.Delete(hash); err != nil {
+		lg.With(zap.Error(err)).Error("failed to remove object from index")
+	}
+	p.totalSize.Sub(size)
+	p.numObjects.Dec()
+	p.expirationNotifier.Remove(hash)
+
+	dir := filepath.Join(p.root, QuarantineDir)
+	if err := os.MkdirAll(dir, 0755); err == nil {
+		if err := os.Rename(p.objectPath(hash), filepath.Join(dir, hash)); err == nil {
+			return
+		}
+	}
+	// The object could not be moved, but it should not be used again.
+	if err := os.Remove(p.objectPath(hash)); err != nil && !os.IsNotExist(err) {
+		lg.With(zap.Error(err)).Error("failed to delete corrupt object")
+	}
+}
+
+// fsck checks that the index is consistent with the object files on disk.
+//
+// Objects are written to a temporary file which is renamed once it is
+// complete, and are then added to the index. After a crash, there may be
+// temporary files left behind, or complete files which are missing from the
+// index. Both are deleted. Index entries for files which are missing or have
+// the wrong size are removed, along with the files.
+//
+// If the index was just created, files without an index entry are added to
+// the index instead (if they are valid), so that objects stored before the
+// index existed are kept.
+func (p *LocalStorageProvider) fsck(rebuild bool) error {
+	files := map[string]os.FileInfo{}
+	removed := 0
+	remove := func(path string) {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			// Log the error, but don't exit.
+			p.lg.With(
+				zap.String("path", path),
+				zap.Error(err),
+			).Error("failed to delete object from the cache")
+			return
+		}
+		removed++
+	}
+	if err := filepath.Walk(p.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		// Objects are stored in subdirectories, files in the root directory
+		// (such as the index) and quarantined objects are skipped.
+		if info.IsDir() && path == filepath.Join(p.root, QuarantineDir) {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || filepath.Dir(path) == filepath.Clean(p.root) {
+			return nil
+		}
+		if strings.HasSuffix(path, tempFileSuffix) {
+			remove(path)
+			return nil
+		}
+		files[filepath.Base(path)] = info
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	invalid := []string{}
+	if err := p.index.ForEach(func(hash string, entry *types.CacheIndexEntry) error {
+		info, ok := files[hash]
+		delete(files, hash)
+		if !ok || entry == nil || info.Size() != entry.GetFileSize() {
+			invalid = append(invalid, hash)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, hash := range invalid {
+		if err := p.index.Delete(hash); err != nil {
+			return err
+		}
+		remove(p.objectPath(hash))
+	}
+
+	// Any remaining files are not in the index.
+	indexed := 0
+	for hash, info := range files {
+		if rebuild {
+			if entry, err := p.readIndexEntry(hash, info); err == nil {
+				if err := p.index.Put(hash, entry); err != nil {
+					return err
+				}
+				indexed++
+				continue
+			}
+		}
+		remove(p.objectPath(hash))
+	}
+
+	if removed > 0 || indexed > 0 || len(invalid) > 0 {
+		p.lg.With(
+			"removedFiles", removed,
+			"removedEntries", len(invalid),
+			"indexed", indexed,
+		).Warn("Repaired local cache")
+	}
+	return nil
+}
+
+// readIndexEntry reads an object which is not in the index and returns its
+// index entry.
+func (p *LocalStorageProvider) readIndexEntry(
+	hash string,
+	info os.FileInfo,
+) (*types.CacheIndexEntry, error) {
+	data, err := ioutil.ReadFile(p.objectPath(hash))
+	if err != nil {
+		return nil, err
+	}
+	object := &types.CacheObject{}
+	if err := proto.Unmarshal(data, object); err != nil {
+		return nil, err
+	}
+	if object.Metadata == nil {
+		object.Metadata = &types.CacheObjectMeta{}
+	}
+	if object.Metadata.ManagedFields == nil {
+		object.Metadata.ManagedFields = &types.CacheObjectManaged{
+			Location: types.Disk,
+		}
+	}
+	object.Metadata.ManagedFields.Size = int64(len(object.Data))
+	return &types.CacheIndexEntry{
+		Metadata: object.Metadata,
+		FileSize: info.Size(),
+	}, nil
+}
+
+func (p *LocalStorageProvider) objectPath(hash string) string {
+	return path.Join(p.root, hash[0:2], hash)
+}
+
+// deleteObject deletes an object from disk and stops tracking it.
+func (p *LocalStorageProvider) deleteObject(hash string) error {
+	size, _ := p.evictionTracker.Remove(hash)
+	return p.removeObject(hash, size)
+}
+
+// removeObject deletes an object which is no longer tracked for eviction.
+// The object is removed from the index first, so if the file is not deleted
+// it will be removed by the consistency check.
+func (p *LocalStorageProvider) removeObject(hash string, size int64) error {
+	if err := p.index.Delete(hash); err != nil {
+		return err
+	}
+	if err := os.Remove(p.objectPath(hash)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	p.totalSize.Sub(size)
+	p.numObjects.Dec()
+	p.expirationNotifier.Remove(hash)
+	return nil
+}
+
+// DeleteObjectsWithLowestScore deletes objects in the order determined by the
+// configured eviction policy until the total size is less than 90% of the
+// size limit.
+func (p *LocalStorageProvider) DeleteObjectsWithLowestScore() {
+	p.lg.With(
+		"policy", p.cfg.Limits.EvictionPolicy,
+	).Info("Deleting objects with the lowest score")
+
+	numDeleted := 0
+	for float64(p.totalSize.Load()) >= float64(p.sizeLimit)*0.9 {
+		hash, size, ok := p.evictionTracker.Evict()
+		if !ok {
+			break
+		}
+		if err := p.removeObject(hash, size); err != nil {
+			p.lg.With(
+				zap.String("hash", hash),
+			).Error("failed to delete object", zap.Error(err))
+			continue
+		}
+		numDeleted++
+	}
+
+	p.lg.With(
+		"count", numDeleted,
+	).Info("Deleted objects to reclaim disk space")
+}
+
 func (p *LocalStorageProvider) DeleteObjectsClosestToExpiration() {
 	p.lg.Info("Deleting objects closest to expiration")
 
@@ -187,6 +410,8 @@ func (p *LocalStorageProvider) DeleteObjectsClosestToExpiration() {
 		// Get the next object which is closest to expiring.
 		hash, _ := p.expirationNotifier.heap.Peek()
 
+		count := p.numObjects.Load()
+
 		// Force the notifier to expire the object it is currently monitoring.
 		if err := p.expirationNotifier.ForceExpiration(); err != nil {
 			// If the expiration failed, then we should not continue.
@@ -196,20 +421,11 @@ func (p *LocalStorageProvider) DeleteObjectsClosestToExpiration() {
 		// Increment the number of objects deleted.
 		numDeleted++
 
-		// Wait until the object is expired
+		// Wait until the object is deleted. The object closest to expiration
+		// may not be the one that was peeked if its expiration date was
+		// extended, so wait for the object count to change instead.
 		err := wait.Poll(100*time.Millisecond, 2*time.Second, func() (bool, error) {
-			// Try to get the object with the hash that we just forced to expire.
-			// If the object is not found, then it has been deleted.
-			_, err := p.Get(p.ctx, &types.CacheKey{
-				Hash: hash,
-			})
-			if err != nil {
-				// If the object is not found, then we can continue.
-				return true, nil
-			}
-			// The object is still in the cache, so we need to wait for it to
-			// expire.
-			return false, nil
+			return p.numObjects.Load() < count, nil
 		})
 		if err != nil {
 			p.lg.With(
@@ -231,17 +447,35 @@ func (p *LocalStorageProvider) Put(
 	object *types.CacheObject,
 ) error {
 	lg := meta.Log(ctx)
+	if entry, err := p.index.Get(key.GetHash()); err == nil && entry != nil {
+		if exp := entry.GetMetadata().GetExpirationDate(); exp == 0 ||
+			time.Now().Before(time.Unix(0, exp)) {
+			return status.Error(codes.AlreadyExists, "Object already exists")
+		}
+		// The object has expired, but has not been deleted yet. Delete it
+		// first so that it is not counted twice.
+		if err := p.deleteObject(key.GetHash()); err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+	if object.Metadata == nil {
+		object.Metadata = &types.CacheObjectMeta{}
+	}
+	// Objects without an expiration date are given one based on the
+	// retention policy.
+	p.retention.Apply(object.Metadata)
 	// Fill in the object's managed fields
 	object.Metadata.ManagedFields = &types.CacheObjectManaged{
 		Size:      int64(len(object.Data)),
-		Timestamp: time.Now().UnixNano(),
+		Timestamp: time.Now().Unix(),
 		Location:  types.Disk,
+		Digest:    util.Digest(object.Data),
 	}
 
 	lg.With(
 		"size", fmt.Sprintf("%d Ki", len(object.Data)/1024),
 		"tags", object.Metadata.GetTags(),
-		"ttl", time.Until(time.UnixMicro(object.Metadata.GetExpirationDate())),
+		"ttl", time.Until(time.Unix(0, object.Metadata.GetExpirationDate())),
 	).Info("Storing new object")
 
 	// Store objects as flat files in the directory named by the object hash
@@ -257,7 +491,15 @@ func (p *LocalStorageProvider) Put(
 	if err != nil {
 		return status.Error(codes.Internal, err.Error())
 	}
-	if err := ioutil.WriteFile(path.Join(objPath, objHash), data, 0644); err != nil {
+	if err := writeFileAtomic(path.Join(objPath, objHash), data); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	// The object is only added to the index once its file is complete.
+	if err := p.index.Put(objHash, &types.CacheIndexEntry{
+		Metadata: object.Metadata,
+		FileSize: int64(len(data)),
+	}); err != nil {
+		os.Remove(path.Join(objPath, objHash))
 		return status.Error(codes.Internal, err.Error())
 	}
 
@@ -267,37 +509,128 @@ func (p *LocalStorageProvider) Put(
 
 	// Add the object to the expiration notifier.
 	p.expirationNotifier.Add(objHash, time.Unix(0, object.Metadata.GetExpirationDate()))
+	// Start tracking the object for eviction.
+	p.evictionTracker.Add(objHash, object.Metadata.ManagedFields)
 	return nil
 }
 
+// writeFileAtomic writes data to a temporary file which is synced and then
+// renamed to the given path, so that the file is either complete or missing
+// if the process is interrupted.
+func writeFileAtomic(filename string, data []byte) error {
+	tmp := filename + tempFileSuffix
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
+
+// rewriteObject replaces an existing object and updates its index entry.
+func (p *LocalStorageProvider) rewriteObject(
+	hash string,
+	object *types.CacheObject,
+) error {
+	data, err := proto.Marshal(object)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(p.objectPath(hash), data); err != nil {
+		return err
+	}
+	return p.index.Put(hash, &types.CacheIndexEntry{
+		Metadata: proto.Clone(object.Metadata).(*types.CacheObjectMeta),
+		FileSize: int64(len(data)),
+	})
+}
+
 func (p *LocalStorageProvider) Get(
 	ctx context.Context,
 	key *types.CacheKey,
 ) (*types.CacheObject, error) {
-	// Check if the object exists on disk based on the provided hash and return
-	// it if it does. Otherwise, return an error. The objects are stored in the
-	// protobuf binary format.
 	objHash := key.Hash
+	object, fileSize, err := p.readObject(objHash)
+	if err != nil {
+		p.cacheMissesTotal.Inc()
+		if status.Code(err) == codes.DataLoss {
+			p.quarantineObject(objHash, err)
+		}
+		return nil, err
+	}
+	p.cacheHitsTotal.Inc()
+
+	// Record the hit so the object's score reflects how often it is used.
+	// The object's timestamp is the time it was stored, and is not changed.
+	p.evictionTracker.Hit(objHash, object.Metadata.ManagedFields)
+
+	// Extend the object's expiration date if sliding expiration is enabled,
+	// and persist it so that it is kept after a restart. Otherwise, the hit
+	// is recorded in the index the next time it is flushed.
+	if p.retention.Extend(object.Metadata) {
+		p.expirationNotifier.Add(objHash,
+			time.Unix(0, object.Metadata.GetExpirationDate()))
+		if err := p.rewriteObject(objHash, object); err != nil {
+			p.lg.With(
+				zap.Error(err),
+			).Warn("Failed to update object expiration date")
+		}
+	} else {
+		p.index.PutDeferred(objHash, &types.CacheIndexEntry{
+			Metadata: proto.Clone(object.Metadata).(*types.CacheObjectMeta),
+			FileSize: fileSize,
+		})
+	}
+	return object, nil
+}
+
+func (p *LocalStorageProvider) Peek(
+	ctx context.Context,
+	key *types.CacheKey,
+) (*types.CacheObject, error) {
+	object, _, err := p.readObject(key.Hash)
+	return object, err
+}
+
+// readObject reads and verifies an object stored on disk, and returns it
+// along with the size of its file. The objects are stored in the protobuf
+// binary format.
+func (p *LocalStorageProvider) readObject(
+	objHash string,
+) (*types.CacheObject, int64, error) {
 	objPath := path.Join(p.root, objHash[0:2], objHash)
 	if _, err := os.Stat(objPath); err != nil {
-		// Increment cache misses.
-		p.cacheMissesTotal.Inc()
-		return nil, status.Error(codes.NotFound,
+		return nil, 0, status.Error(codes.NotFound,
 			fmt.Errorf("Object not found: %w", err).Error())
 	}
-	p.cacheHitsTotal.Inc()
 	// Read the object from disk.
 	data, err := ioutil.ReadFile(objPath)
 	if err != nil {
 		// Something went wrong reading the object, but it exists.
-		return nil, status.Error(codes.NotFound,
+		return nil, 0, status.Error(codes.NotFound,
 			fmt.Errorf("Error retrieving object: %w", err).Error())
 	}
-	// Unmarshal the object from the data.
+	// Unmarshal the object from the data and verify its contents.
 	object := &types.CacheObject{}
 	if err := proto.Unmarshal(data, object); err != nil {
-		return nil, status.Error(codes.NotFound,
-			fmt.Errorf("Object is corrupted or invalid: %w", err).Error())
+		return nil, 0, corruptObjectError(
+			fmt.Errorf("Object is corrupted or invalid: %w", err))
+	}
+	if err := VerifyObject(object); err != nil {
+		return nil, 0, corruptObjectError(err)
 	}
 
 	// Fill in some fields that are set to omitempty
@@ -307,52 +640,78 @@ func (p *LocalStorageProvider) Get(
 	if object.Metadata.Tags == nil {
 		object.Metadata.Tags = make(map[string]string)
 	}
-	// Update the object's timestamp to the current time.
-	object.Metadata.ManagedFields.Timestamp = time.Now().UnixNano()
-	return object, nil
+	if object.Metadata.ManagedFields == nil {
+		object.Metadata.ManagedFields = &types.CacheObjectManaged{}
+	}
+	return object, int64(len(data)), nil
 }
 
 func (p *LocalStorageProvider) Query(
 	ctx context.Context,
 	keys []*types.CacheKey,
 ) ([]*types.CacheObjectMeta, error) {
-	// Query the local storage provider for the objects that match the provided
-	// keys. The objects are stored in the protobuf binary format.
-	var objects []*types.CacheObjectMeta
-	for _, key := range keys {
-		objHash := key.Hash
-		objPath := path.Join(p.root, objHash[0:2], objHash)
-		if _, err := os.Stat(objPath); err != nil {
-			continue
-		}
-		// Read the object from disk.
-		data, err := ioutil.ReadFile(objPath)
+	// Query the index for the objects that match the provided keys.
+	objects := make([]*types.CacheObjectMeta, len(keys))
+	for i, key := range keys {
+		entry, err := p.index.Get(key.Hash)
 		if err != nil {
 			p.lg.Debug(err)
 			continue
 		}
-		// Unmarshal the object from the data.
-		object := &types.CacheObject{}
-		if err := proto.Unmarshal(data, object); err != nil {
-			p.lg.Debug(err)
+		if entry == nil {
 			continue
 		}
+		metadata := entry.GetMetadata()
 		// Fill in some fields that are set to omitempty
-		if object.Metadata == nil {
-			object.Metadata = &types.CacheObjectMeta{}
+		if metadata == nil {
+			metadata = &types.CacheObjectMeta{}
 		}
-		if object.Metadata.Tags == nil {
-			object.Metadata.Tags = make(map[string]string)
+		if metadata.Tags == nil {
+			metadata.Tags = make(map[string]string)
 		}
-		objects = append(objects, &types.CacheObjectMeta{
-			Tags:           object.Metadata.GetTags(),
-			ExpirationDate: object.Metadata.GetExpirationDate(),
-			ManagedFields:  object.Metadata.GetManagedFields(),
-		})
+		objects[i] = metadata
 	}
 	return objects, nil
 }
 
+func (p *LocalStorageProvider) Delete(
+	ctx context.Context,
+	key *types.CacheKey,
+) error {
+	entry, err := p.index.Get(key.GetHash())
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if entry == nil {
+		return status.Error(codes.NotFound, "Object not found")
+	}
+	p.evictionTracker.Remove(key.GetHash())
+	if err := p.removeObject(key.GetHash(),
+		entry.GetMetadata().GetManagedFields().GetSize()); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+func (p *LocalStorageProvider) List(
+	ctx context.Context,
+) ([]*types.CacheKey, error) {
+	keys := []*types.CacheKey{}
+	err := p.index.ForEach(func(hash string, _ *types.CacheIndexEntry) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		keys = append(keys, &types.CacheKey{
+			Hash: hash,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return keys, nil
+}
+
 func (p *LocalStorageProvider) UsageInfo() *metrics.CacheUsage {
 	count := p.numObjects.Load()
 	size := p.totalSize.Load()
@@ -368,25 +727,35 @@ func (p *LocalStorageProvider) CacheHits() *metrics.CacheHits {
 	hits := p.cacheHitsTotal.Load()
 	misses := p.cacheMissesTotal.Load()
 	total := hits + misses
+	var percent float64
 	if total > 0 {
-		return &metrics.CacheHits{
-			CacheHitsTotal:   hits,
-			CacheMissesTotal: misses,
-			CacheHitPercent:  float64(hits) / float64(total),
-		}
+		percent = float64(hits) / float64(total)
+	}
+	return &metrics.CacheHits{
+		CacheHitsTotal:      hits,
+		CacheMissesTotal:    misses,
+		CacheHitPercent:     percent,
+		CorruptObjectsTotal: p.corruptTotal.Load(),
 	}
-	return nil
 }
 
 // ExpirationNotifier provides an efficient way to monitor the expiration of
 // many objects. It stores expiration dates of objects in a binary heap and
 // only waits for the next expiration event. It is used by the localStorageMonitor
 // to monitor the expiration of objects.
+//
+// The expiration date of an object can be changed by adding it again, and
+// objects can be removed from the notifier. Heap entries which no longer
+// match the object's current expiration date are skipped.
 type ExpirationNotifier struct {
 	// The heap is a binary heap that stores the expiration dates of objects.
 	// The heap is indexed by the hash of the object.
 	heap *ExpirationHeap
 
+	// The current expiration date of each object in the heap.
+	dates     map[string]time.Time
+	datesLock sync.Mutex
+
 	// The channel that is used to signal the expiration of an object.
 	// The channel is buffered to prevent the expiration of objects
 	// that are currently being added to the heap.
@@ -409,6 +778,7 @@ type ExpirationNotifier struct {
 func NewExpirationNotifier() *ExpirationNotifier {
 	return &ExpirationNotifier{
 		heap:            NewExpirationHeap(),
+		dates:           make(map[string]time.Time),
 		expirationChan:  make(chan string, 1000),
 		rescanChan:      make(chan struct{}, 1),
 		addChan:         make(chan struct{}, 1),
@@ -417,6 +787,15 @@ func NewExpirationNotifier() *ExpirationNotifier {
 }
 
 func (e *ExpirationNotifier) Add(hash string, expirationDate time.Time) {
+	if expirationDate.UnixNano() <= 0 {
+		// Objects without an expiration date never expire
+		e.Remove(hash)
+		return
+	}
+	e.datesLock.Lock()
+	e.dates[hash] = expirationDate
+	e.datesLock.Unlock()
+
 	// Add the object to the heap.
 	e.heap.Push(hash, expirationDate)
 
@@ -439,6 +818,39 @@ func (e *ExpirationNotifier) Add(hash string, expirationDate time.Time) {
 	}
 }
 
+// Remove stops monitoring the expiration of an object.
+func (e *ExpirationNotifier) Remove(hash string) {
+	e.datesLock.Lock()
+	defer e.datesLock.Unlock()
+	delete(e.dates, hash)
+}
+
+// isCurrent returns true if the given heap entry matches the current
+// expiration date of its object, and stops monitoring the object if so.
+func (e *ExpirationNotifier) isCurrent(entry *ExpirationEntry) bool {
+	e.datesLock.Lock()
+	defer e.datesLock.Unlock()
+	if date, ok := e.dates[entry.Hash]; ok && date.Equal(entry.Date) {
+		delete(e.dates, entry.Hash)
+		return true
+	}
+	return false
+}
+
+// popCurrent pops entries from the heap until one is found that matches its
+// object's current expiration date, and returns its hash.
+func (e *ExpirationNotifier) popCurrent() (string, bool) {
+	for {
+		entry := e.heap.Pop()
+		if entry == nil {
+			return "", false
+		}
+		if e.isCurrent(entry) {
+			return entry.Hash, true
+		}
+	}
+}
+
 func (e *ExpirationNotifier) NextExpiration() (string, time.Time) {
 	// Get the next expiration event.
 	hash, expirationDate := e.heap.Peek()
@@ -452,20 +864,25 @@ rescan:
 		return
 	}
 	// Get the next expiration event.
-	hash, expirationDate := e.NextExpiration()
+	_, expirationDate := e.NextExpiration()
 
 	// Wait for the next expiration event.
 	select {
 	case <-ctx.Done():
 		return
 	case <-time.After(time.Until(expirationDate)):
-		// Pop the object from the heap.
-		e.heap.Pop()
-		e.expirationChan <- hash
+		// Pop the object from the heap. If its expiration date has since been
+		// changed, wait for the next one instead.
+		entry := e.heap.Pop()
+		if entry == nil || !e.isCurrent(entry) {
+			goto rescan
+		}
+		e.expirationChan <- entry.Hash
 	case <-e.forceExpireChan:
-		// Pop the object from the heap.
-		e.heap.Pop()
-		e.expirationChan <- hash
+		// Pop the next object that has not been removed or rescheduled.
+		if hash, ok := e.popCurrent(); ok {
+			e.expirationChan <- hash
+		}
 	case <-e.rescanChan:
 		// Rescan the heap for the latest expiration event.
 		goto rescan
@@ -312,6 +312,64 @@ var _ = Describe("Local Storage Provider", func() {
 			Expect(obj2.Metadata.Tags).To(Equal(metadata))
 			Expect(obj2.Metadata.ExpirationDate).To(Equal(expirationDate))
 		})
+		It("should keep the time objects were stored when they are read", func() {
+			obj := make([]byte, 1024)
+			rand.Read(obj)
+			key := &types.CacheKey{
+				Hash: fmt.Sprintf("%x", md5.Sum(obj)),
+			}
+			err := storageProvider.Put(testCtx, key, &types.CacheObject{
+				Data: obj,
+				Metadata: &types.CacheObjectMeta{
+					ExpirationDate: time.Now().Add(time.Hour).UnixNano(),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			md, err := storageProvider.Query(testCtx, []*types.CacheKey{key})
+			Expect(err).NotTo(HaveOccurred())
+			stored := md[0].GetManagedFields().GetTimestamp()
+			Expect(time.Unix(stored, 0)).To(BeTemporally("~", time.Now(), time.Minute))
+
+			time.Sleep(1100 * time.Millisecond)
+			obj2, err := storageProvider.Get(testCtx, key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obj2.Metadata.ManagedFields.Timestamp).To(Equal(stored))
+			md, err = storageProvider.Query(testCtx, []*types.CacheKey{key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(md[0].GetManagedFields().GetTimestamp()).To(Equal(stored))
+			Expect(storage.MatchesFilter(&types.CacheFilter{
+				MinAge: int64(time.Second),
+			}, md[0])).To(BeTrue())
+		})
+		It("should replace expired objects which have not been deleted yet", func() {
+			obj := make([]byte, 1024)
+			rand.Read(obj)
+			key := &types.CacheKey{
+				Hash: fmt.Sprintf("%x", md5.Sum(obj)),
+			}
+			err := storageProvider.Put(testCtx, key, &types.CacheObject{
+				Data: obj,
+				Metadata: &types.CacheObjectMeta{
+					ExpirationDate: time.Now().Add(-time.Second).UnixNano(),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			err = storageProvider.Put(testCtx, key, &types.CacheObject{
+				Data: obj[:512],
+				Metadata: &types.CacheObjectMeta{
+					ExpirationDate: time.Now().Add(time.Hour).UnixNano(),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			usageInfo := storageProvider.UsageInfo()
+			Expect(usageInfo.ObjectCount).To(BeEquivalentTo(1))
+			Expect(usageInfo.TotalSize).To(BeEquivalentTo(512))
+			Consistently(func() int64 {
+				return storageProvider.UsageInfo().ObjectCount
+			}, 500*time.Millisecond, 50*time.Millisecond).Should(BeEquivalentTo(1))
+		})
 		It("should get and put 11 objects with different metadata and expiration", func() {
 			// Create 11 new objects with 1024 random bytes, and random metadata
 			// and expiration dates, and store them in a map for later use
@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// FormatTag is the object tag used to record how the data of a cached
+// compile result is encoded.
+const FormatTag = "format"
+
+// CompileOutputFormat is the FormatTag value of objects whose data is an
+// encoded types.CompileOutput.
+const CompileOutputFormat = "compile-output"
+
+// EncodeCompileOutput encodes a compile output as the data of a cache object.
+// FormatTag should be set to CompileOutputFormat in the object's tags.
+func EncodeCompileOutput(output *types.CompileOutput) ([]byte, error) {
+	return proto.Marshal(output)
+}
+
+// DecodeCompileOutput returns the compile output stored in a cache object,
+// according to its format tag. Objects stored before compiler output was
+// cached have no format tag and contain only the object file, which is
+// returned as the compiled source with no compiler output.
+func DecodeCompileOutput(obj *types.CacheObject) (*types.CompileOutput, error) {
+	format, ok := obj.GetMetadata().GetTags()[FormatTag]
+	if !ok {
+		return &types.CompileOutput{
+			CompiledSource: obj.GetData(),
+		}, nil
+	}
+	if format != CompileOutputFormat {
+		return nil, fmt.Errorf("unknown compile output format %q", format)
+	}
+	output := &types.CompileOutput{}
+	if err := proto.Unmarshal(obj.GetData(), output); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
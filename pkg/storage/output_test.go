@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+var _ = Describe("Compile Output", func() {
+	It("should decode encoded compile outputs", func() {
+		data, err := storage.EncodeCompileOutput(&types.CompileOutput{
+			CompiledSource: []byte("object"),
+			Stderr:         []byte("warning: test"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		output, err := storage.DecodeCompileOutput(&types.CacheObject{
+			Data: data,
+			Metadata: &types.CacheObjectMeta{
+				Tags: map[string]string{
+					storage.FormatTag: storage.CompileOutputFormat,
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output.CompiledSource).To(BeEquivalentTo("object"))
+		Expect(output.Stderr).To(BeEquivalentTo("warning: test"))
+	})
+	It("should return an error if a tagged object cannot be decoded", func() {
+		_, err := storage.DecodeCompileOutput(&types.CacheObject{
+			Data: []byte{0xff, 0xff, 0xff},
+			Metadata: &types.CacheObjectMeta{
+				Tags: map[string]string{
+					storage.FormatTag: storage.CompileOutputFormat,
+				},
+			},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+	It("should return an error if the format is unknown", func() {
+		_, err := storage.DecodeCompileOutput(&types.CacheObject{
+			Data: []byte("object"),
+			Metadata: &types.CacheObjectMeta{
+				Tags: map[string]string{
+					storage.FormatTag: "unknown",
+				},
+			},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+	It("should treat untagged objects as raw objects", func() {
+		// Raw object files can also be valid encoded compile outputs
+		data, err := storage.EncodeCompileOutput(&types.CompileOutput{
+			CompiledSource: []byte("object"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		output, err := storage.DecodeCompileOutput(&types.CacheObject{
+			Data: data,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output.CompiledSource).To(Equal(data))
+	})
+	It("should treat untagged objects that cannot be decoded as raw objects", func() {
+		raw := []byte{0x7f, 'E', 'L', 'F', 0xff, 0xff, 0xff}
+		output, err := storage.DecodeCompileOutput(&types.CacheObject{
+			Data: raw,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output.CompiledSource).To(Equal(raw))
+		Expect(output.Stdout).To(BeEmpty())
+		Expect(output.Stderr).To(BeEmpty())
+	})
+})
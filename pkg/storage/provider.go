@@ -38,11 +38,22 @@ type StorageProvider interface {
 	// Get should return the keyed object if it exists, or return
 	// a relevant error if it does not exist.
 	Get(context.Context, *types.CacheKey) (*types.CacheObject, error)
+	// Peek should return the keyed object like Get, but without recording a
+	// cache hit, updating the object's score or expiration date, or storing
+	// it in other storage providers. Corrupt objects should be reported with
+	// a DataLoss error, but not removed.
+	Peek(context.Context, *types.CacheKey) (*types.CacheObject, error)
 	// Query should return object metadata for each key in the provided
 	// slice. If any key does not exist, the corresponding element in the
 	// resulting slice should be nil. The length of the resulting slice
 	// must match exactly with the length of the input slice.
 	Query(context.Context, []*types.CacheKey) ([]*types.CacheObjectMeta, error)
+	// Delete should remove the keyed object, or return a NotFound error if it
+	// does not exist.
+	Delete(context.Context, *types.CacheKey) error
+	// List should return the keys of all unexpired objects currently stored
+	// by the storage provider, in no particular order.
+	List(context.Context) ([]*types.CacheKey, error)
 
 	// UsageInfo should calculate and return usage information for the storage
 	// provider.
@@ -0,0 +1,504 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/meta"
+	"github.com/kubecc-io/kubecc/pkg/metrics"
+	"github.com/kubecc-io/kubecc/pkg/types"
+	"github.com/kubecc-io/kubecc/pkg/util"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultRedisKeyPrefix is prepended to all keys if the storage spec does not
+// specify a prefix.
+const DefaultRedisKeyPrefix = "kubecc:"
+
+// Object metadata is stored in a hash next to the object data. Tags are
+// stored as individual fields prefixed with redisTagField.
+const (
+	redisSizeField       = "size"
+	redisTimestampField  = "timestamp"
+	redisHitsField       = "hits"
+	redisScoreField      = "score"
+	redisDigestField     = "digest"
+	redisExpirationField = "expiration"
+	redisTagField        = "tag:"
+)
+
+// Usage is tracked with an index of all objects, sorted by their expiration
+// date in milliseconds, a hash of object sizes, and the total size. The keys
+// are passed to each script before any object keys.
+const redisForgetFunc = `
+local function forget(hash)
+  local size = redis.call("HGET", KEYS[2], hash)
+  if size then
+    redis.call("DECRBY", KEYS[3], size)
+    redis.call("HDEL", KEYS[2], hash)
+  end
+  redis.call("ZREM", KEYS[1], hash)
+end
+`
+
+// redisPutScript stores an object and its metadata if it does not already
+// exist, and adds it to the usage index. An object is only considered to
+// exist if both its data and metadata keys exist, otherwise it is replaced.
+// KEYS: usage keys, data, meta
+// ARGV: hash, data, size, expiration (0 for none), metadata fields...
+var redisPutScript = redis.NewScript(redisForgetFunc + `
+if redis.call("EXISTS", KEYS[4], KEYS[5]) == 2 then
+  return 0
+end
+redis.call("SET", KEYS[4], ARGV[2])
+redis.call("DEL", KEYS[5])
+redis.call("HSET", KEYS[5], unpack(ARGV, 5))
+local score = "+inf"
+if tonumber(ARGV[4]) > 0 then
+  redis.call("PEXPIREAT", KEYS[4], ARGV[4])
+  redis.call("PEXPIREAT", KEYS[5], ARGV[4])
+  score = ARGV[4]
+end
+forget(ARGV[1])
+redis.call("ZADD", KEYS[1], score, ARGV[1])
+redis.call("HSET", KEYS[2], ARGV[1], ARGV[3])
+redis.call("INCRBY", KEYS[3], ARGV[3])
+return 1
+`)
+
+// redisDeleteScript deletes an object and removes it from the usage index.
+// KEYS: usage keys, data, meta
+// ARGV: hash
+var redisDeleteScript = redis.NewScript(redisForgetFunc + `
+local n = redis.call("DEL", KEYS[4], KEYS[5])
+forget(ARGV[1])
+return n
+`)
+
+// redisQuarantineScript moves the data of an object to the quarantine key,
+// keeping its TTL, and removes it from the usage index.
+// KEYS: usage keys, data, meta, quarantine
+// ARGV: hash
+var redisQuarantineScript = redis.NewScript(redisForgetFunc + `
+if redis.call("EXISTS", KEYS[4]) == 1 then
+  redis.call("RENAME", KEYS[4], KEYS[6])
+end
+redis.call("DEL", KEYS[5])
+forget(ARGV[1])
+return 1
+`)
+
+// redisUsageScript removes objects which have expired from the usage index
+// and returns the number of objects and their total size.
+// KEYS: usage keys
+// ARGV: current time
+var redisUsageScript = redis.NewScript(redisForgetFunc + `
+local expired = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", ARGV[1], "LIMIT", 0, 1000)
+for _, hash in ipairs(expired) do
+  forget(hash)
+end
+return {redis.call("ZCARD", KEYS[1]), tonumber(redis.call("GET", KEYS[3]) or "0")}
+`)
+
+// RedisStorageProvider stores objects in a Redis server, which allows a
+// single storage tier to be shared by several cache servers. Each object is
+// stored as two keys, one containing the object data and a hash containing
+// its metadata. Objects expire using Redis TTLs. Objects are written using
+// Lua scripts, which also keep track of the number and size of all objects
+// so that usage can be reported without scanning the keyspace.
+type RedisStorageProvider struct {
+	ctx              context.Context
+	lg               *zap.SugaredLogger
+	cfg              config.RedisStorageSpec
+	client           *redis.Client
+	prefix           string
+	cacheHitsTotal   *atomic.Int64
+	cacheMissesTotal *atomic.Int64
+	corruptTotal     *atomic.Int64
+	retention        RetentionPolicy
+}
+
+func NewRedisStorageProvider(
+	ctx context.Context,
+	cfg config.RedisStorageSpec,
+	opts ...StorageProviderOption,
+) StorageProvider {
+	options := StorageProviderOptions{}
+	options.Apply(opts...)
+
+	prefix := cfg.KeyPrefix
+	if prefix == "" {
+		prefix = DefaultRedisKeyPrefix
+	}
+	return &RedisStorageProvider{
+		ctx:              ctx,
+		lg:               meta.Log(ctx),
+		cfg:              cfg,
+		prefix:           prefix,
+		cacheHitsTotal:   atomic.NewInt64(0),
+		cacheMissesTotal: atomic.NewInt64(0),
+		corruptTotal:     atomic.NewInt64(0),
+		retention:        options.retention,
+	}
+}
+
+func (sp *RedisStorageProvider) Location() types.StorageLocation {
+	return types.Redis
+}
+
+func (sp *RedisStorageProvider) Configure() error {
+	if sp.cfg.Address == "" {
+		return fmt.Errorf("%w: Redis address is required", ConfigurationError)
+	}
+	options := &redis.Options{
+		Addr:     sp.cfg.Address,
+		Password: sp.cfg.Password,
+		DB:       sp.cfg.DB,
+	}
+	if sp.cfg.TLS {
+		options.TLSConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+	sp.client = redis.NewClient(options)
+
+	// The client reconnects on demand, so the server does not need to be
+	// reachable yet.
+	ctx, cancel := context.WithTimeout(sp.ctx, 5*time.Second)
+	defer cancel()
+	if err := sp.client.Ping(ctx).Err(); err != nil {
+		sp.lg.With(
+			zap.Error(err),
+			zap.String("address", sp.cfg.Address),
+		).Warn("Redis server is not reachable")
+	} else {
+		sp.lg.With(
+			zap.String("address", sp.cfg.Address),
+		).Info("Redis storage provider configured")
+	}
+	return nil
+}
+
+func (sp *RedisStorageProvider) dataKey(hash string) string {
+	return sp.prefix + "data:" + hash
+}
+
+func (sp *RedisStorageProvider) metaKey(hash string) string {
+	return sp.prefix + "meta:" + hash
+}
+
+func (sp *RedisStorageProvider) quarantineKey(hash string) string {
+	return sp.prefix + QuarantineDir + ":" + hash
+}
+
+// usageKeys returns the keys used to track usage. These are the first keys
+// passed to each script.
+func (sp *RedisStorageProvider) usageKeys(keys ...string) []string {
+	return append([]string{
+		sp.prefix + "usage:objects",
+		sp.prefix + "usage:sizes",
+		sp.prefix + "usage:size",
+	}, keys...)
+}
+
+func (sp *RedisStorageProvider) Put(
+	ctx context.Context,
+	key *types.CacheKey,
+	object *types.CacheObject,
+) error {
+	if object.Metadata == nil {
+		object.Metadata = &types.CacheObjectMeta{}
+	}
+	sp.retention.Apply(object.Metadata)
+	object.Metadata.ManagedFields = &types.CacheObjectManaged{
+		Location:  sp.Location(),
+		Size:      int64(len(object.Data)),
+		Timestamp: time.Now().Unix(),
+		Digest:    util.Digest(object.Data),
+	}
+
+	// The script stores the data, metadata, and TTLs atomically, and only if
+	// no other writer has stored the object yet.
+	hash := key.GetHash()
+	args := append([]interface{}{
+		hash,
+		object.Data,
+		len(object.Data),
+		redisMillis(object.Metadata.GetExpirationDate()),
+	}, encodeRedisMetadata(object.Metadata)...)
+	stored, err := redisPutScript.Run(ctx, sp.client,
+		sp.usageKeys(sp.dataKey(hash), sp.metaKey(hash)), args...).Int()
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if stored == 0 {
+		return status.Error(codes.AlreadyExists, "Object already exists")
+	}
+	return nil
+}
+
+func (sp *RedisStorageProvider) Get(
+	ctx context.Context,
+	key *types.CacheKey,
+) (*types.CacheObject, error) {
+	hash := key.GetHash()
+	object, err := sp.readObject(ctx, hash)
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound:
+		sp.cacheMissesTotal.Inc()
+		return nil, err
+	case codes.DataLoss:
+		sp.lg.With(
+			zap.Error(err),
+			"hash", hash,
+		).Error("Quarantining corrupt object")
+		sp.quarantineObject(ctx, hash)
+		sp.corruptTotal.Inc()
+		sp.cacheMissesTotal.Inc()
+		return nil, err
+	default:
+		return nil, err
+	}
+	sp.cacheHitsTotal.Inc()
+
+	extended := sp.retention.Extend(object.Metadata)
+	_, err = sp.client.Pipelined(ctx, func(p redis.Pipeliner) error {
+		p.HIncrBy(ctx, sp.metaKey(hash), redisHitsField, 1)
+		if extended {
+			exp := object.Metadata.ExpirationDate
+			p.HSet(ctx, sp.metaKey(hash), redisExpirationField, exp)
+			p.PExpireAt(ctx, sp.dataKey(hash), time.Unix(0, exp))
+			p.PExpireAt(ctx, sp.metaKey(hash), time.Unix(0, exp))
+			p.ZAddXX(ctx, sp.usageKeys()[0], &redis.Z{
+				Score:  float64(redisMillis(exp)),
+				Member: hash,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		sp.lg.With(zap.Error(err)).Warn("Error updating object metadata")
+	}
+	object.Metadata.ManagedFields.Hits++
+	return object, nil
+}
+
+func (sp *RedisStorageProvider) Peek(
+	ctx context.Context,
+	key *types.CacheKey,
+) (*types.CacheObject, error) {
+	return sp.readObject(ctx, key.GetHash())
+}
+
+// readObject reads and verifies the data and metadata of an object. Objects
+// without metadata are treated as missing.
+func (sp *RedisStorageProvider) readObject(
+	ctx context.Context,
+	hash string,
+) (*types.CacheObject, error) {
+	var data *redis.StringCmd
+	var fields *redis.StringStringMapCmd
+	_, err := sp.client.Pipelined(ctx, func(p redis.Pipeliner) error {
+		data = p.Get(ctx, sp.dataKey(hash))
+		fields = p.HGetAll(ctx, sp.metaKey(hash))
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if data.Err() != nil || len(fields.Val()) == 0 {
+		return nil, status.Error(codes.NotFound, "Object not found")
+	}
+	object := &types.CacheObject{
+		Data:     []byte(data.Val()),
+		Metadata: decodeRedisMetadata(fields.Val()),
+	}
+	if err := VerifyObject(object); err != nil {
+		return nil, corruptObjectError(err)
+	}
+	return object, nil
+}
+
+// quarantineObject moves the data of a corrupt object out of the keyspace
+// that is read from. The quarantined key keeps the object's TTL.
+func (sp *RedisStorageProvider) quarantineObject(ctx context.Context, hash string) {
+	err := redisQuarantineScript.Run(ctx, sp.client, sp.usageKeys(
+		sp.dataKey(hash), sp.metaKey(hash), sp.quarantineKey(hash),
+	), hash).Err()
+	if err != nil {
+		sp.lg.With(
+			zap.Error(err),
+			"hash", hash,
+		).Error("Error quarantining object")
+	}
+}
+
+func (sp *RedisStorageProvider) Query(
+	ctx context.Context,
+	keys []*types.CacheKey,
+) ([]*types.CacheObjectMeta, error) {
+	exists := make([]*redis.IntCmd, len(keys))
+	fields := make([]*redis.StringStringMapCmd, len(keys))
+	_, err := sp.client.Pipelined(ctx, func(p redis.Pipeliner) error {
+		for i, key := range keys {
+			exists[i] = p.Exists(ctx, sp.dataKey(key.GetHash()))
+			fields[i] = p.HGetAll(ctx, sp.metaKey(key.GetHash()))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	results := make([]*types.CacheObjectMeta, len(keys))
+	for i := range keys {
+		if exists[i].Val() == 1 && len(fields[i].Val()) > 0 {
+			results[i] = decodeRedisMetadata(fields[i].Val())
+		}
+	}
+	return results, nil
+}
+
+func (sp *RedisStorageProvider) Delete(
+	ctx context.Context,
+	key *types.CacheKey,
+) error {
+	hash := key.GetHash()
+	n, err := redisDeleteScript.Run(ctx, sp.client,
+		sp.usageKeys(sp.dataKey(hash), sp.metaKey(hash)), hash).Int()
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if n == 0 {
+		return status.Error(codes.NotFound, "Object not found")
+	}
+	return nil
+}
+
+func (sp *RedisStorageProvider) List(
+	ctx context.Context,
+) ([]*types.CacheKey, error) {
+	keys := []*types.CacheKey{}
+	prefix := sp.dataKey("")
+	iter := sp.client.Scan(ctx, 0, prefix+"*", 1000).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, &types.CacheKey{
+			Hash: strings.TrimPrefix(iter.Val(), prefix),
+		})
+	}
+	if err := iter.Err(); err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return keys, nil
+}
+
+// UsageInfo reports the number and size of objects tracked by the usage
+// index, after removing objects which have expired. Objects removed by the
+// Redis server's own eviction policy are counted until they expire. Memory
+// limits are managed by the Redis server, so the usage percent is not
+// reported.
+func (sp *RedisStorageProvider) UsageInfo() *metrics.CacheUsage {
+	usage := &metrics.CacheUsage{}
+	values, err := redisUsageScript.Run(sp.ctx, sp.client, sp.usageKeys(),
+		redisMillis(time.Now().UnixNano())).Int64Slice()
+	if err != nil {
+		sp.lg.With(zap.Error(err)).Error("Error reading storage usage")
+		return usage
+	}
+	if len(values) == 2 {
+		usage.ObjectCount = values[0]
+		usage.TotalSize = values[1]
+	}
+	return usage
+}
+
+// redisMillis converts a time in nanoseconds to milliseconds, which is the
+// precision of Redis expiration times.
+func redisMillis(nanos int64) int64 {
+	return nanos / int64(time.Millisecond)
+}
+
+func (sp *RedisStorageProvider) CacheHits() *metrics.CacheHits {
+	hitTotal := sp.cacheHitsTotal.Load()
+	missTotal := sp.cacheMissesTotal.Load()
+	var percent float64
+	if hitTotal+missTotal == 0 {
+		percent = 0
+	} else {
+		percent = float64(hitTotal) / float64(hitTotal+missTotal)
+	}
+	return &metrics.CacheHits{
+		CacheHitsTotal:      hitTotal,
+		CacheMissesTotal:    missTotal,
+		CacheHitPercent:     percent,
+		CorruptObjectsTotal: sp.corruptTotal.Load(),
+	}
+}
+
+func encodeRedisMetadata(md *types.CacheObjectMeta) []interface{} {
+	managed := md.GetManagedFields()
+	values := []interface{}{
+		redisSizeField, managed.GetSize(),
+		redisTimestampField, managed.GetTimestamp(),
+		redisHitsField, managed.GetHits(),
+		redisScoreField, managed.GetScore(),
+		redisDigestField, managed.GetDigest(),
+		redisExpirationField, md.GetExpirationDate(),
+	}
+	for k, v := range md.GetTags() {
+		values = append(values, redisTagField+k, v)
+	}
+	return values
+}
+
+func decodeRedisMetadata(fields map[string]string) *types.CacheObjectMeta {
+	parseInt := func(field string) int64 {
+		value, _ := strconv.ParseInt(fields[field], 10, 64)
+		return value
+	}
+	md := &types.CacheObjectMeta{
+		Tags:           map[string]string{},
+		ExpirationDate: parseInt(redisExpirationField),
+		ManagedFields: &types.CacheObjectManaged{
+			Location:  types.Redis,
+			Size:      parseInt(redisSizeField),
+			Timestamp: parseInt(redisTimestampField),
+			Hits:      parseInt(redisHitsField),
+			Score:     parseInt(redisScoreField),
+			Digest:    fields[redisDigestField],
+		},
+	}
+	for field, value := range fields {
+		if strings.HasPrefix(field, redisTagField) {
+			md.Tags[strings.TrimPrefix(field, redisTagField)] = value
+		}
+	}
+	return md
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+var _ = Describe("Redis Storage Provider", func() {
+	var mr *miniredis.Miniredis
+	var rsp storage.StorageProvider
+
+	BeforeEach(func() {
+		if mr != nil {
+			return
+		}
+		var err error
+		mr, err = miniredis.Run()
+		Expect(err).NotTo(HaveOccurred())
+		rsp = storage.NewRedisStorageProvider(testCtx, config.RedisStorageSpec{
+			Address: mr.Addr(),
+		}, storage.WithRetentionPolicy(storage.RetentionPolicy{
+			DefaultTTL:        time.Hour,
+			SlidingExpiration: true,
+		}))
+		Expect(rsp.Configure()).To(Succeed())
+	})
+
+	It("Should fail when given an invalid configuration", func() {
+		Expect(storage.NewRedisStorageProvider(testCtx, config.RedisStorageSpec{}).
+			Configure()).To(MatchError(storage.ConfigurationError))
+	})
+	It("Should have the proper location set", func() {
+		Expect(rsp.Location()).To(Equal(types.Redis))
+	})
+	It("Should persist stored objects", func() {
+		for i := 10; i < 60; i++ {
+			Expect(rsp.Put(testCtx, &types.CacheKey{
+				Hash: fmt.Sprint(i),
+			}, &types.CacheObject{
+				Data: []byte("12345"),
+				Metadata: &types.CacheObjectMeta{
+					Tags: map[string]string{
+						"tag": fmt.Sprint(i),
+					},
+				},
+			})).To(Succeed())
+			obj, err := rsp.Get(testCtx, &types.CacheKey{
+				Hash: fmt.Sprint(i),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obj.Data).To(BeEquivalentTo([]byte("12345")))
+			Expect(obj.Metadata.Tags).To(HaveKeyWithValue("tag", fmt.Sprint(i)))
+			Expect(obj.Metadata.ManagedFields.Location).To(Equal(types.Redis))
+			Expect(obj.Metadata.ManagedFields.Hits).To(Equal(int64(1)))
+		}
+		usage := rsp.UsageInfo()
+		Expect(usage.ObjectCount).To(Equal(int64(50)))
+		Expect(usage.TotalSize).To(Equal(int64(250)))
+	})
+	It("Should not store the same object twice", func() {
+		err := rsp.Put(testCtx, &types.CacheKey{
+			Hash: "10",
+		}, &types.CacheObject{
+			Data: []byte("54321"),
+		})
+		Expect(status.Code(err)).To(Equal(codes.AlreadyExists))
+	})
+	It("Should replace objects with missing metadata", func() {
+		mr.Del("kubecc:meta:11")
+		Expect(rsp.Put(testCtx, &types.CacheKey{
+			Hash: "11",
+		}, &types.CacheObject{
+			Data: []byte("54321"),
+			Metadata: &types.CacheObjectMeta{
+				Tags: map[string]string{
+					"tag": "11",
+				},
+			},
+		})).To(Succeed())
+		Expect(mr.Get("kubecc:data:11")).To(Equal("54321"))
+		results, err := rsp.Query(testCtx, []*types.CacheKey{{Hash: "11"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results[0].GetTags()).To(HaveKeyWithValue("tag", "11"))
+		usage := rsp.UsageInfo()
+		Expect(usage.ObjectCount).To(Equal(int64(50)))
+		Expect(usage.TotalSize).To(Equal(int64(250)))
+	})
+	It("Should query and list stored objects", func() {
+		results, err := rsp.Query(testCtx, []*types.CacheKey{
+			{Hash: "10"},
+			{Hash: "missing"},
+			{Hash: "59"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(3))
+		Expect(results[0].GetManagedFields().GetSize()).To(Equal(int64(5)))
+		Expect(results[1]).To(BeNil())
+		Expect(results[2].GetTags()).To(HaveKeyWithValue("tag", "59"))
+
+		keys, err := rsp.List(testCtx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(HaveLen(50))
+	})
+	It("Should count cache hits and misses", func() {
+		_, err := rsp.Get(testCtx, &types.CacheKey{
+			Hash: "missing",
+		})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+		hits := rsp.CacheHits()
+		Expect(hits.CacheHitsTotal).To(Equal(int64(50)))
+		Expect(hits.CacheMissesTotal).To(Equal(int64(1)))
+	})
+	It("Should expire objects using Redis TTLs", func() {
+		Expect(mr.TTL("kubecc:data:10")).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(mr.TTL("kubecc:meta:10")).To(BeNumerically("~", time.Hour, time.Minute))
+		mr.FastForward(2 * time.Hour)
+		_, err := rsp.Get(testCtx, &types.CacheKey{
+			Hash: "10",
+		})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+		keys, err := rsp.List(testCtx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(BeEmpty())
+	})
+	It("Should quarantine corrupt objects", func() {
+		Expect(rsp.Put(testCtx, &types.CacheKey{
+			Hash: "corrupt",
+		}, &types.CacheObject{
+			Data: []byte("12345"),
+		})).To(Succeed())
+		Expect(mr.Set("kubecc:data:corrupt", "54321")).To(Succeed())
+		_, err := rsp.Get(testCtx, &types.CacheKey{
+			Hash: "corrupt",
+		})
+		Expect(status.Code(err)).To(Equal(codes.DataLoss))
+		Expect(mr.Exists("kubecc:quarantine:corrupt")).To(BeTrue())
+		Expect(mr.Exists("kubecc:meta:corrupt")).To(BeFalse())
+		Expect(rsp.CacheHits().CorruptObjectsTotal).To(Equal(int64(1)))
+	})
+	It("Should track usage without scanning objects", func() {
+		// Objects expired by fast-forwarding the server are still counted
+		// until their expiration date has passed
+		base := rsp.UsageInfo()
+		Expect(rsp.Put(testCtx, &types.CacheKey{
+			Hash: "usage1",
+		}, &types.CacheObject{
+			Data: []byte("1234567890"),
+		})).To(Succeed())
+		Expect(rsp.Put(testCtx, &types.CacheKey{
+			Hash: "usage2",
+		}, &types.CacheObject{
+			Data: []byte("12345"),
+			Metadata: &types.CacheObjectMeta{
+				ExpirationDate: time.Now().Add(100 * time.Millisecond).UnixNano(),
+			},
+		})).To(Succeed())
+		Expect(mr.TTL("kubecc:data:usage2")).To(BeNumerically(">", 0))
+		usage := rsp.UsageInfo()
+		Expect(usage.ObjectCount).To(Equal(base.ObjectCount + 2))
+		Expect(usage.TotalSize).To(Equal(base.TotalSize + 15))
+
+		Expect(rsp.Delete(testCtx, &types.CacheKey{
+			Hash: "usage1",
+		})).To(Succeed())
+		usage = rsp.UsageInfo()
+		Expect(usage.ObjectCount).To(Equal(base.ObjectCount + 1))
+		Expect(usage.TotalSize).To(Equal(base.TotalSize + 5))
+
+		Eventually(func() int64 {
+			return rsp.UsageInfo().ObjectCount
+		}).Should(Equal(base.ObjectCount))
+		Expect(rsp.UsageInfo().TotalSize).To(Equal(base.TotalSize))
+	})
+})
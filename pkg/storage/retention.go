@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+// ToolchainTag is the object tag used to record the kind of toolchain that
+// produced a cached object. It is used to apply per-toolchain retention.
+const ToolchainTag = "toolchain"
+
+// DefaultTTL is the amount of time compile results are cached for if no
+// retention policy has been configured.
+const DefaultTTL = 1 * time.Hour
+
+// RetentionPolicy controls how long objects are kept by a storage provider.
+type RetentionPolicy struct {
+	// DefaultTTL is used to set the expiration date of objects that do not
+	// have one. If 0, such objects do not expire.
+	DefaultTTL time.Duration
+	// SlidingExpiration extends the expiration date of an object each time
+	// it is retrieved.
+	SlidingExpiration bool
+	// ToolchainTTLs overrides DefaultTTL for objects produced by specific
+	// toolchain kinds.
+	ToolchainTTLs map[types.ToolchainKind]time.Duration
+}
+
+// ToolchainName returns the name used to identify a toolchain kind in object
+// tags and retention configuration.
+func ToolchainName(kind types.ToolchainKind) string {
+	return strings.ToLower(
+		strings.TrimPrefix(types.ToolchainKind_name[int32(kind)], "ToolchainKind_"))
+}
+
+func parseToolchainName(name string) (types.ToolchainKind, bool) {
+	for value := range types.ToolchainKind_name {
+		if kind := types.ToolchainKind(value); strings.EqualFold(ToolchainName(kind), name) {
+			return kind, true
+		}
+	}
+	return types.ToolchainKind_ToolchainKind_Unknown, false
+}
+
+// NewRetentionPolicy creates a RetentionPolicy from its configuration.
+func NewRetentionPolicy(spec config.RetentionSpec) (RetentionPolicy, error) {
+	policy := RetentionPolicy{
+		SlidingExpiration: spec.SlidingExpiration,
+		ToolchainTTLs:     make(map[types.ToolchainKind]time.Duration),
+	}
+	if spec.DefaultTTL != "" {
+		ttl, err := time.ParseDuration(spec.DefaultTTL)
+		if err != nil {
+			return policy, fmt.Errorf("%w: invalid default TTL: %s",
+				ConfigurationError, err.Error())
+		}
+		policy.DefaultTTL = ttl
+	}
+	for name, value := range spec.ToolchainTTLs {
+		kind, ok := parseToolchainName(name)
+		if !ok {
+			return policy, fmt.Errorf("%w: unknown toolchain %q",
+				ConfigurationError, name)
+		}
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return policy, fmt.Errorf("%w: invalid TTL for toolchain %q: %s",
+				ConfigurationError, name, err.Error())
+		}
+		policy.ToolchainTTLs[kind] = ttl
+	}
+	return policy, nil
+}
+
+// TTL returns the amount of time objects produced by the given toolchain kind
+// should be kept for.
+func (p RetentionPolicy) TTL(kind types.ToolchainKind) time.Duration {
+	if ttl, ok := p.ToolchainTTLs[kind]; ok {
+		return ttl
+	}
+	return p.DefaultTTL
+}
+
+// ObjectTTL returns the amount of time the object with the given metadata
+// should be kept for, based on its toolchain tag.
+func (p RetentionPolicy) ObjectTTL(meta *types.CacheObjectMeta) time.Duration {
+	if kind, ok := parseToolchainName(meta.GetTags()[ToolchainTag]); ok {
+		return p.TTL(kind)
+	}
+	return p.DefaultTTL
+}
+
+// Apply sets the expiration date of an object that does not have one.
+func (p RetentionPolicy) Apply(meta *types.CacheObjectMeta) {
+	if meta.ExpirationDate != 0 {
+		return
+	}
+	if ttl := p.ObjectTTL(meta); ttl > 0 {
+		meta.ExpirationDate = time.Now().Add(ttl).UnixNano()
+	}
+}
+
+// Extend updates the expiration date of an object that was just retrieved if
+// sliding expiration is enabled. To avoid rewriting objects on every hit, the
+// expiration date is only extended once less than half of the object's TTL
+// remains. Returns true if the expiration date was changed.
+func (p RetentionPolicy) Extend(meta *types.CacheObjectMeta) bool {
+	if !p.SlidingExpiration || meta.GetExpirationDate() == 0 {
+		return false
+	}
+	ttl := p.ObjectTTL(meta)
+	if ttl <= 0 {
+		return false
+	}
+	if time.Until(time.Unix(0, meta.ExpirationDate)) >= ttl/2 {
+		return false
+	}
+	meta.ExpirationDate = time.Now().Add(ttl).UnixNano()
+	return true
+}
+
+type StorageProviderOptions struct {
+	retention RetentionPolicy
+}
+
+type StorageProviderOption func(*StorageProviderOptions)
+
+func (o *StorageProviderOptions) Apply(opts ...StorageProviderOption) {
+	for _, op := range opts {
+		op(o)
+	}
+}
+
+// WithRetentionPolicy sets the retention policy enforced by the local,
+// volatile, Redis and S3 storage providers.
+func WithRetentionPolicy(policy RetentionPolicy) StorageProviderOption {
+	return func(o *StorageProviderOptions) {
+		o.retention = policy
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/kubecc-io/kubecc/pkg/config"
+	"github.com/kubecc-io/kubecc/pkg/storage"
+	"github.com/kubecc-io/kubecc/pkg/types"
+)
+
+var _ = Describe("Retention Policy", func() {
+	Context("Configuration", func() {
+		It("should parse a valid configuration", func() {
+			policy, err := storage.NewRetentionPolicy(config.RetentionSpec{
+				DefaultTTL:        "2h",
+				SlidingExpiration: true,
+				ToolchainTTLs: map[string]string{
+					"gnu":   "30m",
+					"Clang": "24h",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy.SlidingExpiration).To(BeTrue())
+			Expect(policy.TTL(types.Gnu)).To(Equal(30 * time.Minute))
+			Expect(policy.TTL(types.Clang)).To(Equal(24 * time.Hour))
+			Expect(policy.TTL(types.Sleep)).To(Equal(2 * time.Hour))
+		})
+		It("should reject invalid configurations", func() {
+			_, err := storage.NewRetentionPolicy(config.RetentionSpec{
+				DefaultTTL: "1 hour",
+			})
+			Expect(err).To(MatchError(storage.ConfigurationError))
+			_, err = storage.NewRetentionPolicy(config.RetentionSpec{
+				ToolchainTTLs: map[string]string{
+					"msvc": "1h",
+				},
+			})
+			Expect(err).To(MatchError(storage.ConfigurationError))
+			_, err = storage.NewRetentionPolicy(config.RetentionSpec{
+				ToolchainTTLs: map[string]string{
+					"gnu": "",
+				},
+			})
+			Expect(err).To(MatchError(storage.ConfigurationError))
+		})
+	})
+	Context("Expiration dates", func() {
+		policy := storage.RetentionPolicy{
+			DefaultTTL:        1 * time.Hour,
+			SlidingExpiration: true,
+			ToolchainTTLs: map[types.ToolchainKind]time.Duration{
+				types.Gnu: 10 * time.Minute,
+			},
+		}
+		It("should apply the toolchain TTL to untagged objects", func() {
+			meta := &types.CacheObjectMeta{}
+			policy.Apply(meta)
+			Expect(time.Until(time.Unix(0, meta.ExpirationDate))).
+				To(BeNumerically("~", 1*time.Hour, time.Second))
+
+			meta = &types.CacheObjectMeta{
+				Tags: map[string]string{
+					storage.ToolchainTag: storage.ToolchainName(types.Gnu),
+				},
+			}
+			policy.Apply(meta)
+			Expect(time.Until(time.Unix(0, meta.ExpirationDate))).
+				To(BeNumerically("~", 10*time.Minute, time.Second))
+		})
+		It("should not override existing expiration dates", func() {
+			date := time.Now().Add(5 * time.Hour).UnixNano()
+			meta := &types.CacheObjectMeta{
+				ExpirationDate: date,
+			}
+			policy.Apply(meta)
+			Expect(meta.ExpirationDate).To(Equal(date))
+		})
+		It("should not set an expiration date without a TTL", func() {
+			meta := &types.CacheObjectMeta{}
+			storage.RetentionPolicy{}.Apply(meta)
+			Expect(meta.ExpirationDate).To(BeZero())
+		})
+		It("should extend expiration dates close to expiring", func() {
+			date := time.Now().Add(50 * time.Minute).UnixNano()
+			meta := &types.CacheObjectMeta{
+				ExpirationDate: date,
+			}
+			Expect(policy.Extend(meta)).To(BeFalse())
+			Expect(meta.ExpirationDate).To(Equal(date))
+
+			meta.ExpirationDate = time.Now().Add(10 * time.Minute).UnixNano()
+			Expect(policy.Extend(meta)).To(BeTrue())
+			Expect(time.Until(time.Unix(0, meta.ExpirationDate))).
+				To(BeNumerically("~", 1*time.Hour, time.Second))
+		})
+		It("should not extend expiration dates unless enabled", func() {
+			date := time.Now().Add(1 * time.Minute).UnixNano()
+			meta := &types.CacheObjectMeta{
+				ExpirationDate: date,
+			}
+			Expect(storage.RetentionPolicy{
+				DefaultTTL: 1 * time.Hour,
+			}.Extend(meta)).To(BeFalse())
+			Expect(meta.ExpirationDate).To(Equal(date))
+		})
+	})
+	Context("Storage providers", func() {
+		policy := storage.RetentionPolicy{
+			DefaultTTL:        1 * time.Second,
+			SlidingExpiration: true,
+		}
+		It("should expire objects in volatile storage", func() {
+			vsp := storage.NewVolatileStorageProvider(testCtx,
+				config.VolatileStorageSpec{
+					Limits: config.StorageLimitsSpec{
+						Memory: "1Ki",
+					},
+				},
+				storage.WithRetentionPolicy(storage.RetentionPolicy{
+					DefaultTTL: policy.DefaultTTL,
+				}),
+			)
+			Expect(vsp.Configure()).To(Succeed())
+			Expect(vsp.Put(testCtx, &types.CacheKey{
+				Hash: "1",
+			}, &types.CacheObject{
+				Data: []byte("1"),
+			})).To(Succeed())
+			Eventually(func() error {
+				_, err := vsp.Get(testCtx, &types.CacheKey{
+					Hash: "1",
+				})
+				return err
+			}, 2*time.Second, 50*time.Millisecond).Should(HaveOccurred())
+		})
+		It("should extend objects in volatile storage when retrieved", func() {
+			vsp := storage.NewVolatileStorageProvider(testCtx,
+				config.VolatileStorageSpec{
+					Limits: config.StorageLimitsSpec{
+						Memory: "1Ki",
+					},
+				},
+				storage.WithRetentionPolicy(policy),
+			)
+			Expect(vsp.Configure()).To(Succeed())
+			Expect(vsp.Put(testCtx, &types.CacheKey{
+				Hash: "1",
+			}, &types.CacheObject{
+				Data: []byte("1"),
+			})).To(Succeed())
+			// Keep retrieving the object for longer than its TTL
+			Consistently(func() error {
+				_, err := vsp.Get(testCtx, &types.CacheKey{
+					Hash: "1",
+				})
+				return err
+			}, 2*time.Second, 100*time.Millisecond).Should(Succeed())
+		})
+		It("should expire and extend objects in local storage", func() {
+			tempDir, err := ioutil.TempDir("", "retention-test")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(tempDir)
+			ctx, cancel := context.WithCancel(testCtx)
+			defer cancel()
+
+			lsp := storage.NewLocalStorageProvider(ctx,
+				config.LocalStorageSpec{
+					Path: tempDir,
+					Limits: config.StorageLimitsSpec{
+						Disk: "10Ki",
+					},
+				},
+				storage.WithRetentionPolicy(policy),
+			)
+			Expect(lsp.Configure()).To(Succeed())
+			for _, hash := range []string{"hash1", "hash2"} {
+				Expect(lsp.Put(testCtx, &types.CacheKey{
+					Hash: hash,
+				}, &types.CacheObject{
+					Data: []byte(hash),
+				})).To(Succeed())
+			}
+			By("retrieving only the first object")
+			Consistently(func() error {
+				_, err := lsp.Get(testCtx, &types.CacheKey{
+					Hash: "hash1",
+				})
+				return err
+			}, 2*time.Second, 100*time.Millisecond).Should(Succeed())
+			_, err = lsp.Get(testCtx, &types.CacheKey{
+				Hash: "hash2",
+			})
+			Expect(err).To(HaveOccurred())
+			By("no longer retrieving the first object")
+			Eventually(func() int64 {
+				return lsp.UsageInfo().ObjectCount
+			}, 2*time.Second, 50*time.Millisecond).Should(BeZero())
+		})
+	})
+})
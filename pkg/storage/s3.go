@@ -23,13 +23,16 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"path"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kubecc-io/kubecc/pkg/config"
 	"github.com/kubecc-io/kubecc/pkg/meta"
 	"github.com/kubecc-io/kubecc/pkg/metrics"
 	"github.com/kubecc-io/kubecc/pkg/types"
+	"github.com/kubecc-io/kubecc/pkg/util"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/minio/minio-go/v7/pkg/lifecycle"
@@ -53,12 +56,18 @@ type S3StorageProvider struct {
 	bucket           string
 	cacheHitsTotal   *atomic.Int64
 	cacheMissesTotal *atomic.Int64
+	corruptTotal     *atomic.Int64
+	retention        RetentionPolicy
 }
 
 func NewS3StorageProvider(
 	ctx context.Context,
 	cfg config.RemoteStorageSpec,
+	opts ...StorageProviderOption,
 ) StorageProvider {
+	options := StorageProviderOptions{}
+	options.Apply(opts...)
+
 	if cfg.Bucket == "" {
 		cfg.Bucket = "kubecc"
 	}
@@ -69,6 +78,8 @@ func NewS3StorageProvider(
 		bucket:           cfg.Bucket,
 		cacheHitsTotal:   atomic.NewInt64(0),
 		cacheMissesTotal: atomic.NewInt64(0),
+		corruptTotal:     atomic.NewInt64(0),
+		retention:        options.retention,
 	}
 	return sp
 }
@@ -165,6 +176,19 @@ func (sp *S3StorageProvider) Put(
 	if object.Metadata == nil {
 		object.Metadata = &types.CacheObjectMeta{}
 	}
+	// Objects without an expiration date are given one based on the
+	// retention policy. The bucket lifecycle only expires objects after a
+	// number of days, so the expiration date is stored with the object and
+	// checked when it is read.
+	sp.retention.Apply(object.Metadata)
+	metadata := map[string]string{
+		"timestamp": strconv.FormatInt(time.Now().UnixNano(), 10),
+		"score":     "1",
+		"digest":    util.Digest(object.Data),
+	}
+	if exp := object.Metadata.GetExpirationDate(); exp != 0 {
+		metadata["expiration"] = strconv.FormatInt(exp, 10)
+	}
 	_, err := sp.client.PutObject(
 		sp.ctx,
 		sp.bucket,
@@ -172,12 +196,9 @@ func (sp *S3StorageProvider) Put(
 		bytes.NewReader(object.Data),
 		int64(len(object.Data)),
 		minio.PutObjectOptions{
-			UserMetadata: map[string]string{
-				"timestamp": strconv.FormatInt(time.Now().UnixNano(), 10),
-				"score":     "1",
-			},
-			UserTags:    object.Metadata.GetTags(),
-			ContentType: "application/octet-stream",
+			UserMetadata: metadata,
+			UserTags:     object.Metadata.GetTags(),
+			ContentType:  "application/octet-stream",
 		},
 	)
 	if err != nil {
@@ -200,43 +221,26 @@ func (sp *S3StorageProvider) Get(
 		return nil, status.Error(codes.NotFound,
 			fmt.Errorf("Object not found: %w", err).Error())
 	}
-	objectBuf := bytebufferpool.Get()
-	defer bytebufferpool.Put(objectBuf)
-	done := make(chan error)
-
-	go func() {
-		// Start streaming object data from s3
-		obj, err := sp.client.GetObject(
-			sp.ctx,
-			sp.bucket,
-			hash,
-			minio.GetObjectOptions{},
-		)
-		if err != nil {
-			// Something went wrong, but the object exists
-			done <- status.Error(codes.NotFound,
-				fmt.Errorf("Error retrieving object: %w", err).Error())
-		}
-		_, err = objectBuf.ReadFrom(obj)
-		if err != nil {
-			done <- status.Error(codes.Internal, err.Error())
-		}
-		done <- nil
-		close(done)
-	}()
+	if sp.expired(hash, info) {
+		sp.cacheMissesTotal.Inc()
+		return nil, status.Error(codes.NotFound, "Object expired")
+	}
 
 	// Increment the score by 1
-	score := int64(1)
 	metadata := info.UserMetadata
-	if value, ok := metadata["score"]; ok {
-		s, err := strconv.ParseInt(value, 10, 64)
-		if err == nil {
-			score = s
-		}
-	}
-	score++
+	score := storedScore(metadata) + 1
 	metadata["score"] = strconv.FormatInt(score, 10)
 
+	// Extend the object's expiration date if sliding expiration is enabled.
+	// It is updated along with the score.
+	md := &types.CacheObjectMeta{
+		Tags:           info.UserTags,
+		ExpirationDate: storedExpiration(info),
+	}
+	if sp.retention.Extend(md) {
+		metadata["expiration"] = strconv.FormatInt(md.ExpirationDate, 10)
+	}
+
 	// Copy object to itself and replace the metadata
 	go func() {
 		_, err := sp.client.CopyObject(sp.ctx,
@@ -254,28 +258,227 @@ func (sp *S3StorageProvider) Get(
 			sp.lg.With(zap.Error(err)).Error("Failed to update object")
 		}
 	}()
+
+	object, err := sp.readObject(ctx, hash, info, score)
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.DataLoss:
+		sp.cacheMissesTotal.Inc()
+		sp.quarantineObject(hash, err)
+		return nil, err
+	default:
+		if ctx.Err() == nil {
+			sp.cacheMissesTotal.Inc()
+		}
+		return nil, err
+	}
 	sp.cacheHitsTotal.Inc()
+	return object, nil
+}
+
+func (sp *S3StorageProvider) Peek(
+	ctx context.Context,
+	key *types.CacheKey,
+) (*types.CacheObject, error) {
+	hash := key.GetHash()
+	info, err := sp.client.StatObject(
+		ctx, sp.bucket, hash, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, status.Error(codes.NotFound,
+			fmt.Errorf("Object not found: %w", err).Error())
+	}
+	if sp.expired(hash, info) {
+		return nil, status.Error(codes.NotFound, "Object expired")
+	}
+	return sp.readObject(ctx, hash, info, storedScore(info.UserMetadata))
+}
+
+// readObject reads and verifies the data of an object, using the metadata
+// from its stat info and the given score.
+func (sp *S3StorageProvider) readObject(
+	ctx context.Context,
+	hash string,
+	info minio.ObjectInfo,
+	score int64,
+) (*types.CacheObject, error) {
+	objectBuf := bytebufferpool.Get()
+	defer bytebufferpool.Put(objectBuf)
+	done := make(chan error, 1)
+
+	go func() {
+		defer close(done)
+		// Start streaming object data from s3
+		obj, err := sp.client.GetObject(
+			sp.ctx,
+			sp.bucket,
+			hash,
+			minio.GetObjectOptions{},
+		)
+		if err != nil {
+			// Something went wrong, but the object exists
+			done <- status.Error(codes.NotFound,
+				fmt.Errorf("Error retrieving object: %w", err).Error())
+			return
+		}
+		_, err = objectBuf.ReadFrom(obj)
+		if err != nil {
+			done <- status.Error(codes.Internal, err.Error())
+			return
+		}
+		done <- nil
+	}()
 
 	// Wait for read to complete, or context canceled
 	select {
-	case <-done:
+	case err := <-done:
+		if err != nil {
+			return nil, err
+		}
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	}
 
-	return &types.CacheObject{
-		Data: objectBuf.Bytes(),
+	object := &types.CacheObject{
+		// The buffer is returned to the pool when this function returns
+		Data: append([]byte(nil), objectBuf.Bytes()...),
 		Metadata: &types.CacheObjectMeta{
 			Tags:           info.UserTags,
-			ExpirationDate: info.Expiration.UnixNano(),
+			ExpirationDate: storedExpiration(info),
 			ManagedFields: &types.CacheObjectManaged{
 				Size:      info.Size,
-				Timestamp: time.Now().UnixNano(),
+				Timestamp: storedTimestamp(info.UserMetadata),
 				Score:     score,
 				Location:  types.S3,
+				Digest:    userMetadata(info.UserMetadata, "digest"),
 			},
 		},
-	}, nil
+	}
+	if err := VerifyObject(object); err != nil {
+		return nil, corruptObjectError(err)
+	}
+	return object, nil
+}
+
+// quarantineObject moves a corrupt object under the quarantine prefix so it
+// will no longer be served, but can still be inspected.
+func (sp *S3StorageProvider) quarantineObject(hash string, reason error) {
+	sp.corruptTotal.Inc()
+	lg := sp.lg.With(
+		zap.String("hash", hash),
+		zap.Error(reason),
+	)
+	lg.Error("Quarantining corrupt object")
+	_, err := sp.client.CopyObject(sp.ctx,
+		minio.CopyDestOptions{
+			Bucket: sp.bucket,
+			Object: path.Join(QuarantineDir, hash),
+		},
+		minio.CopySrcOptions{
+			Bucket: sp.bucket,
+			Object: hash,
+		})
+	if err != nil {
+		lg.With(zap.Error(err)).Error("Failed to copy object to quarantine")
+	}
+	err = sp.client.RemoveObject(sp.ctx, sp.bucket, hash, minio.RemoveObjectOptions{})
+	if err != nil {
+		lg.With(zap.Error(err)).Error("Failed to remove corrupt object")
+	}
+}
+
+// userMetadata looks up a user metadata key, ignoring case. The S3 API
+// canonicalizes metadata header names, so the case may not be preserved.
+func userMetadata(metadata map[string]string, key string) string {
+	if value, ok := metadata[key]; ok {
+		return value
+	}
+	for k, v := range metadata {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
+// storedScore returns the score of an object from its user metadata. Objects
+// start with a score of 1.
+func storedScore(metadata map[string]string) int64 {
+	if value, ok := metadata["score"]; ok {
+		if s, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return s
+		}
+	}
+	return 1
+}
+
+// storedTimestamp returns the time an object was stored, in seconds, from its
+// user metadata. Timestamps are stored with nanosecond precision, but other
+// providers report them in seconds.
+func storedTimestamp(metadata map[string]string) int64 {
+	ns, err := strconv.ParseInt(userMetadata(metadata, "timestamp"), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return time.Unix(0, ns).Unix()
+}
+
+// storedExpiration returns the expiration date of an object in nanoseconds,
+// which is the earlier of the date stored in its user metadata and the date
+// set by the bucket lifecycle. Returns 0 if the object does not expire.
+func storedExpiration(info minio.ObjectInfo) int64 {
+	var exp int64
+	if ns, err := strconv.ParseInt(
+		userMetadata(info.UserMetadata, "expiration"), 10, 64); err == nil {
+		exp = ns
+	}
+	if !info.Expiration.IsZero() {
+		if lc := info.Expiration.UnixNano(); exp == 0 || lc < exp {
+			exp = lc
+		}
+	}
+	return exp
+}
+
+// expired returns true if the object has passed its expiration date, in
+// which case it is deleted in the background.
+func (sp *S3StorageProvider) expired(hash string, info minio.ObjectInfo) bool {
+	exp := storedExpiration(info)
+	if exp == 0 || time.Now().Before(time.Unix(0, exp)) {
+		return false
+	}
+	go func() {
+		err := sp.client.RemoveObject(sp.ctx, sp.bucket, hash, minio.RemoveObjectOptions{})
+		if err != nil {
+			sp.lg.With(
+				zap.String("hash", hash),
+				zap.Error(err),
+			).Error("Failed to remove expired object")
+		}
+	}()
+	return true
+}
+
+// isQuarantined returns true if the given object key is in the quarantine
+// prefix.
+func isQuarantined(key string) bool {
+	return strings.HasPrefix(key, QuarantineDir+"/")
+}
+
+func (sp *S3StorageProvider) Delete(
+	ctx context.Context,
+	key *types.CacheKey,
+) error {
+	hash := key.GetHash()
+	if _, err := sp.client.StatObject(
+		ctx, sp.bucket, hash, minio.GetObjectOptions{}); err != nil {
+		return status.Error(codes.NotFound,
+			fmt.Errorf("Object not found: %w", err).Error())
+	}
+	err := sp.client.RemoveObject(ctx, sp.bucket, hash, minio.RemoveObjectOptions{})
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
 }
 
 func (sp *S3StorageProvider) Query(
@@ -289,7 +492,7 @@ func (sp *S3StorageProvider) Query(
 		}
 		info, err := sp.client.StatObject(
 			ctx, sp.bucket, key.GetHash(), minio.GetObjectOptions{})
-		if err != nil {
+		if err != nil || sp.expired(key.GetHash(), info) {
 			continue
 		}
 		timestamp, err := strconv.ParseInt(info.UserMetadata["timestamp"], 10, 64)
@@ -304,9 +507,9 @@ func (sp *S3StorageProvider) Query(
 		}
 		results[i] = &types.CacheObjectMeta{
 			Tags:           info.UserTags,
-			ExpirationDate: info.Expiration.UnixNano(),
+			ExpirationDate: storedExpiration(info),
 			ManagedFields: &types.CacheObjectManaged{
-				Timestamp: timestamp,
+				Timestamp: time.Unix(0, timestamp).Unix(),
 				Score:     score,
 				Size:      info.Size,
 				Location:  types.S3,
@@ -316,6 +519,24 @@ func (sp *S3StorageProvider) Query(
 	return results, nil
 }
 
+func (sp *S3StorageProvider) List(
+	ctx context.Context,
+) ([]*types.CacheKey, error) {
+	keys := []*types.CacheKey{}
+	for object := range sp.client.ListObjects(ctx, sp.bucket, minio.ListObjectsOptions{}) {
+		if object.Err != nil {
+			return nil, status.Error(codes.Internal, object.Err.Error())
+		}
+		if isQuarantined(object.Key) {
+			continue
+		}
+		keys = append(keys, &types.CacheKey{
+			Hash: object.Key,
+		})
+	}
+	return keys, nil
+}
+
 func (sp *S3StorageProvider) UsageInfo() *metrics.CacheUsage {
 	info := &metrics.CacheUsage{
 		ObjectCount: 0,
@@ -325,6 +546,9 @@ func (sp *S3StorageProvider) UsageInfo() *metrics.CacheUsage {
 		return info
 	}
 	for object := range sp.client.ListObjects(sp.ctx, sp.bucket, minio.ListObjectsOptions{}) {
+		if isQuarantined(object.Key) {
+			continue
+		}
 		info.ObjectCount++
 		info.TotalSize += object.Size
 	}
@@ -341,8 +565,9 @@ func (sp *S3StorageProvider) CacheHits() *metrics.CacheHits {
 		percent = float64(hitTotal) / float64(hitTotal+missTotal)
 	}
 	return &metrics.CacheHits{
-		CacheHitsTotal:   hitTotal,
-		CacheMissesTotal: missTotal,
-		CacheHitPercent:  percent,
+		CacheHitsTotal:      hitTotal,
+		CacheMissesTotal:    missTotal,
+		CacheHitPercent:     percent,
+		CorruptObjectsTotal: sp.corruptTotal.Load(),
 	}
 }
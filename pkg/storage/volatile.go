@@ -20,6 +20,8 @@ package storage
 import (
 	"context"
 	"fmt"
+	"math"
+	"sync"
 	"time"
 
 	"github.com/karlseguin/ccache/v2"
@@ -27,13 +29,48 @@ import (
 	"github.com/kubecc-io/kubecc/pkg/meta"
 	"github.com/kubecc-io/kubecc/pkg/metrics"
 	"github.com/kubecc-io/kubecc/pkg/types"
+	"github.com/kubecc-io/kubecc/pkg/util"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+// volatileExpirationSweepInterval is how often expired objects are deleted.
+// The cache does not delete expired objects by itself, they are only
+// replaced when it needs space, or never if an eviction policy is used.
+const volatileExpirationSweepInterval = 1 * time.Minute
+
+// volatileObject is the value stored in the cache for each object. Keys are
+// not accessible from cache items, so they are stored alongside the object.
+type volatileObject struct {
+	hash   string
+	object *types.CacheObject
+	// Guards the object's metadata, which is updated on each hit
+	lock sync.Mutex
+}
+
+// metadata returns a copy of the object's metadata.
+func (o *volatileObject) metadata() *types.CacheObjectMeta {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	return proto.Clone(o.object.Metadata).(*types.CacheObjectMeta)
+}
+
+// expired returns true if the item has expired and the object has an
+// expiration date. Objects without one are stored with a TTL of 0, which
+// the cache considers to be expired immediately.
+func (o *volatileObject) expired(item *ccache.Item) bool {
+	if !item.Expired() {
+		return false
+	}
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	return o.object.GetMetadata().GetExpirationDate() > 0
+}
+
 type VolatileStorageProvider struct {
 	ctx              context.Context
 	lg               *zap.SugaredLogger
@@ -43,18 +80,30 @@ type VolatileStorageProvider struct {
 	totalSize        *atomic.Int64
 	cacheHitsTotal   *atomic.Int64
 	cacheMissesTotal *atomic.Int64
+	corruptTotal     *atomic.Int64
+	evictionTracker  *EvictionTracker
+	evictionPolicy   EvictionPolicy
+	retention        RetentionPolicy
+	lastSweep        *atomic.Int64
 }
 
 func NewVolatileStorageProvider(
 	ctx context.Context,
 	cfg config.VolatileStorageSpec,
+	opts ...StorageProviderOption,
 ) StorageProvider {
+	options := StorageProviderOptions{}
+	options.Apply(opts...)
+
 	sp := &VolatileStorageProvider{
 		ctx:              ctx,
 		lg:               meta.Log(ctx),
 		cfg:              cfg,
 		cacheHitsTotal:   atomic.NewInt64(0),
 		cacheMissesTotal: atomic.NewInt64(0),
+		corruptTotal:     atomic.NewInt64(0),
+		retention:        options.retention,
+		lastSweep:        atomic.NewInt64(0),
 	}
 	return sp
 }
@@ -68,24 +117,64 @@ func (sp *VolatileStorageProvider) Configure() error {
 	if err != nil {
 		return fmt.Errorf("%w: %s", ConfigurationError, err.Error())
 	}
+	policy, err := NewEvictionPolicy(sp.cfg.Limits.EvictionPolicy)
+	if err != nil {
+		return err
+	}
 	storageLimit := q.Value()
 	totalSize := atomic.NewInt64(0)
-	conf := ccache.Configure().
-		MaxSize(storageLimit).
-		ItemsToPrune(20).
-		OnDelete(func(item *ccache.Item) {
-			totalSize.Sub(item.Value().(*types.CacheObject).
-				GetMetadata().
-				GetManagedFields().
-				GetSize())
-		})
+	evictionTracker := NewEvictionTracker(policy)
+	conf := ccache.Configure()
+	if policy == nil {
+		conf = conf.
+			MaxSize(storageLimit).
+			ItemsToPrune(20).
+			OnDelete(func(item *ccache.Item) {
+				value := item.Value().(*volatileObject)
+				totalSize.Sub(value.object.
+					GetMetadata().
+					GetManagedFields().
+					GetSize())
+				// Deletes are processed asynchronously, so the object may have
+				// been stored again in the meantime.
+				if sp.cache.Get(value.hash) == nil {
+					evictionTracker.Remove(value.hash)
+				}
+			})
+	} else {
+		// Objects are evicted according to the policy instead of being pruned
+		// by the cache. The cache does not always call OnDelete for objects
+		// that are deleted explicitly, so deletes are accounted for in
+		// deleteObject instead.
+		conf = conf.MaxSize(math.MaxInt64)
+	}
 	sp.cache = ccache.New(conf)
+	sp.evictionPolicy = policy
+	sp.evictionTracker = evictionTracker
 	sp.storageLimit = storageLimit
 	sp.totalSize = totalSize
+	util.RunPeriodic(sp.ctx, volatileExpirationSweepInterval, 0.1, false,
+		sp.deleteExpiredObjects)
 	sp.lg.Info("In-memory storage provider configured")
 	return nil
 }
 
+// deleteExpiredObjects deletes all objects which have expired.
+func (sp *VolatileStorageProvider) deleteExpiredObjects() {
+	sp.lastSweep.Store(time.Now().UnixNano())
+	expired := map[string]*volatileObject{}
+	sp.cache.ForEachFunc(func(key string, item *ccache.Item) bool {
+		value := item.Value().(*volatileObject)
+		if value.expired(item) {
+			expired[key] = value
+		}
+		return true
+	})
+	for hash, value := range expired {
+		sp.deleteObject(hash, value.object)
+	}
+}
+
 func (sp *VolatileStorageProvider) Put(
 	ctx context.Context,
 	key *types.CacheKey,
@@ -99,16 +188,53 @@ func (sp *VolatileStorageProvider) Put(
 	if object.Metadata == nil {
 		object.Metadata = &types.CacheObjectMeta{}
 	}
+	sp.retention.Apply(object.Metadata)
 	object.Metadata.ManagedFields = &types.CacheObjectManaged{
 		Location:  sp.Location(),
 		Size:      sz,
 		Timestamp: time.Now().Unix(),
+		Digest:    util.Digest(object.Data),
 	}
-	sp.cache.Set(key.GetHash(), object,
+	sp.evictionTracker.Add(key.GetHash(), object.Metadata.ManagedFields)
+	sp.cache.Set(key.GetHash(), &volatileObject{
+		hash:   key.GetHash(),
+		object: object,
+	},
 		time.Until(time.Unix(0, object.Metadata.ExpirationDate)))
+	sp.evict()
 	return nil
 }
 
+// evict deletes objects in the order determined by the eviction policy until
+// the total size is within the storage limit. Expired objects are deleted
+// first, at most once per second.
+func (sp *VolatileStorageProvider) evict() {
+	if sp.evictionPolicy == nil || sp.totalSize.Load() <= sp.storageLimit {
+		return
+	}
+	if time.Since(time.Unix(0, sp.lastSweep.Load())) > time.Second {
+		sp.deleteExpiredObjects()
+	}
+	for sp.totalSize.Load() > sp.storageLimit {
+		hash, size, ok := sp.evictionTracker.Evict()
+		if !ok {
+			return
+		}
+		if sp.cache.Delete(hash) {
+			sp.totalSize.Sub(size)
+		}
+	}
+}
+
+// deleteObject deletes an object from the cache. Without an eviction policy,
+// the object is accounted for in the cache's OnDelete callback.
+func (sp *VolatileStorageProvider) deleteObject(hash string, object *types.CacheObject) {
+	if sp.cache.Delete(hash) && sp.evictionPolicy != nil {
+		sp.totalSize.Sub(object.GetMetadata().GetManagedFields().GetSize())
+		sp.evictionTracker.Remove(hash)
+	}
+}
+
 func (sp *VolatileStorageProvider) Get(
 	ctx context.Context,
 	key *types.CacheKey,
@@ -118,14 +244,57 @@ func (sp *VolatileStorageProvider) Get(
 		sp.cacheMissesTotal.Inc()
 		return nil, status.Error(codes.NotFound, "Object not found")
 	}
-	obj := item.Value().(*types.CacheObject)
-	if item.Expired() && obj.GetMetadata().GetExpirationDate() > 0 {
-		sp.cache.Delete(key.GetHash())
+	value := item.Value().(*volatileObject)
+	obj := value.object
+	if value.expired(item) {
+		sp.deleteObject(key.GetHash(), obj)
 		return nil, status.Error(codes.NotFound, "Object expired")
 	}
-	item.Extend(time.Until(time.Unix(0, obj.Metadata.ExpirationDate)))
+	if err := VerifyObject(obj); err != nil {
+		// There is nothing to keep for in-memory objects, so corrupt objects
+		// are deleted instead of being quarantined.
+		sp.lg.With(
+			zap.Error(err),
+			"hash", key.GetHash(),
+		).Error("Deleting corrupt object")
+		sp.deleteObject(key.GetHash(), obj)
+		sp.corruptTotal.Inc()
+		sp.cacheMissesTotal.Inc()
+		return nil, corruptObjectError(err)
+	}
 	sp.cacheHitsTotal.Inc()
-	return obj, nil
+
+	// The hit is recorded in the stored metadata, and the caller is given a
+	// copy of it.
+	value.lock.Lock()
+	if sp.retention.Extend(obj.Metadata) {
+		item.Extend(time.Until(time.Unix(0, obj.Metadata.ExpirationDate)))
+	}
+	sp.evictionTracker.Hit(key.GetHash(), obj.Metadata.ManagedFields)
+	metadata := proto.Clone(obj.Metadata).(*types.CacheObjectMeta)
+	value.lock.Unlock()
+	return &types.CacheObject{
+		Data:     obj.Data,
+		Metadata: metadata,
+	}, nil
+}
+
+func (sp *VolatileStorageProvider) Peek(
+	ctx context.Context,
+	key *types.CacheKey,
+) (*types.CacheObject, error) {
+	item := sp.cache.Get(key.GetHash())
+	if item == nil || item.Value().(*volatileObject).expired(item) {
+		return nil, status.Error(codes.NotFound, "Object not found")
+	}
+	value := item.Value().(*volatileObject)
+	if err := VerifyObject(value.object); err != nil {
+		return nil, corruptObjectError(err)
+	}
+	return &types.CacheObject{
+		Data:     value.object.Data,
+		Metadata: value.metadata(),
+	}, nil
 }
 
 func (sp *VolatileStorageProvider) Query(
@@ -135,17 +304,44 @@ func (sp *VolatileStorageProvider) Query(
 	results := make([]*types.CacheObjectMeta, len(keys))
 	for i, key := range keys {
 		if item := sp.cache.Get(key.GetHash()); item != nil {
-			obj := item.Value().(*types.CacheObject)
-			if item.Expired() && obj.GetMetadata().GetExpirationDate() > 0 {
-				sp.cache.Delete(key.GetHash())
+			value := item.Value().(*volatileObject)
+			if value.expired(item) {
+				sp.deleteObject(key.GetHash(), value.object)
 			} else {
-				results[i] = item.Value().(*types.CacheObject).GetMetadata()
+				results[i] = value.metadata()
 			}
 		}
 	}
 	return results, nil
 }
 
+func (sp *VolatileStorageProvider) Delete(
+	ctx context.Context,
+	key *types.CacheKey,
+) error {
+	item := sp.cache.Get(key.GetHash())
+	if item == nil {
+		return status.Error(codes.NotFound, "Object not found")
+	}
+	sp.deleteObject(key.GetHash(), item.Value().(*volatileObject).object)
+	return nil
+}
+
+func (sp *VolatileStorageProvider) List(
+	ctx context.Context,
+) ([]*types.CacheKey, error) {
+	keys := []*types.CacheKey{}
+	sp.cache.ForEachFunc(func(key string, item *ccache.Item) bool {
+		if !item.Value().(*volatileObject).expired(item) {
+			keys = append(keys, &types.CacheKey{
+				Hash: key,
+			})
+		}
+		return ctx.Err() == nil
+	})
+	return keys, nil
+}
+
 func (sp *VolatileStorageProvider) UsageInfo() *metrics.CacheUsage {
 	totalSize := sp.totalSize.Load()
 	var usagePercent float64
@@ -171,8 +367,9 @@ func (sp *VolatileStorageProvider) CacheHits() *metrics.CacheHits {
 		percent = float64(hitTotal) / float64(hitTotal+missTotal)
 	}
 	return &metrics.CacheHits{
-		CacheHitsTotal:   hitTotal,
-		CacheMissesTotal: missTotal,
-		CacheHitPercent:  percent,
+		CacheHitsTotal:      hitTotal,
+		CacheMissesTotal:    missTotal,
+		CacheHitPercent:     percent,
+		CorruptObjectsTotal: sp.corruptTotal.Load(),
 	}
 }
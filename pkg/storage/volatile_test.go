@@ -137,6 +137,11 @@ var _ = Describe("Volatile Storage Provider", func() {
 				Expect(result.ManagedFields.Size).To(Equal(int64(5)))
 			}
 		})
+		It("Should list stored objects", func() {
+			keys, err := vsp.List(testCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(100))
+		})
 		It("Should store managed fields", func() {
 			obj, err := vsp.Get(testCtx, &types.CacheKey{
 				Hash: "1",
@@ -25,6 +25,7 @@ import (
 
 	"github.com/kubecc-io/kubecc/internal/logkc"
 	"github.com/kubecc-io/kubecc/internal/zapkc"
+	"github.com/kubecc-io/kubecc/pkg/cachesrv"
 	"github.com/kubecc-io/kubecc/pkg/clients"
 	"github.com/kubecc-io/kubecc/pkg/config"
 	"github.com/kubecc-io/kubecc/pkg/identity"
@@ -59,7 +60,8 @@ var (
 )
 
 func (e *BufconnEnvironment) Serve(ctx context.Context, server interface{}, name string) {
-	srv := servers.NewServer(ctx)
+	srv := servers.NewServer(ctx,
+		servers.WithExternalServices(cachesrv.REAPIServices...))
 	component := meta.Component(ctx)
 	e.listenersMu.Lock()
 	if _, ok := e.listeners[component][name]; !ok {
@@ -75,6 +77,9 @@ func (e *BufconnEnvironment) Serve(ctx context.Context, server interface{}, name
 		types.RegisterMonitorServer(srv, s)
 	case types.CacheServer:
 		types.RegisterCacheServer(srv, s)
+		if c, ok := s.(*cachesrv.CacheServer); ok && c.REAPI() != nil {
+			cachesrv.RegisterREAPIServer(srv, c.REAPI())
+		}
 	}
 	go func() {
 		go func() {
@@ -25,6 +25,7 @@ import (
 
 	"github.com/kubecc-io/kubecc/internal/logkc"
 	"github.com/kubecc-io/kubecc/internal/zapkc"
+	"github.com/kubecc-io/kubecc/pkg/cachesrv"
 	"github.com/kubecc-io/kubecc/pkg/clients"
 	"github.com/kubecc-io/kubecc/pkg/config"
 	"github.com/kubecc-io/kubecc/pkg/identity"
@@ -220,7 +221,8 @@ func (e *LocalhostEnvironment) Log() *zap.SugaredLogger {
 }
 
 func (e *LocalhostEnvironment) Serve(ctx context.Context, server interface{}, name string) {
-	srv := servers.NewServer(ctx)
+	srv := servers.NewServer(ctx,
+		servers.WithExternalServices(cachesrv.REAPIServices...))
 	component := meta.Component(ctx)
 	e.listenersMu.Lock()
 	if existingListener, ok := e.listeners[component][name]; !ok {
@@ -253,6 +255,9 @@ func (e *LocalhostEnvironment) Serve(ctx context.Context, server interface{}, na
 		types.RegisterMonitorServer(srv, s)
 	case types.CacheServer:
 		types.RegisterCacheServer(srv, s)
+		if c, ok := s.(*cachesrv.CacheServer); ok && c.REAPI() != nil {
+			cachesrv.RegisterREAPIServer(srv, c.REAPI())
+		}
 	}
 	go func() {
 		go func() {
@@ -344,6 +344,11 @@ func SpawnCache(e Environment, opts ...SpawnOption) (context.Context, context.Ca
 	so, cfg := makeOptions(e, opts...)
 	parentCtx, cancel := ctxutil.WithCancel(e.Context())
 
+	// Cache servers are identified in a cache cluster by their hostname, so
+	// use the component name in its place.
+	sysInfo := host.GetSystemInfo()
+	sysInfo.Hostname = so.name
+
 	ctx := meta.NewContextWithParent(parentCtx,
 		meta.WithProvider(identity.Component, meta.WithValue(types.Cache)),
 		meta.WithProvider(identity.UUID, meta.WithValue(so.uuid)),
@@ -354,27 +359,49 @@ func SpawnCache(e Environment, opts ...SpawnOption) (context.Context, context.Ca
 			),
 		)),
 		meta.WithProvider(tracing.Tracer),
+		meta.WithProvider(host.SystemInfo, meta.WithValue(sysInfo)),
 	)
 
 	options := []cachesrv.CacheServerOption{
 		cachesrv.WithMonitorClient(NewMonitorClient(e, ctx)),
 	}
 
+	retention, err := storage.NewRetentionPolicy(cfg.Cache.Retention)
+	if err != nil {
+		panic(err)
+	}
+	if cfg.Cache.Retention.DefaultTTL == "" {
+		retention.DefaultTTL = storage.DefaultTTL
+	}
+
 	providers := []storage.StorageProvider{}
 	if cfg.Cache.VolatileStorage != nil {
 		providers = append(providers,
-			storage.NewVolatileStorageProvider(ctx, *cfg.Cache.VolatileStorage))
+			storage.NewVolatileStorageProvider(ctx, *cfg.Cache.VolatileStorage,
+				storage.WithRetentionPolicy(retention)))
 	}
 	if cfg.Cache.LocalStorage != nil {
 		providers = append(providers,
-			storage.NewLocalStorageProvider(ctx, *cfg.Cache.LocalStorage))
+			storage.NewLocalStorageProvider(ctx, *cfg.Cache.LocalStorage,
+				storage.WithRetentionPolicy(retention)))
+	}
+	if cfg.Cache.RedisStorage != nil {
+		providers = append(providers,
+			storage.NewRedisStorageProvider(ctx, *cfg.Cache.RedisStorage,
+				storage.WithRetentionPolicy(retention)))
 	}
 	if cfg.Cache.RemoteStorage != nil {
 		providers = append(providers,
-			storage.NewS3StorageProvider(ctx, *cfg.Cache.RemoteStorage))
+			storage.NewS3StorageProvider(ctx, *cfg.Cache.RemoteStorage,
+				storage.WithRetentionPolicy(retention)))
+	}
+	chainOptions, err := storage.ChainOptionsFromSpec(cfg.Cache.StorageChain)
+	if err != nil {
+		panic(err)
 	}
+	chainOptions = append(chainOptions, storage.WithChainRetention(retention))
 	options = append(options, cachesrv.WithStorageProvider(
-		storage.NewChainStorageProvider(ctx, providers...),
+		storage.NewChainStorageProvider(ctx, providers, chainOptions...),
 	))
 	options = append(options, so.cacheOptions...)
 
@@ -221,6 +221,26 @@ func (mr *MockSchedulerClientMockRecorder) Compile(ctx, in interface{}, opts ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compile", reflect.TypeOf((*MockSchedulerClient)(nil).Compile), varargs...)
 }
 
+// DrainAgent mocks base method.
+func (m *MockSchedulerClient) DrainAgent(ctx context.Context, in *types.DrainAgentRequest, opts ...grpc.CallOption) (types.Scheduler_DrainAgentClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DrainAgent", varargs...)
+	ret0, _ := ret[0].(types.Scheduler_DrainAgentClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DrainAgent indicates an expected call of DrainAgent.
+func (mr *MockSchedulerClientMockRecorder) DrainAgent(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DrainAgent", reflect.TypeOf((*MockSchedulerClient)(nil).DrainAgent), varargs...)
+}
+
 // GetRoutes mocks base method.
 func (m *MockSchedulerClient) GetRoutes(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*types.RouteList, error) {
 	m.ctrl.T.Helper()
@@ -241,6 +261,26 @@ func (mr *MockSchedulerClientMockRecorder) GetRoutes(ctx, in interface{}, opts .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoutes", reflect.TypeOf((*MockSchedulerClient)(nil).GetRoutes), varargs...)
 }
 
+// ListTasks mocks base method.
+func (m *MockSchedulerClient) ListTasks(ctx context.Context, in *types.ListTasksRequest, opts ...grpc.CallOption) (types.Scheduler_ListTasksClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTasks", varargs...)
+	ret0, _ := ret[0].(types.Scheduler_ListTasksClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTasks indicates an expected call of ListTasks.
+func (mr *MockSchedulerClientMockRecorder) ListTasks(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockSchedulerClient)(nil).ListTasks), varargs...)
+}
+
 // StreamIncomingTasks mocks base method.
 func (m *MockSchedulerClient) StreamIncomingTasks(ctx context.Context, opts ...grpc.CallOption) (types.Scheduler_StreamIncomingTasksClient, error) {
 	m.ctrl.T.Helper()
@@ -555,6 +595,252 @@ func (mr *MockScheduler_StreamOutgoingTasksClientMockRecorder) Trailer() *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockScheduler_StreamOutgoingTasksClient)(nil).Trailer))
 }
 
+// MockScheduler_DrainAgentClient is a mock of Scheduler_DrainAgentClient interface.
+type MockScheduler_DrainAgentClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduler_DrainAgentClientMockRecorder
+}
+
+// MockScheduler_DrainAgentClientMockRecorder is the mock recorder for MockScheduler_DrainAgentClient.
+type MockScheduler_DrainAgentClientMockRecorder struct {
+	mock *MockScheduler_DrainAgentClient
+}
+
+// NewMockScheduler_DrainAgentClient creates a new mock instance.
+func NewMockScheduler_DrainAgentClient(ctrl *gomock.Controller) *MockScheduler_DrainAgentClient {
+	mock := &MockScheduler_DrainAgentClient{ctrl: ctrl}
+	mock.recorder = &MockScheduler_DrainAgentClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduler_DrainAgentClient) EXPECT() *MockScheduler_DrainAgentClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockScheduler_DrainAgentClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockScheduler_DrainAgentClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockScheduler_DrainAgentClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockScheduler_DrainAgentClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockScheduler_DrainAgentClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockScheduler_DrainAgentClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockScheduler_DrainAgentClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockScheduler_DrainAgentClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockScheduler_DrainAgentClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockScheduler_DrainAgentClient) Recv() (*types.DrainAgentProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*types.DrainAgentProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockScheduler_DrainAgentClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockScheduler_DrainAgentClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockScheduler_DrainAgentClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockScheduler_DrainAgentClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockScheduler_DrainAgentClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockScheduler_DrainAgentClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockScheduler_DrainAgentClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockScheduler_DrainAgentClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockScheduler_DrainAgentClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockScheduler_DrainAgentClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockScheduler_DrainAgentClient)(nil).Trailer))
+}
+
+// MockScheduler_ListTasksClient is a mock of Scheduler_ListTasksClient interface.
+type MockScheduler_ListTasksClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduler_ListTasksClientMockRecorder
+}
+
+// MockScheduler_ListTasksClientMockRecorder is the mock recorder for MockScheduler_ListTasksClient.
+type MockScheduler_ListTasksClientMockRecorder struct {
+	mock *MockScheduler_ListTasksClient
+}
+
+// NewMockScheduler_ListTasksClient creates a new mock instance.
+func NewMockScheduler_ListTasksClient(ctrl *gomock.Controller) *MockScheduler_ListTasksClient {
+	mock := &MockScheduler_ListTasksClient{ctrl: ctrl}
+	mock.recorder = &MockScheduler_ListTasksClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduler_ListTasksClient) EXPECT() *MockScheduler_ListTasksClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockScheduler_ListTasksClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockScheduler_ListTasksClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockScheduler_ListTasksClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockScheduler_ListTasksClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockScheduler_ListTasksClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockScheduler_ListTasksClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockScheduler_ListTasksClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockScheduler_ListTasksClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockScheduler_ListTasksClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockScheduler_ListTasksClient) Recv() (*types.TaskList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*types.TaskList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockScheduler_ListTasksClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockScheduler_ListTasksClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockScheduler_ListTasksClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockScheduler_ListTasksClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockScheduler_ListTasksClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockScheduler_ListTasksClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockScheduler_ListTasksClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockScheduler_ListTasksClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockScheduler_ListTasksClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockScheduler_ListTasksClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockScheduler_ListTasksClient)(nil).Trailer))
+}
+
 // MockSchedulerServer is a mock of SchedulerServer interface.
 type MockSchedulerServer struct {
 	ctrl     *gomock.Controller
@@ -593,6 +879,20 @@ func (mr *MockSchedulerServerMockRecorder) Compile(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compile", reflect.TypeOf((*MockSchedulerServer)(nil).Compile), arg0, arg1)
 }
 
+// DrainAgent mocks base method.
+func (m *MockSchedulerServer) DrainAgent(arg0 *types.DrainAgentRequest, arg1 types.Scheduler_DrainAgentServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DrainAgent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DrainAgent indicates an expected call of DrainAgent.
+func (mr *MockSchedulerServerMockRecorder) DrainAgent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DrainAgent", reflect.TypeOf((*MockSchedulerServer)(nil).DrainAgent), arg0, arg1)
+}
+
 // GetRoutes mocks base method.
 func (m *MockSchedulerServer) GetRoutes(arg0 context.Context, arg1 *types.Empty) (*types.RouteList, error) {
 	m.ctrl.T.Helper()
@@ -608,6 +908,20 @@ func (mr *MockSchedulerServerMockRecorder) GetRoutes(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoutes", reflect.TypeOf((*MockSchedulerServer)(nil).GetRoutes), arg0, arg1)
 }
 
+// ListTasks mocks base method.
+func (m *MockSchedulerServer) ListTasks(arg0 *types.ListTasksRequest, arg1 types.Scheduler_ListTasksServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTasks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListTasks indicates an expected call of ListTasks.
+func (mr *MockSchedulerServerMockRecorder) ListTasks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockSchedulerServer)(nil).ListTasks), arg0, arg1)
+}
+
 // StreamIncomingTasks mocks base method.
 func (m *MockSchedulerServer) StreamIncomingTasks(arg0 types.Scheduler_StreamIncomingTasksServer) error {
 	m.ctrl.T.Helper()
@@ -951,53 +1265,291 @@ func (mr *MockScheduler_StreamOutgoingTasksServerMockRecorder) SetTrailer(arg0 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockScheduler_StreamOutgoingTasksServer)(nil).SetTrailer), arg0)
 }
 
-// MockMonitorClient is a mock of MonitorClient interface.
-type MockMonitorClient struct {
+// MockScheduler_DrainAgentServer is a mock of Scheduler_DrainAgentServer interface.
+type MockScheduler_DrainAgentServer struct {
 	ctrl     *gomock.Controller
-	recorder *MockMonitorClientMockRecorder
+	recorder *MockScheduler_DrainAgentServerMockRecorder
 }
 
-// MockMonitorClientMockRecorder is the mock recorder for MockMonitorClient.
-type MockMonitorClientMockRecorder struct {
-	mock *MockMonitorClient
+// MockScheduler_DrainAgentServerMockRecorder is the mock recorder for MockScheduler_DrainAgentServer.
+type MockScheduler_DrainAgentServerMockRecorder struct {
+	mock *MockScheduler_DrainAgentServer
 }
 
-// NewMockMonitorClient creates a new mock instance.
-func NewMockMonitorClient(ctrl *gomock.Controller) *MockMonitorClient {
-	mock := &MockMonitorClient{ctrl: ctrl}
-	mock.recorder = &MockMonitorClientMockRecorder{mock}
+// NewMockScheduler_DrainAgentServer creates a new mock instance.
+func NewMockScheduler_DrainAgentServer(ctrl *gomock.Controller) *MockScheduler_DrainAgentServer {
+	mock := &MockScheduler_DrainAgentServer{ctrl: ctrl}
+	mock.recorder = &MockScheduler_DrainAgentServerMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockMonitorClient) EXPECT() *MockMonitorClientMockRecorder {
+func (m *MockScheduler_DrainAgentServer) EXPECT() *MockScheduler_DrainAgentServerMockRecorder {
 	return m.recorder
 }
 
-// GetBuckets mocks base method.
-func (m *MockMonitorClient) GetBuckets(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*types.BucketList, error) {
+// Context mocks base method.
+func (m *MockScheduler_DrainAgentServer) Context() context.Context {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, in}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "GetBuckets", varargs...)
-	ret0, _ := ret[0].(*types.BucketList)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
 }
 
-// GetBuckets indicates an expected call of GetBuckets.
-func (mr *MockMonitorClientMockRecorder) GetBuckets(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// Context indicates an expected call of Context.
+func (mr *MockScheduler_DrainAgentServerMockRecorder) Context() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBuckets", reflect.TypeOf((*MockMonitorClient)(nil).GetBuckets), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockScheduler_DrainAgentServer)(nil).Context))
 }
 
-// GetKeys mocks base method.
-func (m *MockMonitorClient) GetKeys(ctx context.Context, in *types.Bucket, opts ...grpc.CallOption) (*types.KeyList, error) {
-	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, in}
+// RecvMsg mocks base method.
+func (m_2 *MockScheduler_DrainAgentServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockScheduler_DrainAgentServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockScheduler_DrainAgentServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockScheduler_DrainAgentServer) Send(arg0 *types.DrainAgentProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockScheduler_DrainAgentServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockScheduler_DrainAgentServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockScheduler_DrainAgentServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockScheduler_DrainAgentServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockScheduler_DrainAgentServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockScheduler_DrainAgentServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockScheduler_DrainAgentServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockScheduler_DrainAgentServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockScheduler_DrainAgentServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockScheduler_DrainAgentServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockScheduler_DrainAgentServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockScheduler_DrainAgentServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockScheduler_DrainAgentServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockScheduler_DrainAgentServer)(nil).SetTrailer), arg0)
+}
+
+// MockScheduler_ListTasksServer is a mock of Scheduler_ListTasksServer interface.
+type MockScheduler_ListTasksServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduler_ListTasksServerMockRecorder
+}
+
+// MockScheduler_ListTasksServerMockRecorder is the mock recorder for MockScheduler_ListTasksServer.
+type MockScheduler_ListTasksServerMockRecorder struct {
+	mock *MockScheduler_ListTasksServer
+}
+
+// NewMockScheduler_ListTasksServer creates a new mock instance.
+func NewMockScheduler_ListTasksServer(ctrl *gomock.Controller) *MockScheduler_ListTasksServer {
+	mock := &MockScheduler_ListTasksServer{ctrl: ctrl}
+	mock.recorder = &MockScheduler_ListTasksServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduler_ListTasksServer) EXPECT() *MockScheduler_ListTasksServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockScheduler_ListTasksServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockScheduler_ListTasksServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockScheduler_ListTasksServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockScheduler_ListTasksServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockScheduler_ListTasksServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockScheduler_ListTasksServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockScheduler_ListTasksServer) Send(arg0 *types.TaskList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockScheduler_ListTasksServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockScheduler_ListTasksServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockScheduler_ListTasksServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockScheduler_ListTasksServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockScheduler_ListTasksServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockScheduler_ListTasksServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockScheduler_ListTasksServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockScheduler_ListTasksServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockScheduler_ListTasksServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockScheduler_ListTasksServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockScheduler_ListTasksServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockScheduler_ListTasksServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockScheduler_ListTasksServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockScheduler_ListTasksServer)(nil).SetTrailer), arg0)
+}
+
+// MockMonitorClient is a mock of MonitorClient interface.
+type MockMonitorClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMonitorClientMockRecorder
+}
+
+// MockMonitorClientMockRecorder is the mock recorder for MockMonitorClient.
+type MockMonitorClientMockRecorder struct {
+	mock *MockMonitorClient
+}
+
+// NewMockMonitorClient creates a new mock instance.
+func NewMockMonitorClient(ctrl *gomock.Controller) *MockMonitorClient {
+	mock := &MockMonitorClient{ctrl: ctrl}
+	mock.recorder = &MockMonitorClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMonitorClient) EXPECT() *MockMonitorClientMockRecorder {
+	return m.recorder
+}
+
+// GetBuckets mocks base method.
+func (m *MockMonitorClient) GetBuckets(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*types.BucketList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetBuckets", varargs...)
+	ret0, _ := ret[0].(*types.BucketList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBuckets indicates an expected call of GetBuckets.
+func (mr *MockMonitorClientMockRecorder) GetBuckets(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBuckets", reflect.TypeOf((*MockMonitorClient)(nil).GetBuckets), varargs...)
+}
+
+// GetKeys mocks base method.
+func (m *MockMonitorClient) GetKeys(ctx context.Context, in *types.Bucket, opts ...grpc.CallOption) (*types.KeyList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
@@ -1788,100 +2340,220 @@ func (m *MockCacheClient) EXPECT() *MockCacheClientMockRecorder {
 	return m.recorder
 }
 
-// Pull mocks base method.
-func (m *MockCacheClient) Pull(ctx context.Context, in *types.PullRequest, opts ...grpc.CallOption) (*types.CacheObject, error) {
+// Export mocks base method.
+func (m *MockCacheClient) Export(ctx context.Context, in *types.ExportRequest, opts ...grpc.CallOption) (types.Cache_ExportClient, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "Pull", varargs...)
-	ret0, _ := ret[0].(*types.CacheObject)
+	ret := m.ctrl.Call(m, "Export", varargs...)
+	ret0, _ := ret[0].(types.Cache_ExportClient)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Pull indicates an expected call of Pull.
-func (mr *MockCacheClientMockRecorder) Pull(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// Export indicates an expected call of Export.
+func (mr *MockCacheClientMockRecorder) Export(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockCacheClient)(nil).Pull), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockCacheClient)(nil).Export), varargs...)
 }
 
-// Push mocks base method.
-func (m *MockCacheClient) Push(ctx context.Context, in *types.PushRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+// Import mocks base method.
+func (m *MockCacheClient) Import(ctx context.Context, opts ...grpc.CallOption) (types.Cache_ImportClient, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, in}
+	varargs := []interface{}{ctx}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "Push", varargs...)
-	ret0, _ := ret[0].(*types.Empty)
+	ret := m.ctrl.Call(m, "Import", varargs...)
+	ret0, _ := ret[0].(types.Cache_ImportClient)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Push indicates an expected call of Push.
-func (mr *MockCacheClientMockRecorder) Push(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// Import indicates an expected call of Import.
+func (mr *MockCacheClientMockRecorder) Import(ctx interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockCacheClient)(nil).Push), varargs...)
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockCacheClient)(nil).Import), varargs...)
 }
 
-// Query mocks base method.
-func (m *MockCacheClient) Query(ctx context.Context, in *types.QueryRequest, opts ...grpc.CallOption) (*types.QueryResponse, error) {
+// Inspect mocks base method.
+func (m *MockCacheClient) Inspect(ctx context.Context, in *types.CacheKey, opts ...grpc.CallOption) (*types.InspectResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "Query", varargs...)
-	ret0, _ := ret[0].(*types.QueryResponse)
+	ret := m.ctrl.Call(m, "Inspect", varargs...)
+	ret0, _ := ret[0].(*types.InspectResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Query indicates an expected call of Query.
-func (mr *MockCacheClientMockRecorder) Query(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// Inspect indicates an expected call of Inspect.
+func (mr *MockCacheClientMockRecorder) Inspect(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockCacheClient)(nil).Query), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inspect", reflect.TypeOf((*MockCacheClient)(nil).Inspect), varargs...)
 }
 
-// Sync mocks base method.
-func (m *MockCacheClient) Sync(ctx context.Context, in *types.SyncRequest, opts ...grpc.CallOption) (types.Cache_SyncClient, error) {
+// List mocks base method.
+func (m *MockCacheClient) List(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (types.Cache_ListClient, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "Sync", varargs...)
-	ret0, _ := ret[0].(types.Cache_SyncClient)
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].(types.Cache_ListClient)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Sync indicates an expected call of Sync.
-func (mr *MockCacheClientMockRecorder) Sync(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// List indicates an expected call of List.
+func (mr *MockCacheClientMockRecorder) List(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockCacheClient)(nil).Sync), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCacheClient)(nil).List), varargs...)
 }
 
-// MockCache_SyncClient is a mock of Cache_SyncClient interface.
-type MockCache_SyncClient struct {
-	ctrl     *gomock.Controller
-	recorder *MockCache_SyncClientMockRecorder
+// Pull mocks base method.
+func (m *MockCacheClient) Pull(ctx context.Context, in *types.PullRequest, opts ...grpc.CallOption) (*types.CacheObject, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Pull", varargs...)
+	ret0, _ := ret[0].(*types.CacheObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockCache_SyncClientMockRecorder is the mock recorder for MockCache_SyncClient.
-type MockCache_SyncClientMockRecorder struct {
-	mock *MockCache_SyncClient
+// Pull indicates an expected call of Pull.
+func (mr *MockCacheClientMockRecorder) Pull(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockCacheClient)(nil).Pull), varargs...)
 }
 
-// NewMockCache_SyncClient creates a new mock instance.
-func NewMockCache_SyncClient(ctrl *gomock.Controller) *MockCache_SyncClient {
-	mock := &MockCache_SyncClient{ctrl: ctrl}
+// Purge mocks base method.
+func (m *MockCacheClient) Purge(ctx context.Context, in *types.PurgeRequest, opts ...grpc.CallOption) (*types.PurgeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Purge", varargs...)
+	ret0, _ := ret[0].(*types.PurgeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockCacheClientMockRecorder) Purge(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockCacheClient)(nil).Purge), varargs...)
+}
+
+// Push mocks base method.
+func (m *MockCacheClient) Push(ctx context.Context, in *types.PushRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Push", varargs...)
+	ret0, _ := ret[0].(*types.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockCacheClientMockRecorder) Push(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockCacheClient)(nil).Push), varargs...)
+}
+
+// Query mocks base method.
+func (m *MockCacheClient) Query(ctx context.Context, in *types.QueryRequest, opts ...grpc.CallOption) (*types.QueryResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Query", varargs...)
+	ret0, _ := ret[0].(*types.QueryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockCacheClientMockRecorder) Query(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockCacheClient)(nil).Query), varargs...)
+}
+
+// Stats mocks base method.
+func (m *MockCacheClient) Stats(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*types.CacheStats, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Stats", varargs...)
+	ret0, _ := ret[0].(*types.CacheStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockCacheClientMockRecorder) Stats(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockCacheClient)(nil).Stats), varargs...)
+}
+
+// Sync mocks base method.
+func (m *MockCacheClient) Sync(ctx context.Context, in *types.SyncRequest, opts ...grpc.CallOption) (types.Cache_SyncClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Sync", varargs...)
+	ret0, _ := ret[0].(types.Cache_SyncClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockCacheClientMockRecorder) Sync(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockCacheClient)(nil).Sync), varargs...)
+}
+
+// MockCache_SyncClient is a mock of Cache_SyncClient interface.
+type MockCache_SyncClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCache_SyncClientMockRecorder
+}
+
+// MockCache_SyncClientMockRecorder is the mock recorder for MockCache_SyncClient.
+type MockCache_SyncClientMockRecorder struct {
+	mock *MockCache_SyncClient
+}
+
+// NewMockCache_SyncClient creates a new mock instance.
+func NewMockCache_SyncClient(ctrl *gomock.Controller) *MockCache_SyncClient {
+	mock := &MockCache_SyncClient{ctrl: ctrl}
 	mock.recorder = &MockCache_SyncClientMockRecorder{mock}
 	return mock
 }
@@ -1935,10 +2607,10 @@ func (mr *MockCache_SyncClientMockRecorder) Header() *gomock.Call {
 }
 
 // Recv mocks base method.
-func (m *MockCache_SyncClient) Recv() (*types.CacheObject, error) {
+func (m *MockCache_SyncClient) Recv() (*types.SyncObject, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Recv")
-	ret0, _ := ret[0].(*types.CacheObject)
+	ret0, _ := ret[0].(*types.SyncObject)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1991,160 +2663,183 @@ func (mr *MockCache_SyncClientMockRecorder) Trailer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockCache_SyncClient)(nil).Trailer))
 }
 
-// MockCacheServer is a mock of CacheServer interface.
-type MockCacheServer struct {
+// MockCache_ExportClient is a mock of Cache_ExportClient interface.
+type MockCache_ExportClient struct {
 	ctrl     *gomock.Controller
-	recorder *MockCacheServerMockRecorder
+	recorder *MockCache_ExportClientMockRecorder
 }
 
-// MockCacheServerMockRecorder is the mock recorder for MockCacheServer.
-type MockCacheServerMockRecorder struct {
-	mock *MockCacheServer
+// MockCache_ExportClientMockRecorder is the mock recorder for MockCache_ExportClient.
+type MockCache_ExportClientMockRecorder struct {
+	mock *MockCache_ExportClient
 }
 
-// NewMockCacheServer creates a new mock instance.
-func NewMockCacheServer(ctrl *gomock.Controller) *MockCacheServer {
-	mock := &MockCacheServer{ctrl: ctrl}
-	mock.recorder = &MockCacheServerMockRecorder{mock}
+// NewMockCache_ExportClient creates a new mock instance.
+func NewMockCache_ExportClient(ctrl *gomock.Controller) *MockCache_ExportClient {
+	mock := &MockCache_ExportClient{ctrl: ctrl}
+	mock.recorder = &MockCache_ExportClientMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockCacheServer) EXPECT() *MockCacheServerMockRecorder {
+func (m *MockCache_ExportClient) EXPECT() *MockCache_ExportClientMockRecorder {
 	return m.recorder
 }
 
-// Pull mocks base method.
-func (m *MockCacheServer) Pull(arg0 context.Context, arg1 *types.PullRequest) (*types.CacheObject, error) {
+// CloseSend mocks base method.
+func (m *MockCache_ExportClient) CloseSend() error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Pull", arg0, arg1)
-	ret0, _ := ret[0].(*types.CacheObject)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// Pull indicates an expected call of Pull.
-func (mr *MockCacheServerMockRecorder) Pull(arg0, arg1 interface{}) *gomock.Call {
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockCache_ExportClientMockRecorder) CloseSend() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockCacheServer)(nil).Pull), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockCache_ExportClient)(nil).CloseSend))
 }
 
-// Push mocks base method.
-func (m *MockCacheServer) Push(arg0 context.Context, arg1 *types.PushRequest) (*types.Empty, error) {
+// Context mocks base method.
+func (m *MockCache_ExportClient) Context() context.Context {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Push", arg0, arg1)
-	ret0, _ := ret[0].(*types.Empty)
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockCache_ExportClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockCache_ExportClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockCache_ExportClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Push indicates an expected call of Push.
-func (mr *MockCacheServerMockRecorder) Push(arg0, arg1 interface{}) *gomock.Call {
+// Header indicates an expected call of Header.
+func (mr *MockCache_ExportClientMockRecorder) Header() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockCacheServer)(nil).Push), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockCache_ExportClient)(nil).Header))
 }
 
-// Query mocks base method.
-func (m *MockCacheServer) Query(arg0 context.Context, arg1 *types.QueryRequest) (*types.QueryResponse, error) {
+// Recv mocks base method.
+func (m *MockCache_ExportClient) Recv() (*types.ArchiveChunk, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Query", arg0, arg1)
-	ret0, _ := ret[0].(*types.QueryResponse)
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*types.ArchiveChunk)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Query indicates an expected call of Query.
-func (mr *MockCacheServerMockRecorder) Query(arg0, arg1 interface{}) *gomock.Call {
+// Recv indicates an expected call of Recv.
+func (mr *MockCache_ExportClientMockRecorder) Recv() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockCacheServer)(nil).Query), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockCache_ExportClient)(nil).Recv))
 }
 
-// Sync mocks base method.
-func (m *MockCacheServer) Sync(arg0 *types.SyncRequest, arg1 types.Cache_SyncServer) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Sync", arg0, arg1)
+// RecvMsg mocks base method.
+func (m_2 *MockCache_ExportClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Sync indicates an expected call of Sync.
-func (mr *MockCacheServerMockRecorder) Sync(arg0, arg1 interface{}) *gomock.Call {
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockCache_ExportClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockCacheServer)(nil).Sync), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockCache_ExportClient)(nil).RecvMsg), m)
 }
 
-// mustEmbedUnimplementedCacheServer mocks base method.
-func (m *MockCacheServer) mustEmbedUnimplementedCacheServer() {
+// SendMsg mocks base method.
+func (m_2 *MockCache_ExportClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockCache_ExportClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockCache_ExportClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockCache_ExportClient) Trailer() metadata.MD {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "mustEmbedUnimplementedCacheServer")
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
 }
 
-// mustEmbedUnimplementedCacheServer indicates an expected call of mustEmbedUnimplementedCacheServer.
-func (mr *MockCacheServerMockRecorder) mustEmbedUnimplementedCacheServer() *gomock.Call {
+// Trailer indicates an expected call of Trailer.
+func (mr *MockCache_ExportClientMockRecorder) Trailer() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedCacheServer", reflect.TypeOf((*MockCacheServer)(nil).mustEmbedUnimplementedCacheServer))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockCache_ExportClient)(nil).Trailer))
 }
 
-// MockUnsafeCacheServer is a mock of UnsafeCacheServer interface.
-type MockUnsafeCacheServer struct {
+// MockCache_ImportClient is a mock of Cache_ImportClient interface.
+type MockCache_ImportClient struct {
 	ctrl     *gomock.Controller
-	recorder *MockUnsafeCacheServerMockRecorder
+	recorder *MockCache_ImportClientMockRecorder
 }
 
-// MockUnsafeCacheServerMockRecorder is the mock recorder for MockUnsafeCacheServer.
-type MockUnsafeCacheServerMockRecorder struct {
-	mock *MockUnsafeCacheServer
+// MockCache_ImportClientMockRecorder is the mock recorder for MockCache_ImportClient.
+type MockCache_ImportClientMockRecorder struct {
+	mock *MockCache_ImportClient
 }
 
-// NewMockUnsafeCacheServer creates a new mock instance.
-func NewMockUnsafeCacheServer(ctrl *gomock.Controller) *MockUnsafeCacheServer {
-	mock := &MockUnsafeCacheServer{ctrl: ctrl}
-	mock.recorder = &MockUnsafeCacheServerMockRecorder{mock}
+// NewMockCache_ImportClient creates a new mock instance.
+func NewMockCache_ImportClient(ctrl *gomock.Controller) *MockCache_ImportClient {
+	mock := &MockCache_ImportClient{ctrl: ctrl}
+	mock.recorder = &MockCache_ImportClientMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUnsafeCacheServer) EXPECT() *MockUnsafeCacheServerMockRecorder {
+func (m *MockCache_ImportClient) EXPECT() *MockCache_ImportClientMockRecorder {
 	return m.recorder
 }
 
-// mustEmbedUnimplementedCacheServer mocks base method.
-func (m *MockUnsafeCacheServer) mustEmbedUnimplementedCacheServer() {
+// CloseAndRecv mocks base method.
+func (m *MockCache_ImportClient) CloseAndRecv() (*types.ImportResponse, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "mustEmbedUnimplementedCacheServer")
+	ret := m.ctrl.Call(m, "CloseAndRecv")
+	ret0, _ := ret[0].(*types.ImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// mustEmbedUnimplementedCacheServer indicates an expected call of mustEmbedUnimplementedCacheServer.
-func (mr *MockUnsafeCacheServerMockRecorder) mustEmbedUnimplementedCacheServer() *gomock.Call {
+// CloseAndRecv indicates an expected call of CloseAndRecv.
+func (mr *MockCache_ImportClientMockRecorder) CloseAndRecv() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedCacheServer", reflect.TypeOf((*MockUnsafeCacheServer)(nil).mustEmbedUnimplementedCacheServer))
-}
-
-// MockCache_SyncServer is a mock of Cache_SyncServer interface.
-type MockCache_SyncServer struct {
-	ctrl     *gomock.Controller
-	recorder *MockCache_SyncServerMockRecorder
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAndRecv", reflect.TypeOf((*MockCache_ImportClient)(nil).CloseAndRecv))
 }
 
-// MockCache_SyncServerMockRecorder is the mock recorder for MockCache_SyncServer.
-type MockCache_SyncServerMockRecorder struct {
-	mock *MockCache_SyncServer
-}
-
-// NewMockCache_SyncServer creates a new mock instance.
-func NewMockCache_SyncServer(ctrl *gomock.Controller) *MockCache_SyncServer {
-	mock := &MockCache_SyncServer{ctrl: ctrl}
-	mock.recorder = &MockCache_SyncServerMockRecorder{mock}
-	return mock
+// CloseSend mocks base method.
+func (m *MockCache_ImportClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockCache_SyncServer) EXPECT() *MockCache_SyncServerMockRecorder {
-	return m.recorder
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockCache_ImportClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockCache_ImportClient)(nil).CloseSend))
 }
 
 // Context mocks base method.
-func (m *MockCache_SyncServer) Context() context.Context {
+func (m *MockCache_ImportClient) Context() context.Context {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Context")
 	ret0, _ := ret[0].(context.Context)
@@ -2152,13 +2847,28 @@ func (m *MockCache_SyncServer) Context() context.Context {
 }
 
 // Context indicates an expected call of Context.
-func (mr *MockCache_SyncServerMockRecorder) Context() *gomock.Call {
+func (mr *MockCache_ImportClientMockRecorder) Context() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockCache_SyncServer)(nil).Context))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockCache_ImportClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockCache_ImportClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockCache_ImportClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockCache_ImportClient)(nil).Header))
 }
 
 // RecvMsg mocks base method.
-func (m_2 *MockCache_SyncServer) RecvMsg(m interface{}) error {
+func (m_2 *MockCache_ImportClient) RecvMsg(m interface{}) error {
 	m_2.ctrl.T.Helper()
 	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
 	ret0, _ := ret[0].(error)
@@ -2166,13 +2876,13 @@ func (m_2 *MockCache_SyncServer) RecvMsg(m interface{}) error {
 }
 
 // RecvMsg indicates an expected call of RecvMsg.
-func (mr *MockCache_SyncServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+func (mr *MockCache_ImportClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockCache_SyncServer)(nil).RecvMsg), m)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockCache_ImportClient)(nil).RecvMsg), m)
 }
 
 // Send mocks base method.
-func (m *MockCache_SyncServer) Send(arg0 *types.CacheObject) error {
+func (m *MockCache_ImportClient) Send(arg0 *types.ImportRequest) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Send", arg0)
 	ret0, _ := ret[0].(error)
@@ -2180,13 +2890,564 @@ func (m *MockCache_SyncServer) Send(arg0 *types.CacheObject) error {
 }
 
 // Send indicates an expected call of Send.
-func (mr *MockCache_SyncServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+func (mr *MockCache_ImportClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockCache_SyncServer)(nil).Send), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockCache_ImportClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockCache_ImportClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockCache_ImportClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockCache_ImportClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockCache_ImportClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockCache_ImportClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockCache_ImportClient)(nil).Trailer))
+}
+
+// MockCache_ListClient is a mock of Cache_ListClient interface.
+type MockCache_ListClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCache_ListClientMockRecorder
+}
+
+// MockCache_ListClientMockRecorder is the mock recorder for MockCache_ListClient.
+type MockCache_ListClientMockRecorder struct {
+	mock *MockCache_ListClient
+}
+
+// NewMockCache_ListClient creates a new mock instance.
+func NewMockCache_ListClient(ctrl *gomock.Controller) *MockCache_ListClient {
+	mock := &MockCache_ListClient{ctrl: ctrl}
+	mock.recorder = &MockCache_ListClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCache_ListClient) EXPECT() *MockCache_ListClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockCache_ListClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockCache_ListClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockCache_ListClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockCache_ListClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockCache_ListClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockCache_ListClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockCache_ListClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockCache_ListClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockCache_ListClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockCache_ListClient) Recv() (*types.CacheKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*types.CacheKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockCache_ListClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockCache_ListClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockCache_ListClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockCache_ListClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockCache_ListClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockCache_ListClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockCache_ListClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockCache_ListClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockCache_ListClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockCache_ListClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockCache_ListClient)(nil).Trailer))
+}
+
+// MockCacheServer is a mock of CacheServer interface.
+type MockCacheServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheServerMockRecorder
+}
+
+// MockCacheServerMockRecorder is the mock recorder for MockCacheServer.
+type MockCacheServerMockRecorder struct {
+	mock *MockCacheServer
+}
+
+// NewMockCacheServer creates a new mock instance.
+func NewMockCacheServer(ctrl *gomock.Controller) *MockCacheServer {
+	mock := &MockCacheServer{ctrl: ctrl}
+	mock.recorder = &MockCacheServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCacheServer) EXPECT() *MockCacheServerMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockCacheServer) Export(arg0 *types.ExportRequest, arg1 types.Cache_ExportServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockCacheServerMockRecorder) Export(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockCacheServer)(nil).Export), arg0, arg1)
+}
+
+// Import mocks base method.
+func (m *MockCacheServer) Import(arg0 types.Cache_ImportServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockCacheServerMockRecorder) Import(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockCacheServer)(nil).Import), arg0)
+}
+
+// Inspect mocks base method.
+func (m *MockCacheServer) Inspect(arg0 context.Context, arg1 *types.CacheKey) (*types.InspectResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Inspect", arg0, arg1)
+	ret0, _ := ret[0].(*types.InspectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Inspect indicates an expected call of Inspect.
+func (mr *MockCacheServerMockRecorder) Inspect(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inspect", reflect.TypeOf((*MockCacheServer)(nil).Inspect), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockCacheServer) List(arg0 *types.Empty, arg1 types.Cache_ListServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockCacheServerMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCacheServer)(nil).List), arg0, arg1)
+}
+
+// Pull mocks base method.
+func (m *MockCacheServer) Pull(arg0 context.Context, arg1 *types.PullRequest) (*types.CacheObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pull", arg0, arg1)
+	ret0, _ := ret[0].(*types.CacheObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pull indicates an expected call of Pull.
+func (mr *MockCacheServerMockRecorder) Pull(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockCacheServer)(nil).Pull), arg0, arg1)
+}
+
+// Purge mocks base method.
+func (m *MockCacheServer) Purge(arg0 context.Context, arg1 *types.PurgeRequest) (*types.PurgeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(*types.PurgeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockCacheServerMockRecorder) Purge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockCacheServer)(nil).Purge), arg0, arg1)
+}
+
+// Push mocks base method.
+func (m *MockCacheServer) Push(arg0 context.Context, arg1 *types.PushRequest) (*types.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Push", arg0, arg1)
+	ret0, _ := ret[0].(*types.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockCacheServerMockRecorder) Push(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockCacheServer)(nil).Push), arg0, arg1)
+}
+
+// Query mocks base method.
+func (m *MockCacheServer) Query(arg0 context.Context, arg1 *types.QueryRequest) (*types.QueryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", arg0, arg1)
+	ret0, _ := ret[0].(*types.QueryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockCacheServerMockRecorder) Query(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockCacheServer)(nil).Query), arg0, arg1)
+}
+
+// Stats mocks base method.
+func (m *MockCacheServer) Stats(arg0 context.Context, arg1 *types.Empty) (*types.CacheStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", arg0, arg1)
+	ret0, _ := ret[0].(*types.CacheStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockCacheServerMockRecorder) Stats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockCacheServer)(nil).Stats), arg0, arg1)
+}
+
+// Sync mocks base method.
+func (m *MockCacheServer) Sync(arg0 *types.SyncRequest, arg1 types.Cache_SyncServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockCacheServerMockRecorder) Sync(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockCacheServer)(nil).Sync), arg0, arg1)
+}
+
+// mustEmbedUnimplementedCacheServer mocks base method.
+func (m *MockCacheServer) mustEmbedUnimplementedCacheServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedCacheServer")
+}
+
+// mustEmbedUnimplementedCacheServer indicates an expected call of mustEmbedUnimplementedCacheServer.
+func (mr *MockCacheServerMockRecorder) mustEmbedUnimplementedCacheServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedCacheServer", reflect.TypeOf((*MockCacheServer)(nil).mustEmbedUnimplementedCacheServer))
+}
+
+// MockUnsafeCacheServer is a mock of UnsafeCacheServer interface.
+type MockUnsafeCacheServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeCacheServerMockRecorder
+}
+
+// MockUnsafeCacheServerMockRecorder is the mock recorder for MockUnsafeCacheServer.
+type MockUnsafeCacheServerMockRecorder struct {
+	mock *MockUnsafeCacheServer
+}
+
+// NewMockUnsafeCacheServer creates a new mock instance.
+func NewMockUnsafeCacheServer(ctrl *gomock.Controller) *MockUnsafeCacheServer {
+	mock := &MockUnsafeCacheServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeCacheServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeCacheServer) EXPECT() *MockUnsafeCacheServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedCacheServer mocks base method.
+func (m *MockUnsafeCacheServer) mustEmbedUnimplementedCacheServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedCacheServer")
+}
+
+// mustEmbedUnimplementedCacheServer indicates an expected call of mustEmbedUnimplementedCacheServer.
+func (mr *MockUnsafeCacheServerMockRecorder) mustEmbedUnimplementedCacheServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedCacheServer", reflect.TypeOf((*MockUnsafeCacheServer)(nil).mustEmbedUnimplementedCacheServer))
+}
+
+// MockCache_SyncServer is a mock of Cache_SyncServer interface.
+type MockCache_SyncServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCache_SyncServerMockRecorder
+}
+
+// MockCache_SyncServerMockRecorder is the mock recorder for MockCache_SyncServer.
+type MockCache_SyncServerMockRecorder struct {
+	mock *MockCache_SyncServer
+}
+
+// NewMockCache_SyncServer creates a new mock instance.
+func NewMockCache_SyncServer(ctrl *gomock.Controller) *MockCache_SyncServer {
+	mock := &MockCache_SyncServer{ctrl: ctrl}
+	mock.recorder = &MockCache_SyncServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCache_SyncServer) EXPECT() *MockCache_SyncServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockCache_SyncServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockCache_SyncServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockCache_SyncServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockCache_SyncServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockCache_SyncServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockCache_SyncServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockCache_SyncServer) Send(arg0 *types.SyncObject) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockCache_SyncServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockCache_SyncServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockCache_SyncServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockCache_SyncServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockCache_SyncServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockCache_SyncServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockCache_SyncServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockCache_SyncServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockCache_SyncServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockCache_SyncServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockCache_SyncServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockCache_SyncServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockCache_SyncServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockCache_SyncServer)(nil).SetTrailer), arg0)
+}
+
+// MockCache_ExportServer is a mock of Cache_ExportServer interface.
+type MockCache_ExportServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCache_ExportServerMockRecorder
+}
+
+// MockCache_ExportServerMockRecorder is the mock recorder for MockCache_ExportServer.
+type MockCache_ExportServerMockRecorder struct {
+	mock *MockCache_ExportServer
+}
+
+// NewMockCache_ExportServer creates a new mock instance.
+func NewMockCache_ExportServer(ctrl *gomock.Controller) *MockCache_ExportServer {
+	mock := &MockCache_ExportServer{ctrl: ctrl}
+	mock.recorder = &MockCache_ExportServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCache_ExportServer) EXPECT() *MockCache_ExportServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockCache_ExportServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockCache_ExportServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockCache_ExportServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockCache_ExportServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockCache_ExportServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockCache_ExportServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockCache_ExportServer) Send(arg0 *types.ArchiveChunk) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockCache_ExportServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockCache_ExportServer)(nil).Send), arg0)
 }
 
 // SendHeader mocks base method.
-func (m *MockCache_SyncServer) SendHeader(arg0 metadata.MD) error {
+func (m *MockCache_ExportServer) SendHeader(arg0 metadata.MD) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SendHeader", arg0)
 	ret0, _ := ret[0].(error)
@@ -2194,13 +3455,13 @@ func (m *MockCache_SyncServer) SendHeader(arg0 metadata.MD) error {
 }
 
 // SendHeader indicates an expected call of SendHeader.
-func (mr *MockCache_SyncServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+func (mr *MockCache_ExportServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockCache_SyncServer)(nil).SendHeader), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockCache_ExportServer)(nil).SendHeader), arg0)
 }
 
 // SendMsg mocks base method.
-func (m_2 *MockCache_SyncServer) SendMsg(m interface{}) error {
+func (m_2 *MockCache_ExportServer) SendMsg(m interface{}) error {
 	m_2.ctrl.T.Helper()
 	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
 	ret0, _ := ret[0].(error)
@@ -2208,13 +3469,13 @@ func (m_2 *MockCache_SyncServer) SendMsg(m interface{}) error {
 }
 
 // SendMsg indicates an expected call of SendMsg.
-func (mr *MockCache_SyncServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+func (mr *MockCache_ExportServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockCache_SyncServer)(nil).SendMsg), m)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockCache_ExportServer)(nil).SendMsg), m)
 }
 
 // SetHeader mocks base method.
-func (m *MockCache_SyncServer) SetHeader(arg0 metadata.MD) error {
+func (m *MockCache_ExportServer) SetHeader(arg0 metadata.MD) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetHeader", arg0)
 	ret0, _ := ret[0].(error)
@@ -2222,19 +3483,272 @@ func (m *MockCache_SyncServer) SetHeader(arg0 metadata.MD) error {
 }
 
 // SetHeader indicates an expected call of SetHeader.
-func (mr *MockCache_SyncServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+func (mr *MockCache_ExportServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockCache_SyncServer)(nil).SetHeader), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockCache_ExportServer)(nil).SetHeader), arg0)
 }
 
 // SetTrailer mocks base method.
-func (m *MockCache_SyncServer) SetTrailer(arg0 metadata.MD) {
+func (m *MockCache_ExportServer) SetTrailer(arg0 metadata.MD) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "SetTrailer", arg0)
 }
 
 // SetTrailer indicates an expected call of SetTrailer.
-func (mr *MockCache_SyncServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+func (mr *MockCache_ExportServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockCache_SyncServer)(nil).SetTrailer), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockCache_ExportServer)(nil).SetTrailer), arg0)
+}
+
+// MockCache_ImportServer is a mock of Cache_ImportServer interface.
+type MockCache_ImportServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCache_ImportServerMockRecorder
+}
+
+// MockCache_ImportServerMockRecorder is the mock recorder for MockCache_ImportServer.
+type MockCache_ImportServerMockRecorder struct {
+	mock *MockCache_ImportServer
+}
+
+// NewMockCache_ImportServer creates a new mock instance.
+func NewMockCache_ImportServer(ctrl *gomock.Controller) *MockCache_ImportServer {
+	mock := &MockCache_ImportServer{ctrl: ctrl}
+	mock.recorder = &MockCache_ImportServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCache_ImportServer) EXPECT() *MockCache_ImportServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockCache_ImportServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockCache_ImportServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockCache_ImportServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockCache_ImportServer) Recv() (*types.ImportRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*types.ImportRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockCache_ImportServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockCache_ImportServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockCache_ImportServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockCache_ImportServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockCache_ImportServer)(nil).RecvMsg), m)
+}
+
+// SendAndClose mocks base method.
+func (m *MockCache_ImportServer) SendAndClose(arg0 *types.ImportResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAndClose", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAndClose indicates an expected call of SendAndClose.
+func (mr *MockCache_ImportServerMockRecorder) SendAndClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAndClose", reflect.TypeOf((*MockCache_ImportServer)(nil).SendAndClose), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockCache_ImportServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockCache_ImportServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockCache_ImportServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockCache_ImportServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockCache_ImportServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockCache_ImportServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockCache_ImportServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockCache_ImportServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockCache_ImportServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockCache_ImportServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockCache_ImportServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockCache_ImportServer)(nil).SetTrailer), arg0)
+}
+
+// MockCache_ListServer is a mock of Cache_ListServer interface.
+type MockCache_ListServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCache_ListServerMockRecorder
+}
+
+// MockCache_ListServerMockRecorder is the mock recorder for MockCache_ListServer.
+type MockCache_ListServerMockRecorder struct {
+	mock *MockCache_ListServer
+}
+
+// NewMockCache_ListServer creates a new mock instance.
+func NewMockCache_ListServer(ctrl *gomock.Controller) *MockCache_ListServer {
+	mock := &MockCache_ListServer{ctrl: ctrl}
+	mock.recorder = &MockCache_ListServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCache_ListServer) EXPECT() *MockCache_ListServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockCache_ListServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockCache_ListServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockCache_ListServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockCache_ListServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockCache_ListServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockCache_ListServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockCache_ListServer) Send(arg0 *types.CacheKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockCache_ListServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockCache_ListServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockCache_ListServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockCache_ListServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockCache_ListServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockCache_ListServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockCache_ListServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockCache_ListServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockCache_ListServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockCache_ListServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockCache_ListServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockCache_ListServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockCache_ListServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockCache_ListServer)(nil).SetTrailer), arg0)
 }
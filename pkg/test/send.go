@@ -48,6 +48,8 @@ func (m sendRemoteRunnerManager) Process(
 		RequestID: uuid.NewString(),
 		Toolchain: req.GetToolchain(),
 		Args:      req.Args,
+		Priority:  req.GetPriority(),
+		Tenant:    req.GetTenant(),
 	})
 	if err != nil {
 		if errors.Is(err, clients.ErrStreamNotReady) {
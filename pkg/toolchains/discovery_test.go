@@ -194,6 +194,7 @@ var _ = Describe("Find Toolchains", func() {
 			TargetArch: "x86_64",
 			Version:    "10",
 			PicDefault: true,
+			PieDefault: true,
 		},
 		"usr/bin/x86_64-linux-gnu-g++-10": {
 			Kind:       types.Gnu,
@@ -202,6 +203,7 @@ var _ = Describe("Find Toolchains", func() {
 			TargetArch: "x86_64",
 			Version:    "10",
 			PicDefault: true,
+			PieDefault: true,
 		},
 		"usr/bin/x86_64-linux-gnu-gcc-9": {
 			Kind:       types.Gnu,
@@ -210,6 +212,7 @@ var _ = Describe("Find Toolchains", func() {
 			TargetArch: "x86_64",
 			Version:    "9",
 			PicDefault: true,
+			PieDefault: true,
 		},
 		"usr/bin/x86_64-linux-gnu-g++-9": {
 			Kind:       types.Gnu,
@@ -218,6 +221,7 @@ var _ = Describe("Find Toolchains", func() {
 			TargetArch: "x86_64",
 			Version:    "9",
 			PicDefault: true,
+			PieDefault: true,
 		},
 		"usr/lib/llvm-11/bin/clang": {
 			Kind:       types.Clang,
@@ -226,6 +230,7 @@ var _ = Describe("Find Toolchains", func() {
 			TargetArch: "x86_64",
 			Version:    "11.0.0",
 			PicDefault: false,
+			PieDefault: false,
 		},
 	}
 
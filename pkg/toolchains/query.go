@@ -95,12 +95,46 @@ func (q ExecQuerier) TargetArch(compiler string) (string, error) {
 	}
 	triple := strings.Split(stdoutBuf.String(), "-")
 	if len(triple) <= 2 {
-		return "", errors.New("GCC returned an invalid target triple with -dumpmachine")
+		return "", errors.New("Compiler returned an invalid target triple with -dumpmachine")
 	}
 	return strings.TrimSpace(triple[0]), nil
 }
 
+// predefinedMacros returns the names and values of all macros the compiler
+// defines by default.
+func predefinedMacros(compiler string) (map[string]string, error) {
+	cmd := exec.Command(compiler, "-dM", "-E", "-x", "c", "-")
+	stdoutBuf := new(bytes.Buffer)
+	cmd.Stdin = strings.NewReader("")
+	cmd.Stdout = stdoutBuf
+	cmd.Stderr = nil
+	cmd.Env = []string{}
+	err := cmd.Run()
+	if err != nil {
+		return nil, err
+	}
+	macros := map[string]string{}
+	for _, line := range strings.Split(stdoutBuf.String(), "\n") {
+		fields := strings.SplitN(strings.TrimPrefix(line, "#define "), " ", 2)
+		if len(fields) == 2 {
+			macros[fields[0]] = fields[1]
+		}
+	}
+	return macros, nil
+}
+
 func (q ExecQuerier) Version(compiler string) (string, error) {
+	// Older versions of clang report a GCC-compatible version with
+	// -dumpversion, so the real version is read from the clang macros.
+	if macros, err := predefinedMacros(compiler); err == nil {
+		if _, ok := macros["__clang__"]; ok {
+			return strings.Join([]string{
+				macros["__clang_major__"],
+				macros["__clang_minor__"],
+				macros["__clang_patchlevel__"],
+			}, "."), nil
+		}
+	}
 	cmd := exec.Command(compiler, "-dumpversion")
 	stdoutBuf := new(bytes.Buffer)
 	cmd.Stdin = nil
@@ -115,6 +149,16 @@ func (q ExecQuerier) Version(compiler string) (string, error) {
 }
 
 func (q ExecQuerier) Kind(compiler string) (types.ToolchainKind, error) {
+	// Generic names such as cc or c++ can point to either gcc or clang,
+	// so check the predefined macros before falling back to the name.
+	if macros, err := predefinedMacros(compiler); err == nil {
+		if _, ok := macros["__clang__"]; ok {
+			return types.Clang, nil
+		}
+		if _, ok := macros["__GNUC__"]; ok {
+			return types.Gnu, nil
+		}
+	}
 	switch base := filepath.Base(compiler); {
 	case strings.Contains(base, "clang"):
 		return types.Clang, nil
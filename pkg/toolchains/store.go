@@ -103,6 +103,10 @@ func fillInfo(tc *types.Toolchain, q Querier) error {
 	if err != nil {
 		return errors.WithMessage(err, "Could not determine compiler PIC defaults")
 	}
+	tc.PieDefault, err = q.IsPieDefault(tc.Executable)
+	if err != nil {
+		return errors.WithMessage(err, "Could not determine compiler PIE defaults")
+	}
 	tc.Kind, err = q.Kind(tc.Executable)
 	if err != nil {
 		return errors.WithMessage(err, "Could not determine compiler kind (gcc/clang)")
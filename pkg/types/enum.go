@@ -43,4 +43,18 @@ const (
 	Memory  = StorageLocation_StorageLocation_Memory
 	Disk    = StorageLocation_StorageLocation_Disk
 	S3      = StorageLocation_StorageLocation_S3
+	Redis   = StorageLocation_StorageLocation_Redis
+
+	DefaultPriority = PriorityClass_PriorityClass_Default
+	Interactive     = PriorityClass_PriorityClass_Interactive
+	CI              = PriorityClass_PriorityClass_CI
+	Batch           = PriorityClass_PriorityClass_Batch
+
+	Drain    = DrainAction_DrainAction_Drain
+	Cordon   = DrainAction_DrainAction_Cordon
+	Uncordon = DrainAction_DrainAction_Uncordon
+
+	TaskPending   = TaskState_TaskState_Pending
+	TaskRunning   = TaskState_TaskState_Running
+	TaskCoalesced = TaskState_TaskState_Coalesced
 )
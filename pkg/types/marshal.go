@@ -59,5 +59,8 @@ func (r *CompileResponse) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	case *CompileResponse_CompiledSource:
 		enc.AddInt("dataLen", len(data.CompiledSource))
 	}
+	if len(r.Stderr) > 0 {
+		enc.AddInt("stderrLen", len(r.Stderr))
+	}
 	return nil
 }
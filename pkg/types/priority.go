@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PriorityClasses lists the priority classes requests can be assigned to,
+// from highest to lowest priority.
+var PriorityClasses = []PriorityClass{Interactive, CI, Batch}
+
+// Name returns the lowercase name of the priority class, as used in
+// configuration and metrics.
+func (c PriorityClass) Name() string {
+	switch c {
+	case Interactive:
+		return "interactive"
+	case CI:
+		return "ci"
+	case Batch:
+		return "batch"
+	}
+	return "default"
+}
+
+// ParsePriorityClass returns the priority class with the given name. An
+// empty name refers to the default priority class.
+func ParsePriorityClass(name string) (PriorityClass, error) {
+	if name == "" {
+		return DefaultPriority, nil
+	}
+	for _, c := range PriorityClasses {
+		if strings.EqualFold(c.Name(), name) {
+			return c, nil
+		}
+	}
+	return DefaultPriority, fmt.Errorf("unknown priority class %q", name)
+}
@@ -28,6 +28,7 @@ const (
 	StorageLocation_StorageLocation_Memory  StorageLocation = 1
 	StorageLocation_StorageLocation_Disk    StorageLocation = 2
 	StorageLocation_StorageLocation_S3      StorageLocation = 3
+	StorageLocation_StorageLocation_Redis   StorageLocation = 4
 )
 
 // Enum value maps for StorageLocation.
@@ -37,12 +38,14 @@ var (
 		1: "StorageLocation_Memory",
 		2: "StorageLocation_Disk",
 		3: "StorageLocation_S3",
+		4: "StorageLocation_Redis",
 	}
 	StorageLocation_value = map[string]int32{
 		"StorageLocation_Unknown": 0,
 		"StorageLocation_Memory":  1,
 		"StorageLocation_Disk":    2,
 		"StorageLocation_S3":      3,
+		"StorageLocation_Redis":   4,
 	}
 )
 
@@ -73,6 +76,104 @@ func (StorageLocation) EnumDescriptor() ([]byte, []int) {
 	return file_pkg_types_types_proto_rawDescGZIP(), []int{0}
 }
 
+type DrainAction int32
+
+const (
+	DrainAction_DrainAction_Drain    DrainAction = 0
+	DrainAction_DrainAction_Cordon   DrainAction = 1
+	DrainAction_DrainAction_Uncordon DrainAction = 2
+)
+
+// Enum value maps for DrainAction.
+var (
+	DrainAction_name = map[int32]string{
+		0: "DrainAction_Drain",
+		1: "DrainAction_Cordon",
+		2: "DrainAction_Uncordon",
+	}
+	DrainAction_value = map[string]int32{
+		"DrainAction_Drain":    0,
+		"DrainAction_Cordon":   1,
+		"DrainAction_Uncordon": 2,
+	}
+)
+
+func (x DrainAction) Enum() *DrainAction {
+	p := new(DrainAction)
+	*p = x
+	return p
+}
+
+func (x DrainAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DrainAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_types_types_proto_enumTypes[1].Descriptor()
+}
+
+func (DrainAction) Type() protoreflect.EnumType {
+	return &file_pkg_types_types_proto_enumTypes[1]
+}
+
+func (x DrainAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DrainAction.Descriptor instead.
+func (DrainAction) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{1}
+}
+
+type TaskState int32
+
+const (
+	TaskState_TaskState_Pending   TaskState = 0
+	TaskState_TaskState_Running   TaskState = 1
+	TaskState_TaskState_Coalesced TaskState = 2
+)
+
+// Enum value maps for TaskState.
+var (
+	TaskState_name = map[int32]string{
+		0: "TaskState_Pending",
+		1: "TaskState_Running",
+		2: "TaskState_Coalesced",
+	}
+	TaskState_value = map[string]int32{
+		"TaskState_Pending":   0,
+		"TaskState_Running":   1,
+		"TaskState_Coalesced": 2,
+	}
+)
+
+func (x TaskState) Enum() *TaskState {
+	p := new(TaskState)
+	*p = x
+	return p
+}
+
+func (x TaskState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskState) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_types_types_proto_enumTypes[2].Descriptor()
+}
+
+func (TaskState) Type() protoreflect.EnumType {
+	return &file_pkg_types_types_proto_enumTypes[2]
+}
+
+func (x TaskState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskState.Descriptor instead.
+func (TaskState) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{2}
+}
+
 type Component int32
 
 const (
@@ -133,11 +234,11 @@ func (x Component) String() string {
 }
 
 func (Component) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_types_types_proto_enumTypes[1].Descriptor()
+	return file_pkg_types_types_proto_enumTypes[3].Descriptor()
 }
 
 func (Component) Type() protoreflect.EnumType {
-	return &file_pkg_types_types_proto_enumTypes[1]
+	return &file_pkg_types_types_proto_enumTypes[3]
 }
 
 func (x Component) Number() protoreflect.EnumNumber {
@@ -146,7 +247,7 @@ func (x Component) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Component.Descriptor instead.
 func (Component) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{1}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{3}
 }
 
 type ToolchainKind int32
@@ -188,11 +289,11 @@ func (x ToolchainKind) String() string {
 }
 
 func (ToolchainKind) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_types_types_proto_enumTypes[2].Descriptor()
+	return file_pkg_types_types_proto_enumTypes[4].Descriptor()
 }
 
 func (ToolchainKind) Type() protoreflect.EnumType {
-	return &file_pkg_types_types_proto_enumTypes[2]
+	return &file_pkg_types_types_proto_enumTypes[4]
 }
 
 func (x ToolchainKind) Number() protoreflect.EnumNumber {
@@ -201,7 +302,7 @@ func (x ToolchainKind) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ToolchainKind.Descriptor instead.
 func (ToolchainKind) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{2}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{4}
 }
 
 type ToolchainLang int32
@@ -240,11 +341,11 @@ func (x ToolchainLang) String() string {
 }
 
 func (ToolchainLang) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_types_types_proto_enumTypes[3].Descriptor()
+	return file_pkg_types_types_proto_enumTypes[5].Descriptor()
 }
 
 func (ToolchainLang) Type() protoreflect.EnumType {
-	return &file_pkg_types_types_proto_enumTypes[3]
+	return &file_pkg_types_types_proto_enumTypes[5]
 }
 
 func (x ToolchainLang) Number() protoreflect.EnumNumber {
@@ -253,7 +354,59 @@ func (x ToolchainLang) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ToolchainLang.Descriptor instead.
 func (ToolchainLang) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{3}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{5}
+}
+
+type PriorityClass int32
+
+const (
+	PriorityClass_PriorityClass_Default     PriorityClass = 0
+	PriorityClass_PriorityClass_Interactive PriorityClass = 1
+	PriorityClass_PriorityClass_CI          PriorityClass = 2
+	PriorityClass_PriorityClass_Batch       PriorityClass = 3
+)
+
+// Enum value maps for PriorityClass.
+var (
+	PriorityClass_name = map[int32]string{
+		0: "PriorityClass_Default",
+		1: "PriorityClass_Interactive",
+		2: "PriorityClass_CI",
+		3: "PriorityClass_Batch",
+	}
+	PriorityClass_value = map[string]int32{
+		"PriorityClass_Default":     0,
+		"PriorityClass_Interactive": 1,
+		"PriorityClass_CI":          2,
+		"PriorityClass_Batch":       3,
+	}
+)
+
+func (x PriorityClass) Enum() *PriorityClass {
+	p := new(PriorityClass)
+	*p = x
+	return p
+}
+
+func (x PriorityClass) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PriorityClass) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_types_types_proto_enumTypes[6].Descriptor()
+}
+
+func (PriorityClass) Type() protoreflect.EnumType {
+	return &file_pkg_types_types_proto_enumTypes[6]
+}
+
+func (x PriorityClass) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PriorityClass.Descriptor instead.
+func (PriorityClass) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{6}
 }
 
 type RetryAction int32
@@ -289,11 +442,11 @@ func (x RetryAction) String() string {
 }
 
 func (RetryAction) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_types_types_proto_enumTypes[4].Descriptor()
+	return file_pkg_types_types_proto_enumTypes[7].Descriptor()
 }
 
 func (RetryAction) Type() protoreflect.EnumType {
-	return &file_pkg_types_types_proto_enumTypes[4]
+	return &file_pkg_types_types_proto_enumTypes[7]
 }
 
 func (x RetryAction) Number() protoreflect.EnumNumber {
@@ -302,7 +455,7 @@ func (x RetryAction) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RetryAction.Descriptor instead.
 func (RetryAction) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{4}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{7}
 }
 
 type CompileResponse_Result int32
@@ -344,11 +497,11 @@ func (x CompileResponse_Result) String() string {
 }
 
 func (CompileResponse_Result) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_types_types_proto_enumTypes[5].Descriptor()
+	return file_pkg_types_types_proto_enumTypes[8].Descriptor()
 }
 
 func (CompileResponse_Result) Type() protoreflect.EnumType {
-	return &file_pkg_types_types_proto_enumTypes[5]
+	return &file_pkg_types_types_proto_enumTypes[8]
 }
 
 func (x CompileResponse_Result) Number() protoreflect.EnumNumber {
@@ -357,7 +510,7 @@ func (x CompileResponse_Result) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CompileResponse_Result.Descriptor instead.
 func (CompileResponse_Result) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{29, 0}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{47, 0}
 }
 
 type Empty struct {
@@ -649,16 +802,17 @@ func (x *SyncRequest) GetDesiredCacheSizeKb() int64 {
 	return 0
 }
 
-type CacheKey struct {
+type SyncObject struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Hash string `protobuf:"bytes,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Key    *CacheKey    `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Object *CacheObject `protobuf:"bytes,2,opt,name=Object,proto3" json:"Object,omitempty"`
 }
 
-func (x *CacheKey) Reset() {
-	*x = CacheKey{}
+func (x *SyncObject) Reset() {
+	*x = SyncObject{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_types_types_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -666,13 +820,13 @@ func (x *CacheKey) Reset() {
 	}
 }
 
-func (x *CacheKey) String() string {
+func (x *SyncObject) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CacheKey) ProtoMessage() {}
+func (*SyncObject) ProtoMessage() {}
 
-func (x *CacheKey) ProtoReflect() protoreflect.Message {
+func (x *SyncObject) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_types_types_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -684,29 +838,35 @@ func (x *CacheKey) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CacheKey.ProtoReflect.Descriptor instead.
-func (*CacheKey) Descriptor() ([]byte, []int) {
+// Deprecated: Use SyncObject.ProtoReflect.Descriptor instead.
+func (*SyncObject) Descriptor() ([]byte, []int) {
 	return file_pkg_types_types_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CacheKey) GetHash() string {
+func (x *SyncObject) GetKey() *CacheKey {
 	if x != nil {
-		return x.Hash
+		return x.Key
 	}
-	return ""
+	return nil
 }
 
-type CacheObject struct {
+func (x *SyncObject) GetObject() *CacheObject {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+type CacheKey struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Data     []byte           `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
-	Metadata *CacheObjectMeta `protobuf:"bytes,2,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+	Hash string `protobuf:"bytes,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
 }
 
-func (x *CacheObject) Reset() {
-	*x = CacheObject{}
+func (x *CacheKey) Reset() {
+	*x = CacheKey{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_types_types_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -714,13 +874,13 @@ func (x *CacheObject) Reset() {
 	}
 }
 
-func (x *CacheObject) String() string {
+func (x *CacheKey) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CacheObject) ProtoMessage() {}
+func (*CacheKey) ProtoMessage() {}
 
-func (x *CacheObject) ProtoReflect() protoreflect.Message {
+func (x *CacheKey) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_types_types_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -732,37 +892,31 @@ func (x *CacheObject) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CacheObject.ProtoReflect.Descriptor instead.
-func (*CacheObject) Descriptor() ([]byte, []int) {
+// Deprecated: Use CacheKey.ProtoReflect.Descriptor instead.
+func (*CacheKey) Descriptor() ([]byte, []int) {
 	return file_pkg_types_types_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *CacheObject) GetData() []byte {
-	if x != nil {
-		return x.Data
-	}
-	return nil
-}
-
-func (x *CacheObject) GetMetadata() *CacheObjectMeta {
+func (x *CacheKey) GetHash() string {
 	if x != nil {
-		return x.Metadata
+		return x.Hash
 	}
-	return nil
+	return ""
 }
 
-type CacheObjectMeta struct {
+type CacheFilter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tags           map[string]string   `protobuf:"bytes,1,rep,name=Tags,proto3" json:"Tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	ExpirationDate int64               `protobuf:"varint,4,opt,name=ExpirationDate,proto3" json:"ExpirationDate,omitempty"`
-	ManagedFields  *CacheObjectManaged `protobuf:"bytes,5,opt,name=ManagedFields,proto3" json:"ManagedFields,omitempty"`
+	Toolchains []string          `protobuf:"bytes,1,rep,name=Toolchains,proto3" json:"Toolchains,omitempty"`
+	Tags       map[string]string `protobuf:"bytes,2,rep,name=Tags,proto3" json:"Tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	MinAge     int64             `protobuf:"varint,3,opt,name=MinAge,proto3" json:"MinAge,omitempty"`
+	MaxAge     int64             `protobuf:"varint,4,opt,name=MaxAge,proto3" json:"MaxAge,omitempty"`
 }
 
-func (x *CacheObjectMeta) Reset() {
-	*x = CacheObjectMeta{}
+func (x *CacheFilter) Reset() {
+	*x = CacheFilter{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_types_types_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -770,13 +924,13 @@ func (x *CacheObjectMeta) Reset() {
 	}
 }
 
-func (x *CacheObjectMeta) String() string {
+func (x *CacheFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CacheObjectMeta) ProtoMessage() {}
+func (*CacheFilter) ProtoMessage() {}
 
-func (x *CacheObjectMeta) ProtoReflect() protoreflect.Message {
+func (x *CacheFilter) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_types_types_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -788,45 +942,49 @@ func (x *CacheObjectMeta) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CacheObjectMeta.ProtoReflect.Descriptor instead.
-func (*CacheObjectMeta) Descriptor() ([]byte, []int) {
+// Deprecated: Use CacheFilter.ProtoReflect.Descriptor instead.
+func (*CacheFilter) Descriptor() ([]byte, []int) {
 	return file_pkg_types_types_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CacheObjectMeta) GetTags() map[string]string {
+func (x *CacheFilter) GetToolchains() []string {
+	if x != nil {
+		return x.Toolchains
+	}
+	return nil
+}
+
+func (x *CacheFilter) GetTags() map[string]string {
 	if x != nil {
 		return x.Tags
 	}
 	return nil
 }
 
-func (x *CacheObjectMeta) GetExpirationDate() int64 {
+func (x *CacheFilter) GetMinAge() int64 {
 	if x != nil {
-		return x.ExpirationDate
+		return x.MinAge
 	}
 	return 0
 }
 
-func (x *CacheObjectMeta) GetManagedFields() *CacheObjectManaged {
+func (x *CacheFilter) GetMaxAge() int64 {
 	if x != nil {
-		return x.ManagedFields
+		return x.MaxAge
 	}
-	return nil
+	return 0
 }
 
-type CacheObjectManaged struct {
+type ExportRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Size      int64           `protobuf:"varint,1,opt,name=Size,proto3" json:"Size,omitempty"`
-	Timestamp int64           `protobuf:"varint,2,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
-	Score     int64           `protobuf:"varint,3,opt,name=Score,proto3" json:"Score,omitempty"`
-	Location  StorageLocation `protobuf:"varint,5,opt,name=Location,proto3,enum=types.StorageLocation" json:"Location,omitempty"`
+	Filter *CacheFilter `protobuf:"bytes,1,opt,name=Filter,proto3" json:"Filter,omitempty"`
 }
 
-func (x *CacheObjectManaged) Reset() {
-	*x = CacheObjectManaged{}
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_types_types_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -834,13 +992,13 @@ func (x *CacheObjectManaged) Reset() {
 	}
 }
 
-func (x *CacheObjectManaged) String() string {
+func (x *ExportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CacheObjectManaged) ProtoMessage() {}
+func (*ExportRequest) ProtoMessage() {}
 
-func (x *CacheObjectManaged) ProtoReflect() protoreflect.Message {
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_types_types_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -852,49 +1010,28 @@ func (x *CacheObjectManaged) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CacheObjectManaged.ProtoReflect.Descriptor instead.
-func (*CacheObjectManaged) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_types_types_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CacheObjectManaged) GetSize() int64 {
-	if x != nil {
-		return x.Size
-	}
-	return 0
-}
-
-func (x *CacheObjectManaged) GetTimestamp() int64 {
-	if x != nil {
-		return x.Timestamp
-	}
-	return 0
-}
-
-func (x *CacheObjectManaged) GetScore() int64 {
-	if x != nil {
-		return x.Score
-	}
-	return 0
-}
-
-func (x *CacheObjectManaged) GetLocation() StorageLocation {
+func (x *ExportRequest) GetFilter() *CacheFilter {
 	if x != nil {
-		return x.Location
+		return x.Filter
 	}
-	return StorageLocation_StorageLocation_Unknown
+	return nil
 }
 
-type WhoisRequest struct {
+type ArchiveChunk struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UUID string `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	Data []byte `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
 }
 
-func (x *WhoisRequest) Reset() {
-	*x = WhoisRequest{}
+func (x *ArchiveChunk) Reset() {
+	*x = ArchiveChunk{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_types_types_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -902,13 +1039,13 @@ func (x *WhoisRequest) Reset() {
 	}
 }
 
-func (x *WhoisRequest) String() string {
+func (x *ArchiveChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WhoisRequest) ProtoMessage() {}
+func (*ArchiveChunk) ProtoMessage() {}
 
-func (x *WhoisRequest) ProtoReflect() protoreflect.Message {
+func (x *ArchiveChunk) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_types_types_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -920,30 +1057,29 @@ func (x *WhoisRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WhoisRequest.ProtoReflect.Descriptor instead.
-func (*WhoisRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ArchiveChunk.ProtoReflect.Descriptor instead.
+func (*ArchiveChunk) Descriptor() ([]byte, []int) {
 	return file_pkg_types_types_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *WhoisRequest) GetUUID() string {
+func (x *ArchiveChunk) GetData() []byte {
 	if x != nil {
-		return x.UUID
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
-type WhoisResponse struct {
+type ImportRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UUID      string    `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
-	Address   string    `protobuf:"bytes,2,opt,name=Address,proto3" json:"Address,omitempty"`
-	Component Component `protobuf:"varint,3,opt,name=Component,proto3,enum=types.Component" json:"Component,omitempty"`
+	Location StorageLocation `protobuf:"varint,1,opt,name=Location,proto3,enum=types.StorageLocation" json:"Location,omitempty"`
+	Data     []byte          `protobuf:"bytes,2,opt,name=Data,proto3" json:"Data,omitempty"`
 }
 
-func (x *WhoisResponse) Reset() {
-	*x = WhoisResponse{}
+func (x *ImportRequest) Reset() {
+	*x = ImportRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_types_types_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -951,13 +1087,13 @@ func (x *WhoisResponse) Reset() {
 	}
 }
 
-func (x *WhoisResponse) String() string {
+func (x *ImportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WhoisResponse) ProtoMessage() {}
+func (*ImportRequest) ProtoMessage() {}
 
-func (x *WhoisResponse) ProtoReflect() protoreflect.Message {
+func (x *ImportRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_types_types_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -969,43 +1105,36 @@ func (x *WhoisResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WhoisResponse.ProtoReflect.Descriptor instead.
-func (*WhoisResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ImportRequest.ProtoReflect.Descriptor instead.
+func (*ImportRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_types_types_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *WhoisResponse) GetUUID() string {
+func (x *ImportRequest) GetLocation() StorageLocation {
 	if x != nil {
-		return x.UUID
+		return x.Location
 	}
-	return ""
+	return StorageLocation_StorageLocation_Unknown
 }
 
-func (x *WhoisResponse) GetAddress() string {
+func (x *ImportRequest) GetData() []byte {
 	if x != nil {
-		return x.Address
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
-func (x *WhoisResponse) GetComponent() Component {
-	if x != nil {
-		return x.Component
-	}
-	return Component_Component_Unknown
-}
-
-type Metric struct {
+type CacheStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key   *Key       `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
-	Value *anypb.Any `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	Tiers      []*CacheTierStats      `protobuf:"bytes,1,rep,name=Tiers,proto3" json:"Tiers,omitempty"`
+	Toolchains []*CacheToolchainStats `protobuf:"bytes,2,rep,name=Toolchains,proto3" json:"Toolchains,omitempty"`
 }
 
-func (x *Metric) Reset() {
-	*x = Metric{}
+func (x *CacheStats) Reset() {
+	*x = CacheStats{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_types_types_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1013,13 +1142,13 @@ func (x *Metric) Reset() {
 	}
 }
 
-func (x *Metric) String() string {
+func (x *CacheStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Metric) ProtoMessage() {}
+func (*CacheStats) ProtoMessage() {}
 
-func (x *Metric) ProtoReflect() protoreflect.Message {
+func (x *CacheStats) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_types_types_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1031,36 +1160,41 @@ func (x *Metric) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Metric.ProtoReflect.Descriptor instead.
-func (*Metric) Descriptor() ([]byte, []int) {
+// Deprecated: Use CacheStats.ProtoReflect.Descriptor instead.
+func (*CacheStats) Descriptor() ([]byte, []int) {
 	return file_pkg_types_types_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *Metric) GetKey() *Key {
+func (x *CacheStats) GetTiers() []*CacheTierStats {
 	if x != nil {
-		return x.Key
+		return x.Tiers
 	}
 	return nil
 }
 
-func (x *Metric) GetValue() *anypb.Any {
+func (x *CacheStats) GetToolchains() []*CacheToolchainStats {
 	if x != nil {
-		return x.Value
+		return x.Toolchains
 	}
 	return nil
 }
 
-type Key struct {
+type CacheTierStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Bucket string `protobuf:"bytes,1,opt,name=Bucket,proto3" json:"Bucket,omitempty"`
-	Name   string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Location            StorageLocation `protobuf:"varint,1,opt,name=Location,proto3,enum=types.StorageLocation" json:"Location,omitempty"`
+	ObjectCount         int64           `protobuf:"varint,2,opt,name=ObjectCount,proto3" json:"ObjectCount,omitempty"`
+	TotalSize           int64           `protobuf:"varint,3,opt,name=TotalSize,proto3" json:"TotalSize,omitempty"`
+	UsagePercent        float64         `protobuf:"fixed64,4,opt,name=UsagePercent,proto3" json:"UsagePercent,omitempty"`
+	CacheHitsTotal      int64           `protobuf:"varint,5,opt,name=CacheHitsTotal,proto3" json:"CacheHitsTotal,omitempty"`
+	CacheMissesTotal    int64           `protobuf:"varint,6,opt,name=CacheMissesTotal,proto3" json:"CacheMissesTotal,omitempty"`
+	CorruptObjectsTotal int64           `protobuf:"varint,7,opt,name=CorruptObjectsTotal,proto3" json:"CorruptObjectsTotal,omitempty"`
 }
 
-func (x *Key) Reset() {
-	*x = Key{}
+func (x *CacheTierStats) Reset() {
+	*x = CacheTierStats{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_types_types_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1068,13 +1202,13 @@ func (x *Key) Reset() {
 	}
 }
 
-func (x *Key) String() string {
+func (x *CacheTierStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Key) ProtoMessage() {}
+func (*CacheTierStats) ProtoMessage() {}
 
-func (x *Key) ProtoReflect() protoreflect.Message {
+func (x *CacheTierStats) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_types_types_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1086,35 +1220,72 @@ func (x *Key) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Key.ProtoReflect.Descriptor instead.
-func (*Key) Descriptor() ([]byte, []int) {
+// Deprecated: Use CacheTierStats.ProtoReflect.Descriptor instead.
+func (*CacheTierStats) Descriptor() ([]byte, []int) {
 	return file_pkg_types_types_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *Key) GetBucket() string {
+func (x *CacheTierStats) GetLocation() StorageLocation {
 	if x != nil {
-		return x.Bucket
+		return x.Location
 	}
-	return ""
+	return StorageLocation_StorageLocation_Unknown
 }
 
-func (x *Key) GetName() string {
+func (x *CacheTierStats) GetObjectCount() int64 {
 	if x != nil {
-		return x.Name
+		return x.ObjectCount
 	}
-	return ""
+	return 0
 }
 
-type Bucket struct {
+func (x *CacheTierStats) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *CacheTierStats) GetUsagePercent() float64 {
+	if x != nil {
+		return x.UsagePercent
+	}
+	return 0
+}
+
+func (x *CacheTierStats) GetCacheHitsTotal() int64 {
+	if x != nil {
+		return x.CacheHitsTotal
+	}
+	return 0
+}
+
+func (x *CacheTierStats) GetCacheMissesTotal() int64 {
+	if x != nil {
+		return x.CacheMissesTotal
+	}
+	return 0
+}
+
+func (x *CacheTierStats) GetCorruptObjectsTotal() int64 {
+	if x != nil {
+		return x.CorruptObjectsTotal
+	}
+	return 0
+}
+
+type CacheToolchainStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Toolchain   string `protobuf:"bytes,1,opt,name=Toolchain,proto3" json:"Toolchain,omitempty"`
+	ObjectCount int64  `protobuf:"varint,2,opt,name=ObjectCount,proto3" json:"ObjectCount,omitempty"`
+	TotalSize   int64  `protobuf:"varint,3,opt,name=TotalSize,proto3" json:"TotalSize,omitempty"`
 }
 
-func (x *Bucket) Reset() {
-	*x = Bucket{}
+func (x *CacheToolchainStats) Reset() {
+	*x = CacheToolchainStats{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_types_types_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1122,13 +1293,13 @@ func (x *Bucket) Reset() {
 	}
 }
 
-func (x *Bucket) String() string {
+func (x *CacheToolchainStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Bucket) ProtoMessage() {}
+func (*CacheToolchainStats) ProtoMessage() {}
 
-func (x *Bucket) ProtoReflect() protoreflect.Message {
+func (x *CacheToolchainStats) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_types_types_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1140,43 +1311,1143 @@ func (x *Bucket) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Bucket.ProtoReflect.Descriptor instead.
-func (*Bucket) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use CacheToolchainStats.ProtoReflect.Descriptor instead.
+func (*CacheToolchainStats) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CacheToolchainStats) GetToolchain() string {
+	if x != nil {
+		return x.Toolchain
+	}
+	return ""
+}
+
+func (x *CacheToolchainStats) GetObjectCount() int64 {
+	if x != nil {
+		return x.ObjectCount
+	}
+	return 0
+}
+
+func (x *CacheToolchainStats) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type InspectResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tiers []*CacheObjectMeta `protobuf:"bytes,1,rep,name=Tiers,proto3" json:"Tiers,omitempty"`
+}
+
+func (x *InspectResponse) Reset() {
+	*x = InspectResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InspectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectResponse) ProtoMessage() {}
+
+func (x *InspectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectResponse.ProtoReflect.Descriptor instead.
+func (*InspectResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *InspectResponse) GetTiers() []*CacheObjectMeta {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+type PurgeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Filter *CacheFilter `protobuf:"bytes,1,opt,name=Filter,proto3" json:"Filter,omitempty"`
+	DryRun bool         `protobuf:"varint,2,opt,name=DryRun,proto3" json:"DryRun,omitempty"`
+}
+
+func (x *PurgeRequest) Reset() {
+	*x = PurgeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PurgeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeRequest) ProtoMessage() {}
+
+func (x *PurgeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeRequest.ProtoReflect.Descriptor instead.
+func (*PurgeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PurgeRequest) GetFilter() *CacheFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *PurgeRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type PurgeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ObjectCount int64 `protobuf:"varint,1,opt,name=ObjectCount,proto3" json:"ObjectCount,omitempty"`
+	TotalSize   int64 `protobuf:"varint,2,opt,name=TotalSize,proto3" json:"TotalSize,omitempty"`
+}
+
+func (x *PurgeResponse) Reset() {
+	*x = PurgeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PurgeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeResponse) ProtoMessage() {}
+
+func (x *PurgeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeResponse.ProtoReflect.Descriptor instead.
+func (*PurgeResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PurgeResponse) GetObjectCount() int64 {
+	if x != nil {
+		return x.ObjectCount
+	}
+	return 0
+}
+
+func (x *PurgeResponse) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type ImportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Imported int64 `protobuf:"varint,1,opt,name=Imported,proto3" json:"Imported,omitempty"`
+	Skipped  int64 `protobuf:"varint,2,opt,name=Skipped,proto3" json:"Skipped,omitempty"`
+	Invalid  int64 `protobuf:"varint,3,opt,name=Invalid,proto3" json:"Invalid,omitempty"`
+}
+
+func (x *ImportResponse) Reset() {
+	*x = ImportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportResponse) ProtoMessage() {}
+
+func (x *ImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportResponse.ProtoReflect.Descriptor instead.
+func (*ImportResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ImportResponse) GetImported() int64 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportResponse) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ImportResponse) GetInvalid() int64 {
+	if x != nil {
+		return x.Invalid
+	}
+	return 0
+}
+
+type CacheObject struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data     []byte           `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
+	Metadata *CacheObjectMeta `protobuf:"bytes,2,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+}
+
+func (x *CacheObject) Reset() {
+	*x = CacheObject{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CacheObject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheObject) ProtoMessage() {}
+
+func (x *CacheObject) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheObject.ProtoReflect.Descriptor instead.
+func (*CacheObject) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CacheObject) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CacheObject) GetMetadata() *CacheObjectMeta {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type CacheObjectMeta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tags           map[string]string   `protobuf:"bytes,1,rep,name=Tags,proto3" json:"Tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ExpirationDate int64               `protobuf:"varint,4,opt,name=ExpirationDate,proto3" json:"ExpirationDate,omitempty"`
+	ManagedFields  *CacheObjectManaged `protobuf:"bytes,5,opt,name=ManagedFields,proto3" json:"ManagedFields,omitempty"`
+}
+
+func (x *CacheObjectMeta) Reset() {
+	*x = CacheObjectMeta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CacheObjectMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheObjectMeta) ProtoMessage() {}
+
+func (x *CacheObjectMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheObjectMeta.ProtoReflect.Descriptor instead.
+func (*CacheObjectMeta) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CacheObjectMeta) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CacheObjectMeta) GetExpirationDate() int64 {
+	if x != nil {
+		return x.ExpirationDate
+	}
+	return 0
+}
+
+func (x *CacheObjectMeta) GetManagedFields() *CacheObjectManaged {
+	if x != nil {
+		return x.ManagedFields
+	}
+	return nil
+}
+
+type CacheObjectManaged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size      int64           `protobuf:"varint,1,opt,name=Size,proto3" json:"Size,omitempty"`
+	Timestamp int64           `protobuf:"varint,2,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	Score     int64           `protobuf:"varint,3,opt,name=Score,proto3" json:"Score,omitempty"`
+	Location  StorageLocation `protobuf:"varint,5,opt,name=Location,proto3,enum=types.StorageLocation" json:"Location,omitempty"`
+	Hits      int64           `protobuf:"varint,6,opt,name=Hits,proto3" json:"Hits,omitempty"`
+	Digest    string          `protobuf:"bytes,7,opt,name=Digest,proto3" json:"Digest,omitempty"`
+}
+
+func (x *CacheObjectManaged) Reset() {
+	*x = CacheObjectManaged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CacheObjectManaged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheObjectManaged) ProtoMessage() {}
+
+func (x *CacheObjectManaged) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheObjectManaged.ProtoReflect.Descriptor instead.
+func (*CacheObjectManaged) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CacheObjectManaged) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *CacheObjectManaged) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *CacheObjectManaged) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *CacheObjectManaged) GetLocation() StorageLocation {
+	if x != nil {
+		return x.Location
+	}
+	return StorageLocation_StorageLocation_Unknown
+}
+
+func (x *CacheObjectManaged) GetHits() int64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *CacheObjectManaged) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+type CacheIndexEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *CacheObjectMeta `protobuf:"bytes,1,opt,name=Metadata,proto3" json:"Metadata,omitempty"`
+	FileSize int64            `protobuf:"varint,2,opt,name=FileSize,proto3" json:"FileSize,omitempty"`
+}
+
+func (x *CacheIndexEntry) Reset() {
+	*x = CacheIndexEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CacheIndexEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheIndexEntry) ProtoMessage() {}
+
+func (x *CacheIndexEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheIndexEntry.ProtoReflect.Descriptor instead.
+func (*CacheIndexEntry) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CacheIndexEntry) GetMetadata() *CacheObjectMeta {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CacheIndexEntry) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+type WhoisRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UUID string `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+}
+
+func (x *WhoisRequest) Reset() {
+	*x = WhoisRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WhoisRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhoisRequest) ProtoMessage() {}
+
+func (x *WhoisRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhoisRequest.ProtoReflect.Descriptor instead.
+func (*WhoisRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WhoisRequest) GetUUID() string {
+	if x != nil {
+		return x.UUID
+	}
+	return ""
+}
+
+type WhoisResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UUID      string    `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	Address   string    `protobuf:"bytes,2,opt,name=Address,proto3" json:"Address,omitempty"`
+	Component Component `protobuf:"varint,3,opt,name=Component,proto3,enum=types.Component" json:"Component,omitempty"`
+	Hostname  string    `protobuf:"bytes,4,opt,name=Hostname,proto3" json:"Hostname,omitempty"`
+}
+
+func (x *WhoisResponse) Reset() {
+	*x = WhoisResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WhoisResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhoisResponse) ProtoMessage() {}
+
+func (x *WhoisResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhoisResponse.ProtoReflect.Descriptor instead.
+func (*WhoisResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *WhoisResponse) GetUUID() string {
+	if x != nil {
+		return x.UUID
+	}
+	return ""
+}
+
+func (x *WhoisResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *WhoisResponse) GetComponent() Component {
+	if x != nil {
+		return x.Component
+	}
+	return Component_Component_Unknown
+}
+
+func (x *WhoisResponse) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+type Metric struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   *Key       `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Value *anypb.Any `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+}
+
+func (x *Metric) Reset() {
+	*x = Metric{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Metric) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metric) ProtoMessage() {}
+
+func (x *Metric) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metric.ProtoReflect.Descriptor instead.
+func (*Metric) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Metric) GetKey() *Key {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *Metric) GetValue() *anypb.Any {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type Key struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bucket string `protobuf:"bytes,1,opt,name=Bucket,proto3" json:"Bucket,omitempty"`
+	Name   string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+}
+
+func (x *Key) Reset() {
+	*x = Key{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Key) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Key) ProtoMessage() {}
+
+func (x *Key) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Key.ProtoReflect.Descriptor instead.
+func (*Key) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Key) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *Key) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Bucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+}
+
+func (x *Bucket) Reset() {
+	*x = Bucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Bucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bucket) ProtoMessage() {}
+
+func (x *Bucket) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bucket.ProtoReflect.Descriptor instead.
+func (*Bucket) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Bucket) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type BucketList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Buckets []*Bucket `protobuf:"bytes,1,rep,name=Buckets,proto3" json:"Buckets,omitempty"`
+}
+
+func (x *BucketList) Reset() {
+	*x = BucketList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BucketList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketList) ProtoMessage() {}
+
+func (x *BucketList) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketList.ProtoReflect.Descriptor instead.
+func (*BucketList) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *BucketList) GetBuckets() []*Bucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type KeyList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys []*Key `protobuf:"bytes,1,rep,name=Keys,proto3" json:"Keys,omitempty"`
+}
+
+func (x *KeyList) Reset() {
+	*x = KeyList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyList) ProtoMessage() {}
+
+func (x *KeyList) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyList.ProtoReflect.Descriptor instead.
+func (*KeyList) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *KeyList) GetKeys() []*Key {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type RouteList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Routes []*Route `protobuf:"bytes,1,rep,name=Routes,proto3" json:"Routes,omitempty"`
+}
+
+func (x *RouteList) Reset() {
+	*x = RouteList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteList) ProtoMessage() {}
+
+func (x *RouteList) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteList.ProtoReflect.Descriptor instead.
+func (*RouteList) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RouteList) GetRoutes() []*Route {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+type Route struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Toolchain  *Toolchain `protobuf:"bytes,1,opt,name=Toolchain,proto3" json:"Toolchain,omitempty"`
+	Consumerds []string   `protobuf:"bytes,2,rep,name=Consumerds,proto3" json:"Consumerds,omitempty"`
+	Agents     []string   `protobuf:"bytes,3,rep,name=Agents,proto3" json:"Agents,omitempty"`
+}
+
+func (x *Route) Reset() {
+	*x = Route{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Route) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Route) ProtoMessage() {}
+
+func (x *Route) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Route.ProtoReflect.Descriptor instead.
+func (*Route) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Route) GetToolchain() *Toolchain {
+	if x != nil {
+		return x.Toolchain
+	}
+	return nil
+}
+
+func (x *Route) GetConsumerds() []string {
+	if x != nil {
+		return x.Consumerds
+	}
+	return nil
+}
+
+func (x *Route) GetAgents() []string {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+type DrainAgentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UUID   string      `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	Action DrainAction `protobuf:"varint,2,opt,name=Action,proto3,enum=types.DrainAction" json:"Action,omitempty"`
+}
+
+func (x *DrainAgentRequest) Reset() {
+	*x = DrainAgentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DrainAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainAgentRequest) ProtoMessage() {}
+
+func (x *DrainAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainAgentRequest.ProtoReflect.Descriptor instead.
+func (*DrainAgentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DrainAgentRequest) GetUUID() string {
+	if x != nil {
+		return x.UUID
+	}
+	return ""
+}
+
+func (x *DrainAgentRequest) GetAction() DrainAction {
+	if x != nil {
+		return x.Action
+	}
+	return DrainAction_DrainAction_Drain
+}
+
+type DrainAgentProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UUID         string `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	Cordoned     bool   `protobuf:"varint,2,opt,name=Cordoned,proto3" json:"Cordoned,omitempty"`
+	RunningTasks int32  `protobuf:"varint,3,opt,name=RunningTasks,proto3" json:"RunningTasks,omitempty"`
+	Drained      bool   `protobuf:"varint,4,opt,name=Drained,proto3" json:"Drained,omitempty"`
+}
+
+func (x *DrainAgentProgress) Reset() {
+	*x = DrainAgentProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DrainAgentProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainAgentProgress) ProtoMessage() {}
+
+func (x *DrainAgentProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainAgentProgress.ProtoReflect.Descriptor instead.
+func (*DrainAgentProgress) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DrainAgentProgress) GetUUID() string {
+	if x != nil {
+		return x.UUID
+	}
+	return ""
+}
+
+func (x *DrainAgentProgress) GetCordoned() bool {
+	if x != nil {
+		return x.Cordoned
+	}
+	return false
+}
+
+func (x *DrainAgentProgress) GetRunningTasks() int32 {
+	if x != nil {
+		return x.RunningTasks
+	}
+	return 0
 }
 
-func (x *Bucket) GetName() string {
+func (x *DrainAgentProgress) GetDrained() bool {
 	if x != nil {
-		return x.Name
+		return x.Drained
 	}
-	return ""
+	return false
 }
 
-type BucketList struct {
+type ListTasksRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Buckets []*Bucket `protobuf:"bytes,1,rep,name=Buckets,proto3" json:"Buckets,omitempty"`
+	Watch    bool  `protobuf:"varint,1,opt,name=Watch,proto3" json:"Watch,omitempty"`
+	Interval int64 `protobuf:"varint,2,opt,name=Interval,proto3" json:"Interval,omitempty"`
 }
 
-func (x *BucketList) Reset() {
-	*x = BucketList{}
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[15]
+		mi := &file_pkg_types_types_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BucketList) String() string {
+func (x *ListTasksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BucketList) ProtoMessage() {}
+func (*ListTasksRequest) ProtoMessage() {}
 
-func (x *BucketList) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[15]
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1187,43 +2458,59 @@ func (x *BucketList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BucketList.ProtoReflect.Descriptor instead.
-func (*BucketList) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *BucketList) GetBuckets() []*Bucket {
+func (x *ListTasksRequest) GetWatch() bool {
 	if x != nil {
-		return x.Buckets
+		return x.Watch
 	}
-	return nil
+	return false
 }
 
-type KeyList struct {
+func (x *ListTasksRequest) GetInterval() int64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+type TaskInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Keys []*Key `protobuf:"bytes,1,rep,name=Keys,proto3" json:"Keys,omitempty"`
+	RequestID   string        `protobuf:"bytes,1,opt,name=RequestID,proto3" json:"RequestID,omitempty"`
+	State       TaskState     `protobuf:"varint,2,opt,name=State,proto3,enum=types.TaskState" json:"State,omitempty"`
+	Consumerd   string        `protobuf:"bytes,3,opt,name=Consumerd,proto3" json:"Consumerd,omitempty"`
+	Agent       string        `protobuf:"bytes,4,opt,name=Agent,proto3" json:"Agent,omitempty"`
+	Toolchain   *Toolchain    `protobuf:"bytes,5,opt,name=Toolchain,proto3" json:"Toolchain,omitempty"`
+	Priority    PriorityClass `protobuf:"varint,6,opt,name=Priority,proto3,enum=types.PriorityClass" json:"Priority,omitempty"`
+	Tenant      string        `protobuf:"bytes,7,opt,name=Tenant,proto3" json:"Tenant,omitempty"`
+	Attempts    int32         `protobuf:"varint,8,opt,name=Attempts,proto3" json:"Attempts,omitempty"`
+	QueueTime   int64         `protobuf:"varint,9,opt,name=QueueTime,proto3" json:"QueueTime,omitempty"`
+	ElapsedTime int64         `protobuf:"varint,10,opt,name=ElapsedTime,proto3" json:"ElapsedTime,omitempty"`
 }
 
-func (x *KeyList) Reset() {
-	*x = KeyList{}
+func (x *TaskInfo) Reset() {
+	*x = TaskInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[16]
+		mi := &file_pkg_types_types_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *KeyList) String() string {
+func (x *TaskInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyList) ProtoMessage() {}
+func (*TaskInfo) ProtoMessage() {}
 
-func (x *KeyList) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[16]
+func (x *TaskInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1234,92 +2521,106 @@ func (x *KeyList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyList.ProtoReflect.Descriptor instead.
-func (*KeyList) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use TaskInfo.ProtoReflect.Descriptor instead.
+func (*TaskInfo) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *KeyList) GetKeys() []*Key {
+func (x *TaskInfo) GetRequestID() string {
 	if x != nil {
-		return x.Keys
+		return x.RequestID
 	}
-	return nil
+	return ""
 }
 
-type RouteList struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *TaskInfo) GetState() TaskState {
+	if x != nil {
+		return x.State
+	}
+	return TaskState_TaskState_Pending
+}
 
-	Routes []*Route `protobuf:"bytes,1,rep,name=Routes,proto3" json:"Routes,omitempty"`
+func (x *TaskInfo) GetConsumerd() string {
+	if x != nil {
+		return x.Consumerd
+	}
+	return ""
 }
 
-func (x *RouteList) Reset() {
-	*x = RouteList{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[17]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *TaskInfo) GetAgent() string {
+	if x != nil {
+		return x.Agent
 	}
+	return ""
 }
 
-func (x *RouteList) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *TaskInfo) GetToolchain() *Toolchain {
+	if x != nil {
+		return x.Toolchain
+	}
+	return nil
 }
 
-func (*RouteList) ProtoMessage() {}
+func (x *TaskInfo) GetPriority() PriorityClass {
+	if x != nil {
+		return x.Priority
+	}
+	return PriorityClass_PriorityClass_Default
+}
 
-func (x *RouteList) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[17]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *TaskInfo) GetTenant() string {
+	if x != nil {
+		return x.Tenant
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use RouteList.ProtoReflect.Descriptor instead.
-func (*RouteList) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{17}
+func (x *TaskInfo) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
 }
 
-func (x *RouteList) GetRoutes() []*Route {
+func (x *TaskInfo) GetQueueTime() int64 {
 	if x != nil {
-		return x.Routes
+		return x.QueueTime
 	}
-	return nil
+	return 0
 }
 
-type Route struct {
+func (x *TaskInfo) GetElapsedTime() int64 {
+	if x != nil {
+		return x.ElapsedTime
+	}
+	return 0
+}
+
+type TaskList struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Toolchain  *Toolchain `protobuf:"bytes,1,opt,name=Toolchain,proto3" json:"Toolchain,omitempty"`
-	Consumerds []string   `protobuf:"bytes,2,rep,name=Consumerds,proto3" json:"Consumerds,omitempty"`
-	Agents     []string   `protobuf:"bytes,3,rep,name=Agents,proto3" json:"Agents,omitempty"`
+	Items []*TaskInfo `protobuf:"bytes,1,rep,name=Items,proto3" json:"Items,omitempty"`
 }
 
-func (x *Route) Reset() {
-	*x = Route{}
+func (x *TaskList) Reset() {
+	*x = TaskList{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[18]
+		mi := &file_pkg_types_types_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Route) String() string {
+func (x *TaskList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Route) ProtoMessage() {}
+func (*TaskList) ProtoMessage() {}
 
-func (x *Route) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[18]
+func (x *TaskList) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1330,28 +2631,14 @@ func (x *Route) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Route.ProtoReflect.Descriptor instead.
-func (*Route) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{18}
-}
-
-func (x *Route) GetToolchain() *Toolchain {
-	if x != nil {
-		return x.Toolchain
-	}
-	return nil
-}
-
-func (x *Route) GetConsumerds() []string {
-	if x != nil {
-		return x.Consumerds
-	}
-	return nil
+// Deprecated: Use TaskList.ProtoReflect.Descriptor instead.
+func (*TaskList) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *Route) GetAgents() []string {
+func (x *TaskList) GetItems() []*TaskInfo {
 	if x != nil {
-		return x.Agents
+		return x.Items
 	}
 	return nil
 }
@@ -1373,7 +2660,7 @@ type Toolchain struct {
 func (x *Toolchain) Reset() {
 	*x = Toolchain{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[19]
+		mi := &file_pkg_types_types_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1386,7 +2673,7 @@ func (x *Toolchain) String() string {
 func (*Toolchain) ProtoMessage() {}
 
 func (x *Toolchain) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[19]
+	mi := &file_pkg_types_types_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1399,7 +2686,7 @@ func (x *Toolchain) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Toolchain.ProtoReflect.Descriptor instead.
 func (*Toolchain) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{19}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *Toolchain) GetKind() ToolchainKind {
@@ -1462,7 +2749,7 @@ type ToolchainList struct {
 func (x *ToolchainList) Reset() {
 	*x = ToolchainList{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[20]
+		mi := &file_pkg_types_types_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1475,7 +2762,7 @@ func (x *ToolchainList) String() string {
 func (*ToolchainList) ProtoMessage() {}
 
 func (x *ToolchainList) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[20]
+	mi := &file_pkg_types_types_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1488,7 +2775,7 @@ func (x *ToolchainList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToolchainList.ProtoReflect.Descriptor instead.
 func (*ToolchainList) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{20}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *ToolchainList) GetItems() []*Toolchain {
@@ -1511,7 +2798,7 @@ type AgentToolchainInfo struct {
 func (x *AgentToolchainInfo) Reset() {
 	*x = AgentToolchainInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[21]
+		mi := &file_pkg_types_types_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1524,7 +2811,7 @@ func (x *AgentToolchainInfo) String() string {
 func (*AgentToolchainInfo) ProtoMessage() {}
 
 func (x *AgentToolchainInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[21]
+	mi := &file_pkg_types_types_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1537,7 +2824,7 @@ func (x *AgentToolchainInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentToolchainInfo.ProtoReflect.Descriptor instead.
 func (*AgentToolchainInfo) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{21}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *AgentToolchainInfo) GetKind() string {
@@ -1572,7 +2859,7 @@ type AgentToolchainInfoList struct {
 func (x *AgentToolchainInfoList) Reset() {
 	*x = AgentToolchainInfoList{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[22]
+		mi := &file_pkg_types_types_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1585,7 +2872,7 @@ func (x *AgentToolchainInfoList) String() string {
 func (*AgentToolchainInfoList) ProtoMessage() {}
 
 func (x *AgentToolchainInfoList) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[22]
+	mi := &file_pkg_types_types_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1598,7 +2885,7 @@ func (x *AgentToolchainInfoList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentToolchainInfoList.ProtoReflect.Descriptor instead.
 func (*AgentToolchainInfoList) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{22}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *AgentToolchainInfoList) GetInfo() []*AgentToolchainInfo {
@@ -1623,12 +2910,14 @@ type RunRequest struct {
 	WorkDir  string                `protobuf:"bytes,6,opt,name=WorkDir,proto3" json:"WorkDir,omitempty"`
 	Env      []string              `protobuf:"bytes,7,rep,name=Env,proto3" json:"Env,omitempty"`
 	Stdin    []byte                `protobuf:"bytes,8,opt,name=Stdin,proto3" json:"Stdin,omitempty"`
+	Priority PriorityClass         `protobuf:"varint,9,opt,name=Priority,proto3,enum=types.PriorityClass" json:"Priority,omitempty"`
+	Tenant   string                `protobuf:"bytes,10,opt,name=Tenant,proto3" json:"Tenant,omitempty"`
 }
 
 func (x *RunRequest) Reset() {
 	*x = RunRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[23]
+		mi := &file_pkg_types_types_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1641,7 +2930,7 @@ func (x *RunRequest) String() string {
 func (*RunRequest) ProtoMessage() {}
 
 func (x *RunRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[23]
+	mi := &file_pkg_types_types_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1654,7 +2943,7 @@ func (x *RunRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunRequest.ProtoReflect.Descriptor instead.
 func (*RunRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{23}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{41}
 }
 
 func (m *RunRequest) GetCompiler() isRunRequest_Compiler {
@@ -1720,6 +3009,20 @@ func (x *RunRequest) GetStdin() []byte {
 	return nil
 }
 
+func (x *RunRequest) GetPriority() PriorityClass {
+	if x != nil {
+		return x.Priority
+	}
+	return PriorityClass_PriorityClass_Default
+}
+
+func (x *RunRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
 type isRunRequest_Compiler interface {
 	isRunRequest_Compiler()
 }
@@ -1749,7 +3052,7 @@ type RunResponse struct {
 func (x *RunResponse) Reset() {
 	*x = RunResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[24]
+		mi := &file_pkg_types_types_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1762,7 +3065,7 @@ func (x *RunResponse) String() string {
 func (*RunResponse) ProtoMessage() {}
 
 func (x *RunResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[24]
+	mi := &file_pkg_types_types_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1775,7 +3078,7 @@ func (x *RunResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunResponse.ProtoReflect.Descriptor instead.
 func (*RunResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{24}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *RunResponse) GetReturnCode() int32 {
@@ -1808,7 +3111,7 @@ type ScheduleRequest struct {
 func (x *ScheduleRequest) Reset() {
 	*x = ScheduleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[25]
+		mi := &file_pkg_types_types_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1821,7 +3124,7 @@ func (x *ScheduleRequest) String() string {
 func (*ScheduleRequest) ProtoMessage() {}
 
 func (x *ScheduleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[25]
+	mi := &file_pkg_types_types_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1834,7 +3137,7 @@ func (x *ScheduleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleRequest.ProtoReflect.Descriptor instead.
 func (*ScheduleRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{25}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{43}
 }
 
 type ScheduleResponse struct {
@@ -1846,7 +3149,7 @@ type ScheduleResponse struct {
 func (x *ScheduleResponse) Reset() {
 	*x = ScheduleResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[26]
+		mi := &file_pkg_types_types_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1859,7 +3162,7 @@ func (x *ScheduleResponse) String() string {
 func (*ScheduleResponse) ProtoMessage() {}
 
 func (x *ScheduleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[26]
+	mi := &file_pkg_types_types_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1872,7 +3175,7 @@ func (x *ScheduleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleResponse.ProtoReflect.Descriptor instead.
 func (*ScheduleResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{26}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{44}
 }
 
 type CompileRequest struct {
@@ -1885,12 +3188,14 @@ type CompileRequest struct {
 	Args               []string               `protobuf:"bytes,3,rep,name=Args,proto3" json:"Args,omitempty"`
 	PreprocessedSource []byte                 `protobuf:"bytes,4,opt,name=PreprocessedSource,proto3" json:"PreprocessedSource,omitempty"`
 	ManagedFields      *CompileRequestManaged `protobuf:"bytes,5,opt,name=ManagedFields,proto3" json:"ManagedFields,omitempty"`
+	Priority           PriorityClass          `protobuf:"varint,6,opt,name=Priority,proto3,enum=types.PriorityClass" json:"Priority,omitempty"`
+	Tenant             string                 `protobuf:"bytes,7,opt,name=Tenant,proto3" json:"Tenant,omitempty"`
 }
 
 func (x *CompileRequest) Reset() {
 	*x = CompileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[27]
+		mi := &file_pkg_types_types_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1903,7 +3208,7 @@ func (x *CompileRequest) String() string {
 func (*CompileRequest) ProtoMessage() {}
 
 func (x *CompileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[27]
+	mi := &file_pkg_types_types_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1916,7 +3221,7 @@ func (x *CompileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompileRequest.ProtoReflect.Descriptor instead.
 func (*CompileRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{27}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *CompileRequest) GetRequestID() string {
@@ -1954,6 +3259,20 @@ func (x *CompileRequest) GetManagedFields() *CompileRequestManaged {
 	return nil
 }
 
+func (x *CompileRequest) GetPriority() PriorityClass {
+	if x != nil {
+		return x.Priority
+	}
+	return PriorityClass_PriorityClass_Default
+}
+
+func (x *CompileRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
 type CompileRequestManaged struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1965,7 +3284,7 @@ type CompileRequestManaged struct {
 func (x *CompileRequestManaged) Reset() {
 	*x = CompileRequestManaged{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[28]
+		mi := &file_pkg_types_types_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1978,7 +3297,7 @@ func (x *CompileRequestManaged) String() string {
 func (*CompileRequestManaged) ProtoMessage() {}
 
 func (x *CompileRequestManaged) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[28]
+	mi := &file_pkg_types_types_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1991,7 +3310,7 @@ func (x *CompileRequestManaged) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompileRequestManaged.ProtoReflect.Descriptor instead.
 func (*CompileRequestManaged) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{28}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *CompileRequestManaged) GetComputedHash() string {
@@ -2013,13 +3332,17 @@ type CompileResponse struct {
 	//	*CompileResponse_Error
 	//	*CompileResponse_CompiledSource
 	//	*CompileResponse_RetryAction
-	Data isCompileResponse_Data `protobuf_oneof:"Data"`
+	Data       isCompileResponse_Data `protobuf_oneof:"Data"`
+	Stdout     []byte                 `protobuf:"bytes,7,opt,name=Stdout,proto3" json:"Stdout,omitempty"`
+	Stderr     []byte                 `protobuf:"bytes,8,opt,name=Stderr,proto3" json:"Stderr,omitempty"`
+	ReturnCode int32                  `protobuf:"varint,9,opt,name=ReturnCode,proto3" json:"ReturnCode,omitempty"`
+	Digest     string                 `protobuf:"bytes,10,opt,name=Digest,proto3" json:"Digest,omitempty"`
 }
 
 func (x *CompileResponse) Reset() {
 	*x = CompileResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[29]
+		mi := &file_pkg_types_types_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2032,7 +3355,7 @@ func (x *CompileResponse) String() string {
 func (*CompileResponse) ProtoMessage() {}
 
 func (x *CompileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[29]
+	mi := &file_pkg_types_types_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2045,7 +3368,7 @@ func (x *CompileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompileResponse.ProtoReflect.Descriptor instead.
 func (*CompileResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{29}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *CompileResponse) GetRequestID() string {
@@ -2097,6 +3420,34 @@ func (x *CompileResponse) GetRetryAction() RetryAction {
 	return RetryAction_RetryAction_Unknown
 }
 
+func (x *CompileResponse) GetStdout() []byte {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *CompileResponse) GetStderr() []byte {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *CompileResponse) GetReturnCode() int32 {
+	if x != nil {
+		return x.ReturnCode
+	}
+	return 0
+}
+
+func (x *CompileResponse) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
 type isCompileResponse_Data interface {
 	isCompileResponse_Data()
 }
@@ -2119,6 +3470,77 @@ func (*CompileResponse_CompiledSource) isCompileResponse_Data() {}
 
 func (*CompileResponse_RetryAction) isCompileResponse_Data() {}
 
+type CompileOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CompiledSource []byte `protobuf:"bytes,1,opt,name=CompiledSource,proto3" json:"CompiledSource,omitempty"`
+	Stdout         []byte `protobuf:"bytes,2,opt,name=Stdout,proto3" json:"Stdout,omitempty"`
+	Stderr         []byte `protobuf:"bytes,3,opt,name=Stderr,proto3" json:"Stderr,omitempty"`
+	Digest         string `protobuf:"bytes,4,opt,name=Digest,proto3" json:"Digest,omitempty"`
+}
+
+func (x *CompileOutput) Reset() {
+	*x = CompileOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_types_types_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompileOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompileOutput) ProtoMessage() {}
+
+func (x *CompileOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_types_types_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompileOutput.ProtoReflect.Descriptor instead.
+func (*CompileOutput) Descriptor() ([]byte, []int) {
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CompileOutput) GetCompiledSource() []byte {
+	if x != nil {
+		return x.CompiledSource
+	}
+	return nil
+}
+
+func (x *CompileOutput) GetStdout() []byte {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *CompileOutput) GetStderr() []byte {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *CompileOutput) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
 type SystemInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2128,12 +3550,14 @@ type SystemInfo struct {
 	CpuThreads   int32  `protobuf:"varint,2,opt,name=CpuThreads,proto3" json:"CpuThreads,omitempty"`
 	SystemMemory uint64 `protobuf:"varint,3,opt,name=SystemMemory,proto3" json:"SystemMemory,omitempty"`
 	Hostname     string `protobuf:"bytes,4,opt,name=Hostname,proto3" json:"Hostname,omitempty"`
+	Node         string `protobuf:"bytes,5,opt,name=Node,proto3" json:"Node,omitempty"`
+	Zone         string `protobuf:"bytes,6,opt,name=Zone,proto3" json:"Zone,omitempty"`
 }
 
 func (x *SystemInfo) Reset() {
 	*x = SystemInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_types_types_proto_msgTypes[30]
+		mi := &file_pkg_types_types_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2146,7 +3570,7 @@ func (x *SystemInfo) String() string {
 func (*SystemInfo) ProtoMessage() {}
 
 func (x *SystemInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_types_types_proto_msgTypes[30]
+	mi := &file_pkg_types_types_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2159,7 +3583,7 @@ func (x *SystemInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemInfo.ProtoReflect.Descriptor instead.
 func (*SystemInfo) Descriptor() ([]byte, []int) {
-	return file_pkg_types_types_proto_rawDescGZIP(), []int{30}
+	return file_pkg_types_types_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *SystemInfo) GetArch() string {
@@ -2190,6 +3614,20 @@ func (x *SystemInfo) GetHostname() string {
 	return ""
 }
 
+func (x *SystemInfo) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *SystemInfo) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
 var File_pkg_types_types_proto protoreflect.FileDescriptor
 
 var file_pkg_types_types_proto_rawDesc = []byte{
@@ -2218,41 +3656,120 @@ var file_pkg_types_types_proto_rawDesc = []byte{
 	0x32, 0x0f, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4b, 0x65,
 	0x79, 0x42, 0x00, 0x12, 0x1c, 0x0a, 0x12, 0x44, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x43, 0x61,
 	0x63, 0x68, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x4b, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42,
-	0x00, 0x3a, 0x00, 0x22, 0x1c, 0x0a, 0x08, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4b, 0x65, 0x79, 0x12,
-	0x0e, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x3a,
-	0x00, 0x22, 0x4b, 0x0a, 0x0b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
-	0x12, 0x0e, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x00,
-	0x12, 0x2a, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01,
+	0x00, 0x3a, 0x00, 0x22, 0x54, 0x0a, 0x0a, 0x53, 0x79, 0x6e, 0x63, 0x4f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x12, 0x1e, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4b, 0x65, 0x79, 0x42,
+	0x00, 0x12, 0x24, 0x0a, 0x06, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x1c, 0x0a, 0x08, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x0e, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x00, 0x3a, 0x00, 0x22, 0xae, 0x01, 0x0a, 0x0b, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x0a, 0x54, 0x6f, 0x6f, 0x6c, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x42, 0x00, 0x12, 0x2a, 0x0a,
+	0x04, 0x54, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x2e,
+	0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x06, 0x4d, 0x69, 0x6e,
+	0x41, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x10, 0x0a, 0x06, 0x4d,
+	0x61, 0x78, 0x41, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x1a, 0x37, 0x0a,
+	0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x3a, 0x00, 0x22, 0x37, 0x0a, 0x0d, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x06, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x42, 0x00, 0x3a,
+	0x00, 0x22, 0x20, 0x0a, 0x0c, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x12, 0x0e, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x42,
+	0x00, 0x3a, 0x00, 0x22, 0x4d, 0x0a, 0x0d, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x00,
+	0x12, 0x0e, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x00,
+	0x3a, 0x00, 0x22, 0x68, 0x0a, 0x0a, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x26, 0x0a, 0x05, 0x54, 0x69, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x54, 0x69, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x42, 0x00, 0x12, 0x30, 0x0a, 0x0a, 0x54, 0x6f, 0x6f, 0x6c,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x42, 0x00, 0x3a, 0x00, 0x22, 0xd7, 0x01, 0x0a,
+	0x0e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x54, 0x69, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x2a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x00, 0x12, 0x15, 0x0a, 0x0b, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x42, 0x00, 0x12, 0x13, 0x0a, 0x09, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x16, 0x0a, 0x0c, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x42, 0x00, 0x12,
+	0x18, 0x0a, 0x0e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61,
+	0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x1a, 0x0a, 0x10, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x1d, 0x0a, 0x13, 0x43, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x58, 0x0a, 0x13, 0x43, 0x61, 0x63, 0x68, 0x65, 0x54,
+	0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x13, 0x0a,
+	0x09, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x00, 0x12, 0x15, 0x0a, 0x0b, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x13, 0x0a, 0x09, 0x54, 0x6f, 0x74,
+	0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a, 0x00,
+	0x22, 0x3c, 0x0a, 0x0f, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x54, 0x69, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
 	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65,
-	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x42, 0x00, 0x3a, 0x00, 0x22, 0xca,
-	0x01, 0x0a, 0x0f, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4d, 0x65,
-	0x74, 0x61, 0x12, 0x2e, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x20, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4f, 0x62,
-	0x6a, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x18, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x44, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x32, 0x0a, 0x0d,
-	0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68,
-	0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x42, 0x00,
-	0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x3a, 0x00, 0x22, 0x78, 0x0a, 0x12, 0x43,
-	0x61, 0x63, 0x68, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65,
-	0x64, 0x12, 0x0e, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42,
-	0x00, 0x12, 0x13, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x0f, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x2a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x20, 0x0a, 0x0c, 0x57, 0x68, 0x6f, 0x69, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x5b, 0x0a, 0x0d, 0x57, 0x68, 0x6f, 0x69, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x11, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x25, 0x0a, 0x09, 0x43,
-	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10,
-	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x48,
+	0x0a, 0x0c, 0x50, 0x75, 0x72, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24,
+	0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x42, 0x00, 0x12, 0x10, 0x0a, 0x06, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x3d, 0x0a, 0x0d, 0x50, 0x75, 0x72, 0x67,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x15, 0x0a, 0x0b, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00,
+	0x12, 0x13, 0x0a, 0x09, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x4c, 0x0a, 0x0e, 0x49, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x08, 0x49, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x11, 0x0a,
+	0x07, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00,
+	0x12, 0x11, 0x0a, 0x07, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x4b, 0x0a, 0x0b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x12, 0x0e, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x42, 0x00, 0x12, 0x2a, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x42, 0x00,
+	0x3a, 0x00, 0x22, 0xca, 0x01, 0x0a, 0x0f, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x2e, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x2e, 0x54, 0x61, 0x67,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00,
+	0x12, 0x32, 0x0a, 0x0d, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x64, 0x42, 0x00, 0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x3a, 0x00, 0x22,
+	0x9a, 0x01, 0x0a, 0x12, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x12, 0x0e, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x13, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x0f, 0x0a, 0x05, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x2a, 0x0a, 0x08,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x00, 0x12, 0x0e, 0x0a, 0x04, 0x48, 0x69, 0x74, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x10, 0x0a, 0x06, 0x44, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x53, 0x0a, 0x0f,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x2a, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x42, 0x00, 0x12, 0x12, 0x0a, 0x08, 0x46,
+	0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a,
+	0x00, 0x22, 0x20, 0x0a, 0x0c, 0x57, 0x68, 0x6f, 0x69, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x00, 0x3a, 0x00, 0x22, 0x6f, 0x0a, 0x0d, 0x57, 0x68, 0x6f, 0x69, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x00, 0x12, 0x11, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x25, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x70, 0x6f,
+	0x6e, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x42, 0x00, 0x12, 0x12,
+	0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
 	0x42, 0x00, 0x3a, 0x00, 0x22, 0x4c, 0x0a, 0x06, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x19,
 	0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x74, 0x79,
 	0x70, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x42, 0x00, 0x12, 0x25, 0x0a, 0x05, 0x56, 0x61, 0x6c,
@@ -2276,200 +3793,305 @@ var file_pkg_types_types_proto_rawDesc = []byte{
 	0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69,
 	0x6e, 0x42, 0x00, 0x12, 0x14, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x64,
 	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x42, 0x00, 0x12, 0x10, 0x0a, 0x06, 0x41, 0x67, 0x65,
-	0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x42, 0x00, 0x3a, 0x00, 0x22, 0xc4, 0x01,
-	0x0a, 0x09, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x24, 0x0a, 0x04, 0x4b,
-	0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x2e, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x42,
-	0x00, 0x12, 0x24, 0x0a, 0x04, 0x4c, 0x61, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x14, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69,
-	0x6e, 0x4c, 0x61, 0x6e, 0x67, 0x42, 0x00, 0x12, 0x14, 0x0a, 0x0a, 0x45, 0x78, 0x65, 0x63, 0x75,
-	0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x14, 0x0a,
-	0x0a, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x41, 0x72, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x42, 0x00, 0x12, 0x11, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x14, 0x0a, 0x0a, 0x50, 0x69, 0x63, 0x44, 0x65, 0x66,
-	0x61, 0x75, 0x6c, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x42, 0x00, 0x12, 0x14, 0x0a, 0x0a,
-	0x50, 0x69, 0x65, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
-	0x42, 0x00, 0x3a, 0x00, 0x22, 0x34, 0x0a, 0x0d, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69,
-	0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6f,
-	0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x4c, 0x0a, 0x12, 0x41, 0x67,
-	0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
-	0x12, 0x0e, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00,
-	0x12, 0x10, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x42, 0x00, 0x12, 0x12, 0x0a, 0x08, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x09, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x45, 0x0a, 0x16, 0x41, 0x67, 0x65, 0x6e,
-	0x74, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x4c, 0x69,
-	0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x54, 0x6f,
-	0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x00, 0x3a, 0x00, 0x22,
-	0xb8, 0x01, 0x0a, 0x0a, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
-	0x0a, 0x04, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x48, 0x00,
-	0x12, 0x27, 0x0a, 0x09, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6f, 0x6c,
-	0x63, 0x68, 0x61, 0x69, 0x6e, 0x42, 0x00, 0x48, 0x00, 0x12, 0x0e, 0x0a, 0x04, 0x41, 0x72, 0x67,
-	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x42, 0x00, 0x12, 0x0d, 0x0a, 0x03, 0x55, 0x49, 0x44,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x00, 0x12, 0x0d, 0x0a, 0x03, 0x47, 0x49, 0x44, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x00, 0x12, 0x11, 0x0a, 0x07, 0x57, 0x6f, 0x72, 0x6b, 0x44,
-	0x69, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x0d, 0x0a, 0x03, 0x45, 0x6e,
-	0x76, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x42, 0x00, 0x12, 0x0f, 0x0a, 0x05, 0x53, 0x74, 0x64,
-	0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x00, 0x3a, 0x00, 0x42, 0x0c, 0x0a, 0x08,
-	0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72, 0x12, 0x00, 0x22, 0x49, 0x0a, 0x0b, 0x52, 0x75,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x0a, 0x52, 0x65, 0x74,
-	0x75, 0x72, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x00, 0x12,
-	0x10, 0x0a, 0x06, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x42,
-	0x00, 0x12, 0x10, 0x0a, 0x06, 0x53, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x13, 0x0a, 0x0f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x3a, 0x00, 0x22, 0x14, 0x0a, 0x10, 0x53, 0x63,
-	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x3a, 0x00,
-	0x22, 0xb3, 0x01, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x13, 0x0a, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x25, 0x0a, 0x09, 0x54, 0x6f, 0x6f, 0x6c,
-	0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79,
-	0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x42, 0x00, 0x12,
-	0x0e, 0x0a, 0x04, 0x41, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x42, 0x00, 0x12,
-	0x1c, 0x0a, 0x12, 0x50, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x53,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x00, 0x12, 0x35, 0x0a,
-	0x0d, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d,
-	0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x61, 0x6e, 0x61, 0x67,
-	0x65, 0x64, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x31, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x12,
-	0x16, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x3a, 0x00, 0x22, 0xae, 0x02, 0x0a, 0x0f, 0x43, 0x6f,
-	0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x13, 0x0a,
-	0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x42, 0x00, 0x12, 0x36, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x4b, 0x0a,
+	0x11, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x00, 0x12, 0x24, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x12, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x44, 0x72, 0x61, 0x69, 0x6e,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x65, 0x0a, 0x12, 0x44, 0x72,
+	0x61, 0x69, 0x6e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x0e, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00,
+	0x12, 0x12, 0x0a, 0x08, 0x43, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x42, 0x00, 0x12, 0x16, 0x0a, 0x0c, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x54,
+	0x61, 0x73, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x42, 0x00, 0x12, 0x11, 0x0a, 0x07,
+	0x44, 0x72, 0x61, 0x69, 0x6e, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x42, 0x00, 0x3a,
+	0x00, 0x22, 0x39, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0f, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x42, 0x00, 0x12, 0x12, 0x0a, 0x08, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x8d, 0x02, 0x0a,
+	0x08, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x13, 0x0a, 0x09, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x21,
+	0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x42,
+	0x00, 0x12, 0x13, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x0f, 0x0a, 0x05, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x25, 0x0a, 0x09, 0x54, 0x6f, 0x6f, 0x6c, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x42, 0x00, 0x12, 0x28,
+	0x0a, 0x08, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
+	0x79, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x42, 0x00, 0x12, 0x10, 0x0a, 0x06, 0x54, 0x65, 0x6e, 0x61,
+	0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x12, 0x0a, 0x08, 0x41, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x42, 0x00, 0x12, 0x13,
+	0x0a, 0x09, 0x51, 0x75, 0x65, 0x75, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x03, 0x42, 0x00, 0x12, 0x15, 0x0a, 0x0b, 0x45, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x2e, 0x0a, 0x08,
+	0x54, 0x61, 0x73, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x05, 0x49, 0x74, 0x65, 0x6d,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x54, 0x61, 0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x00, 0x3a, 0x00, 0x22, 0xc4, 0x01, 0x0a,
+	0x09, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x24, 0x0a, 0x04, 0x4b, 0x69,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x42, 0x00,
+	0x12, 0x24, 0x0a, 0x04, 0x4c, 0x61, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x4c, 0x61, 0x6e, 0x67, 0x42, 0x00, 0x12, 0x14, 0x0a, 0x0a, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x14, 0x0a, 0x0a,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x41, 0x72, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x00, 0x12, 0x11, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x14, 0x0a, 0x0a, 0x50, 0x69, 0x63, 0x44, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x42, 0x00, 0x12, 0x14, 0x0a, 0x0a, 0x50,
+	0x69, 0x65, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x42,
+	0x00, 0x3a, 0x00, 0x22, 0x34, 0x0a, 0x0d, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6f, 0x6c,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x4c, 0x0a, 0x12, 0x41, 0x67, 0x65,
+	0x6e, 0x74, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x0e, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12,
+	0x10, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x00, 0x12, 0x12, 0x0a, 0x08, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x45, 0x0a, 0x16, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x29, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6f,
+	0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x00, 0x3a, 0x00, 0x22, 0xf4,
+	0x01, 0x0a, 0x0a, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
+	0x04, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x48, 0x00, 0x12,
+	0x27, 0x0a, 0x09, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6f, 0x6c, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x42, 0x00, 0x48, 0x00, 0x12, 0x0e, 0x0a, 0x04, 0x41, 0x72, 0x67, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x42, 0x00, 0x12, 0x0d, 0x0a, 0x03, 0x55, 0x49, 0x44, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x00, 0x12, 0x0d, 0x0a, 0x03, 0x47, 0x49, 0x44, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x42, 0x00, 0x12, 0x11, 0x0a, 0x07, 0x57, 0x6f, 0x72, 0x6b, 0x44, 0x69,
+	0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x0d, 0x0a, 0x03, 0x45, 0x6e, 0x76,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x42, 0x00, 0x12, 0x0f, 0x0a, 0x05, 0x53, 0x74, 0x64, 0x69,
+	0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x00, 0x12, 0x28, 0x0a, 0x08, 0x50, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43, 0x6c, 0x61, 0x73,
+	0x73, 0x42, 0x00, 0x12, 0x10, 0x0a, 0x06, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x00, 0x3a, 0x00, 0x42, 0x0c, 0x0a, 0x08, 0x43, 0x6f, 0x6d, 0x70, 0x69,
+	0x6c, 0x65, 0x72, 0x12, 0x00, 0x22, 0x49, 0x0a, 0x0b, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x0a, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x43, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x00, 0x12, 0x10, 0x0a, 0x06, 0x53, 0x74,
+	0x64, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x00, 0x12, 0x10, 0x0a, 0x06,
+	0x53, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x00, 0x3a, 0x00,
+	0x22, 0x13, 0x0a, 0x0f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x3a, 0x00, 0x22, 0x14, 0x0a, 0x10, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x3a, 0x00, 0x22, 0xef, 0x01, 0x0a, 0x0e,
+	0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x13,
+	0x0a, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x00, 0x12, 0x25, 0x0a, 0x09, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54,
+	0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x42, 0x00, 0x12, 0x0e, 0x0a, 0x04, 0x41, 0x72,
+	0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x42, 0x00, 0x12, 0x1c, 0x0a, 0x12, 0x50, 0x72,
+	0x65, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x00, 0x12, 0x35, 0x0a, 0x0d, 0x4d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x42, 0x00, 0x12,
+	0x28, 0x0a, 0x08, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x42, 0x00, 0x12, 0x10, 0x0a, 0x06, 0x54, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x3a, 0x00, 0x22, 0x31, 0x0a,
+	0x15, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x3a, 0x00,
+	0x22, 0xfa, 0x02, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x13, 0x0a, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x36, 0x0a, 0x0d, 0x43, 0x6f, 0x6d,
+	0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x42,
+	0x00, 0x12, 0x18, 0x0a, 0x0e, 0x43, 0x70, 0x75, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x55,
+	0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x42, 0x00, 0x12, 0x11, 0x0a, 0x05, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x48, 0x00, 0x12, 0x1a,
+	0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x00, 0x48, 0x00, 0x12, 0x2b, 0x0a, 0x0b, 0x52, 0x65,
+	0x74, 0x72, 0x79, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x12, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x42, 0x00, 0x48, 0x00, 0x12, 0x10, 0x0a, 0x06, 0x53, 0x74, 0x64, 0x6f, 0x75,
+	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x00, 0x12, 0x10, 0x0a, 0x06, 0x53, 0x74, 0x64,
+	0x65, 0x72, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x00, 0x12, 0x14, 0x0a, 0x0a, 0x52,
+	0x65, 0x74, 0x75, 0x72, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x42,
+	0x00, 0x12, 0x10, 0x0a, 0x06, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x00, 0x22, 0x4c, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0b, 0x0a,
+	0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x61,
+	0x69, 0x6c, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x65, 0x66, 0x75, 0x6e,
+	0x63, 0x74, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x65, 0x74, 0x72, 0x79, 0x10, 0x04, 0x1a,
+	0x00, 0x3a, 0x00, 0x42, 0x08, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x00, 0x22, 0x61, 0x0a,
+	0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x18,
+	0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x00, 0x12, 0x10, 0x0a, 0x06, 0x53, 0x74, 0x64, 0x6f,
+	0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x00, 0x12, 0x10, 0x0a, 0x06, 0x53, 0x74,
+	0x64, 0x65, 0x72, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x00, 0x12, 0x10, 0x0a, 0x06,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x3a, 0x00,
+	0x22, 0x80, 0x01, 0x0a, 0x0a, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x0e, 0x0a, 0x04, 0x41, 0x72, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12,
+	0x14, 0x0a, 0x0a, 0x43, 0x70, 0x75, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x42, 0x00, 0x12, 0x16, 0x0a, 0x0c, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x42, 0x00, 0x12, 0x12, 0x0a,
+	0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x00, 0x12, 0x0e, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x00, 0x12, 0x0e, 0x0a, 0x04, 0x5a, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x00, 0x3a, 0x00, 0x2a, 0x99, 0x01, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x55, 0x6e, 0x6b, 0x6e, 0x6f,
+	0x77, 0x6e, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x10, 0x01,
+	0x12, 0x18, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x44, 0x69, 0x73, 0x6b, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x53, 0x33,
+	0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x52, 0x65, 0x64, 0x69, 0x73, 0x10, 0x04, 0x1a, 0x00, 0x2a,
+	0x58, 0x0a, 0x0b, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15,
+	0x0a, 0x11, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x44, 0x72,
+	0x61, 0x69, 0x6e, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x43, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x10, 0x01, 0x12, 0x18, 0x0a,
+	0x14, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x55, 0x6e, 0x63,
+	0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x10, 0x02, 0x1a, 0x00, 0x2a, 0x54, 0x0a, 0x09, 0x54, 0x61, 0x73,
+	0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x5f, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x10, 0x00, 0x12, 0x15, 0x0a,
+	0x11, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x52, 0x75, 0x6e, 0x6e, 0x69,
+	0x6e, 0x67, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x5f, 0x43, 0x6f, 0x61, 0x6c, 0x65, 0x73, 0x63, 0x65, 0x64, 0x10, 0x02, 0x1a, 0x00, 0x2a,
+	0x9d, 0x02, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x15, 0x0a,
+	0x11, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x55, 0x6e, 0x6b, 0x6e, 0x6f,
+	0x77, 0x6e, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x5f, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x6f, 0x6d,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72,
+	0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12,
+	0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d,
+	0x65, 0x72, 0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x5f, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x64, 0x10, 0x05, 0x12, 0x12, 0x0a,
+	0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x4d, 0x61, 0x6b, 0x65, 0x10,
+	0x06, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x54,
+	0x65, 0x73, 0x74, 0x10, 0x07, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x5f, 0x44, 0x61, 0x73, 0x68, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x10, 0x08, 0x12, 0x11,
+	0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x43, 0x4c, 0x49, 0x10,
+	0x09, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x4d,
+	0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x10, 0x0a, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x43, 0x61, 0x63, 0x68, 0x65, 0x10, 0x0b, 0x1a, 0x00, 0x2a,
+	0x8d, 0x01, 0x0a, 0x0d, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4b, 0x69, 0x6e,
+	0x64, 0x12, 0x19, 0x0a, 0x15, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4b, 0x69,
+	0x6e, 0x64, 0x5f, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11,
+	0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x5f, 0x47, 0x6e,
+	0x75, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x4b, 0x69, 0x6e, 0x64, 0x5f, 0x43, 0x6c, 0x61, 0x6e, 0x67, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12,
+	0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x5f, 0x54, 0x65,
+	0x73, 0x74, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x5f, 0x53, 0x6c, 0x65, 0x65, 0x70, 0x10, 0x04, 0x1a, 0x00, 0x2a,
+	0x71, 0x0a, 0x0d, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4c, 0x61, 0x6e, 0x67,
+	0x12, 0x19, 0x0a, 0x15, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4c, 0x61, 0x6e,
+	0x67, 0x5f, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x54,
+	0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4c, 0x61, 0x6e, 0x67, 0x5f, 0x43, 0x10, 0x01,
+	0x12, 0x15, 0x0a, 0x11, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4c, 0x61, 0x6e,
+	0x67, 0x5f, 0x43, 0x58, 0x58, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x54, 0x6f, 0x6f, 0x6c, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x4c, 0x61, 0x6e, 0x67, 0x5f, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x10, 0x03,
+	0x1a, 0x00, 0x2a, 0x7a, 0x0a, 0x0d, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43, 0x6c,
+	0x61, 0x73, 0x73, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x10, 0x00, 0x12, 0x1d,
+	0x0a, 0x19, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x5f,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x10, 0x01, 0x12, 0x14, 0x0a,
+	0x10, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x43,
+	0x49, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x5f, 0x42, 0x61, 0x74, 0x63, 0x68, 0x10, 0x03, 0x1a, 0x00, 0x2a, 0x43,
+	0x0a, 0x0b, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a,
+	0x13, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x55, 0x6e, 0x6b,
+	0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x65, 0x74, 0x72, 0x79, 0x10,
+	0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x6f, 0x4e, 0x6f, 0x74, 0x52, 0x65, 0x74, 0x72, 0x79, 0x10,
+	0x02, 0x1a, 0x00, 0x32, 0x7c, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x64,
+	0x12, 0x32, 0x0a, 0x03, 0x52, 0x75, 0x6e, 0x12, 0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x28, 0x00, 0x30, 0x00, 0x12, 0x39, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6f, 0x6c, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6f, 0x6c,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x1a,
+	0x00, 0x32, 0x9e, 0x03, 0x0a, 0x09, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x12,
+	0x3e, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x12, 0x15, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x12,
+	0x4a, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e,
+	0x67, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43,
+	0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x15,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x4a, 0x0a, 0x13, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x54, 0x61, 0x73,
+	0x6b, 0x73, 0x12, 0x15, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69,
+	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65,
 	0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x42, 0x00, 0x12, 0x18, 0x0a, 0x0e, 0x43, 0x70,
-	0x75, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x55, 0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x03, 0x42, 0x00, 0x12, 0x11, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x42, 0x00, 0x48, 0x00, 0x12, 0x1a, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x69,
-	0x6c, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x42,
-	0x00, 0x48, 0x00, 0x12, 0x2b, 0x0a, 0x0b, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
-	0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x00, 0x48, 0x00,
-	0x22, 0x4c, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x75,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x61, 0x69, 0x6c, 0x10,
-	0x01, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x65, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x10,
-	0x03, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x65, 0x74, 0x72, 0x79, 0x10, 0x04, 0x1a, 0x00, 0x3a, 0x00,
-	0x42, 0x08, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x00, 0x22, 0x60, 0x0a, 0x0a, 0x53, 0x79,
-	0x73, 0x74, 0x65, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x04, 0x41, 0x72, 0x63, 0x68,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x12, 0x14, 0x0a, 0x0a, 0x43, 0x70, 0x75, 0x54,
-	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x42, 0x00, 0x12, 0x16,
-	0x0a, 0x0c, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x04, 0x42, 0x00, 0x12, 0x12, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x00, 0x3a, 0x00, 0x2a, 0x7e, 0x0a, 0x0f,
-	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x1b, 0x0a, 0x17, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16,
-	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
-	0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x72,
-	0x61, 0x67, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x44, 0x69, 0x73, 0x6b,
-	0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x6f, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x53, 0x33, 0x10, 0x03, 0x1a, 0x00, 0x2a, 0x9d, 0x02, 0x0a,
-	0x09, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x6f,
-	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10,
-	0x00, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x41,
-	0x67, 0x65, 0x6e, 0x74, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e,
-	0x65, 0x6e, 0x74, 0x5f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x10, 0x02, 0x12,
-	0x18, 0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x43, 0x6f, 0x6e,
-	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x43, 0x6f, 0x6d,
-	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x10,
-	0x04, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x43,
-	0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x64, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x6f,
-	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x4d, 0x61, 0x6b, 0x65, 0x10, 0x06, 0x12, 0x12,
-	0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x54, 0x65, 0x73, 0x74,
-	0x10, 0x07, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f,
-	0x44, 0x61, 0x73, 0x68, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x10, 0x08, 0x12, 0x11, 0x0a, 0x0d, 0x43,
-	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x43, 0x4c, 0x49, 0x10, 0x09, 0x12, 0x15,
-	0x0a, 0x11, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x4d, 0x6f, 0x6e, 0x69,
-	0x74, 0x6f, 0x72, 0x10, 0x0a, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65,
-	0x6e, 0x74, 0x5f, 0x43, 0x61, 0x63, 0x68, 0x65, 0x10, 0x0b, 0x1a, 0x00, 0x2a, 0x8d, 0x01, 0x0a,
-	0x0d, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x19,
-	0x0a, 0x15, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x5f,
-	0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x6f, 0x6f,
-	0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x5f, 0x47, 0x6e, 0x75, 0x10, 0x01,
-	0x12, 0x17, 0x0a, 0x13, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4b, 0x69, 0x6e,
-	0x64, 0x5f, 0x43, 0x6c, 0x61, 0x6e, 0x67, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x54, 0x6f, 0x6f,
-	0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x5f, 0x54, 0x65, 0x73, 0x74, 0x10,
-	0x03, 0x12, 0x17, 0x0a, 0x13, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4b, 0x69,
-	0x6e, 0x64, 0x5f, 0x53, 0x6c, 0x65, 0x65, 0x70, 0x10, 0x04, 0x1a, 0x00, 0x2a, 0x71, 0x0a, 0x0d,
-	0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4c, 0x61, 0x6e, 0x67, 0x12, 0x19, 0x0a,
-	0x15, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4c, 0x61, 0x6e, 0x67, 0x5f, 0x55,
-	0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x54, 0x6f, 0x6f, 0x6c,
-	0x63, 0x68, 0x61, 0x69, 0x6e, 0x4c, 0x61, 0x6e, 0x67, 0x5f, 0x43, 0x10, 0x01, 0x12, 0x15, 0x0a,
-	0x11, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4c, 0x61, 0x6e, 0x67, 0x5f, 0x43,
-	0x58, 0x58, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69,
-	0x6e, 0x4c, 0x61, 0x6e, 0x67, 0x5f, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x10, 0x03, 0x1a, 0x00, 0x2a,
-	0x43, 0x0a, 0x0b, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17,
-	0x0a, 0x13, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x55, 0x6e,
-	0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x65, 0x74, 0x72, 0x79,
-	0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x6f, 0x4e, 0x6f, 0x74, 0x52, 0x65, 0x74, 0x72, 0x79,
-	0x10, 0x02, 0x1a, 0x00, 0x32, 0x7c, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72,
-	0x64, 0x12, 0x32, 0x0a, 0x03, 0x52, 0x75, 0x6e, 0x12, 0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
-	0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x74, 0x79,
-	0x70, 0x65, 0x73, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x28, 0x00, 0x30, 0x00, 0x12, 0x39, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6f, 0x6c,
-	0x63, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6f,
-	0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00,
-	0x1a, 0x00, 0x32, 0x98, 0x02, 0x0a, 0x09, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72,
-	0x12, 0x3e, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x12, 0x15, 0x2e, 0x74, 0x79,
-	0x70, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69,
-	0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00,
-	0x12, 0x4a, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x63, 0x6f, 0x6d, 0x69,
-	0x6e, 0x67, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
-	0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a,
-	0x15, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x4a, 0x0a, 0x13,
-	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x54, 0x61,
-	0x73, 0x6b, 0x73, 0x12, 0x15, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
-	0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x74, 0x79, 0x70,
-	0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x31, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x52,
-	0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x52, 0x6f, 0x75, 0x74,
-	0x65, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x1a, 0x00, 0x32, 0xb7, 0x02,
-	0x0a, 0x07, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x2b, 0x0a, 0x06, 0x53, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x12, 0x0d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4d, 0x65, 0x74, 0x72,
-	0x69, 0x63, 0x1a, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x2c, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x74,
-	0x72, 0x69, 0x63, 0x12, 0x0a, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x1a,
-	0x0d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x22, 0x00,
-	0x28, 0x00, 0x30, 0x00, 0x12, 0x33, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x42, 0x75, 0x63, 0x6b, 0x65,
-	0x74, 0x73, 0x12, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x1a, 0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x4c,
-	0x69, 0x73, 0x74, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x12, 0x2e, 0x0a, 0x07, 0x47, 0x65, 0x74,
-	0x4b, 0x65, 0x79, 0x73, 0x12, 0x0d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42, 0x75, 0x63,
-	0x6b, 0x65, 0x74, 0x1a, 0x0e, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x4c,
-	0x69, 0x73, 0x74, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x12, 0x30, 0x0a, 0x06, 0x4c, 0x69, 0x73,
-	0x74, 0x65, 0x6e, 0x12, 0x0a, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x1a,
-	0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x41, 0x6e, 0x79, 0x22, 0x00, 0x28, 0x00, 0x30, 0x01, 0x12, 0x38, 0x0a, 0x05, 0x57,
-	0x68, 0x6f, 0x69, 0x73, 0x12, 0x13, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x57, 0x68, 0x6f,
-	0x69, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x2e, 0x57, 0x68, 0x6f, 0x69, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x28, 0x00, 0x30, 0x00, 0x1a, 0x00, 0x32, 0xdf, 0x01, 0x0a, 0x05, 0x43, 0x61, 0x63, 0x68,
-	0x65, 0x12, 0x2e, 0x0a, 0x04, 0x50, 0x75, 0x73, 0x68, 0x12, 0x12, 0x2e, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e,
-	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x28, 0x00, 0x30,
-	0x00, 0x12, 0x34, 0x0a, 0x04, 0x50, 0x75, 0x6c, 0x6c, 0x12, 0x12, 0x2e, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x2e, 0x50, 0x75, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e,
-	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63,
-	0x74, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x12, 0x38, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x12, 0x13, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x75,
-	0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x00, 0x30,
-	0x00, 0x12, 0x34, 0x0a, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x12, 0x2e, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e,
-	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63,
-	0x74, 0x22, 0x00, 0x28, 0x00, 0x30, 0x01, 0x1a, 0x00, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x63, 0x63, 0x2d, 0x69,
-	0x6f, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x63, 0x63, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x74, 0x79, 0x70,
-	0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x31, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x52, 0x6f,
+	0x75, 0x74, 0x65, 0x73, 0x12, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x12, 0x47, 0x0a, 0x0a, 0x44, 0x72,
+	0x61, 0x69, 0x6e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x44, 0x72, 0x61, 0x69, 0x6e,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0x00, 0x28,
+	0x00, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x73,
+	0x12, 0x17, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x73,
+	0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x28, 0x00, 0x30, 0x01,
+	0x1a, 0x00, 0x32, 0xb7, 0x02, 0x0a, 0x07, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x2b,
+	0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x0d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x1a, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x2c, 0x0a, 0x09, 0x47,
+	0x65, 0x74, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x0a, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x4b, 0x65, 0x79, 0x1a, 0x0d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x12, 0x33, 0x0a, 0x0a, 0x47, 0x65, 0x74,
+	0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x12, 0x2e,
+	0x0a, 0x07, 0x47, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x0d, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x1a, 0x0e, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x4b, 0x65, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x12, 0x30,
+	0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x12, 0x0a, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x4b, 0x65, 0x79, 0x1a, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x22, 0x00, 0x28, 0x00, 0x30, 0x01,
+	0x12, 0x38, 0x0a, 0x05, 0x57, 0x68, 0x6f, 0x69, 0x73, 0x12, 0x13, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2e, 0x57, 0x68, 0x6f, 0x69, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x57, 0x68, 0x6f, 0x69, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x1a, 0x00, 0x32, 0xa7, 0x04, 0x0a,
+	0x05, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x50, 0x75, 0x73, 0x68, 0x12, 0x12,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x12, 0x34, 0x0a, 0x04, 0x50, 0x75, 0x6c, 0x6c, 0x12, 0x12,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x50, 0x75, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x12, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x12, 0x38, 0x0a, 0x05,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x13, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x12, 0x33, 0x0a, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x12,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x22, 0x00, 0x28, 0x00, 0x30, 0x01, 0x12, 0x39, 0x0a, 0x06, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x22, 0x00, 0x28, 0x00, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x06, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74,
+	0x12, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x49,
+	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28,
+	0x01, 0x30, 0x00, 0x12, 0x2e, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x0c, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0x00, 0x28,
+	0x00, 0x30, 0x00, 0x12, 0x38, 0x0a, 0x07, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x12, 0x0f,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4b, 0x65, 0x79, 0x1a,
+	0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x12, 0x38, 0x0a,
+	0x05, 0x50, 0x75, 0x72, 0x67, 0x65, 0x12, 0x13, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x50,
+	0x75, 0x72, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x28, 0x00, 0x30, 0x00, 0x12, 0x2b, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0f, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4b, 0x65, 0x79, 0x22, 0x00,
+	0x28, 0x00, 0x30, 0x01, 0x1a, 0x00, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x63, 0x63, 0x2d, 0x69, 0x6f, 0x2f, 0x6b,
+	0x75, 0x62, 0x65, 0x63, 0x63, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2484,113 +4106,170 @@ func file_pkg_types_types_proto_rawDescGZIP() []byte {
 	return file_pkg_types_types_proto_rawDescData
 }
 
-var file_pkg_types_types_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
-var file_pkg_types_types_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_pkg_types_types_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_pkg_types_types_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
 var file_pkg_types_types_proto_goTypes = []interface{}{
 	(StorageLocation)(0),           // 0: types.StorageLocation
-	(Component)(0),                 // 1: types.Component
-	(ToolchainKind)(0),             // 2: types.ToolchainKind
-	(ToolchainLang)(0),             // 3: types.ToolchainLang
-	(RetryAction)(0),               // 4: types.RetryAction
-	(CompileResponse_Result)(0),    // 5: types.CompileResponse.Result
-	(*Empty)(nil),                  // 6: types.Empty
-	(*PushRequest)(nil),            // 7: types.PushRequest
-	(*PullRequest)(nil),            // 8: types.PullRequest
-	(*QueryRequest)(nil),           // 9: types.QueryRequest
-	(*QueryResponse)(nil),          // 10: types.QueryResponse
-	(*SyncRequest)(nil),            // 11: types.SyncRequest
-	(*CacheKey)(nil),               // 12: types.CacheKey
-	(*CacheObject)(nil),            // 13: types.CacheObject
-	(*CacheObjectMeta)(nil),        // 14: types.CacheObjectMeta
-	(*CacheObjectManaged)(nil),     // 15: types.CacheObjectManaged
-	(*WhoisRequest)(nil),           // 16: types.WhoisRequest
-	(*WhoisResponse)(nil),          // 17: types.WhoisResponse
-	(*Metric)(nil),                 // 18: types.Metric
-	(*Key)(nil),                    // 19: types.Key
-	(*Bucket)(nil),                 // 20: types.Bucket
-	(*BucketList)(nil),             // 21: types.BucketList
-	(*KeyList)(nil),                // 22: types.KeyList
-	(*RouteList)(nil),              // 23: types.RouteList
-	(*Route)(nil),                  // 24: types.Route
-	(*Toolchain)(nil),              // 25: types.Toolchain
-	(*ToolchainList)(nil),          // 26: types.ToolchainList
-	(*AgentToolchainInfo)(nil),     // 27: types.AgentToolchainInfo
-	(*AgentToolchainInfoList)(nil), // 28: types.AgentToolchainInfoList
-	(*RunRequest)(nil),             // 29: types.RunRequest
-	(*RunResponse)(nil),            // 30: types.RunResponse
-	(*ScheduleRequest)(nil),        // 31: types.ScheduleRequest
-	(*ScheduleResponse)(nil),       // 32: types.ScheduleResponse
-	(*CompileRequest)(nil),         // 33: types.CompileRequest
-	(*CompileRequestManaged)(nil),  // 34: types.CompileRequestManaged
-	(*CompileResponse)(nil),        // 35: types.CompileResponse
-	(*SystemInfo)(nil),             // 36: types.SystemInfo
-	nil,                            // 37: types.CacheObjectMeta.TagsEntry
-	(*anypb.Any)(nil),              // 38: google.protobuf.Any
+	(DrainAction)(0),               // 1: types.DrainAction
+	(TaskState)(0),                 // 2: types.TaskState
+	(Component)(0),                 // 3: types.Component
+	(ToolchainKind)(0),             // 4: types.ToolchainKind
+	(ToolchainLang)(0),             // 5: types.ToolchainLang
+	(PriorityClass)(0),             // 6: types.PriorityClass
+	(RetryAction)(0),               // 7: types.RetryAction
+	(CompileResponse_Result)(0),    // 8: types.CompileResponse.Result
+	(*Empty)(nil),                  // 9: types.Empty
+	(*PushRequest)(nil),            // 10: types.PushRequest
+	(*PullRequest)(nil),            // 11: types.PullRequest
+	(*QueryRequest)(nil),           // 12: types.QueryRequest
+	(*QueryResponse)(nil),          // 13: types.QueryResponse
+	(*SyncRequest)(nil),            // 14: types.SyncRequest
+	(*SyncObject)(nil),             // 15: types.SyncObject
+	(*CacheKey)(nil),               // 16: types.CacheKey
+	(*CacheFilter)(nil),            // 17: types.CacheFilter
+	(*ExportRequest)(nil),          // 18: types.ExportRequest
+	(*ArchiveChunk)(nil),           // 19: types.ArchiveChunk
+	(*ImportRequest)(nil),          // 20: types.ImportRequest
+	(*CacheStats)(nil),             // 21: types.CacheStats
+	(*CacheTierStats)(nil),         // 22: types.CacheTierStats
+	(*CacheToolchainStats)(nil),    // 23: types.CacheToolchainStats
+	(*InspectResponse)(nil),        // 24: types.InspectResponse
+	(*PurgeRequest)(nil),           // 25: types.PurgeRequest
+	(*PurgeResponse)(nil),          // 26: types.PurgeResponse
+	(*ImportResponse)(nil),         // 27: types.ImportResponse
+	(*CacheObject)(nil),            // 28: types.CacheObject
+	(*CacheObjectMeta)(nil),        // 29: types.CacheObjectMeta
+	(*CacheObjectManaged)(nil),     // 30: types.CacheObjectManaged
+	(*CacheIndexEntry)(nil),        // 31: types.CacheIndexEntry
+	(*WhoisRequest)(nil),           // 32: types.WhoisRequest
+	(*WhoisResponse)(nil),          // 33: types.WhoisResponse
+	(*Metric)(nil),                 // 34: types.Metric
+	(*Key)(nil),                    // 35: types.Key
+	(*Bucket)(nil),                 // 36: types.Bucket
+	(*BucketList)(nil),             // 37: types.BucketList
+	(*KeyList)(nil),                // 38: types.KeyList
+	(*RouteList)(nil),              // 39: types.RouteList
+	(*Route)(nil),                  // 40: types.Route
+	(*DrainAgentRequest)(nil),      // 41: types.DrainAgentRequest
+	(*DrainAgentProgress)(nil),     // 42: types.DrainAgentProgress
+	(*ListTasksRequest)(nil),       // 43: types.ListTasksRequest
+	(*TaskInfo)(nil),               // 44: types.TaskInfo
+	(*TaskList)(nil),               // 45: types.TaskList
+	(*Toolchain)(nil),              // 46: types.Toolchain
+	(*ToolchainList)(nil),          // 47: types.ToolchainList
+	(*AgentToolchainInfo)(nil),     // 48: types.AgentToolchainInfo
+	(*AgentToolchainInfoList)(nil), // 49: types.AgentToolchainInfoList
+	(*RunRequest)(nil),             // 50: types.RunRequest
+	(*RunResponse)(nil),            // 51: types.RunResponse
+	(*ScheduleRequest)(nil),        // 52: types.ScheduleRequest
+	(*ScheduleResponse)(nil),       // 53: types.ScheduleResponse
+	(*CompileRequest)(nil),         // 54: types.CompileRequest
+	(*CompileRequestManaged)(nil),  // 55: types.CompileRequestManaged
+	(*CompileResponse)(nil),        // 56: types.CompileResponse
+	(*CompileOutput)(nil),          // 57: types.CompileOutput
+	(*SystemInfo)(nil),             // 58: types.SystemInfo
+	nil,                            // 59: types.CacheFilter.TagsEntry
+	nil,                            // 60: types.CacheObjectMeta.TagsEntry
+	(*anypb.Any)(nil),              // 61: google.protobuf.Any
 }
 var file_pkg_types_types_proto_depIdxs = []int32{
-	12, // 0: types.PushRequest.Key:type_name -> types.CacheKey
-	13, // 1: types.PushRequest.Object:type_name -> types.CacheObject
-	12, // 2: types.PullRequest.Key:type_name -> types.CacheKey
-	12, // 3: types.QueryRequest.Keys:type_name -> types.CacheKey
-	14, // 4: types.QueryResponse.Results:type_name -> types.CacheObjectMeta
-	12, // 5: types.SyncRequest.LocalCache:type_name -> types.CacheKey
-	14, // 6: types.CacheObject.Metadata:type_name -> types.CacheObjectMeta
-	37, // 7: types.CacheObjectMeta.Tags:type_name -> types.CacheObjectMeta.TagsEntry
-	15, // 8: types.CacheObjectMeta.ManagedFields:type_name -> types.CacheObjectManaged
-	0,  // 9: types.CacheObjectManaged.Location:type_name -> types.StorageLocation
-	1,  // 10: types.WhoisResponse.Component:type_name -> types.Component
-	19, // 11: types.Metric.Key:type_name -> types.Key
-	38, // 12: types.Metric.Value:type_name -> google.protobuf.Any
-	20, // 13: types.BucketList.Buckets:type_name -> types.Bucket
-	19, // 14: types.KeyList.Keys:type_name -> types.Key
-	24, // 15: types.RouteList.Routes:type_name -> types.Route
-	25, // 16: types.Route.Toolchain:type_name -> types.Toolchain
-	2,  // 17: types.Toolchain.Kind:type_name -> types.ToolchainKind
-	3,  // 18: types.Toolchain.Lang:type_name -> types.ToolchainLang
-	25, // 19: types.ToolchainList.Items:type_name -> types.Toolchain
-	27, // 20: types.AgentToolchainInfoList.info:type_name -> types.AgentToolchainInfo
-	25, // 21: types.RunRequest.Toolchain:type_name -> types.Toolchain
-	25, // 22: types.CompileRequest.Toolchain:type_name -> types.Toolchain
-	34, // 23: types.CompileRequest.ManagedFields:type_name -> types.CompileRequestManaged
-	5,  // 24: types.CompileResponse.CompileResult:type_name -> types.CompileResponse.Result
-	4,  // 25: types.CompileResponse.RetryAction:type_name -> types.RetryAction
-	29, // 26: types.Consumerd.Run:input_type -> types.RunRequest
-	6,  // 27: types.Consumerd.GetToolchains:input_type -> types.Empty
-	33, // 28: types.Scheduler.Compile:input_type -> types.CompileRequest
-	35, // 29: types.Scheduler.StreamIncomingTasks:input_type -> types.CompileResponse
-	33, // 30: types.Scheduler.StreamOutgoingTasks:input_type -> types.CompileRequest
-	6,  // 31: types.Scheduler.GetRoutes:input_type -> types.Empty
-	18, // 32: types.Monitor.Stream:input_type -> types.Metric
-	19, // 33: types.Monitor.GetMetric:input_type -> types.Key
-	6,  // 34: types.Monitor.GetBuckets:input_type -> types.Empty
-	20, // 35: types.Monitor.GetKeys:input_type -> types.Bucket
-	19, // 36: types.Monitor.Listen:input_type -> types.Key
-	16, // 37: types.Monitor.Whois:input_type -> types.WhoisRequest
-	7,  // 38: types.Cache.Push:input_type -> types.PushRequest
-	8,  // 39: types.Cache.Pull:input_type -> types.PullRequest
-	9,  // 40: types.Cache.Query:input_type -> types.QueryRequest
-	11, // 41: types.Cache.Sync:input_type -> types.SyncRequest
-	30, // 42: types.Consumerd.Run:output_type -> types.RunResponse
-	26, // 43: types.Consumerd.GetToolchains:output_type -> types.ToolchainList
-	35, // 44: types.Scheduler.Compile:output_type -> types.CompileResponse
-	33, // 45: types.Scheduler.StreamIncomingTasks:output_type -> types.CompileRequest
-	35, // 46: types.Scheduler.StreamOutgoingTasks:output_type -> types.CompileResponse
-	23, // 47: types.Scheduler.GetRoutes:output_type -> types.RouteList
-	6,  // 48: types.Monitor.Stream:output_type -> types.Empty
-	18, // 49: types.Monitor.GetMetric:output_type -> types.Metric
-	21, // 50: types.Monitor.GetBuckets:output_type -> types.BucketList
-	22, // 51: types.Monitor.GetKeys:output_type -> types.KeyList
-	38, // 52: types.Monitor.Listen:output_type -> google.protobuf.Any
-	17, // 53: types.Monitor.Whois:output_type -> types.WhoisResponse
-	6,  // 54: types.Cache.Push:output_type -> types.Empty
-	13, // 55: types.Cache.Pull:output_type -> types.CacheObject
-	10, // 56: types.Cache.Query:output_type -> types.QueryResponse
-	13, // 57: types.Cache.Sync:output_type -> types.CacheObject
-	42, // [42:58] is the sub-list for method output_type
-	26, // [26:42] is the sub-list for method input_type
-	26, // [26:26] is the sub-list for extension type_name
-	26, // [26:26] is the sub-list for extension extendee
-	0,  // [0:26] is the sub-list for field type_name
+	16, // 0: types.PushRequest.Key:type_name -> types.CacheKey
+	28, // 1: types.PushRequest.Object:type_name -> types.CacheObject
+	16, // 2: types.PullRequest.Key:type_name -> types.CacheKey
+	16, // 3: types.QueryRequest.Keys:type_name -> types.CacheKey
+	29, // 4: types.QueryResponse.Results:type_name -> types.CacheObjectMeta
+	16, // 5: types.SyncRequest.LocalCache:type_name -> types.CacheKey
+	16, // 6: types.SyncObject.Key:type_name -> types.CacheKey
+	28, // 7: types.SyncObject.Object:type_name -> types.CacheObject
+	59, // 8: types.CacheFilter.Tags:type_name -> types.CacheFilter.TagsEntry
+	17, // 9: types.ExportRequest.Filter:type_name -> types.CacheFilter
+	0,  // 10: types.ImportRequest.Location:type_name -> types.StorageLocation
+	22, // 11: types.CacheStats.Tiers:type_name -> types.CacheTierStats
+	23, // 12: types.CacheStats.Toolchains:type_name -> types.CacheToolchainStats
+	0,  // 13: types.CacheTierStats.Location:type_name -> types.StorageLocation
+	29, // 14: types.InspectResponse.Tiers:type_name -> types.CacheObjectMeta
+	17, // 15: types.PurgeRequest.Filter:type_name -> types.CacheFilter
+	29, // 16: types.CacheObject.Metadata:type_name -> types.CacheObjectMeta
+	60, // 17: types.CacheObjectMeta.Tags:type_name -> types.CacheObjectMeta.TagsEntry
+	30, // 18: types.CacheObjectMeta.ManagedFields:type_name -> types.CacheObjectManaged
+	0,  // 19: types.CacheObjectManaged.Location:type_name -> types.StorageLocation
+	29, // 20: types.CacheIndexEntry.Metadata:type_name -> types.CacheObjectMeta
+	3,  // 21: types.WhoisResponse.Component:type_name -> types.Component
+	35, // 22: types.Metric.Key:type_name -> types.Key
+	61, // 23: types.Metric.Value:type_name -> google.protobuf.Any
+	36, // 24: types.BucketList.Buckets:type_name -> types.Bucket
+	35, // 25: types.KeyList.Keys:type_name -> types.Key
+	40, // 26: types.RouteList.Routes:type_name -> types.Route
+	46, // 27: types.Route.Toolchain:type_name -> types.Toolchain
+	1,  // 28: types.DrainAgentRequest.Action:type_name -> types.DrainAction
+	2,  // 29: types.TaskInfo.State:type_name -> types.TaskState
+	46, // 30: types.TaskInfo.Toolchain:type_name -> types.Toolchain
+	6,  // 31: types.TaskInfo.Priority:type_name -> types.PriorityClass
+	44, // 32: types.TaskList.Items:type_name -> types.TaskInfo
+	4,  // 33: types.Toolchain.Kind:type_name -> types.ToolchainKind
+	5,  // 34: types.Toolchain.Lang:type_name -> types.ToolchainLang
+	46, // 35: types.ToolchainList.Items:type_name -> types.Toolchain
+	48, // 36: types.AgentToolchainInfoList.info:type_name -> types.AgentToolchainInfo
+	46, // 37: types.RunRequest.Toolchain:type_name -> types.Toolchain
+	6,  // 38: types.RunRequest.Priority:type_name -> types.PriorityClass
+	46, // 39: types.CompileRequest.Toolchain:type_name -> types.Toolchain
+	55, // 40: types.CompileRequest.ManagedFields:type_name -> types.CompileRequestManaged
+	6,  // 41: types.CompileRequest.Priority:type_name -> types.PriorityClass
+	8,  // 42: types.CompileResponse.CompileResult:type_name -> types.CompileResponse.Result
+	7,  // 43: types.CompileResponse.RetryAction:type_name -> types.RetryAction
+	50, // 44: types.Consumerd.Run:input_type -> types.RunRequest
+	9,  // 45: types.Consumerd.GetToolchains:input_type -> types.Empty
+	54, // 46: types.Scheduler.Compile:input_type -> types.CompileRequest
+	56, // 47: types.Scheduler.StreamIncomingTasks:input_type -> types.CompileResponse
+	54, // 48: types.Scheduler.StreamOutgoingTasks:input_type -> types.CompileRequest
+	9,  // 49: types.Scheduler.GetRoutes:input_type -> types.Empty
+	41, // 50: types.Scheduler.DrainAgent:input_type -> types.DrainAgentRequest
+	43, // 51: types.Scheduler.ListTasks:input_type -> types.ListTasksRequest
+	34, // 52: types.Monitor.Stream:input_type -> types.Metric
+	35, // 53: types.Monitor.GetMetric:input_type -> types.Key
+	9,  // 54: types.Monitor.GetBuckets:input_type -> types.Empty
+	36, // 55: types.Monitor.GetKeys:input_type -> types.Bucket
+	35, // 56: types.Monitor.Listen:input_type -> types.Key
+	32, // 57: types.Monitor.Whois:input_type -> types.WhoisRequest
+	10, // 58: types.Cache.Push:input_type -> types.PushRequest
+	11, // 59: types.Cache.Pull:input_type -> types.PullRequest
+	12, // 60: types.Cache.Query:input_type -> types.QueryRequest
+	14, // 61: types.Cache.Sync:input_type -> types.SyncRequest
+	18, // 62: types.Cache.Export:input_type -> types.ExportRequest
+	20, // 63: types.Cache.Import:input_type -> types.ImportRequest
+	9,  // 64: types.Cache.Stats:input_type -> types.Empty
+	16, // 65: types.Cache.Inspect:input_type -> types.CacheKey
+	25, // 66: types.Cache.Purge:input_type -> types.PurgeRequest
+	9,  // 67: types.Cache.List:input_type -> types.Empty
+	51, // 68: types.Consumerd.Run:output_type -> types.RunResponse
+	47, // 69: types.Consumerd.GetToolchains:output_type -> types.ToolchainList
+	56, // 70: types.Scheduler.Compile:output_type -> types.CompileResponse
+	54, // 71: types.Scheduler.StreamIncomingTasks:output_type -> types.CompileRequest
+	56, // 72: types.Scheduler.StreamOutgoingTasks:output_type -> types.CompileResponse
+	39, // 73: types.Scheduler.GetRoutes:output_type -> types.RouteList
+	42, // 74: types.Scheduler.DrainAgent:output_type -> types.DrainAgentProgress
+	45, // 75: types.Scheduler.ListTasks:output_type -> types.TaskList
+	9,  // 76: types.Monitor.Stream:output_type -> types.Empty
+	34, // 77: types.Monitor.GetMetric:output_type -> types.Metric
+	37, // 78: types.Monitor.GetBuckets:output_type -> types.BucketList
+	38, // 79: types.Monitor.GetKeys:output_type -> types.KeyList
+	61, // 80: types.Monitor.Listen:output_type -> google.protobuf.Any
+	33, // 81: types.Monitor.Whois:output_type -> types.WhoisResponse
+	9,  // 82: types.Cache.Push:output_type -> types.Empty
+	28, // 83: types.Cache.Pull:output_type -> types.CacheObject
+	13, // 84: types.Cache.Query:output_type -> types.QueryResponse
+	15, // 85: types.Cache.Sync:output_type -> types.SyncObject
+	19, // 86: types.Cache.Export:output_type -> types.ArchiveChunk
+	27, // 87: types.Cache.Import:output_type -> types.ImportResponse
+	21, // 88: types.Cache.Stats:output_type -> types.CacheStats
+	24, // 89: types.Cache.Inspect:output_type -> types.InspectResponse
+	26, // 90: types.Cache.Purge:output_type -> types.PurgeResponse
+	16, // 91: types.Cache.List:output_type -> types.CacheKey
+	68, // [68:92] is the sub-list for method output_type
+	44, // [44:68] is the sub-list for method input_type
+	44, // [44:44] is the sub-list for extension type_name
+	44, // [44:44] is the sub-list for extension extendee
+	0,  // [0:44] is the sub-list for field type_name
 }
 
 func init() { file_pkg_types_types_proto_init() }
@@ -2672,7 +4351,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CacheKey); i {
+			switch v := v.(*SyncObject); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2684,7 +4363,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CacheObject); i {
+			switch v := v.(*CacheKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2696,7 +4375,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CacheObjectMeta); i {
+			switch v := v.(*CacheFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2708,7 +4387,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CacheObjectManaged); i {
+			switch v := v.(*ExportRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2720,7 +4399,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WhoisRequest); i {
+			switch v := v.(*ArchiveChunk); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2732,7 +4411,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WhoisResponse); i {
+			switch v := v.(*ImportRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2744,7 +4423,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Metric); i {
+			switch v := v.(*CacheStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2756,7 +4435,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Key); i {
+			switch v := v.(*CacheTierStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2768,7 +4447,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Bucket); i {
+			switch v := v.(*CacheToolchainStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2780,7 +4459,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BucketList); i {
+			switch v := v.(*InspectResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2792,7 +4471,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeyList); i {
+			switch v := v.(*PurgeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2804,7 +4483,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RouteList); i {
+			switch v := v.(*PurgeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2816,7 +4495,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Route); i {
+			switch v := v.(*ImportResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2828,7 +4507,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Toolchain); i {
+			switch v := v.(*CacheObject); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2840,7 +4519,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ToolchainList); i {
+			switch v := v.(*CacheObjectMeta); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2852,7 +4531,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AgentToolchainInfo); i {
+			switch v := v.(*CacheObjectManaged); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2864,7 +4543,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AgentToolchainInfoList); i {
+			switch v := v.(*CacheIndexEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2876,7 +4555,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RunRequest); i {
+			switch v := v.(*WhoisRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2888,7 +4567,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RunResponse); i {
+			switch v := v.(*WhoisResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2900,7 +4579,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScheduleRequest); i {
+			switch v := v.(*Metric); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2912,7 +4591,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScheduleResponse); i {
+			switch v := v.(*Key); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2924,7 +4603,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompileRequest); i {
+			switch v := v.(*Bucket); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2936,7 +4615,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompileRequestManaged); i {
+			switch v := v.(*BucketList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2948,7 +4627,7 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompileResponse); i {
+			switch v := v.(*KeyList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2960,6 +4639,234 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 		file_pkg_types_types_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Route); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrainAgentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrainAgentProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTasksRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Toolchain); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ToolchainList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AgentToolchainInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AgentToolchainInfoList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompileRequestManaged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompileResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompileOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_types_types_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SystemInfo); i {
 			case 0:
 				return &v.state
@@ -2972,11 +4879,11 @@ func file_pkg_types_types_proto_init() {
 			}
 		}
 	}
-	file_pkg_types_types_proto_msgTypes[23].OneofWrappers = []interface{}{
+	file_pkg_types_types_proto_msgTypes[41].OneofWrappers = []interface{}{
 		(*RunRequest_Path)(nil),
 		(*RunRequest_Toolchain)(nil),
 	}
-	file_pkg_types_types_proto_msgTypes[29].OneofWrappers = []interface{}{
+	file_pkg_types_types_proto_msgTypes[47].OneofWrappers = []interface{}{
 		(*CompileResponse_Error)(nil),
 		(*CompileResponse_CompiledSource)(nil),
 		(*CompileResponse_RetryAction)(nil),
@@ -2986,8 +4893,8 @@ func file_pkg_types_types_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_types_types_proto_rawDesc,
-			NumEnums:      6,
-			NumMessages:   32,
+			NumEnums:      9,
+			NumMessages:   52,
 			NumExtensions: 0,
 			NumServices:   4,
 		},
@@ -149,6 +149,8 @@ type SchedulerClient interface {
 	StreamIncomingTasks(ctx context.Context, opts ...grpc.CallOption) (Scheduler_StreamIncomingTasksClient, error)
 	StreamOutgoingTasks(ctx context.Context, opts ...grpc.CallOption) (Scheduler_StreamOutgoingTasksClient, error)
 	GetRoutes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RouteList, error)
+	DrainAgent(ctx context.Context, in *DrainAgentRequest, opts ...grpc.CallOption) (Scheduler_DrainAgentClient, error)
+	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (Scheduler_ListTasksClient, error)
 }
 
 type schedulerClient struct {
@@ -239,6 +241,70 @@ func (c *schedulerClient) GetRoutes(ctx context.Context, in *Empty, opts ...grpc
 	return out, nil
 }
 
+func (c *schedulerClient) DrainAgent(ctx context.Context, in *DrainAgentRequest, opts ...grpc.CallOption) (Scheduler_DrainAgentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Scheduler_ServiceDesc.Streams[2], "/types.Scheduler/DrainAgent", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &schedulerDrainAgentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Scheduler_DrainAgentClient interface {
+	Recv() (*DrainAgentProgress, error)
+	grpc.ClientStream
+}
+
+type schedulerDrainAgentClient struct {
+	grpc.ClientStream
+}
+
+func (x *schedulerDrainAgentClient) Recv() (*DrainAgentProgress, error) {
+	m := new(DrainAgentProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *schedulerClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (Scheduler_ListTasksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Scheduler_ServiceDesc.Streams[3], "/types.Scheduler/ListTasks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &schedulerListTasksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Scheduler_ListTasksClient interface {
+	Recv() (*TaskList, error)
+	grpc.ClientStream
+}
+
+type schedulerListTasksClient struct {
+	grpc.ClientStream
+}
+
+func (x *schedulerListTasksClient) Recv() (*TaskList, error) {
+	m := new(TaskList)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // SchedulerServer is the server API for Scheduler service.
 // All implementations must embed UnimplementedSchedulerServer
 // for forward compatibility
@@ -247,6 +313,8 @@ type SchedulerServer interface {
 	StreamIncomingTasks(Scheduler_StreamIncomingTasksServer) error
 	StreamOutgoingTasks(Scheduler_StreamOutgoingTasksServer) error
 	GetRoutes(context.Context, *Empty) (*RouteList, error)
+	DrainAgent(*DrainAgentRequest, Scheduler_DrainAgentServer) error
+	ListTasks(*ListTasksRequest, Scheduler_ListTasksServer) error
 	mustEmbedUnimplementedSchedulerServer()
 }
 
@@ -266,6 +334,12 @@ func (UnimplementedSchedulerServer) StreamOutgoingTasks(Scheduler_StreamOutgoing
 func (UnimplementedSchedulerServer) GetRoutes(context.Context, *Empty) (*RouteList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRoutes not implemented")
 }
+func (UnimplementedSchedulerServer) DrainAgent(*DrainAgentRequest, Scheduler_DrainAgentServer) error {
+	return status.Errorf(codes.Unimplemented, "method DrainAgent not implemented")
+}
+func (UnimplementedSchedulerServer) ListTasks(*ListTasksRequest, Scheduler_ListTasksServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
 func (UnimplementedSchedulerServer) mustEmbedUnimplementedSchedulerServer() {}
 
 // UnsafeSchedulerServer may be embedded to opt out of forward compatibility for this service.
@@ -367,6 +441,48 @@ func _Scheduler_GetRoutes_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Scheduler_DrainAgent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DrainAgentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SchedulerServer).DrainAgent(m, &schedulerDrainAgentServer{stream})
+}
+
+type Scheduler_DrainAgentServer interface {
+	Send(*DrainAgentProgress) error
+	grpc.ServerStream
+}
+
+type schedulerDrainAgentServer struct {
+	grpc.ServerStream
+}
+
+func (x *schedulerDrainAgentServer) Send(m *DrainAgentProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Scheduler_ListTasks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListTasksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SchedulerServer).ListTasks(m, &schedulerListTasksServer{stream})
+}
+
+type Scheduler_ListTasksServer interface {
+	Send(*TaskList) error
+	grpc.ServerStream
+}
+
+type schedulerListTasksServer struct {
+	grpc.ServerStream
+}
+
+func (x *schedulerListTasksServer) Send(m *TaskList) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Scheduler_ServiceDesc is the grpc.ServiceDesc for Scheduler service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -396,6 +512,16 @@ var Scheduler_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "DrainAgent",
+			Handler:       _Scheduler_DrainAgent_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListTasks",
+			Handler:       _Scheduler_ListTasks_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "pkg/types/types.proto",
 }
@@ -734,6 +860,12 @@ type CacheClient interface {
 	Pull(ctx context.Context, in *PullRequest, opts ...grpc.CallOption) (*CacheObject, error)
 	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
 	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (Cache_SyncClient, error)
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (Cache_ExportClient, error)
+	Import(ctx context.Context, opts ...grpc.CallOption) (Cache_ImportClient, error)
+	Stats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CacheStats, error)
+	Inspect(ctx context.Context, in *CacheKey, opts ...grpc.CallOption) (*InspectResponse, error)
+	Purge(ctx context.Context, in *PurgeRequest, opts ...grpc.CallOption) (*PurgeResponse, error)
+	List(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Cache_ListClient, error)
 }
 
 type cacheClient struct {
@@ -787,7 +919,7 @@ func (c *cacheClient) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.Ca
 }
 
 type Cache_SyncClient interface {
-	Recv() (*CacheObject, error)
+	Recv() (*SyncObject, error)
 	grpc.ClientStream
 }
 
@@ -795,8 +927,133 @@ type cacheSyncClient struct {
 	grpc.ClientStream
 }
 
-func (x *cacheSyncClient) Recv() (*CacheObject, error) {
-	m := new(CacheObject)
+func (x *cacheSyncClient) Recv() (*SyncObject, error) {
+	m := new(SyncObject)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *cacheClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (Cache_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Cache_ServiceDesc.Streams[1], "/types.Cache/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cacheExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Cache_ExportClient interface {
+	Recv() (*ArchiveChunk, error)
+	grpc.ClientStream
+}
+
+type cacheExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *cacheExportClient) Recv() (*ArchiveChunk, error) {
+	m := new(ArchiveChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *cacheClient) Import(ctx context.Context, opts ...grpc.CallOption) (Cache_ImportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Cache_ServiceDesc.Streams[2], "/types.Cache/Import", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cacheImportClient{stream}
+	return x, nil
+}
+
+type Cache_ImportClient interface {
+	Send(*ImportRequest) error
+	CloseAndRecv() (*ImportResponse, error)
+	grpc.ClientStream
+}
+
+type cacheImportClient struct {
+	grpc.ClientStream
+}
+
+func (x *cacheImportClient) Send(m *ImportRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *cacheImportClient) CloseAndRecv() (*ImportResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *cacheClient) Stats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CacheStats, error) {
+	out := new(CacheStats)
+	err := c.cc.Invoke(ctx, "/types.Cache/Stats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Inspect(ctx context.Context, in *CacheKey, opts ...grpc.CallOption) (*InspectResponse, error) {
+	out := new(InspectResponse)
+	err := c.cc.Invoke(ctx, "/types.Cache/Inspect", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) Purge(ctx context.Context, in *PurgeRequest, opts ...grpc.CallOption) (*PurgeResponse, error) {
+	out := new(PurgeResponse)
+	err := c.cc.Invoke(ctx, "/types.Cache/Purge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheClient) List(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Cache_ListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Cache_ServiceDesc.Streams[3], "/types.Cache/List", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cacheListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Cache_ListClient interface {
+	Recv() (*CacheKey, error)
+	grpc.ClientStream
+}
+
+type cacheListClient struct {
+	grpc.ClientStream
+}
+
+func (x *cacheListClient) Recv() (*CacheKey, error) {
+	m := new(CacheKey)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
@@ -811,6 +1068,12 @@ type CacheServer interface {
 	Pull(context.Context, *PullRequest) (*CacheObject, error)
 	Query(context.Context, *QueryRequest) (*QueryResponse, error)
 	Sync(*SyncRequest, Cache_SyncServer) error
+	Export(*ExportRequest, Cache_ExportServer) error
+	Import(Cache_ImportServer) error
+	Stats(context.Context, *Empty) (*CacheStats, error)
+	Inspect(context.Context, *CacheKey) (*InspectResponse, error)
+	Purge(context.Context, *PurgeRequest) (*PurgeResponse, error)
+	List(*Empty, Cache_ListServer) error
 	mustEmbedUnimplementedCacheServer()
 }
 
@@ -830,6 +1093,24 @@ func (UnimplementedCacheServer) Query(context.Context, *QueryRequest) (*QueryRes
 func (UnimplementedCacheServer) Sync(*SyncRequest, Cache_SyncServer) error {
 	return status.Errorf(codes.Unimplemented, "method Sync not implemented")
 }
+func (UnimplementedCacheServer) Export(*ExportRequest, Cache_ExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (UnimplementedCacheServer) Import(Cache_ImportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
+func (UnimplementedCacheServer) Stats(context.Context, *Empty) (*CacheStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+func (UnimplementedCacheServer) Inspect(context.Context, *CacheKey) (*InspectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Inspect not implemented")
+}
+func (UnimplementedCacheServer) Purge(context.Context, *PurgeRequest) (*PurgeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Purge not implemented")
+}
+func (UnimplementedCacheServer) List(*Empty, Cache_ListServer) error {
+	return status.Errorf(codes.Unimplemented, "method List not implemented")
+}
 func (UnimplementedCacheServer) mustEmbedUnimplementedCacheServer() {}
 
 // UnsafeCacheServer may be embedded to opt out of forward compatibility for this service.
@@ -906,7 +1187,7 @@ func _Cache_Sync_Handler(srv interface{}, stream grpc.ServerStream) error {
 }
 
 type Cache_SyncServer interface {
-	Send(*CacheObject) error
+	Send(*SyncObject) error
 	grpc.ServerStream
 }
 
@@ -914,7 +1195,129 @@ type cacheSyncServer struct {
 	grpc.ServerStream
 }
 
-func (x *cacheSyncServer) Send(m *CacheObject) error {
+func (x *cacheSyncServer) Send(m *SyncObject) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Cache_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CacheServer).Export(m, &cacheExportServer{stream})
+}
+
+type Cache_ExportServer interface {
+	Send(*ArchiveChunk) error
+	grpc.ServerStream
+}
+
+type cacheExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *cacheExportServer) Send(m *ArchiveChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Cache_Import_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CacheServer).Import(&cacheImportServer{stream})
+}
+
+type Cache_ImportServer interface {
+	SendAndClose(*ImportResponse) error
+	Recv() (*ImportRequest, error)
+	grpc.ServerStream
+}
+
+type cacheImportServer struct {
+	grpc.ServerStream
+}
+
+func (x *cacheImportServer) SendAndClose(m *ImportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *cacheImportServer) Recv() (*ImportRequest, error) {
+	m := new(ImportRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Cache_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Cache/Stats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Stats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Inspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Cache/Inspect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Inspect(ctx, req.(*CacheKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_Purge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServer).Purge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Cache/Purge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServer).Purge(ctx, req.(*PurgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cache_List_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CacheServer).List(m, &cacheListServer{stream})
+}
+
+type Cache_ListServer interface {
+	Send(*CacheKey) error
+	grpc.ServerStream
+}
+
+type cacheListServer struct {
+	grpc.ServerStream
+}
+
+func (x *cacheListServer) Send(m *CacheKey) error {
 	return x.ServerStream.SendMsg(m)
 }
 
@@ -937,6 +1340,18 @@ var Cache_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Query",
 			Handler:    _Cache_Query_Handler,
 		},
+		{
+			MethodName: "Stats",
+			Handler:    _Cache_Stats_Handler,
+		},
+		{
+			MethodName: "Inspect",
+			Handler:    _Cache_Inspect_Handler,
+		},
+		{
+			MethodName: "Purge",
+			Handler:    _Cache_Purge_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -944,6 +1359,21 @@ var Cache_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Cache_Sync_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Export",
+			Handler:       _Cache_Export_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Import",
+			Handler:       _Cache_Import_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "List",
+			Handler:       _Cache_List_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "pkg/types/types.proto",
 }
@@ -117,6 +117,11 @@ type routesDataSource struct {
 	client types.SchedulerClient
 }
 
+type tasksDataSource struct {
+	ctx    context.Context
+	client types.SchedulerClient
+}
+
 func NewAgentDataSource(ctx context.Context, client types.MonitorClient) TableDataSource {
 	return &agentDataSource{
 		ctx:    ctx,
@@ -159,6 +164,13 @@ func NewRoutesDataSource(ctx context.Context, client types.SchedulerClient) Tree
 	}
 }
 
+func NewTasksDataSource(ctx context.Context, client types.SchedulerClient) TableDataSource {
+	return &tasksDataSource{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
 func (a *agentDataSource) Title() string {
 	return "Agents"
 }
@@ -651,3 +663,107 @@ func (a *routesDataSource) Data() <-chan []*widgets.TreeNode {
 	}()
 	return ch
 }
+
+func (t *tasksDataSource) Title() string {
+	return "Tasks"
+}
+
+func (t *tasksDataSource) Headers() []string {
+	return []string{"Request", "State", "Consumerd", "Agent", "Toolchain", "Queued", "Elapsed"}
+}
+
+func taskStyle(state types.TaskState) termui.Style {
+	switch state {
+	case types.TaskPending:
+		return termui.NewStyle(termui.ColorYellow)
+	case types.TaskRunning:
+		return termui.NewStyle(termui.ColorGreen)
+	case types.TaskCoalesced:
+		return termui.NewStyle(termui.ColorCyan)
+	}
+	return termui.StyleClear
+}
+
+func formatTaskDuration(ns int64) string {
+	if ns <= 0 {
+		return "-"
+	}
+	return time.Duration(ns).Round(10 * time.Millisecond).String()
+}
+
+// TaskRows formats a task list as rows of the table with the columns given
+// by the tasks data source's headers.
+func TaskRows(tasks *types.TaskList) [][]string {
+	rows := [][]string{}
+	for _, task := range tasks.GetItems() {
+		agent := "-"
+		if task.Agent != "" {
+			agent = types.FormatShortID(task.Agent, 6, types.ElideCenter)
+		}
+		toolchain := "Unknown"
+		if task.Toolchain != nil {
+			toolchain = friendlyName(task.Toolchain)
+		}
+		rows = append(rows, []string{
+			task.RequestID,
+			strings.TrimPrefix(task.State.String(), "TaskState_"),
+			types.FormatShortID(task.Consumerd, 6, types.ElideCenter),
+			agent,
+			toolchain,
+			formatTaskDuration(task.QueueTime),
+			formatTaskDuration(task.ElapsedTime),
+		})
+	}
+	return rows
+}
+
+func (t *tasksDataSource) Data() (<-chan [][]string, <-chan map[int]termui.Style) {
+	dataCh := make(chan [][]string)
+	styleCh := make(chan map[int]termui.Style)
+
+	update := func(tasks *types.TaskList) bool {
+		style := map[int]termui.Style{}
+		for i, task := range tasks.GetItems() {
+			style[i+1] = taskStyle(task.State)
+		}
+		select {
+		case dataCh <- TaskRows(tasks):
+		case <-t.ctx.Done():
+			return false
+		}
+		select {
+		case styleCh <- style:
+		case <-t.ctx.Done():
+			return false
+		}
+		return true
+	}
+	go func() {
+		for {
+			stream, err := t.client.ListTasks(t.ctx, &types.ListTasksRequest{
+				Watch: true,
+			})
+			if err == nil {
+				for {
+					tasks, err := stream.Recv()
+					if err != nil {
+						break
+					}
+					if !update(tasks) {
+						return
+					}
+				}
+			}
+			// The scheduler is unavailable or is not the leader
+			if !update(&types.TaskList{}) {
+				return
+			}
+			select {
+			case <-time.After(1 * time.Second):
+			case <-t.ctx.Done():
+				return
+			}
+		}
+	}()
+	return dataCh, styleCh
+}
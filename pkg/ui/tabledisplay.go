@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Kubecc Authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package ui
+
+import (
+	"log"
+	"time"
+
+	ui "github.com/gizak/termui/v3"
+)
+
+// TableDisplay shows a single table which fills the terminal.
+type TableDisplay struct {
+	table *Table
+}
+
+func NewTableDisplay(src TableDataSource) *TableDisplay {
+	return &TableDisplay{
+		table: NewTable(src),
+	}
+}
+
+func (d *TableDisplay) Run() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	w, h := ui.TerminalDimensions()
+	d.table.SetRect(0, 0, w, h)
+
+	uiEvents := ui.PollEvents()
+	ticker := time.NewTicker(time.Second / 4).C
+
+	for {
+		select {
+		case e := <-uiEvents:
+			switch e.ID {
+			case "q", "<C-c>":
+				return
+			case "<Resize>":
+				payload := e.Payload.(ui.Resize)
+				d.table.SetRect(0, 0, payload.Width, payload.Height)
+				ui.Clear()
+				ui.Render(d.table)
+			}
+		case <-ticker:
+			ui.Render(d.table)
+		}
+	}
+}
@@ -18,6 +18,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package util
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 
 	md5simd "github.com/minio/md5-simd"
@@ -48,3 +49,10 @@ func (hs *HashServer) Hash(obj Hashable) string {
 	obj.Hash(hasher)
 	return hex.EncodeToString(hasher.Sum(nil))
 }
+
+// Digest returns the hex-encoded SHA-256 digest of the given data. It is used
+// to verify the integrity of compile results and cached objects.
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -19,6 +19,7 @@ package integration
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/kubecc-io/kubecc/pkg/agent"
@@ -34,6 +35,8 @@ var _ = Describe("Defunct Tasks", func() {
 	var testEnv test.Environment
 	localJobs := 10
 	numTasks := 20
+	localSlots := 5
+	remoteSlots := 5
 
 	var cdCtx context.Context
 	var agentCancel context.CancelFunc
@@ -45,8 +48,8 @@ var _ = Describe("Defunct Tasks", func() {
 		test.SpawnScheduler(testEnv, test.WaitForReady())
 		cdCtx, _ = test.SpawnConsumerd(testEnv, test.WaitForReady(), test.WithConsumerdOptions(
 			consumerd.WithQueueOptions(
-				consumerd.WithLocalUsageManager(consumerd.FixedUsageLimits(5)),
-				consumerd.WithRemoteUsageManager(consumerd.FixedUsageLimits(5)),
+				consumerd.WithLocalUsageManager(consumerd.FixedUsageLimits(int64(localSlots))),
+				consumerd.WithRemoteUsageManager(consumerd.FixedUsageLimits(int64(remoteSlots))),
 			),
 		))
 		_, agentCancel = test.SpawnAgent(testEnv, test.WaitForReady(), test.WithAgentOptions(
@@ -68,7 +71,9 @@ var _ = Describe("Defunct Tasks", func() {
 					return "1s"
 				}), 5*time.Second)
 
-			// Both should be equal to numTasks
+			// All tasks end up running locally. Tasks that were running on the
+			// agent when it became unavailable are sent back to run locally since
+			// there are no other agents, so no task is delegated more than once.
 			Eventually(testEnv.MetricF(cdCtx, &metrics.LocalTasksCompleted{}),
 				8*time.Second, 100*time.Millisecond,
 			).Should(
@@ -81,7 +86,68 @@ var _ = Describe("Defunct Tasks", func() {
 			).Should(
 				WithTransform(func(m *metrics.DelegatedTasksCompleted) int64 {
 					return m.Total
-				}, BeEquivalentTo(numTasks)),
+				}, And(
+					BeNumerically(">=", remoteSlots),
+					BeNumerically("<=", numTasks-localSlots),
+				)),
+			)
+		})
+	})
+})
+
+var _ = Describe("Re-dispatching Tasks", func() {
+	var testEnv test.Environment
+	numTasks := 8
+
+	var cdCtx context.Context
+	var agentCancel context.CancelFunc
+	Specify("setup", func() {
+		test.SkipInGithubWorkflow()
+		testEnv = test.NewLocalhostEnvironmentWithLogLevel(zapcore.ErrorLevel)
+
+		test.SpawnMonitor(testEnv, test.WaitForReady())
+		test.SpawnScheduler(testEnv, test.WaitForReady())
+		cdCtx, _ = test.SpawnConsumerd(testEnv, test.WaitForReady(), test.WithConsumerdOptions(
+			consumerd.WithQueueOptions(
+				consumerd.WithLocalUsageManager(consumerd.FixedUsageLimits(0)),
+				consumerd.WithRemoteUsageManager(consumerd.FixedUsageLimits(10)),
+			),
+		))
+		_, agentCancel = test.SpawnAgent(testEnv, test.WaitForReady(), test.WithAgentOptions(
+			agent.WithUsageLimits(&metrics.UsageLimits{
+				ConcurrentProcessLimit: 5,
+			}),
+		))
+		test.SpawnAgent(testEnv, test.WaitForReady(), test.WithAgentOptions(
+			agent.WithUsageLimits(&metrics.UsageLimits{
+				ConcurrentProcessLimit: 5,
+			}),
+		))
+	})
+
+	When("An agent becomes unavailable while another agent is available", func() {
+		It("should send the agent's tasks to the other agent", func() {
+			test.SkipInGithubWorkflow()
+			go func() {
+				time.Sleep(250 * time.Millisecond)
+				agentCancel()
+			}()
+			// The consumerd cannot run any tasks locally, so all tasks must
+			// complete remotely. Each task has a different duration so that
+			// identical requests are not coalesced by the scheduler.
+			i := 0
+			test.ProcessTaskPool(testEnv, "default", 10, test.MakeSleepTaskPool(numTasks,
+				func() string {
+					i++
+					return fmt.Sprintf("%dms", 500+i)
+				}), 10*time.Second)
+
+			Eventually(testEnv.MetricF(cdCtx, &metrics.LocalTasksCompleted{}),
+				8*time.Second, 100*time.Millisecond,
+			).Should(
+				WithTransform(func(m *metrics.LocalTasksCompleted) int64 {
+					return m.Total
+				}, BeEquivalentTo(0)),
 			)
 		})
 	})